@@ -208,6 +208,28 @@
 //	    }),
 //	)
 //
+// # Interface Unions and Enums
+//
+// Reflection cannot discover which types implement an interface, or which
+// constants belong to a named type. Register them so fields of those types
+// produce accurate schemas:
+//
+//	builder.RegisterOneOf[Shape](spec, "kind",
+//	    Circle{Kind: "circle"},
+//	    Square{Kind: "square"},
+//	)
+//	builder.RegisterEnum(spec, StatusActive, StatusInactive)
+//
+// RegisterOneOf adds a component schema for the interface with a oneOf of the
+// variants' component schemas. With a discriminator property name it also
+// emits a discriminator whose mapping keys are read from each variant's
+// discriminator field, falling back to the variant's schema name, and marks
+// that property required on each variant. RegisterEnum adds a component schema
+// for the named type with the given values as its enum. Fields of either type
+// generated afterwards become $refs to these components, so register them
+// before the types that use them. Both work with semantic deduplication:
+// discriminator mappings are rewritten along with other references.
+//
 // # Struct Tags
 //
 // Customize schema generation with struct tags:
@@ -231,6 +253,27 @@
 //   - nullable=true - Explicitly nullable
 //   - deprecated=true - Mark as deprecated
 //
+// The builder also understands tags that Go structs commonly carry already:
+//
+//	type Signup struct {
+//		Email string   `json:"email" validate:"required,email"`
+//		Age   int      `json:"age,omitempty" validate:"gte=18" example:"30"`
+//		Plan  string   `json:"plan,omitempty" validate:"oneof=free pro" default:"free"`
+//		Tags  []string `json:"tags,omitempty" validate:"max=5,dive,min=2"`
+//		Token string   `json:"token,omitempty" format:"password" deprecated:"true"`
+//	}
+//
+// A validate tag maps go-playground/validator rules to schema constraints:
+// required marks the field required; min, max, len, gt, gte, lt, and lte
+// become numeric bounds, string lengths, item counts, or property counts
+// depending on the field type; oneof becomes an enum; unique sets uniqueItems;
+// email, url, uuid, ipv4, ipv6, hostname, base64, and datetime set a format;
+// and rules after dive apply to array items. Rules with no schema equivalent,
+// including "|" alternatives, are ignored. The example and default tags are
+// parsed according to the field's type, format sets the format, and
+// deprecated marks the field deprecated. The oas tag is applied last, so its
+// settings take precedence over all of these.
+//
 // # Custom Field Processors
 //
 // For libraries that need to support custom struct tag conventions alongside
@@ -559,6 +602,23 @@
 //
 // Operations without registered handlers return 501 Not Implemented at runtime.
 //
+// # Spec-First Servers
+//
+// To serve an existing specification, load it with [ServerFromSpec] and bind
+// handlers by operationId with [HandleTyped]. The request is decoded into the
+// handler's request type, using `body`, `path`, `query`, `header`, and `cookie`
+// field tags, and the result is encoded with the operation's success status:
+//
+//	type GetPetReq struct {
+//		PetID int64 `path:"petId"`
+//	}
+//
+//	srv, err := builder.ServerFromSpec(parseResult)
+//	builder.HandleTyped(srv, "getPet", func(ctx context.Context, req GetPetReq) (Pet, error) {
+//		return store.Get(ctx, req.PetID)
+//	})
+//	result, err := srv.BuildServer() // fails if any operation is unbound
+//
 // # Response Helpers
 //
 // The builder provides convenient response constructors:
@@ -270,6 +270,71 @@ func Example_schemaGeneration() {
 	// Has Address schema: true
 }
 
+// Shape is a sealed union of drawable shapes.
+type Shape interface{ isShape() }
+
+// Circle is a Shape with a radius.
+type Circle struct {
+	Kind   string  `json:"kind"`
+	Radius float64 `json:"radius"`
+}
+
+func (Circle) isShape() {}
+
+// Square is a Shape with a side length.
+type Square struct {
+	Kind string  `json:"kind"`
+	Side float64 `json:"side"`
+}
+
+func (Square) isShape() {}
+
+// Status is the lifecycle state of a drawing.
+type Status string
+
+// Status values.
+const (
+	StatusDraft     Status = "draft"
+	StatusPublished Status = "published"
+)
+
+// Example_polymorphism demonstrates interface unions and enum types.
+func Example_polymorphism() {
+	type Drawing struct {
+		Status Status  `json:"status"`
+		Shapes []Shape `json:"shapes"`
+	}
+
+	spec := builder.New(parser.OASVersion320).
+		SetTitle("Drawing API").
+		SetVersion("1.0.0")
+
+	// Register unions and enums before the types that use them
+	builder.RegisterOneOf[Shape](spec, "kind", Circle{Kind: "circle"}, Square{Kind: "square"})
+	builder.RegisterEnum(spec, StatusDraft, StatusPublished)
+
+	spec.AddOperation(http.MethodGet, "/drawings/{id}",
+		builder.WithPathParam("id", int64(0)),
+		builder.WithResponse(http.StatusOK, Drawing{}),
+	)
+
+	doc, err := spec.BuildOAS3()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	shape := doc.Components.Schemas["builder_test.Shape"]
+	fmt.Printf("oneOf: %d variants\n", len(shape.OneOf))
+	fmt.Printf("discriminator: %s\n", shape.Discriminator.PropertyName)
+	fmt.Printf("circle: %s\n", shape.Discriminator.Mapping["circle"])
+	fmt.Printf("status enum: %v\n", doc.Components.Schemas["builder_test.Status"].Enum)
+	// Output:
+	// oneOf: 2 variants
+	// discriminator: kind
+	// circle: #/components/schemas/builder_test.Circle
+	// status enum: [draft published]
+}
+
 // Example_fromDocument demonstrates modifying an existing document.
 func Example_fromDocument() {
 	// Create an existing document (in real code, this would be parsed from a file)
@@ -1106,6 +1171,55 @@ func Example_serverBuilderFromBuilder() {
 	// Has handler: true
 }
 
+// Example_serverFromSpec demonstrates spec-first handler registration. The
+// operations come from an existing document, and typed handlers are bound
+// to them by operationId.
+func Example_serverFromSpec() {
+	spec := []byte(`openapi: 3.0.3
+info: {title: Pet Store, version: 1.0.0}
+paths:
+  /pets/{petId}:
+    get:
+      operationId: getPet
+      parameters:
+        - {name: petId, in: path, required: true, schema: {type: integer, format: int64}}
+      responses:
+        "200": {description: OK}
+`)
+	parseResult, err := parser.ParseWithOptions(parser.WithBytes(spec))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	srv, err := builder.ServerFromSpec(parseResult)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	type GetPetReq struct {
+		PetID int64 `path:"petId"`
+	}
+	builder.HandleTyped(srv, "getPet", func(_ context.Context, req GetPetReq) (Pet, error) {
+		return Pet{ID: req.PetID, Name: "Fluffy"}, nil
+	})
+
+	result, err := srv.BuildServer()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var pet Pet
+	rec, err := builder.NewServerTest(result).GetJSON("/pets/42", &pet)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("Status: %d\n", rec.Code)
+	fmt.Printf("Pet: %d %s\n", pet.ID, pet.Name)
+	// Output:
+	// Status: 200
+	// Pet: 42 Fluffy
+}
+
 // Example_serverBuilderWithValidation demonstrates enabling request validation.
 // When validation is enabled, requests are validated against the OpenAPI spec
 // before reaching the handler.
@@ -0,0 +1,195 @@
+package builder
+
+import (
+	"reflect"
+	"slices"
+
+	"github.com/erraggy/oastools/parser"
+)
+
+// RegisterOneOf registers the interface type T as a oneOf union of the given
+// variants and returns a $ref to it. Fields, slices, and maps of type T
+// generated afterwards reference the union instead of an empty schema.
+//
+// Each variant is registered as a component schema. When discriminator is not
+// empty, the union gets a discriminator with that property name and a mapping
+// from each variant's discriminator value to its schema. The value is read
+// from the variant's field with that JSON name, so passing Circle{Kind:
+// "circle"} maps "circle"; a variant with no such value maps its schema name.
+// The discriminator property is also marked required on each variant.
+//
+// oneOf requires OAS 3.0 or later; on an OAS 2.0 builder, or when T is not an
+// interface type, an error is recorded and returned by Build*.
+//
+// Example:
+//
+//	type Shape interface{ Area() float64 }
+//
+//	builder.RegisterOneOf[Shape](spec, "kind",
+//		Circle{Kind: "circle"},
+//		Square{Kind: "square"},
+//	)
+func RegisterOneOf[T any](b *Builder, discriminator string, variants ...T) *parser.Schema {
+	t := reflect.TypeFor[T]()
+	name := b.schemaNameFor(t)
+	if t.Kind() != reflect.Interface {
+		b.errors = append(b.errors, NewSchemaError(name, "RegisterOneOf requires an interface type, got "+t.Kind().String(), nil))
+		return &parser.Schema{}
+	}
+	if b.version == parser.OASVersion20 {
+		b.errors = append(b.errors, NewSchemaError(name, "oneOf requires OAS 3.0 or later", nil))
+		return &parser.Schema{}
+	}
+
+	schema := &parser.Schema{}
+	if discriminator != "" {
+		schema.Discriminator = &parser.Discriminator{
+			PropertyName: discriminator,
+			Mapping:      make(map[string]string, len(variants)),
+		}
+	}
+	for _, variant := range variants {
+		vt := reflect.TypeOf(variant)
+		if vt == nil {
+			b.errors = append(b.errors, NewSchemaError(name, "RegisterOneOf variant must not be nil", nil))
+			continue
+		}
+		ref := b.generateSchemaFromType(vt)
+		schema.OneOf = append(schema.OneOf, ref)
+		if discriminator == "" {
+			continue
+		}
+
+		variantName := extractRefName(ref.Ref)
+		if variantName == "" {
+			b.errors = append(b.errors, NewSchemaError(name, "discriminated variant "+vt.String()+" must be a struct type", nil))
+			continue
+		}
+		value := discriminatorValue(reflect.ValueOf(variant), discriminator)
+		if value == "" {
+			value = variantName
+		}
+		schema.Discriminator.Mapping[value] = ref.Ref
+		requireProperty(b.schemas[variantName], discriminator)
+	}
+
+	b.schemas[name] = schema
+	b.schemaCache.set(t, name, schema)
+	return b.refToSchema(name)
+}
+
+// RegisterEnum registers the named type T as a component schema whose enum
+// lists the given values, and returns a $ref to it. Fields of type T generated
+// afterwards reference the enum instead of repeating the base type.
+//
+// T must be a named string, integer, floating-point, or boolean type, such as
+// a type Status string with constants. Register enums before the types that
+// use them; fields generated earlier keep the plain base type.
+//
+// Example:
+//
+//	type Status string
+//
+//	const (
+//		StatusActive   Status = "active"
+//		StatusInactive Status = "inactive"
+//	)
+//
+//	builder.RegisterEnum(spec, StatusActive, StatusInactive)
+func RegisterEnum[T any](b *Builder, values ...T) *parser.Schema {
+	t := reflect.TypeFor[T]()
+	name := b.schemaNameFor(t)
+	if t.PkgPath() == "" || !isEnumKind(t.Kind()) {
+		b.errors = append(b.errors, NewSchemaError(name, "RegisterEnum requires a named string, number, or boolean type, got "+t.String(), nil))
+		return &parser.Schema{}
+	}
+
+	schema := b.generatePrimitiveSchema(t)
+	schema.Enum = make([]any, 0, len(values))
+	for _, value := range values {
+		schema.Enum = append(schema.Enum, enumValue(reflect.ValueOf(value)))
+	}
+
+	b.schemas[name] = schema
+	b.schemaCache.set(t, name, schema)
+	return b.refToSchema(name)
+}
+
+// schemaNameFor returns the component name for t, disambiguating it from
+// other types that would share the same name.
+func (b *Builder) schemaNameFor(t reflect.Type) string {
+	if name := b.schemaCache.getNameForType(t); name != "" {
+		return name
+	}
+	return b.namer.nameWithConflictCheck(t, func(n string) bool {
+		existing := b.schemaCache.getTypeForName(n)
+		return existing != nil && existing != t
+	})
+}
+
+// discriminatorValue returns the string value of the field of v whose JSON
+// name is property, or "" if v has no such non-empty string field.
+func discriminatorValue(v reflect.Value, property string) string {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return ""
+	}
+	t := v.Type()
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _ := parseJSONTag(field.Tag.Get("json"))
+		if name == "" {
+			name = field.Name
+		}
+		if name == property && field.Type.Kind() == reflect.String {
+			return v.Field(i).String()
+		}
+	}
+	return ""
+}
+
+// requireProperty adds property to schema's required list if schema declares
+// the property and does not already require it.
+func requireProperty(schema *parser.Schema, property string) {
+	if schema == nil {
+		return
+	}
+	if _, ok := schema.Properties[property]; !ok {
+		return
+	}
+	if slices.Contains(schema.Required, property) {
+		return
+	}
+	schema.Required = append(schema.Required, property)
+}
+
+// isEnumKind reports whether values of kind k can be listed in an enum.
+func isEnumKind(k reflect.Kind) bool {
+	return k == reflect.String || k == reflect.Bool || isNumericKind(k)
+}
+
+// enumValue converts v to the plain Go value of its underlying kind, so enum
+// values marshal as their base type rather than the named type.
+func enumValue(v reflect.Value) any {
+	switch {
+	case v.Kind() == reflect.String:
+		return v.String()
+	case v.Kind() == reflect.Bool:
+		return v.Bool()
+	case v.CanInt():
+		return v.Int()
+	case v.CanUint():
+		return v.Uint()
+	case v.CanFloat():
+		return v.Float()
+	}
+	return v.Interface()
+}
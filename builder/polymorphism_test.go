@@ -0,0 +1,204 @@
+package builder
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/erraggy/oastools/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testShape interface{ Area() float64 }
+
+type testCircle struct {
+	Kind   string  `json:"kind,omitempty"`
+	Radius float64 `json:"radius"`
+}
+
+func (c testCircle) Area() float64 { return 3.14 * c.Radius * c.Radius }
+
+type testSquare struct {
+	Kind string  `json:"kind,omitempty"`
+	Side float64 `json:"side"`
+}
+
+func (s testSquare) Area() float64 { return s.Side * s.Side }
+
+type testRect struct {
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+func (r *testRect) Area() float64 { return r.Width * r.Height }
+
+type testDrawing struct {
+	Main   testShape   `json:"main"`
+	Others []testShape `json:"others,omitempty"`
+}
+
+type testStatus string
+
+const (
+	testStatusActive   testStatus = "active"
+	testStatusInactive testStatus = "inactive"
+)
+
+type testPriority int
+
+type testTask struct {
+	Status   testStatus   `json:"status"`
+	Priority testPriority `json:"priority,omitempty"`
+}
+
+func TestRegisterOneOf_Discriminator(t *testing.T) {
+	t.Parallel()
+
+	b := New(parser.OASVersion320)
+	ref := RegisterOneOf[testShape](b, "kind",
+		testCircle{Kind: "circle"},
+		testSquare{Kind: "square"},
+		&testRect{},
+	)
+	assert.Equal(t, "#/components/schemas/builder.testShape", ref.Ref)
+
+	union := b.schemas["builder.testShape"]
+	require.NotNil(t, union)
+	require.Len(t, union.OneOf, 3)
+	assert.Equal(t, "#/components/schemas/builder.testCircle", union.OneOf[0].Ref)
+	assert.Equal(t, "#/components/schemas/builder.testSquare", union.OneOf[1].Ref)
+	assert.Equal(t, "#/components/schemas/builder.testRect", union.OneOf[2].Ref)
+
+	require.NotNil(t, union.Discriminator)
+	assert.Equal(t, "kind", union.Discriminator.PropertyName)
+	assert.Equal(t, map[string]string{
+		"circle":           "#/components/schemas/builder.testCircle",
+		"square":           "#/components/schemas/builder.testSquare",
+		"builder.testRect": "#/components/schemas/builder.testRect",
+	}, union.Discriminator.Mapping)
+
+	// The discriminator property is required even though it is omitempty
+	assert.Contains(t, b.schemas["builder.testCircle"].Required, "kind")
+	assert.NotContains(t, b.schemas["builder.testRect"].Required, "kind")
+}
+
+func TestRegisterOneOf_FieldsReferenceUnion(t *testing.T) {
+	t.Parallel()
+
+	b := New(parser.OASVersion320)
+	RegisterOneOf[testShape](b, "", testCircle{}, testSquare{})
+	b.generateSchema(testDrawing{})
+
+	union := b.schemas["builder.testShape"]
+	require.NotNil(t, union)
+	assert.Nil(t, union.Discriminator)
+	assert.Len(t, union.OneOf, 2)
+
+	drawing := b.schemas["builder.testDrawing"]
+	require.NotNil(t, drawing)
+	assert.Equal(t, "#/components/schemas/builder.testShape", drawing.Properties["main"].Ref)
+	items, ok := drawing.Properties["others"].Items.(*parser.Schema)
+	require.True(t, ok)
+	assert.Equal(t, "#/components/schemas/builder.testShape", items.Ref)
+}
+
+func TestRegisterOneOf_Errors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("OAS 2.0", func(t *testing.T) {
+		t.Parallel()
+		b := New(parser.OASVersion20)
+		RegisterOneOf[testShape](b, "kind", testCircle{})
+		_, err := b.BuildOAS2()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "oneOf requires OAS 3.0 or later")
+	})
+
+	t.Run("not an interface", func(t *testing.T) {
+		t.Parallel()
+		b := New(parser.OASVersion320)
+		RegisterOneOf[testCircle](b, "kind", testCircle{})
+		_, err := b.BuildOAS3()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "requires an interface type")
+	})
+
+	t.Run("nil variant", func(t *testing.T) {
+		t.Parallel()
+		b := New(parser.OASVersion320)
+		RegisterOneOf[testShape](b, "kind", testCircle{}, nil)
+		_, err := b.BuildOAS3()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "must not be nil")
+	})
+}
+
+func TestRegisterOneOf_SemanticDeduplication(t *testing.T) {
+	t.Parallel()
+
+	type Left struct {
+		Kind string `json:"kind"`
+		Size int    `json:"size"`
+	}
+	type Right struct {
+		Kind string `json:"kind"`
+		Size int    `json:"size"`
+	}
+	type Sized interface{}
+
+	b := New(parser.OASVersion320, WithSemanticDeduplication(true))
+	RegisterOneOf[Sized](b, "kind", Left{Kind: "left"}, Right{Kind: "right"})
+	b.AddOperation(http.MethodGet, "/sized",
+		WithResponse(http.StatusOK, RegisterOneOf[Sized](b, "kind", Left{Kind: "left"}, Right{Kind: "right"})),
+	)
+
+	doc, err := b.BuildOAS3()
+	require.NoError(t, err)
+	assert.Contains(t, doc.Components.Schemas, "builder.Left")
+	assert.NotContains(t, doc.Components.Schemas, "builder.Right")
+
+	union := doc.Components.Schemas["builder.Sized"]
+	require.NotNil(t, union)
+	assert.Equal(t, map[string]string{
+		"left":  "#/components/schemas/builder.Left",
+		"right": "#/components/schemas/builder.Left",
+	}, union.Discriminator.Mapping)
+}
+
+func TestRegisterEnum(t *testing.T) {
+	t.Parallel()
+
+	b := New(parser.OASVersion320)
+	ref := RegisterEnum(b, testStatusActive, testStatusInactive)
+	assert.Equal(t, "#/components/schemas/builder.testStatus", ref.Ref)
+	RegisterEnum(b, testPriority(1), testPriority(2), testPriority(3))
+	b.generateSchema(testTask{})
+
+	status := b.schemas["builder.testStatus"]
+	require.NotNil(t, status)
+	assert.Equal(t, "string", status.Type)
+	assert.Equal(t, []any{"active", "inactive"}, status.Enum)
+
+	priority := b.schemas["builder.testPriority"]
+	require.NotNil(t, priority)
+	assert.Equal(t, "integer", priority.Type)
+	assert.Equal(t, []any{int64(1), int64(2), int64(3)}, priority.Enum)
+
+	task := b.schemas["builder.testTask"]
+	require.NotNil(t, task)
+	assert.Equal(t, "#/components/schemas/builder.testStatus", task.Properties["status"].Ref)
+	assert.Equal(t, "#/components/schemas/builder.testPriority", task.Properties["priority"].Ref)
+	assert.Equal(t, []string{"status"}, task.Required)
+}
+
+func TestRegisterEnum_Errors(t *testing.T) {
+	t.Parallel()
+
+	b := New(parser.OASVersion320)
+	RegisterEnum(b, "a", "b")
+	RegisterEnum(b, testCircle{})
+	_, err := b.BuildOAS3()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "got string")
+	assert.Contains(t, err.Error(), "got builder.testCircle")
+}
@@ -207,6 +207,9 @@ func (b *Builder) generateStructSchema(t reflect.Type) *parser.Schema {
 		// Generate schema for field type
 		fieldSchema := b.generateSchemaFromType(field.Type)
 
+		// Apply validate, example, default, format, and deprecated tags
+		fieldSchema = b.applyStructTags(fieldSchema, field)
+
 		// Apply oas tag customizations
 		oasTag := field.Tag.Get("oas")
 		if oasTag != "" {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"maps"
 	"net/http"
+	"slices"
 	"sync"
 
 	"github.com/erraggy/oastools/httpvalidator"
@@ -24,6 +25,7 @@ type ServerBuilder struct {
 	router       RouterStrategy
 	errorHandler ErrorHandler
 	config       serverBuilderConfig
+	spec         *parser.ParseResult // Set by ServerFromSpec
 }
 
 // NewServerBuilder creates a ServerBuilder for the specified OAS version.
@@ -220,7 +222,8 @@ func (s *ServerBuilder) Use(mw ...Middleware) *ServerBuilder {
 // Returns an error if the OAS document is invalid or the router cannot be configured.
 //
 // Operations without registered handlers will return 501 Not Implemented at runtime.
-// To enforce that all operations have handlers, check the handlers map before calling BuildServer.
+// Servers created with ServerFromSpec instead fail to build until every operation
+// has a handler.
 //
 // Example:
 //
@@ -239,6 +242,16 @@ func (s *ServerBuilder) BuildServer() (*ServerResult, error) {
 	// Create ParseResult for httpvalidator
 	parseResult := s.createParseResult(doc)
 
+	// Build route table
+	routes := s.buildRoutes()
+
+	// Spec-first servers must bind every operation
+	if s.spec != nil {
+		if err := s.checkBindings(routes); err != nil {
+			return nil, err
+		}
+	}
+
 	// Create validator if enabled
 	var validator *httpvalidator.Validator
 	if s.config.enableValidation {
@@ -248,9 +261,6 @@ func (s *ServerBuilder) BuildServer() (*ServerResult, error) {
 		}
 	}
 
-	// Build route table
-	routes := s.buildRoutes()
-
 	// Create dispatcher
 	dispatcher := s.buildDispatcher(routes, validator)
 
@@ -301,6 +311,9 @@ func (s *ServerBuilder) MustBuildServer() *ServerResult {
 
 // buildDocument builds the OAS document from the builder state.
 func (s *ServerBuilder) buildDocument() (any, error) {
+	if s.spec != nil {
+		return s.spec.Document, nil
+	}
 	if s.version == parser.OASVersion20 {
 		return s.BuildOAS2()
 	}
@@ -309,6 +322,9 @@ func (s *ServerBuilder) buildDocument() (any, error) {
 
 // createParseResult creates a ParseResult for compatibility with httpvalidator.
 func (s *ServerBuilder) createParseResult(doc any) *parser.ParseResult {
+	if s.spec != nil {
+		return s.spec
+	}
 	return &parser.ParseResult{
 		SourcePath:   strBuilder,
 		SourceFormat: parser.SourceFormatYAML,
@@ -348,6 +364,14 @@ func (s *ServerBuilder) routesFromPathItem(path string, pathItem *parser.PathIte
 		{http.MethodHead, pathItem.Head},
 		{http.MethodOptions, pathItem.Options},
 		{http.MethodTrace, pathItem.Trace},
+		{methodQuery, pathItem.Query},
+	}
+	// additionalOperations keys are the method exactly as sent (OAS 3.2+)
+	for _, method := range slices.Sorted(maps.Keys(pathItem.AdditionalOperations)) {
+		methodOps = append(methodOps, struct {
+			method string
+			op     *parser.Operation
+		}{method, pathItem.AdditionalOperations[method]})
 	}
 
 	for _, mo := range methodOps {
@@ -0,0 +1,476 @@
+package builder
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/erraggy/oastools/parser"
+)
+
+// TypedHandlerFunc is the signature for handlers registered with HandleTyped.
+// Req is decoded from the request and Resp is encoded as the response.
+type TypedHandlerFunc[Req, Resp any] func(ctx context.Context, req Req) (Resp, error)
+
+// ServerFromSpec creates a ServerBuilder for an existing specification.
+// This is the spec-first counterpart to NewServerBuilder: the document
+// already declares the operations, and handlers are bound to them by
+// operationId with HandleTyped, or by method and path with Handle.
+//
+// The server serves and validates against the document as parsed. Unlike
+// code-first servers, BuildServer fails when any operation has no handler.
+//
+// Example:
+//
+//	result, err := parser.ParseWithOptions(parser.WithFilePath("petstore.yaml"))
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	srv, err := builder.ServerFromSpec(result)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	builder.HandleTyped(srv, "createPet", createPet)
+//	server, err := srv.BuildServer()
+func ServerFromSpec(result *parser.ParseResult, opts ...ServerBuilderOption) (*ServerBuilder, error) {
+	if result == nil || result.Document == nil {
+		return nil, fmt.Errorf("builder: ServerFromSpec requires a parsed document")
+	}
+
+	var b *Builder
+	switch doc := result.Document.(type) {
+	case *parser.OAS3Document:
+		b = FromDocument(doc)
+	case *parser.OAS2Document:
+		b = FromOAS2Document(doc)
+	default:
+		return nil, fmt.Errorf("builder: unsupported document type %T", result.Document)
+	}
+
+	s := FromBuilder(b, opts...)
+	s.spec = result
+	for path, item := range b.paths {
+		for _, route := range s.routesFromPathItem(path, item) {
+			if route.OperationID == "" {
+				continue
+			}
+			if first, ok := b.operationIDLocations[route.OperationID]; ok {
+				return nil, NewDuplicateOperationIDError(route.OperationID, route.Method, path, &first)
+			}
+			b.operationIDs[route.OperationID] = true
+			b.operationIDLocations[route.OperationID] = operationLocation{Method: route.Method, Path: path}
+		}
+	}
+	return s, nil
+}
+
+// HandleTyped binds fn to the operation with the given operationId.
+// It is a function rather than a method because Go methods cannot have
+// type parameters.
+//
+// The request is decoded into Req before fn is called:
+//   - A JSON request body is decoded into the Req field tagged `body:""`,
+//     or into Req itself when it has no such field.
+//   - Fields tagged `path:"name"`, `query:"name"`, `header:"name"`, or
+//     `cookie:"name"` receive the matching parameter, converted to the
+//     field's type.
+//
+// A request that cannot be decoded gets a 400 response. The Resp returned by
+// fn is encoded as JSON with the operation's success status: the lowest 2xx
+// response in the spec, or 200 if there is none. A 204 status sends no body,
+// and a Resp that implements Response is written as is.
+//
+// A non-nil error from fn gets a 500 response without its details, unless the
+// error implements Response, in which case it is written instead.
+//
+// HandleTyped also binds operations added with AddOperation. Binding an
+// operationId that no operation declares is reported by BuildServer.
+//
+// Example:
+//
+//	type CreatePetReq struct {
+//		Tenant string `header:"X-Tenant"`
+//		Pet    Pet    `body:""`
+//	}
+//
+//	builder.HandleTyped(srv, "createPet",
+//		func(ctx context.Context, req CreatePetReq) (Pet, error) {
+//			return store.Create(ctx, req.Tenant, req.Pet)
+//		})
+func HandleTyped[Req, Resp any](s *ServerBuilder, operationID string, fn TypedHandlerFunc[Req, Resp]) *ServerBuilder {
+	loc, ok := s.operationIDLocations[operationID]
+	if !ok || loc.IsWebhook {
+		s.errors = append(s.errors, &BuilderError{
+			Component:   ComponentOperation,
+			OperationID: operationID,
+			Message:     "no operation declares this operationId",
+		})
+		return s
+	}
+
+	status := successStatus(s.paths[loc.Path], loc.Method)
+	s.registerHandler(loc.Method, loc.Path, func(ctx context.Context, req *Request) Response {
+		in, err := decodeTypedRequest[Req](req)
+		if err != nil {
+			return Error(http.StatusBadRequest, err.Error())
+		}
+		out, err := fn(ctx, in)
+		if err != nil {
+			var resp Response
+			if errors.As(err, &resp) {
+				return resp
+			}
+			return Error(http.StatusInternalServerError, "internal server error")
+		}
+		return encodeTypedResponse(out, status)
+	})
+	return s
+}
+
+// checkBindings reports the operationIds HandleTyped could not find and the
+// operations of a spec-first server that have no handler.
+func (s *ServerBuilder) checkBindings(routes []operationRoute) error {
+	errs, _ := s.checkErrors().(BuilderErrors)
+	var unbound BuilderErrors
+	for _, route := range routes {
+		if route.Handler == nil {
+			unbound = append(unbound, &BuilderError{
+				Component:   ComponentOperation,
+				Method:      route.Method,
+				Path:        route.Path,
+				OperationID: route.OperationID,
+				Message:     "no handler bound",
+			})
+		}
+	}
+	slices.SortFunc(unbound, func(a, b *BuilderError) int {
+		return cmp.Or(strings.Compare(a.Path, b.Path), strings.Compare(a.Method, b.Method))
+	})
+	errs = append(errs, unbound...)
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// successStatus returns the status a typed handler responds with: the lowest
+// 2xx code the operation declares, or 200.
+func successStatus(item *parser.PathItem, method string) int {
+	status := http.StatusOK
+	op := operationForMethod(item, method)
+	if op == nil || op.Responses == nil {
+		return status
+	}
+	lowest := 0
+	for code := range op.Responses.Codes {
+		if n, err := strconv.Atoi(code); err == nil && n >= 200 && n < 300 && (lowest == 0 || n < lowest) {
+			lowest = n
+		}
+	}
+	if lowest != 0 {
+		status = lowest
+	}
+	return status
+}
+
+// methodQuery is the HTTP QUERY method (OAS 3.2+), which net/http does not
+// define.
+const methodQuery = "QUERY"
+
+// operationForMethod returns the operation of item for an uppercase HTTP
+// method, or for a method declared in additionalOperations.
+func operationForMethod(item *parser.PathItem, method string) *parser.Operation {
+	if item == nil {
+		return nil
+	}
+	switch method {
+	case http.MethodGet:
+		return item.Get
+	case http.MethodPost:
+		return item.Post
+	case http.MethodPut:
+		return item.Put
+	case http.MethodDelete:
+		return item.Delete
+	case http.MethodPatch:
+		return item.Patch
+	case http.MethodHead:
+		return item.Head
+	case http.MethodOptions:
+		return item.Options
+	case http.MethodTrace:
+		return item.Trace
+	case methodQuery:
+		return item.Query
+	}
+	return item.AdditionalOperations[method]
+}
+
+// encodeTypedResponse turns a typed handler's result into a Response.
+func encodeTypedResponse[Resp any](out Resp, status int) Response {
+	if resp, ok := any(out).(Response); ok && resp != nil {
+		return resp
+	}
+	if status == http.StatusNoContent {
+		return NoContent()
+	}
+	return JSON(status, out)
+}
+
+// decodeTypedRequest builds a Req from the request body and parameters.
+func decodeTypedRequest[Req any](req *Request) (Req, error) {
+	var in Req
+	v := reflect.ValueOf(&in).Elem()
+	if v.Kind() == reflect.Pointer {
+		v.Set(reflect.New(v.Type().Elem()))
+		v = v.Elem()
+	}
+
+	body := v
+	if v.Kind() == reflect.Struct {
+		if i := taggedField(v.Type(), "body"); i >= 0 {
+			body = v.Field(i)
+		}
+	}
+	if len(req.RawBody) > 0 {
+		if err := json.Unmarshal(req.RawBody, body.Addr().Interface()); err != nil {
+			return in, fmt.Errorf("invalid request body: %w", err)
+		}
+	}
+
+	if v.Kind() != reflect.Struct {
+		return in, nil
+	}
+	sources := []struct {
+		tag    string
+		values map[string]any
+	}{
+		{"path", req.PathParams},
+		{"query", req.QueryParams},
+		{"header", req.HeaderParams},
+		{"cookie", req.CookieParams},
+	}
+	t := v.Type()
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		for _, src := range sources {
+			name, ok := field.Tag.Lookup(src.tag)
+			if !ok {
+				continue
+			}
+			raw, ok := src.values[name]
+			if !ok && src.tag == "header" {
+				raw, ok = headerValue(req.HTTPRequest, name)
+			}
+			if !ok && src.tag == "cookie" {
+				raw, ok = cookieValue(req.HTTPRequest, name)
+			}
+			if !ok {
+				continue
+			}
+			if err := setParam(v.Field(i), raw); err != nil {
+				return in, fmt.Errorf("invalid %s parameter %q: %w", src.tag, name, err)
+			}
+		}
+	}
+	return in, nil
+}
+
+// taggedField returns the index of the first exported field of t with the
+// given tag, or -1.
+func taggedField(t reflect.Type, tag string) int {
+	for i := range t.NumField() {
+		if _, ok := t.Field(i).Tag.Lookup(tag); ok && t.Field(i).IsExported() {
+			return i
+		}
+	}
+	return -1
+}
+
+// headerValue reads a header parameter when validation did not extract it.
+func headerValue(r *http.Request, name string) (any, bool) {
+	if r == nil {
+		return nil, false
+	}
+	values := r.Header.Values(name)
+	if len(values) == 0 {
+		return nil, false
+	}
+	return values[0], true
+}
+
+// cookieValue reads a cookie parameter when validation did not extract it.
+func cookieValue(r *http.Request, name string) (any, bool) {
+	if r == nil {
+		return nil, false
+	}
+	c, err := r.Cookie(name)
+	if err != nil {
+		return nil, false
+	}
+	return c.Value, true
+}
+
+// setParam stores a parameter value in field, converting between the types
+// parameter extraction produces (strings, numbers, bools, and slices of
+// those) and the field's type.
+func setParam(field reflect.Value, raw any) error {
+	if raw == nil {
+		return nil
+	}
+	if field.Kind() == reflect.Pointer {
+		elem := reflect.New(field.Type().Elem())
+		if err := setParam(elem.Elem(), raw); err != nil {
+			return err
+		}
+		field.Set(elem)
+		return nil
+	}
+
+	rv := reflect.ValueOf(raw)
+	if rv.Type().AssignableTo(field.Type()) {
+		field.Set(rv)
+		return nil
+	}
+
+	switch {
+	case field.Kind() == reflect.Slice && (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array):
+		out := reflect.MakeSlice(field.Type(), rv.Len(), rv.Len())
+		for i := range rv.Len() {
+			if err := setParam(out.Index(i), rv.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+		field.Set(out)
+		return nil
+	case field.Kind() == reflect.Slice:
+		out := reflect.MakeSlice(field.Type(), 1, 1)
+		if err := setParam(out.Index(0), raw); err != nil {
+			return err
+		}
+		field.Set(out)
+		return nil
+	case isNumberKind(field.Kind()) && isNumberKind(rv.Kind()):
+		return setNumber(field, rv)
+	case rv.Kind() == reflect.String:
+		return setFromString(field, rv.String())
+	case field.Kind() == reflect.String:
+		field.SetString(fmt.Sprint(raw))
+		return nil
+	}
+	return fmt.Errorf("cannot convert %T to %s", raw, field.Type())
+}
+
+// setFromString parses s into field according to the field's kind.
+func setFromString(field reflect.Value, s string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	default:
+		return fmt.Errorf("cannot convert string to %s", field.Type())
+	}
+	return nil
+}
+
+// setNumber stores the number rv in the numeric field, failing rather than
+// truncating a fraction or wrapping a value the field cannot hold.
+func setNumber(field, rv reflect.Value) error {
+	overflow := fmt.Errorf("%v overflows %s", rv.Interface(), field.Type())
+	isFloat := rv.Kind() == reflect.Float32 || rv.Kind() == reflect.Float64
+	if isFloat && field.Kind() != reflect.Float32 && field.Kind() != reflect.Float64 {
+		if f := rv.Float(); f != math.Trunc(f) {
+			return fmt.Errorf("%v is not an integer", rv.Interface())
+		}
+	}
+
+	switch field.Kind() {
+	case reflect.Float32, reflect.Float64:
+		f := rv.Convert(reflect.TypeFor[float64]()).Float()
+		if field.OverflowFloat(f) {
+			return overflow
+		}
+		field.SetFloat(f)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n int64
+		switch {
+		case isFloat:
+			// -2^63 and 2^63 are exact in float64.
+			if f := rv.Float(); f < math.MinInt64 || f >= -math.MinInt64 {
+				return overflow
+			}
+			n = int64(rv.Float())
+		case rv.CanUint():
+			if rv.Uint() > math.MaxInt64 {
+				return overflow
+			}
+			n = int64(rv.Uint())
+		default:
+			n = rv.Int()
+		}
+		if field.OverflowInt(n) {
+			return overflow
+		}
+		field.SetInt(n)
+	default:
+		var u uint64
+		switch {
+		case isFloat:
+			if f := rv.Float(); f < 0 || f >= math.MaxUint64 {
+				return overflow
+			}
+			u = uint64(rv.Float())
+		case rv.CanUint():
+			u = rv.Uint()
+		default:
+			if rv.Int() < 0 {
+				return overflow
+			}
+			u = uint64(rv.Int())
+		}
+		if field.OverflowUint(u) {
+			return overflow
+		}
+		field.SetUint(u)
+	}
+	return nil
+}
+
+// isNumberKind reports whether k is an integer or floating-point kind.
+func isNumberKind(k reflect.Kind) bool {
+	return (k >= reflect.Int && k <= reflect.Uint64) || k == reflect.Float32 || k == reflect.Float64
+}
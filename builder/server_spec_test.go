@@ -0,0 +1,519 @@
+package builder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erraggy/oastools/parser"
+)
+
+const petStoreSpec = `openapi: 3.0.3
+info:
+  title: Pet Store
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+    post:
+      operationId: createPet
+      parameters:
+        - name: X-Tenant
+          in: header
+          required: true
+          schema:
+            type: string
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        "201":
+          description: Created
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+        "400":
+          description: Bad request
+  /pets/{petId}:
+    parameters:
+      - name: petId
+        in: path
+        required: true
+        schema:
+          type: integer
+          format: int64
+    get:
+      operationId: getPet
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+        "404":
+          description: Not found
+    delete:
+      operationId: deletePet
+      responses:
+        "204":
+          description: Deleted
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        id:
+          type: integer
+          format: int64
+        name:
+          type: string
+`
+
+type specPet struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+type listPetsReq struct {
+	Limit *int `query:"limit"`
+}
+
+type createPetReq struct {
+	Tenant string  `header:"X-Tenant"`
+	Pet    specPet `body:""`
+}
+
+type petIDReq struct {
+	PetID int64 `path:"petId"`
+}
+
+func parseSpec(t *testing.T, spec string) *parser.ParseResult {
+	t.Helper()
+	result, err := parser.ParseWithOptions(parser.WithBytes([]byte(spec)))
+	require.NoError(t, err)
+	return result
+}
+
+// bindPetStore binds every petStoreSpec operation except those named in skip.
+func bindPetStore(srv *ServerBuilder, skip ...string) {
+	bind := map[string]func(){
+		"listPets": func() {
+			HandleTyped(srv, "listPets", func(_ context.Context, req listPetsReq) ([]specPet, error) {
+				pets := []specPet{{ID: 1, Name: "Fluffy"}, {ID: 2, Name: "Spot"}}
+				if req.Limit != nil && *req.Limit < len(pets) {
+					pets = pets[:*req.Limit]
+				}
+				return pets, nil
+			})
+		},
+		"createPet": func() {
+			HandleTyped(srv, "createPet", func(_ context.Context, req createPetReq) (specPet, error) {
+				req.Pet.ID = 7
+				req.Pet.Name = req.Tenant + "/" + req.Pet.Name
+				return req.Pet, nil
+			})
+		},
+		"getPet": func() {
+			HandleTyped(srv, "getPet", func(_ context.Context, req petIDReq) (*specPet, error) {
+				if req.PetID != 1 {
+					return nil, errNotFound
+				}
+				return &specPet{ID: req.PetID, Name: "Fluffy"}, nil
+			})
+		},
+		"deletePet": func() {
+			HandleTyped(srv, "deletePet", func(_ context.Context, req petIDReq) (struct{}, error) {
+				if req.PetID == 99 {
+					return struct{}{}, errors.New("database unavailable")
+				}
+				return struct{}{}, nil
+			})
+		},
+	}
+	for id, fn := range bind {
+		if !slices.Contains(skip, id) {
+			fn()
+		}
+	}
+}
+
+// notFoundError is an error that is also a Response.
+type notFoundError struct{ Response }
+
+func (notFoundError) Error() string { return "not found" }
+
+var errNotFound error = notFoundError{Error(http.StatusNotFound, "pet not found")}
+
+func TestServerFromSpec_HandleTyped(t *testing.T) {
+	t.Parallel()
+
+	srv, err := ServerFromSpec(parseSpec(t, petStoreSpec))
+	require.NoError(t, err)
+	bindPetStore(srv)
+
+	result, err := srv.BuildServer()
+	require.NoError(t, err)
+	assert.IsType(t, &parser.OAS3Document{}, result.Spec)
+	st := NewServerTest(result)
+
+	t.Run("query parameter", func(t *testing.T) {
+		var pets []specPet
+		rec := st.Execute(st.Request(http.MethodGet, "/pets").Query("limit", "1"))
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &pets))
+		assert.Equal(t, []specPet{{ID: 1, Name: "Fluffy"}}, pets)
+	})
+
+	t.Run("body and header with spec status", func(t *testing.T) {
+		var pet specPet
+		rec := st.Execute(st.Request(http.MethodPost, "/pets").
+			Header("X-Tenant", "acme").
+			JSONBody(specPet{Name: "Rex"}))
+		require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &pet))
+		assert.Equal(t, specPet{ID: 7, Name: "acme/Rex"}, pet)
+	})
+
+	t.Run("path parameter", func(t *testing.T) {
+		var pet specPet
+		rec, err := st.GetJSON("/pets/1", &pet)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, specPet{ID: 1, Name: "Fluffy"}, pet)
+	})
+
+	t.Run("error implementing Response", func(t *testing.T) {
+		rec := st.Execute(st.Request(http.MethodGet, "/pets/2"))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Contains(t, rec.Body.String(), "pet not found")
+	})
+
+	t.Run("no content", func(t *testing.T) {
+		rec := st.Delete("/pets/1")
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Empty(t, rec.Body.String())
+	})
+
+	t.Run("plain error hides details", func(t *testing.T) {
+		rec := st.Delete("/pets/99")
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.NotContains(t, rec.Body.String(), "database")
+	})
+}
+
+func TestServerFromSpec_UnboundOperations(t *testing.T) {
+	t.Parallel()
+
+	srv, err := ServerFromSpec(parseSpec(t, petStoreSpec))
+	require.NoError(t, err)
+	bindPetStore(srv, "getPet", "deletePet")
+
+	_, err = srv.BuildServer()
+	require.Error(t, err)
+
+	var errs BuilderErrors
+	require.ErrorAs(t, err, &errs)
+	require.Len(t, errs, 2)
+	assert.Equal(t, "DELETE", errs[0].Method)
+	assert.Equal(t, "deletePet", errs[0].OperationID)
+	assert.Equal(t, "getPet", errs[1].OperationID)
+	assert.Contains(t, err.Error(), "no handler bound")
+}
+
+func TestServerFromSpec_QueryAndAdditionalOperations(t *testing.T) {
+	t.Parallel()
+
+	spec := `openapi: 3.2.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    query:
+      operationId: searchPets
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+      responses:
+        '200':
+          description: Matching pets
+    additionalOperations:
+      PURGE:
+        operationId: purgePets
+        responses:
+          '204':
+            description: Purged
+`
+	srv, err := ServerFromSpec(parseSpec(t, spec))
+	require.NoError(t, err)
+	HandleTyped(srv, "searchPets", func(_ context.Context, req specPet) ([]specPet, error) {
+		return []specPet{{ID: 1, Name: req.Name}}, nil
+	})
+	HandleTyped(srv, "purgePets", func(context.Context, struct{}) (struct{}, error) {
+		return struct{}{}, nil
+	})
+
+	result, err := srv.BuildServer()
+	require.NoError(t, err)
+	st := NewServerTest(result)
+
+	var pets []specPet
+	rec := st.Execute(st.Request("QUERY", "/pets").JSONBody(specPet{Name: "Rex"}))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &pets))
+	assert.Equal(t, []specPet{{ID: 1, Name: "Rex"}}, pets)
+
+	rec = st.Execute(st.Request("PURGE", "/pets"))
+	assert.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+}
+
+func TestServerFromSpec_HandleByMethodAndPath(t *testing.T) {
+	t.Parallel()
+
+	srv, err := ServerFromSpec(parseSpec(t, petStoreSpec))
+	require.NoError(t, err)
+	bindPetStore(srv, "listPets")
+	srv.Handle(http.MethodGet, "/pets", StubHandler(JSON(http.StatusOK, []specPet{})))
+
+	_, err = srv.BuildServer()
+	assert.NoError(t, err)
+}
+
+func TestHandleTyped_UnknownOperationID(t *testing.T) {
+	t.Parallel()
+
+	srv, err := ServerFromSpec(parseSpec(t, petStoreSpec))
+	require.NoError(t, err)
+	bindPetStore(srv)
+	HandleTyped(srv, "updatePet", func(_ context.Context, _ specPet) (specPet, error) {
+		return specPet{}, nil
+	})
+
+	_, err = srv.BuildServer()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "updatePet")
+	assert.Contains(t, err.Error(), "no operation declares this operationId")
+}
+
+func TestHandleTyped_InvalidRequest(t *testing.T) {
+	t.Parallel()
+
+	srv, err := ServerFromSpec(parseSpec(t, petStoreSpec), WithoutValidation())
+	require.NoError(t, err)
+	bindPetStore(srv)
+	result, err := srv.BuildServer()
+	require.NoError(t, err)
+	st := NewServerTest(result)
+
+	rec := st.Execute(st.Request(http.MethodGet, "/pets/abc"))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), `invalid path parameter \"petId\"`)
+
+	rec = st.Execute(st.Request(http.MethodPost, "/pets").
+		Header("X-Tenant", "acme").
+		JSONBody([]string{"not", "a", "pet"}))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "invalid request body")
+}
+
+func TestHandleTyped_CodeFirst(t *testing.T) {
+	t.Parallel()
+
+	srv := NewServerBuilder(parser.OASVersion320, WithoutValidation())
+	srv.AddOperation(http.MethodGet, "/pets/{petId}",
+		WithOperationID("getPet"),
+		WithPathParam("petId", int64(0)),
+		WithResponse(http.StatusOK, specPet{}),
+	)
+	HandleTyped(srv, "getPet", func(_ context.Context, req petIDReq) (specPet, error) {
+		return specPet{ID: req.PetID, Name: "Fluffy"}, nil
+	})
+
+	result, err := srv.BuildServer()
+	require.NoError(t, err)
+
+	var pet specPet
+	rec, err := NewServerTest(result).GetJSON("/pets/3", &pet)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, int64(3), pet.ID)
+}
+
+func TestServerFromSpec_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := ServerFromSpec(nil)
+	assert.Error(t, err)
+
+	_, err = ServerFromSpec(&parser.ParseResult{Document: "not a document"})
+	assert.Error(t, err)
+
+	dup := parseSpec(t, `openapi: 3.0.3
+info: {title: Dup, version: 1.0.0}
+paths:
+  /a:
+    get:
+      operationId: same
+      responses: {"200": {description: OK}}
+  /b:
+    get:
+      operationId: same
+      responses: {"200": {description: OK}}
+`)
+	_, err = ServerFromSpec(dup)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `duplicate operationId "same"`)
+}
+
+func TestServerFromSpec_OAS2(t *testing.T) {
+	t.Parallel()
+
+	result := parseSpec(t, `swagger: "2.0"
+info: {title: Pets, version: 1.0.0}
+paths:
+  /pets/{petId}:
+    get:
+      operationId: getPet
+      parameters:
+        - {name: petId, in: path, required: true, type: integer, format: int64}
+      responses:
+        "200": {description: OK}
+`)
+	srv, err := ServerFromSpec(result, WithoutValidation())
+	require.NoError(t, err)
+	HandleTyped(srv, "getPet", func(_ context.Context, req petIDReq) (specPet, error) {
+		return specPet{ID: req.PetID}, nil
+	})
+
+	built, err := srv.BuildServer()
+	require.NoError(t, err)
+	assert.IsType(t, &parser.OAS2Document{}, built.Spec)
+
+	var pet specPet
+	_, err = NewServerTest(built).GetJSON("/pets/5", &pet)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), pet.ID)
+}
+
+func TestSetParam(t *testing.T) {
+	t.Parallel()
+
+	var target struct {
+		I   int
+		U   uint8
+		F   float32
+		B   bool
+		S   string
+		P   *int64
+		Arr []int
+		One []string
+	}
+	tests := []struct {
+		field string
+		raw   any
+		want  any
+	}{
+		{"I", "42", 42},
+		{"I", float64(7), 7},
+		{"U", "200", uint8(200)},
+		{"F", "1.5", float32(1.5)},
+		{"B", "true", true},
+		{"B", true, true},
+		{"S", "x", "x"},
+		{"S", int64(3), "3"},
+		{"Arr", []any{"1", float64(2)}, []int{1, 2}},
+		{"One", "a", []string{"a"}},
+	}
+	rv := reflect.ValueOf(&target).Elem()
+	for _, tt := range tests {
+		field := rv.FieldByName(tt.field)
+		require.NoError(t, setParam(field, tt.raw), tt.field)
+		assert.Equal(t, tt.want, field.Interface(), tt.field)
+	}
+
+	require.NoError(t, setParam(rv.FieldByName("P"), "9"))
+	require.NotNil(t, target.P)
+	assert.Equal(t, int64(9), *target.P)
+
+	assert.Error(t, setParam(rv.FieldByName("I"), "x"))
+	assert.Error(t, setParam(rv.FieldByName("U"), "300"))
+	assert.Error(t, setParam(rv.FieldByName("I"), map[string]any{}))
+}
+
+func TestSetParam_Numbers(t *testing.T) {
+	t.Parallel()
+
+	var target struct {
+		I   int
+		I8  int8
+		U8  uint8
+		U64 uint64
+		F32 float32
+	}
+	rv := reflect.ValueOf(&target).Elem()
+
+	require.NoError(t, setParam(rv.FieldByName("I8"), float64(-128)))
+	assert.Equal(t, int8(-128), target.I8)
+	require.NoError(t, setParam(rv.FieldByName("U8"), int64(255)))
+	assert.Equal(t, uint8(255), target.U8)
+	require.NoError(t, setParam(rv.FieldByName("U64"), float64(1<<63)))
+	assert.Equal(t, uint64(1<<63), target.U64)
+	require.NoError(t, setParam(rv.FieldByName("F32"), int64(3)))
+	assert.Equal(t, float32(3), target.F32)
+
+	tests := []struct {
+		field string
+		raw   any
+		err   string
+	}{
+		{"I", float64(1.5), "1.5 is not an integer"},
+		{"I8", int64(300), "300 overflows int8"},
+		{"I8", float64(300), "300 overflows int8"},
+		{"I", uint64(1 << 63), "9223372036854775808 overflows int"},
+		{"I", float64(1 << 63), "overflows int"},
+		{"U8", int64(-1), "-1 overflows uint8"},
+		{"U8", float64(256), "256 overflows uint8"},
+		{"U64", float64(-1), "-1 overflows uint64"},
+		{"F32", float64(1e39), "overflows float32"},
+	}
+	for _, tt := range tests {
+		err := setParam(rv.FieldByName(tt.field), tt.raw)
+		require.Error(t, err, "%s <- %v", tt.field, tt.raw)
+		assert.Contains(t, err.Error(), tt.err)
+	}
+	assert.Equal(t, int8(-128), target.I8, "a failed conversion leaves the field unchanged")
+}
@@ -1,8 +1,10 @@
 package builder
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 
 	"github.com/erraggy/oastools/httpvalidator"
@@ -17,7 +19,20 @@ func validationMiddleware(v *httpvalidator.Validator, cfg ValidationConfig) Midd
 				return
 			}
 
+			// The validator consumes what it reads of the body. Keep only those
+			// bytes and replay them ahead of the unread remainder, so the
+			// handler sees the whole body and nothing is buffered when the
+			// operation has no body to validate.
+			body := r.Body
+			var consumed bytes.Buffer
+			if body != nil && body != http.NoBody {
+				r.Body = io.NopCloser(io.TeeReader(body, &consumed))
+			}
+
 			result, err := v.ValidateRequest(r)
+			if body != nil && body != http.NoBody {
+				r.Body = replayBody{Reader: io.MultiReader(&consumed, body), Closer: body}
+			}
 			if err != nil {
 				writeValidationError(w, http.StatusInternalServerError, err.Error())
 				return
@@ -42,6 +57,13 @@ func validationMiddleware(v *httpvalidator.Validator, cfg ValidationConfig) Midd
 	}
 }
 
+// replayBody is a request body that reads the bytes already consumed by the
+// validator before the rest of the original body, and closes the original.
+type replayBody struct {
+	io.Reader
+	io.Closer
+}
+
 // validationResultKey is the context key for validation results.
 type validationResultKey struct{}
 
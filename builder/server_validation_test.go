@@ -3,11 +3,14 @@ package builder
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/erraggy/oastools/httpvalidator"
+	"github.com/erraggy/oastools/parser"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -162,3 +165,81 @@ func TestValidationMiddleware_Disabled(t *testing.T) {
 	assert.True(t, nextCalled)
 	assert.Equal(t, http.StatusOK, rec.Code)
 }
+
+func TestValidationMiddleware_KeepsBodyForHandler(t *testing.T) {
+	t.Parallel()
+
+	type Pet struct {
+		Name string `json:"name"`
+	}
+
+	srv := NewServerBuilder(parser.OASVersion320)
+	srv.AddOperation(http.MethodPost, "/pets",
+		WithOperationID("createPet"),
+		WithRequestBody(contentTypeJSON, Pet{}, WithRequired(true)),
+		WithResponse(http.StatusCreated, Pet{}),
+		WithHandler(func(_ context.Context, req *Request) Response {
+			return JSON(http.StatusCreated, req.Body)
+		}),
+	)
+	result, err := srv.BuildServer()
+	require.NoError(t, err)
+
+	rec := NewServerTest(result).Execute(NewTestRequest(http.MethodPost, "/pets").JSONBody(Pet{Name: "Rex"}))
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	assert.JSONEq(t, `{"name":"Rex"}`, rec.Body.String())
+}
+
+// countingBody records how many bytes have been read from a request body.
+type countingBody struct {
+	r    io.Reader
+	read int
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.r.Read(p)
+	b.read += n
+	return n, err
+}
+
+func (b *countingBody) Close() error { return nil }
+
+func TestValidationMiddleware_DoesNotBufferUnvalidatedBody(t *testing.T) {
+	t.Parallel()
+
+	spec := `openapi: 3.0.3
+info:
+  title: Upload
+  version: 1.0.0
+paths:
+  /upload:
+    post:
+      responses:
+        '204':
+          description: Stored
+`
+	parsed, err := parser.ParseWithOptions(parser.WithBytes([]byte(spec)))
+	require.NoError(t, err)
+	v, err := httpvalidator.New(parsed)
+	require.NoError(t, err)
+
+	payload := strings.Repeat("x", 64<<10)
+	body := &countingBody{r: strings.NewReader(payload)}
+
+	var readBeforeHandler int
+	var got []byte
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		readBeforeHandler = body.read
+		got, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	handler := validationMiddleware(v, ValidationConfig{IncludeRequestValidation: true})(next)
+
+	req := httptest.NewRequest(http.MethodPost, "/upload", body)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+	assert.Zero(t, readBeforeHandler, "a body the validator does not read must reach the handler unread")
+	assert.Equal(t, payload, string(got))
+}
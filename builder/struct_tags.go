@@ -0,0 +1,276 @@
+package builder
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/erraggy/oastools/parser"
+)
+
+// validateFormats maps go-playground validator rules to OpenAPI formats.
+var validateFormats = map[string]string{
+	"email":            "email",
+	"url":              "uri",
+	"http_url":         "uri",
+	"uri":              "uri",
+	"uuid":             "uuid",
+	"uuid3":            "uuid",
+	"uuid4":            "uuid",
+	"uuid5":            "uuid",
+	"uuid_rfc4122":     "uuid",
+	"ipv4":             "ipv4",
+	"ip4_addr":         "ipv4",
+	"ipv6":             "ipv6",
+	"ip6_addr":         "ipv6",
+	"hostname":         "hostname",
+	"hostname_rfc1123": "hostname",
+	"fqdn":             "hostname",
+	"base64":           "byte",
+}
+
+// validatePatterns maps go-playground validator rules to equivalent patterns.
+var validatePatterns = map[string]string{
+	"alpha":    "^[a-zA-Z]+$",
+	"alphanum": "^[a-zA-Z0-9]+$",
+	"numeric":  `^[-+]?[0-9]+(?:\.[0-9]+)?$`,
+	"number":   "^[0-9]+$",
+}
+
+// applyStructTags applies the widely used validate, example, default, format,
+// and deprecated struct tags to a field's schema. The oas tag is applied
+// afterwards, so its settings take precedence.
+func (b *Builder) applyStructTags(schema *parser.Schema, field reflect.StructField) *parser.Schema {
+	validate, hasValidate := field.Tag.Lookup("validate")
+	example, hasExample := field.Tag.Lookup("example")
+	def, hasDefault := field.Tag.Lookup(defaultKeyword)
+	format, hasFormat := field.Tag.Lookup("format")
+	deprecated, hasDeprecated := field.Tag.Lookup("deprecated")
+	if !hasValidate && !hasExample && !hasDefault && !hasFormat && !hasDeprecated {
+		return schema
+	}
+
+	result := copySchema(schema)
+	if hasValidate {
+		b.applyValidateTag(result, derefType(field.Type), strings.Split(validate, ","))
+	}
+	if hasFormat && format != "" {
+		result.Format = format
+	}
+	if hasExample {
+		result.Example = parseTagValue(example, result)
+	}
+	if hasDefault {
+		result.Default = parseTagValue(def, result)
+	}
+	if hasDeprecated {
+		// A bare deprecated:"" marks the field deprecated
+		result.Deprecated = deprecated != "false"
+	}
+	return result
+}
+
+// applyValidateTag maps go-playground validator rules onto schema. Rules
+// after "dive" apply to the items of a slice or array. Rules with no schema
+// equivalent, including "|" alternatives, are ignored; "required" is handled
+// by isFieldRequired.
+func (b *Builder) applyValidateTag(schema *parser.Schema, t reflect.Type, rules []string) {
+	for i, rule := range rules {
+		rule = strings.TrimSpace(rule)
+		if rule == "" || strings.Contains(rule, "|") {
+			continue
+		}
+		name, param, _ := strings.Cut(rule, "=")
+
+		switch name {
+		case "dive":
+			items, ok := schema.Items.(*parser.Schema)
+			if ok && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+				items = copySchema(items)
+				b.applyValidateTag(items, derefType(t.Elem()), rules[i+1:])
+				schema.Items = items
+			}
+			return
+		case "keys":
+			// Map key rules have no schema equivalent
+			return
+		case "min", "gte":
+			b.applyLowerBound(schema, t, param, false)
+		case "max", "lte":
+			b.applyUpperBound(schema, t, param, false)
+		case "gt":
+			b.applyLowerBound(schema, t, param, true)
+		case "lt":
+			b.applyUpperBound(schema, t, param, true)
+		case "len":
+			b.applyLowerBound(schema, t, param, false)
+			b.applyUpperBound(schema, t, param, false)
+		case "oneof":
+			schema.Enum = parseOneOf(param, schema)
+		case "unique":
+			if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+				schema.UniqueItems = true
+			}
+		case "startswith":
+			schema.Pattern = "^" + regexp.QuoteMeta(param)
+		case "endswith":
+			schema.Pattern = regexp.QuoteMeta(param) + "$"
+		case "datetime":
+			switch {
+			case param == "2006-01-02":
+				schema.Format = "date"
+			case strings.HasPrefix(param, "2006-01-02T15:04:05"):
+				schema.Format = "date-time"
+			}
+		default:
+			if format, ok := validateFormats[name]; ok {
+				schema.Format = format
+			} else if pattern, ok := validatePatterns[name]; ok {
+				schema.Pattern = pattern
+			}
+		}
+	}
+}
+
+// applyLowerBound applies a min/gte/gt rule: a minimum for numbers and a
+// minimum length, item count, or property count otherwise.
+func (b *Builder) applyLowerBound(schema *parser.Schema, t reflect.Type, param string, exclusive bool) {
+	if isNumericKind(t.Kind()) {
+		f, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return
+		}
+		if exclusive && b.version >= parser.OASVersion310 {
+			schema.ExclusiveMinimum = f
+			return
+		}
+		schema.Minimum = &f
+		if exclusive {
+			schema.ExclusiveMinimum = true
+		}
+		return
+	}
+	n, err := strconv.Atoi(param)
+	if err != nil {
+		return
+	}
+	if exclusive {
+		n++
+	}
+	switch t.Kind() {
+	case reflect.String:
+		schema.MinLength = &n
+	case reflect.Slice, reflect.Array:
+		schema.MinItems = &n
+	case reflect.Map:
+		schema.MinProperties = &n
+	}
+}
+
+// applyUpperBound applies a max/lte/lt rule: a maximum for numbers and a
+// maximum length, item count, or property count otherwise.
+func (b *Builder) applyUpperBound(schema *parser.Schema, t reflect.Type, param string, exclusive bool) {
+	if isNumericKind(t.Kind()) {
+		f, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return
+		}
+		if exclusive && b.version >= parser.OASVersion310 {
+			schema.ExclusiveMaximum = f
+			return
+		}
+		schema.Maximum = &f
+		if exclusive {
+			schema.ExclusiveMaximum = true
+		}
+		return
+	}
+	n, err := strconv.Atoi(param)
+	if err != nil {
+		return
+	}
+	if exclusive {
+		n--
+	}
+	switch t.Kind() {
+	case reflect.String:
+		schema.MaxLength = &n
+	case reflect.Slice, reflect.Array:
+		schema.MaxItems = &n
+	case reflect.Map:
+		schema.MaxProperties = &n
+	}
+}
+
+// parseOneOf parses the space-separated values of a oneof rule, where values
+// containing spaces are wrapped in single quotes.
+func parseOneOf(param string, schema *parser.Schema) []any {
+	var values []any
+	for param = strings.TrimSpace(param); param != ""; param = strings.TrimSpace(param) {
+		var value string
+		if strings.HasPrefix(param, "'") {
+			end := strings.Index(param[1:], "'")
+			if end < 0 {
+				value, param = param[1:], ""
+			} else {
+				value, param = param[1:end+1], param[end+2:]
+			}
+		} else {
+			value, param, _ = strings.Cut(param, " ")
+		}
+		values = append(values, parseDefaultValue(value, schema.Type))
+	}
+	return values
+}
+
+// parseTagValue parses an example or default tag value for schema: numbers
+// and booleans by type, JSON for arrays and objects, and strings as is.
+func parseTagValue(value string, schema *parser.Schema) any {
+	switch schema.Type {
+	case "integer", "number", "boolean":
+		return parseDefaultValue(value, schema.Type)
+	case "string":
+		return value
+	}
+	if strings.HasPrefix(value, "{") || strings.HasPrefix(value, "[") {
+		var parsed any
+		if json.Unmarshal([]byte(value), &parsed) == nil {
+			return parsed
+		}
+	}
+	return value
+}
+
+// derefType returns the type a pointer type points to, or t itself.
+func derefType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// isNumericKind reports whether k is an integer or floating-point kind.
+func isNumericKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// hasValidateRequired reports whether a validate tag includes the required rule.
+func hasValidateRequired(tag string) bool {
+	for rule := range strings.SplitSeq(tag, ",") {
+		if strings.TrimSpace(rule) == "required" {
+			return true
+		}
+		if strings.TrimSpace(rule) == "dive" {
+			return false
+		}
+	}
+	return false
+}
@@ -0,0 +1,163 @@
+package builder
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/erraggy/oastools/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyStructTags_Validate(t *testing.T) {
+	t.Parallel()
+
+	type Signup struct {
+		Email    string            `json:"email" validate:"required,email"`
+		Name     string            `json:"name,omitempty" validate:"min=1,max=64"`
+		Code     string            `json:"code,omitempty" validate:"len=6,numeric"`
+		Age      int               `json:"age,omitempty" validate:"gte=18,lte=130"`
+		Score    float64           `json:"score,omitempty" validate:"gt=0,lt=1"`
+		Role     string            `json:"role,omitempty" validate:"oneof=admin 'power user' guest"`
+		Level    int               `json:"level,omitempty" validate:"oneof=1 2 3"`
+		Tags     []string          `json:"tags,omitempty" validate:"min=1,max=5,unique,dive,min=2,alpha"`
+		Labels   map[string]string `json:"labels,omitempty" validate:"max=10,dive,keys,alpha,endkeys"`
+		Website  *string           `json:"website,omitempty" validate:"omitempty,url"`
+		ID       string            `json:"id,omitempty" validate:"uuid4"`
+		Birthday string            `json:"birthday,omitempty" validate:"datetime=2006-01-02"`
+		Prefix   string            `json:"prefix,omitempty" validate:"startswith=a.b"`
+		Either   string            `json:"either,omitempty" validate:"email|url"`
+	}
+
+	b := New(parser.OASVersion320)
+	b.generateSchema(Signup{})
+	require.Contains(t, b.schemas, "builder.Signup")
+	props := b.schemas["builder.Signup"].Properties
+
+	assert.Equal(t, "email", props["email"].Format)
+	assert.Equal(t, []string{"email"}, b.schemas["builder.Signup"].Required)
+
+	assert.Equal(t, 1, *props["name"].MinLength)
+	assert.Equal(t, 64, *props["name"].MaxLength)
+
+	assert.Equal(t, 6, *props["code"].MinLength)
+	assert.Equal(t, 6, *props["code"].MaxLength)
+	assert.Equal(t, validatePatterns["numeric"], props["code"].Pattern)
+
+	assert.Equal(t, 18.0, *props["age"].Minimum)
+	assert.Equal(t, 130.0, *props["age"].Maximum)
+
+	// OAS 3.1+ uses numeric exclusive bounds
+	assert.Nil(t, props["score"].Minimum)
+	assert.Equal(t, 0.0, props["score"].ExclusiveMinimum)
+	assert.Equal(t, 1.0, props["score"].ExclusiveMaximum)
+
+	assert.Equal(t, []any{"admin", "power user", "guest"}, props["role"].Enum)
+	assert.Equal(t, []any{int64(1), int64(2), int64(3)}, props["level"].Enum)
+
+	tags := props["tags"]
+	assert.Equal(t, 1, *tags.MinItems)
+	assert.Equal(t, 5, *tags.MaxItems)
+	assert.True(t, tags.UniqueItems)
+	items, ok := tags.Items.(*parser.Schema)
+	require.True(t, ok)
+	assert.Equal(t, 2, *items.MinLength)
+	assert.Equal(t, validatePatterns["alpha"], items.Pattern)
+
+	assert.Equal(t, 10, *props["labels"].MaxProperties)
+	assert.Equal(t, "uri", props["website"].Format)
+	assert.Equal(t, "uuid", props["id"].Format)
+	assert.Equal(t, "date", props["birthday"].Format)
+	assert.Equal(t, `^a\.b`, props["prefix"].Pattern)
+	assert.Empty(t, props["either"].Format, "alternatives have no schema equivalent")
+}
+
+func TestApplyStructTags_ExclusiveBoundsBefore31(t *testing.T) {
+	t.Parallel()
+
+	type Ratio struct {
+		Value float64 `json:"value" validate:"gt=0,lt=1"`
+		Name  string  `json:"name" validate:"gt=2,lt=10"`
+	}
+
+	b := New(parser.OASVersion303)
+	b.generateSchema(Ratio{})
+	props := b.schemas["builder.Ratio"].Properties
+
+	assert.Equal(t, 0.0, *props["value"].Minimum)
+	assert.Equal(t, true, props["value"].ExclusiveMinimum)
+	assert.Equal(t, 1.0, *props["value"].Maximum)
+	assert.Equal(t, true, props["value"].ExclusiveMaximum)
+
+	assert.Equal(t, 3, *props["name"].MinLength)
+	assert.Equal(t, 9, *props["name"].MaxLength)
+}
+
+func TestApplyStructTags_ExampleDefaultFormatDeprecated(t *testing.T) {
+	t.Parallel()
+
+	type Settings struct {
+		Count   int            `json:"count" example:"42" default:"10"`
+		Ratio   float64        `json:"ratio" example:"0.5"`
+		Enabled bool           `json:"enabled" default:"true"`
+		Name    string         `json:"name" example:"[not json]" format:"hostname"`
+		Tags    []string       `json:"tags" example:"[\"a\",\"b\"]"`
+		Meta    map[string]int `json:"meta" example:"{\"a\":1}"`
+		Old     string         `json:"old" deprecated:"true"`
+		Bare    string         `json:"bare" deprecated:""`
+		Kept    string         `json:"kept" deprecated:"false"`
+		Email   string         `json:"email" validate:"email" format:"idn-email"`
+		Wins    string         `json:"wins" example:"tag" oas:"example=oas,format=uri" format:"email"`
+	}
+
+	b := New(parser.OASVersion320)
+	b.generateSchema(Settings{})
+	props := b.schemas["builder.Settings"].Properties
+
+	assert.Equal(t, int64(42), props["count"].Example)
+	assert.Equal(t, int64(10), props["count"].Default)
+	assert.Equal(t, 0.5, props["ratio"].Example)
+	assert.Equal(t, true, props["enabled"].Default)
+	assert.Equal(t, "[not json]", props["name"].Example)
+	assert.Equal(t, "hostname", props["name"].Format)
+	assert.Equal(t, []any{"a", "b"}, props["tags"].Example)
+	assert.Equal(t, map[string]any{"a": 1.0}, props["meta"].Example)
+	assert.True(t, props["old"].Deprecated)
+	assert.True(t, props["bare"].Deprecated)
+	assert.False(t, props["kept"].Deprecated)
+	assert.Equal(t, "idn-email", props["email"].Format, "format tag overrides validate")
+	assert.Equal(t, "oas", props["wins"].Example, "oas tag takes precedence")
+	assert.Equal(t, "uri", props["wins"].Format, "oas tag takes precedence")
+}
+
+func TestApplyStructTags_NoTags(t *testing.T) {
+	t.Parallel()
+
+	b := New(parser.OASVersion320)
+	schema := &parser.Schema{Type: "string"}
+	field := reflect.StructField{Name: "Name", Type: reflect.TypeFor[string](), Tag: `json:"name"`}
+	assert.Same(t, schema, b.applyStructTags(schema, field))
+}
+
+func TestHasValidateRequired(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, hasValidateRequired("required"))
+	assert.True(t, hasValidateRequired("min=1, required"))
+	assert.False(t, hasValidateRequired(""))
+	assert.False(t, hasValidateRequired("required_if=Kind a"))
+	assert.False(t, hasValidateRequired("dive,required"))
+}
+
+func TestIsFieldRequired_ValidateTag(t *testing.T) {
+	t.Parallel()
+
+	type Req struct {
+		Pointer  *string `json:"pointer" validate:"required"`
+		Explicit string  `json:"explicit,omitempty" validate:"required" oas:"required=false"`
+	}
+	typ := reflect.TypeFor[Req]()
+
+	assert.True(t, isFieldRequired(typ.Field(0), []string{}))
+	assert.False(t, isFieldRequired(typ.Field(1), []string{"omitempty"}), "oas tag takes precedence")
+}
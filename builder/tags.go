@@ -80,6 +80,11 @@ func isFieldRequired(field reflect.StructField, jsonOpts []string) bool {
 		}
 	}
 
+	// A validate:"required" rule marks the field required
+	if hasValidateRequired(field.Tag.Get("validate")) {
+		return true
+	}
+
 	// Pointer fields are optional by default
 	if field.Type.Kind() == reflect.Pointer {
 		return false
@@ -33,9 +33,12 @@ func TestCLIFlagsDocumented(t *testing.T) {
 		"convert":          mustFS(SetupConvertFlags()),
 		"diff":             mustFS(SetupDiffFlags()),
 		"join":             mustFS(SetupJoinFlags()),
+		"export":           mustFS(SetupExportFlags()),
 		"generate":         mustFS(SetupGenerateFlags()),
 		"overlay apply":    mustFS(SetupOverlayApplyFlags()),
 		"overlay validate": mustFS(SetupOverlayValidateFlags()),
+		"overlay generate": mustFS(SetupOverlayGenerateFlags()),
+		"query":            mustFS(SetupQueryFlags()),
 		"walk operations":  mustFS(SetupWalkOperationsFlags()),
 		"walk schemas":     mustFS(SetupWalkSchemasFlags()),
 		"walk parameters":  mustFS(SetupWalkParametersFlags()),
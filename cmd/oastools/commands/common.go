@@ -184,6 +184,18 @@ func MarshalDocument(doc any, format parser.SourceFormat) ([]byte, error) {
 	return yaml.Marshal(doc)
 }
 
+// MarshalPreservedDocument marshals a document like MarshalDocument, except
+// that YAML output is written as edits to the source text kept in source,
+// which must have been parsed with parser.WithPreserveFormatting. Comments,
+// anchors, quoting and blank lines in unchanged parts of the source survive.
+// A nil source, or one without preserved text, is marshaled as usual.
+func MarshalPreservedDocument(source *parser.ParseResult, doc any, format parser.SourceFormat) ([]byte, error) {
+	if source == nil || !source.HasPreservedFormatting() || format != parser.SourceFormatYAML {
+		return MarshalDocument(doc, format)
+	}
+	return source.MarshalPreservedYAML(doc)
+}
+
 // FormatSpecPath returns a display-friendly path for the specification.
 // Returns "<stdin>" if the path is StdinFilePath, otherwise returns the path as-is.
 func FormatSpecPath(specPath string) string {
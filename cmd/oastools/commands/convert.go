@@ -16,12 +16,14 @@ import (
 
 // ConvertFlags contains flags for the convert command
 type ConvertFlags struct {
-	Target     string
-	Output     string
-	Strict     bool
-	NoWarnings bool
-	Quiet      bool
-	SourceMap  bool
+	Target         string
+	Output         string
+	Strict         bool
+	NoWarnings     bool
+	Quiet          bool
+	SourceMap      bool
+	Lossless       bool
+	PreserveFormat bool
 }
 
 // SetupConvertFlags creates and configures a FlagSet for the convert command.
@@ -40,6 +42,8 @@ func SetupConvertFlags() (*flag.FlagSet, *ConvertFlags) {
 	fs.BoolVar(&flags.Quiet, "quiet", false, "quiet mode: only output the document, no diagnostic messages")
 	fs.BoolVar(&flags.SourceMap, "source-map", false, "include line numbers in conversion issues (IDE-friendly format)")
 	fs.BoolVar(&flags.SourceMap, "s", false, "include line numbers in conversion issues (IDE-friendly format)")
+	fs.BoolVar(&flags.Lossless, "lossless", false, "preserve OAS 3.x constructs dropped by a conversion to 2.0 in x-oas3-* extensions")
+	fs.BoolVar(&flags.PreserveFormat, "preserve-format", false, "keep the comments, anchors, quoting and blank lines of YAML input, changing only what the conversion changes")
 
 	fs.Usage = func() {
 		Writef(fs.Output(), "Usage: oastools convert [flags] <file|url|->\n\n")
@@ -57,6 +61,8 @@ func SetupConvertFlags() (*flag.FlagSet, *ConvertFlags) {
 		Writef(fs.Output(), "  oastools convert --strict -t 3.1.0 swagger.yaml -o openapi-v3.yaml\n")
 		Writef(fs.Output(), "  cat swagger.yaml | oastools convert -q -t 3.0.3 - > openapi.yaml\n")
 		Writef(fs.Output(), "  oastools convert -s -t 3.0.3 swagger.yaml  # Include line numbers in issues\n")
+		Writef(fs.Output(), "  oastools convert --lossless -t 2.0 openapi.yaml -o swagger.yaml\n")
+		Writef(fs.Output(), "  oastools convert --preserve-format -t 3.1.0 openapi.yaml -o openapi.yaml\n")
 		Writef(fs.Output(), "\nPipelining:\n")
 		Writef(fs.Output(), "  - Use '-' as the file path to read from stdin\n")
 		Writef(fs.Output(), "  - Use --quiet/-q to suppress diagnostic output for pipelining\n")
@@ -64,6 +70,10 @@ func SetupConvertFlags() (*flag.FlagSet, *ConvertFlags) {
 		Writef(fs.Output(), "  - Critical issues indicate features that cannot be converted (data loss)\n")
 		Writef(fs.Output(), "  - Warnings indicate lossy conversions or best-effort transformations\n")
 		Writef(fs.Output(), "  - Info messages provide context about conversion choices\n")
+		Writef(fs.Output(), "  - With --lossless, constructs OAS 2.0 cannot express are kept in x-oas3-*\n")
+		Writef(fs.Output(), "    extensions, and converting the result back to 3.x restores them\n")
+		Writef(fs.Output(), "  - With --preserve-format, YAML output keeps the input's comments and\n")
+		Writef(fs.Output(), "    formatting wherever the conversion leaves content unchanged\n")
 		Writef(fs.Output(), "  - Always validate converted documents before deployment\n")
 		Writef(fs.Output(), "\nExit Codes:\n")
 		Writef(fs.Output(), "  0    Conversion successful\n")
@@ -99,18 +109,22 @@ func HandleConvert(args []string) error {
 	// Convert the file, URL, or stdin with timing
 	startTime := time.Now()
 	var result *converter.ConversionResult
+	var source *parser.ParseResult // parse result holding preserved formatting, if any
 	var err error
 
 	if specPath == StdinFilePath {
 		// Read from stdin - source map not supported for stdin
 		p := parser.New()
+		p.PreserveFormatting = flags.PreserveFormat
 		parseResult, parseErr := p.ParseReader(os.Stdin)
 		if parseErr != nil {
 			return fmt.Errorf("parsing stdin: %w", parseErr)
 		}
+		source = parseResult
 		c := converter.New()
 		c.StrictMode = flags.Strict
 		c.IncludeInfo = !flags.NoWarnings
+		c.Lossless = flags.Lossless
 		result, err = c.ConvertParsed(*parseResult, flags.Target)
 		if err != nil {
 			return fmt.Errorf("converting from stdin: %w", err)
@@ -122,22 +136,26 @@ func HandleConvert(args []string) error {
 			converter.WithTargetVersion(flags.Target),
 			converter.WithStrictMode(flags.Strict),
 			converter.WithIncludeInfo(!flags.NoWarnings),
+			converter.WithLossless(flags.Lossless),
 		}
 
-		// If source map requested, parse with source map first
-		if flags.SourceMap {
+		// If source map or format preservation requested, parse first
+		if flags.SourceMap || flags.PreserveFormat {
 			parseResult, parseErr := parser.ParseWithOptions(
 				parser.WithFilePath(specPath),
-				parser.WithSourceMap(true),
+				parser.WithSourceMap(flags.SourceMap),
+				parser.WithPreserveFormatting(flags.PreserveFormat),
 			)
 			if parseErr != nil {
 				return fmt.Errorf("parsing file: %w", parseErr)
 			}
+			source = parseResult
 			convertOpts = []converter.Option{
 				converter.WithParsed(*parseResult),
 				converter.WithTargetVersion(flags.Target),
 				converter.WithStrictMode(flags.Strict),
 				converter.WithIncludeInfo(!flags.NoWarnings),
+				converter.WithLossless(flags.Lossless),
 			}
 			if parseResult.SourceMap != nil {
 				convertOpts = append(convertOpts, converter.WithSourceMap(parseResult.SourceMap))
@@ -193,7 +211,7 @@ func HandleConvert(args []string) error {
 	}
 
 	// Write output
-	data, err := MarshalDocument(result.Document, result.SourceFormat)
+	data, err := MarshalPreservedDocument(source, result.Document, result.SourceFormat)
 	if err != nil {
 		return fmt.Errorf("marshaling converted document: %w", err)
 	}
@@ -32,11 +32,12 @@ func TestSetupConvertFlags(t *testing.T) {
 
 	t.Run("long flags", func(t *testing.T) {
 		fs2, flags2 := SetupConvertFlags()
-		args := []string{"--target", "2.0", "--output", "out.yaml", "in.yaml"}
+		args := []string{"--target", "2.0", "--output", "out.yaml", "--lossless", "in.yaml"}
 		require.NoError(t, fs2.Parse(args))
 
 		assert.Equal(t, "2.0", flags2.Target)
 		assert.Equal(t, "out.yaml", flags2.Output)
+		assert.True(t, flags2.Lossless, "expected Lossless to be true")
 	})
 }
 
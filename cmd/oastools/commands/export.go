@@ -0,0 +1,198 @@
+package commands
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/erraggy/oastools"
+	"github.com/erraggy/oastools/exporter"
+	"github.com/erraggy/oastools/parser"
+)
+
+// ExportFlags contains flags for the export command
+type ExportFlags struct {
+	To         string
+	Output     string
+	Bundle     bool
+	BaseURI    string
+	Package    string
+	Strict     bool
+	NoWarnings bool
+	Quiet      bool
+}
+
+// SetupExportFlags creates and configures a FlagSet for the export command.
+// Returns the FlagSet and an ExportFlags struct with bound flag variables.
+func SetupExportFlags() (*flag.FlagSet, *ExportFlags) {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	flags := &ExportFlags{}
+
+	fs.StringVar(&flags.To, "to", "", "export format: jsonschema or proto (required)")
+	fs.StringVar(&flags.Output, "o", "", "output directory (default: stdout, for single-file output)")
+	fs.StringVar(&flags.Output, "output", "", "output directory (default: stdout, for single-file output)")
+	fs.BoolVar(&flags.Bundle, "bundle", false, "write JSON Schema as a single schemas.json with every component in $defs")
+	fs.StringVar(&flags.BaseURI, "base-uri", "", "prefix of each JSON Schema file's $id (e.g., \"https://example.com/schemas/\")")
+	fs.StringVar(&flags.Package, "package", "", "proto package name (default: api)")
+	fs.BoolVar(&flags.Strict, "strict", false, "fail on any export issues (even warnings)")
+	fs.BoolVar(&flags.NoWarnings, "no-warnings", false, "suppress info messages")
+	fs.BoolVar(&flags.Quiet, "q", false, "quiet mode: only output the exported files, no diagnostic messages")
+	fs.BoolVar(&flags.Quiet, "quiet", false, "quiet mode: only output the exported files, no diagnostic messages")
+
+	fs.Usage = func() {
+		Writef(fs.Output(), "Usage: oastools export --to <format> [flags] <file|url|->\n\n")
+		Writef(fs.Output(), "Export the component schemas of an OpenAPI specification as JSON Schema 2020-12\n")
+		Writef(fs.Output(), "documents or proto3 message definitions.\n\n")
+		Writef(fs.Output(), "Flags:\n")
+		fs.PrintDefaults()
+		Writef(fs.Output(), "\nFormats:\n")
+		Writef(fs.Output(), "  jsonschema  One <Name>.json per component, or schemas.json with --bundle\n")
+		Writef(fs.Output(), "  proto       A single schemas.proto in proto3 syntax\n")
+		Writef(fs.Output(), "\nExamples:\n")
+		Writef(fs.Output(), "  oastools export --to jsonschema -o ./schemas openapi.yaml\n")
+		Writef(fs.Output(), "  oastools export --to jsonschema --base-uri https://example.com/schemas/ -o ./schemas openapi.yaml\n")
+		Writef(fs.Output(), "  oastools export --to jsonschema --bundle openapi.yaml > schemas.json\n")
+		Writef(fs.Output(), "  oastools export --to proto --package orders.v1 -o ./proto openapi.yaml\n")
+		Writef(fs.Output(), "  cat openapi.yaml | oastools export -q --to proto - > schemas.proto\n")
+		Writef(fs.Output(), "\nNotes:\n")
+		Writef(fs.Output(), "  - Without -o, single-file output (--bundle or proto) is written to stdout\n")
+		Writef(fs.Output(), "  - Per-component JSON Schema output requires -o\n")
+		Writef(fs.Output(), "  - Warnings mark constructs the format cannot express exactly\n")
+		Writef(fs.Output(), "  - Pin proto field numbers with the x-proto-field extension\n")
+		Writef(fs.Output(), "\nExit Codes:\n")
+		Writef(fs.Output(), "  0    Export successful\n")
+		Writef(fs.Output(), "  1    Export failed or warnings found (in --strict mode)\n")
+	}
+
+	return fs, flags
+}
+
+// HandleExport executes the export command
+func HandleExport(args []string) error {
+	fs, flags := SetupExportFlags()
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("export command requires exactly one file path, URL, or '-' for stdin")
+	}
+
+	specPath := fs.Arg(0)
+
+	if flags.To == "" {
+		fs.Usage()
+		return fmt.Errorf("export format is required (use --to jsonschema or --to proto)")
+	}
+	format := exporter.Format(flags.To)
+	if format != exporter.FormatJSONSchema && format != exporter.FormatProto {
+		return fmt.Errorf("invalid export format %q (must be jsonschema or proto)", flags.To)
+	}
+	if flags.Output == "" && format == exporter.FormatJSONSchema && !flags.Bundle {
+		return fmt.Errorf("per-component JSON Schema output requires an output directory (use -o, or --bundle for stdout)")
+	}
+
+	e := exporter.New()
+	e.Format = format
+	e.Bundle = flags.Bundle
+	e.BaseURI = flags.BaseURI
+	if flags.Package != "" {
+		e.Package = flags.Package
+	}
+	e.StrictMode = flags.Strict
+	e.IncludeInfo = !flags.NoWarnings
+
+	// Export the file, URL, or stdin with timing
+	startTime := time.Now()
+	var result *exporter.ExportResult
+	var exportErr error
+
+	if specPath == StdinFilePath {
+		parseResult, err := parser.New().ParseReader(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("parsing stdin: %w", err)
+		}
+		result, exportErr = e.ExportParsed(*parseResult)
+	} else {
+		result, exportErr = e.Export(specPath)
+	}
+	if result == nil {
+		return fmt.Errorf("exporting: %w", exportErr)
+	}
+	totalTime := time.Since(startTime)
+
+	// Print results to stderr, keeping stdout for the exported file
+	if !flags.Quiet {
+		Writef(os.Stderr, "OpenAPI Schema Exporter\n")
+		Writef(os.Stderr, "=======================\n\n")
+		Writef(os.Stderr, "oastools version: %s\n", oastools.Version())
+		Writef(os.Stderr, "Specification: %s\n", FormatSpecPath(specPath))
+		Writef(os.Stderr, "OAS Version: %s\n", result.SourceVersion)
+		Writef(os.Stderr, "Format: %s\n", result.Format)
+		OutputSpecStats(result.SourceSize, result.Stats, result.LoadTime)
+		Writef(os.Stderr, "Total Time: %v\n\n", totalTime)
+
+		if len(result.Issues) > 0 {
+			Writef(os.Stderr, "Export Issues (%d):\n", len(result.Issues))
+			for _, issue := range result.Issues {
+				Writef(os.Stderr, "  %s\n", issue.String())
+			}
+			Writef(os.Stderr, "\n")
+		}
+
+		if result.Success && exportErr == nil {
+			Writef(os.Stderr, "✓ Exported %d schema(s) to %d file(s)", result.ExportedSchemas, len(result.Files))
+			if result.InfoCount > 0 || result.WarningCount > 0 {
+				Writef(os.Stderr, " (%d info, %d warnings)", result.InfoCount, result.WarningCount)
+			}
+			Writef(os.Stderr, "\n")
+		} else {
+			Writef(os.Stderr, "✗ Export completed with %d critical issue(s)", result.CriticalCount)
+			if result.WarningCount > 0 {
+				Writef(os.Stderr, ", %d warning(s)", result.WarningCount)
+			}
+			Writef(os.Stderr, "\n")
+		}
+	}
+
+	// Strict mode failures are reported above and write nothing
+	if exportErr != nil {
+		return exportErr
+	}
+
+	if flags.Output != "" {
+		cleanedOutput := filepath.Clean(flags.Output)
+		// Reject symlinks to prevent symlink attacks
+		if err := RejectSymlinkOutput(cleanedOutput); err != nil {
+			return err
+		}
+		if err := result.WriteFiles(cleanedOutput); err != nil {
+			return fmt.Errorf("writing files: %w", err)
+		}
+		if !flags.Quiet {
+			Writef(os.Stderr, "\nOutput written to: %s\n", cleanedOutput)
+		}
+	} else {
+		// Only single-file exports reach here
+		for _, file := range result.Files {
+			if _, err := os.Stdout.Write(file.Content); err != nil {
+				return fmt.Errorf("writing %s to stdout: %w", file.Name, err)
+			}
+		}
+	}
+
+	// Exit with error if export failed
+	if !result.Success {
+		os.Exit(1)
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetupExportFlags(t *testing.T) {
+	fs, flags := SetupExportFlags()
+
+	t.Run("default values", func(t *testing.T) {
+		assert.Equal(t, "", flags.To)
+		assert.Equal(t, "", flags.Output)
+		assert.Equal(t, "", flags.Package)
+		assert.False(t, flags.Bundle, "expected Bundle to be false by default")
+		assert.False(t, flags.Strict, "expected Strict to be false by default")
+		assert.False(t, flags.Quiet, "expected Quiet to be false by default")
+	})
+
+	t.Run("parse flags", func(t *testing.T) {
+		args := []string{"--to", "jsonschema", "-o", "./schemas", "--bundle", "--base-uri", "https://example.com/", "--package", "orders.v1", "--strict", "--no-warnings", "-q", "spec.yaml"}
+		require.NoError(t, fs.Parse(args))
+
+		assert.Equal(t, "jsonschema", flags.To)
+		assert.Equal(t, "./schemas", flags.Output)
+		assert.True(t, flags.Bundle, "expected Bundle to be true")
+		assert.Equal(t, "https://example.com/", flags.BaseURI)
+		assert.Equal(t, "orders.v1", flags.Package)
+		assert.True(t, flags.Strict, "expected Strict to be true")
+		assert.True(t, flags.NoWarnings, "expected NoWarnings to be true")
+		assert.True(t, flags.Quiet, "expected Quiet to be true")
+		assert.Equal(t, "spec.yaml", fs.Arg(0))
+	})
+}
+
+func TestHandleExport_Errors(t *testing.T) {
+	spec := filepath.Join("..", "..", "..", "testdata", "exporter", "events-3.0.yaml")
+
+	t.Run("help", func(t *testing.T) {
+		assert.NoError(t, HandleExport([]string{"--help"}))
+	})
+	t.Run("no args", func(t *testing.T) {
+		assert.Error(t, HandleExport([]string{}))
+	})
+	t.Run("no format", func(t *testing.T) {
+		err := HandleExport([]string{spec})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--to")
+	})
+	t.Run("invalid format", func(t *testing.T) {
+		err := HandleExport([]string{"--to", "avro", spec})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "avro")
+	})
+	t.Run("per-component output without directory", func(t *testing.T) {
+		err := HandleExport([]string{"--to", "jsonschema", spec})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "-o")
+	})
+	t.Run("invalid package", func(t *testing.T) {
+		err := HandleExport([]string{"-q", "--to", "proto", "--package", "1bad", spec})
+		assert.Error(t, err)
+	})
+}
+
+func TestHandleExport_WritesFiles(t *testing.T) {
+	spec := filepath.Join("..", "..", "..", "testdata", "exporter", "events-3.0.yaml")
+
+	t.Run("jsonschema", func(t *testing.T) {
+		outDir := t.TempDir()
+		require.NoError(t, HandleExport([]string{"-q", "--to", "jsonschema", "-o", outDir, spec}))
+
+		want, err := os.ReadDir(filepath.Join("..", "..", "..", "testdata", "exporter", "events-3.0", "jsonschema"))
+		require.NoError(t, err)
+		got, err := os.ReadDir(outDir)
+		require.NoError(t, err)
+		assert.Len(t, got, len(want))
+	})
+
+	t.Run("proto", func(t *testing.T) {
+		outDir := t.TempDir()
+		require.NoError(t, HandleExport([]string{"-q", "--to", "proto", "--package", "events.v1", "-o", outDir, spec}))
+
+		content, err := os.ReadFile(filepath.Join(outDir, "schemas.proto"))
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "package events.v1;")
+	})
+}
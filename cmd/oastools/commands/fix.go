@@ -21,6 +21,9 @@ type FixFlags struct {
 	Quiet     bool
 	SourceMap bool
 
+	// PreserveFormat keeps the formatting of YAML input in the output
+	PreserveFormat bool
+
 	// Schema name fixing flags
 	FixSchemaNames        bool
 	GenericNaming         string
@@ -60,6 +63,7 @@ func SetupFixFlags() (*flag.FlagSet, *FixFlags) {
 	fs.BoolVar(&flags.Quiet, "quiet", false, "quiet mode: only output the document, no diagnostic messages")
 	fs.BoolVar(&flags.SourceMap, "source-map", false, "include line numbers in fix output (IDE-friendly format)")
 	fs.BoolVar(&flags.SourceMap, "s", false, "include line numbers in fix output (IDE-friendly format)")
+	fs.BoolVar(&flags.PreserveFormat, "preserve-format", false, "keep the comments, anchors, quoting and blank lines of YAML input, changing only what the fixes change")
 
 	// Schema name fixing flags
 	fs.BoolVar(&flags.FixSchemaNames, "fix-schema-names", false, "fix invalid schema names (illegal per OAS version's charset rules)")
@@ -139,6 +143,7 @@ func SetupFixFlags() (*flag.FlagSet, *FixFlags) {
 		Writef(fs.Output(), "  oastools fix --dry-run --prune-schemas api.yaml\n")
 		Writef(fs.Output(), "  cat openapi.yaml | oastools fix -q - > fixed.yaml\n")
 		Writef(fs.Output(), "  oastools fix -s openapi.yaml  # Include line numbers in fixes\n")
+		Writef(fs.Output(), "  oastools fix --preserve-format -o openapi.yaml openapi.yaml\n")
 		Writef(fs.Output(), "\nPipelining:\n")
 		Writef(fs.Output(), "  oastools fix -q api.yaml | oastools validate -q -\n")
 		Writef(fs.Output(), "  oastools fix -q --infer api.yaml | oastools convert -q -t 3.1.0 -\n")
@@ -147,6 +152,7 @@ func SetupFixFlags() (*flag.FlagSet, *FixFlags) {
 		Writef(fs.Output(), "  - Pruning fixes only run when explicitly requested via flags\n")
 		Writef(fs.Output(), "  - Use --dry-run to preview what would be changed\n")
 		Writef(fs.Output(), "  - Output preserves the original format (JSON or YAML)\n")
+		Writef(fs.Output(), "  - Use --preserve-format to keep the diff of YAML output to the fixed values\n")
 		Writef(fs.Output(), "\nExit Codes:\n")
 		Writef(fs.Output(), "  0    Fixes applied successfully (or no fixes needed)\n")
 		Writef(fs.Output(), "  1    Failed to parse or fix the specification, or the\n")
@@ -221,15 +227,18 @@ func HandleFix(args []string) error {
 	// Fix the file, URL, or stdin with timing
 	startTime := time.Now()
 	var result *fixer.FixResult
+	var source *parser.ParseResult
 	var err error
 
 	if specPath == StdinFilePath {
 		// Read from stdin - source map not supported for stdin
 		p := parser.New()
+		p.PreserveFormatting = flags.PreserveFormat
 		parseResult, parseErr := p.ParseReader(os.Stdin)
 		if parseErr != nil {
 			return fmt.Errorf("parsing stdin: %w", parseErr)
 		}
+		source = parseResult
 		f := fixer.New()
 		f.InferTypes = flags.Infer
 		f.EnabledFixes = enabledFixes
@@ -255,15 +264,17 @@ func HandleFix(args []string) error {
 			fixer.WithDryRun(flags.DryRun),
 		}
 
-		// If source map requested, parse with source map first
-		if flags.SourceMap {
+		// If source map or preserved formatting requested, parse first
+		if flags.SourceMap || flags.PreserveFormat {
 			parseResult, parseErr := parser.ParseWithOptions(
 				parser.WithFilePath(specPath),
-				parser.WithSourceMap(true),
+				parser.WithSourceMap(flags.SourceMap),
+				parser.WithPreserveFormatting(flags.PreserveFormat),
 			)
 			if parseErr != nil {
 				return fmt.Errorf("parsing file: %w", parseErr)
 			}
+			source = parseResult
 			fixOpts = []fixer.Option{
 				fixer.WithParsed(*parseResult),
 				fixer.WithInferTypes(flags.Infer),
@@ -361,7 +372,7 @@ func HandleFix(args []string) error {
 	}
 
 	// Write output
-	data, err := MarshalDocument(result.Document, result.SourceFormat)
+	data, err := MarshalPreservedDocument(source, result.Document, result.SourceFormat)
 	if err != nil {
 		return fmt.Errorf("marshaling fixed document: %w", err)
 	}
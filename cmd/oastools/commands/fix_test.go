@@ -1,6 +1,9 @@
 package commands
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -28,11 +31,12 @@ func TestSetupFixFlags(t *testing.T) {
 
 	t.Run("long flags", func(t *testing.T) {
 		fs2, flags2 := SetupFixFlags()
-		args := []string{"--output", "out.yaml", "--quiet", "in.yaml"}
+		args := []string{"--output", "out.yaml", "--quiet", "--preserve-format", "in.yaml"}
 		require.NoError(t, fs2.Parse(args))
 
 		assert.Equal(t, "out.yaml", flags2.Output)
 		assert.True(t, flags2.Quiet, "expected Quiet to be true")
+		assert.True(t, flags2.PreserveFormat, "expected PreserveFormat to be true")
 	})
 }
 
@@ -45,3 +49,45 @@ func TestHandleFix_Help(t *testing.T) {
 	err := HandleFix([]string{"--help"})
 	assert.NoError(t, err)
 }
+
+func TestHandleFix_PreserveFormat(t *testing.T) {
+	src := `# Pets API
+openapi: 3.0.3
+info:
+  title: Pets   # shown in the portal
+  version: '1.0'
+
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          required: false
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    # Referenced nowhere
+    Unused:
+      type: string
+    Pet:
+      type: object
+`
+	dir := t.TempDir()
+	input := filepath.Join(dir, "api.yaml")
+	output := filepath.Join(dir, "fixed.yaml")
+	require.NoError(t, os.WriteFile(input, []byte(src), 0o600))
+
+	require.NoError(t, HandleFix([]string{"-q", "--prune-schemas", "--preserve-format", "-o", output, input}))
+
+	got, err := os.ReadFile(output)
+	require.NoError(t, err)
+	want := strings.Replace(src, "    # Referenced nowhere\n    Unused:\n      type: string\n", "", 1)
+	assert.Equal(t, want, string(got))
+}
@@ -14,16 +14,21 @@ import (
 
 // GenerateFlags contains flags for the generate command
 type GenerateFlags struct {
-	Output       string
-	PackageName  string
-	Client       bool
-	Server       bool
-	Types        bool
-	NoPointers   bool
-	NoValidation bool
-	Strict       bool
-	NoWarnings   bool
-	SourceMap    bool
+	Config          string
+	Output          string
+	PackageName     string
+	Lang            string
+	Client          bool
+	Server          bool
+	Types           bool
+	NoPointers      bool
+	OptionalTypes   bool
+	NoValidation    bool
+	ValidateMethods bool
+	Strict          bool
+	NoWarnings      bool
+	SourceMap       bool
+	Check           bool
 
 	// Security generation options
 	NoSecurity      bool
@@ -32,6 +37,7 @@ type GenerateFlags struct {
 	SecurityEnforce bool
 	OIDCDiscovery   bool
 	NoReadme        bool
+	NoWebhooks      bool
 
 	// File splitting options
 	MaxLinesPerFile int
@@ -49,6 +55,7 @@ type GenerateFlags struct {
 	ServerResponses  bool
 	ServerStubs      bool
 	ServerEmbedSpec  bool
+	ServerStrict     bool
 	ServerAll        bool
 }
 
@@ -77,6 +84,9 @@ func serverOptions(flags *GenerateFlags) []generator.Option {
 	if flags.ServerEmbedSpec {
 		opts = append(opts, generator.WithServerEmbedSpec(true))
 	}
+	if flags.ServerStrict {
+		opts = append(opts, generator.WithServerStrict(true))
+	}
 	return opts
 }
 
@@ -86,19 +96,24 @@ func SetupGenerateFlags() (*flag.FlagSet, *GenerateFlags) {
 	fs := flag.NewFlagSet("generate", flag.ContinueOnError)
 	flags := &GenerateFlags{}
 
+	fs.StringVar(&flags.Config, "config", "", "generator config file declaring outputs, packages, toggles and import mapping")
 	fs.StringVar(&flags.Output, "o", "", "output directory for generated files (required)")
 	fs.StringVar(&flags.Output, "output", "", "output directory for generated files (required)")
 	fs.StringVar(&flags.PackageName, "p", "api", "Go package name for generated code")
 	fs.StringVar(&flags.PackageName, "package", "api", "Go package name for generated code")
+	fs.StringVar(&flags.Lang, "lang", "go", "language to generate: go or typescript")
 	fs.BoolVar(&flags.Client, "client", false, "generate HTTP client code")
 	fs.BoolVar(&flags.Server, "server", false, "generate server interface code")
 	fs.BoolVar(&flags.Types, "types", true, "generate type definitions from schemas")
 	fs.BoolVar(&flags.NoPointers, "no-pointers", false, "don't use pointer types for optional fields")
+	fs.BoolVar(&flags.OptionalTypes, "optional-types", false, "use Optional[T] and Nullable[T] for optional and nullable fields")
 	fs.BoolVar(&flags.NoValidation, "no-validation", false, "don't include validation tags")
+	fs.BoolVar(&flags.ValidateMethods, "validate-methods", false, "generate Validate() methods that check schema constraints")
 	fs.BoolVar(&flags.Strict, "strict", false, "fail on any generation issues (even warnings)")
 	fs.BoolVar(&flags.NoWarnings, "no-warnings", false, "suppress warning and info messages")
 	fs.BoolVar(&flags.SourceMap, "source-map", false, "include line numbers in generation issues (IDE-friendly format)")
 	fs.BoolVar(&flags.SourceMap, "s", false, "include line numbers in generation issues (IDE-friendly format)")
+	fs.BoolVar(&flags.Check, "check", false, "compare generated code against the output directory without writing; fail with a diff if it is out of date")
 
 	// Security generation flags
 	fs.BoolVar(&flags.NoSecurity, "no-security", false, "don't generate security helper functions")
@@ -107,6 +122,7 @@ func SetupGenerateFlags() (*flag.FlagSet, *GenerateFlags) {
 	fs.BoolVar(&flags.SecurityEnforce, "security-enforce", false, "generate security enforcement middleware")
 	fs.BoolVar(&flags.OIDCDiscovery, "oidc-discovery", false, "generate OpenID Connect discovery client")
 	fs.BoolVar(&flags.NoReadme, "no-readme", false, "don't generate README.md file")
+	fs.BoolVar(&flags.NoWebhooks, "no-webhooks", false, "don't generate webhook receivers or webhook and callback senders")
 
 	// File splitting flags
 	fs.IntVar(&flags.MaxLinesPerFile, "max-lines-per-file", 2000, "maximum lines per generated file (0 = no limit)")
@@ -122,6 +138,7 @@ func SetupGenerateFlags() (*flag.FlagSet, *GenerateFlags) {
 	fs.BoolVar(&flags.ServerResponses, "server-responses", false, "generate typed response writers and error types")
 	fs.BoolVar(&flags.ServerStubs, "server-stubs", false, "generate stub implementations for testing")
 	fs.BoolVar(&flags.ServerEmbedSpec, "server-embed-spec", false, "embed OpenAPI spec in generated code")
+	fs.BoolVar(&flags.ServerStrict, "server-strict", false, "return a typed response per declared status from server methods")
 	fs.BoolVar(&flags.ServerAll, "server-all", false, "enable all server generation options")
 
 	fs.Usage = func() {
@@ -139,6 +156,9 @@ func SetupGenerateFlags() (*flag.FlagSet, *GenerateFlags) {
 		Writef(fs.Output(), "  oastools generate --client --max-lines-per-file 1500 -o ./client large-api.yaml\n")
 		Writef(fs.Output(), "  cat openapi.yaml | oastools generate --client -o ./client -\n")
 		Writef(fs.Output(), "  oastools generate -s --client -o ./client openapi.yaml  # Include line numbers in issues\n")
+		Writef(fs.Output(), "  oastools generate --config oastools-gen.yaml  # Outputs and options from a config file\n")
+		Writef(fs.Output(), "  oastools generate --lang typescript --client -o ./web/api openapi.yaml  # TypeScript types and fetch client\n")
+		Writef(fs.Output(), "  oastools generate --check --client -o ./client openapi.yaml  # Fail in CI if ./client is out of date\n")
 		Writef(fs.Output(), "\nServer Generation Examples:\n")
 		Writef(fs.Output(), "  oastools generate --server --server-all -o ./server openapi.yaml  # Full server with validation\n")
 		Writef(fs.Output(), "  oastools generate --server --server-router=stdlib -o ./server openapi.yaml  # With router\n")
@@ -150,6 +170,11 @@ func SetupGenerateFlags() (*flag.FlagSet, *GenerateFlags) {
 		Writef(fs.Output(), "  - At least one of --client, --server, or --types must be enabled\n")
 		Writef(fs.Output(), "  - Types are always generated when --client or --server is enabled\n")
 		Writef(fs.Output(), "  - Security helpers are generated by default when --client is enabled\n")
+		Writef(fs.Output(), "  - Webhooks get a receiver with --client and a sender with --server; callbacks get a sender with --server\n")
+		Writef(fs.Output(), "  - With --config, outputs and their options come from the file; a spec argument overrides its input\n")
+		Writef(fs.Output(), "  - --lang typescript writes .ts types and a fetch client; server generation is Go only\n")
+		Writef(fs.Output(), "  - --check writes nothing; it exits non-zero with a diff of stale files and a list of\n")
+		Writef(fs.Output(), "    orphaned generated files when the output directory is out of date\n")
 		Writef(fs.Output(), "  - Generated code uses Go idioms and best practices\n")
 		Writef(fs.Output(), "  - Server interface is framework-agnostic\n")
 	}
@@ -168,6 +193,10 @@ func HandleGenerate(args []string) error {
 		return err
 	}
 
+	if flags.Config != "" {
+		return generateFromConfig(fs, flags)
+	}
+
 	if fs.NArg() != 1 {
 		fs.Usage()
 		return fmt.Errorf("generate command requires exactly one file path, URL, or '-' for stdin")
@@ -200,11 +229,14 @@ func HandleGenerate(args []string) error {
 		}
 		g := generator.New()
 		g.PackageName = flags.PackageName
+		g.Language = flags.Lang
 		g.GenerateClient = flags.Client
 		g.GenerateServer = flags.Server
 		g.GenerateTypes = flags.Types || flags.Client || flags.Server
 		g.UsePointers = !flags.NoPointers
+		g.UseOptionalTypes = flags.OptionalTypes
 		g.IncludeValidation = !flags.NoValidation
+		g.GenerateValidateMethods = flags.ValidateMethods
 		g.StrictMode = flags.Strict
 		g.IncludeInfo = !flags.NoWarnings
 		g.GenerateSecurity = !flags.NoSecurity
@@ -213,6 +245,7 @@ func HandleGenerate(args []string) error {
 		g.GenerateSecurityEnforce = flags.SecurityEnforce
 		g.GenerateOIDCDiscovery = flags.OIDCDiscovery
 		g.GenerateReadme = !flags.NoReadme
+		g.GenerateWebhooks = !flags.NoWebhooks
 		g.MaxLinesPerFile = flags.MaxLinesPerFile
 		g.MaxTypesPerFile = flags.MaxTypesPerFile
 		g.MaxOperationsPerFile = flags.MaxOpsPerFile
@@ -233,6 +266,7 @@ func HandleGenerate(args []string) error {
 			g.ServerStubs = flags.ServerStubs
 		}
 		g.ServerEmbedSpec = flags.ServerEmbedSpec
+		g.ServerStrict = flags.ServerStrict
 		result, err = g.GenerateParsed(*parseResult)
 	} else {
 		// Build generator options (server options appended below)
@@ -240,11 +274,14 @@ func HandleGenerate(args []string) error {
 		genOpts = append(genOpts,
 			generator.WithFilePath(specPath),
 			generator.WithPackageName(flags.PackageName),
+			generator.WithLanguage(flags.Lang),
 			generator.WithClient(flags.Client),
 			generator.WithServer(flags.Server),
 			generator.WithTypes(flags.Types || flags.Client || flags.Server),
 			generator.WithPointers(!flags.NoPointers),
+			generator.WithOptionalTypes(flags.OptionalTypes),
 			generator.WithValidation(!flags.NoValidation),
+			generator.WithValidateMethods(flags.ValidateMethods),
 			generator.WithStrictMode(flags.Strict),
 			generator.WithIncludeInfo(!flags.NoWarnings),
 			// Security options
@@ -254,6 +291,7 @@ func HandleGenerate(args []string) error {
 			generator.WithSecurityEnforce(flags.SecurityEnforce),
 			generator.WithOIDCDiscovery(flags.OIDCDiscovery),
 			generator.WithReadme(!flags.NoReadme),
+			generator.WithWebhooks(!flags.NoWebhooks),
 			// File splitting options
 			generator.WithMaxLinesPerFile(flags.MaxLinesPerFile),
 			generator.WithMaxTypesPerFile(flags.MaxTypesPerFile),
@@ -277,11 +315,14 @@ func HandleGenerate(args []string) error {
 			genOpts = []generator.Option{
 				generator.WithParsed(*parseResult),
 				generator.WithPackageName(flags.PackageName),
+				generator.WithLanguage(flags.Lang),
 				generator.WithClient(flags.Client),
 				generator.WithServer(flags.Server),
 				generator.WithTypes(flags.Types || flags.Client || flags.Server),
 				generator.WithPointers(!flags.NoPointers),
+				generator.WithOptionalTypes(flags.OptionalTypes),
 				generator.WithValidation(!flags.NoValidation),
+				generator.WithValidateMethods(flags.ValidateMethods),
 				generator.WithStrictMode(flags.Strict),
 				generator.WithIncludeInfo(!flags.NoWarnings),
 				// Security options
@@ -291,6 +332,7 @@ func HandleGenerate(args []string) error {
 				generator.WithSecurityEnforce(flags.SecurityEnforce),
 				generator.WithOIDCDiscovery(flags.OIDCDiscovery),
 				generator.WithReadme(!flags.NoReadme),
+				generator.WithWebhooks(!flags.NoWebhooks),
 				// File splitting options
 				generator.WithMaxLinesPerFile(flags.MaxLinesPerFile),
 				generator.WithMaxTypesPerFile(flags.MaxTypesPerFile),
@@ -312,6 +354,14 @@ func HandleGenerate(args []string) error {
 		return fmt.Errorf("generating code: %w", err)
 	}
 
+	return reportGeneration(result, specPath, flags.Output, totalTime, flags.SourceMap, flags.Check)
+}
+
+// reportGeneration prints the outcome of generating code from specPath,
+// writes the generated files to outputDir, and returns an error if
+// generation had critical issues. With check, the files are compared against
+// outputDir instead of written.
+func reportGeneration(result *generator.GenerateResult, specPath, outputDir string, totalTime time.Duration, sourceMap, check bool) error {
 	// Print results
 	fmt.Printf("OpenAPI Code Generator\n")
 	fmt.Printf("=====================\n\n")
@@ -332,7 +382,7 @@ func HandleGenerate(args []string) error {
 	if len(result.Issues) > 0 {
 		fmt.Printf("Generation Issues (%d):\n", len(result.Issues))
 		for _, issue := range result.Issues {
-			if flags.SourceMap && issue.HasLocation() {
+			if sourceMap && issue.HasLocation() {
 				// IDE-friendly format: file:line:column: path: message
 				fmt.Printf("  %s: %s: %s\n", issue.Location(), issue.Path, issue.Message)
 			} else {
@@ -342,15 +392,19 @@ func HandleGenerate(args []string) error {
 		fmt.Println()
 	}
 
+	if check {
+		return checkGeneration(result, outputDir)
+	}
+
 	// Write files
-	if err := result.WriteFiles(flags.Output); err != nil {
+	if err := result.WriteFiles(outputDir); err != nil {
 		return fmt.Errorf("writing files: %w", err)
 	}
 
 	// Print generated files
 	fmt.Printf("Generated Files (%d):\n", len(result.Files))
 	for _, file := range result.Files {
-		fmt.Printf("  - %s/%s (%d bytes)\n", flags.Output, file.Name, len(file.Content))
+		fmt.Printf("  - %s/%s (%d bytes)\n", outputDir, file.Name, len(file.Content))
 	}
 	fmt.Println()
 
@@ -372,3 +426,106 @@ func HandleGenerate(args []string) error {
 
 	return nil
 }
+
+// checkGeneration compares the generated files against outputDir without
+// writing them. It lists every out-of-date file, prints a diff of each stale
+// one, and returns an error if outputDir is out of date.
+func checkGeneration(result *generator.GenerateResult, outputDir string) error {
+	if !result.Success {
+		fmt.Printf("✗ Generation completed with %d critical issue(s)\n", result.CriticalCount)
+		return fmt.Errorf("generation failed with %d critical issue(s)", result.CriticalCount)
+	}
+
+	report, err := result.CheckFiles(outputDir)
+	if err != nil {
+		return fmt.Errorf("checking files: %w", err)
+	}
+	if !report.HasDrift() {
+		fmt.Printf("✓ %s is up to date (%d files)\n", outputDir, len(result.Files))
+		return nil
+	}
+
+	fmt.Printf("Out of Date Files (%d):\n", len(report.Files))
+	for _, file := range report.Files {
+		switch file.Status {
+		case generator.DriftMissing:
+			fmt.Printf("  - %s/%s (missing)\n", outputDir, file.Name)
+		case generator.DriftOrphaned:
+			fmt.Printf("  - %s/%s (orphaned: no longer generated, delete it)\n", outputDir, file.Name)
+		default:
+			fmt.Printf("  - %s/%s (stale)\n", outputDir, file.Name)
+		}
+	}
+	fmt.Println()
+	for _, file := range report.Files {
+		if file.Status == generator.DriftStale {
+			fmt.Print(file.Diff)
+		}
+	}
+
+	fmt.Printf("✗ %s is out of date\n", outputDir)
+	return fmt.Errorf("generated code in %s is out of date: %d file(s) differ from the specification", outputDir, len(report.Files))
+}
+
+// generateFromConfig generates every output declared in the config file named
+// by --config. The spec is parsed once and shared by all outputs.
+func generateFromConfig(fs *flag.FlagSet, flags *GenerateFlags) error {
+	cfg, err := generator.LoadConfigFile(flags.Config)
+	if err != nil {
+		return err
+	}
+
+	specPath := cfg.Input
+	switch fs.NArg() {
+	case 0:
+	case 1:
+		specPath = fs.Arg(0)
+	default:
+		fs.Usage()
+		return fmt.Errorf("generate command accepts at most one file path, URL, or '-' for stdin with --config")
+	}
+	if specPath == "" {
+		return fmt.Errorf("no specification given: set input in %s or pass a file path", flags.Config)
+	}
+
+	startTime := time.Now()
+	var parseResult *parser.ParseResult
+	if specPath == StdinFilePath {
+		parseResult, err = parser.New().ParseReader(os.Stdin)
+	} else {
+		parseResult, err = parser.ParseWithOptions(
+			parser.WithFilePath(specPath),
+			parser.WithSourceMap(flags.SourceMap),
+		)
+	}
+	if err != nil {
+		return fmt.Errorf("parsing specification: %w", err)
+	}
+	if len(parseResult.Errors) > 0 {
+		return fmt.Errorf("specification has %d parse error(s), cannot generate", len(parseResult.Errors))
+	}
+	parseTime := time.Since(startTime)
+
+	var checkErrs []error
+	for _, out := range cfg.Outputs {
+		opts := append([]generator.Option{generator.WithParsed(*parseResult)}, cfg.Options(out)...)
+		if parseResult.SourceMap != nil {
+			opts = append(opts, generator.WithSourceMap(parseResult.SourceMap))
+		}
+
+		genStart := time.Now()
+		result, err := generator.GenerateWithOptions(opts...)
+		if err != nil {
+			return fmt.Errorf("generating %s: %w", out.Output, err)
+		}
+		err = reportGeneration(result, specPath, out.Output, parseTime+time.Since(genStart), flags.SourceMap, flags.Check)
+		if err != nil {
+			if !flags.Check {
+				return err
+			}
+			// Check every output, so one run reports all that are out of date
+			checkErrs = append(checkErrs, err)
+		}
+	}
+	return errors.Join(checkErrs...)
+}
@@ -243,6 +243,98 @@ paths:
 	}
 }
 
+// TestHandleGenerate_LangTypeScript verifies --lang typescript writes
+// TypeScript files instead of Go.
+func TestHandleGenerate_LangTypeScript(t *testing.T) {
+	spec := `openapi: "3.0.0"
+info:
+  title: Test API
+  version: "1.0.0"
+paths:
+  /test:
+    get:
+      operationId: getTest
+      responses:
+        '200':
+          description: Success
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Test'
+components:
+  schemas:
+    Test:
+      type: object
+      properties:
+        name:
+          type: string
+`
+	tmpDir := t.TempDir()
+	specFile := filepath.Join(tmpDir, "spec.yaml")
+	require.NoError(t, os.WriteFile(specFile, []byte(spec), 0600))
+
+	outputDir := filepath.Join(tmpDir, "output")
+	require.NoError(t, HandleGenerate([]string{"-o", outputDir, "--lang", "typescript", "--client", specFile}))
+
+	entries, err := os.ReadDir(outputDir)
+	require.NoError(t, err)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	assert.ElementsMatch(t, []string{"client.ts", "types.ts"}, names)
+
+	err = HandleGenerate([]string{"-o", outputDir, "--lang", "typescript", "--server", specFile})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not supported for TypeScript")
+}
+
+// TestHandleGenerate_Check verifies --check compares without writing and fails on drift.
+func TestHandleGenerate_Check(t *testing.T) {
+	spec := `openapi: "3.0.0"
+info:
+  title: Test API
+  version: "1.0.0"
+paths: {}
+components:
+  schemas:
+    Test:
+      type: object
+      properties:
+        name:
+          type: string
+`
+	tmpDir := t.TempDir()
+	specFile := filepath.Join(tmpDir, "spec.yaml")
+	require.NoError(t, os.WriteFile(specFile, []byte(spec), 0600))
+	outputDir := filepath.Join(tmpDir, "output")
+
+	// Nothing generated yet: every file is missing, and nothing is written
+	err := HandleGenerate([]string{"--check", "--client", "-o", outputDir, specFile})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "out of date")
+	assert.NoDirExists(t, outputDir)
+
+	// Unchanged output passes, including the README generated by default
+	require.NoError(t, HandleGenerate([]string{"--client", "-o", outputDir, specFile}))
+	require.FileExists(t, filepath.Join(outputDir, "README.md"))
+	require.NoError(t, HandleGenerate([]string{"--check", "--client", "-o", outputDir, specFile}))
+
+	// A spec edit without regeneration is drift
+	edited := strings.Replace(spec, "name:", "title:", 1)
+	require.NoError(t, os.WriteFile(specFile, []byte(edited), 0600))
+	before, err := os.ReadFile(filepath.Join(outputDir, "types.go"))
+	require.NoError(t, err)
+
+	err = HandleGenerate([]string{"--check", "--client", "-o", outputDir, specFile})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 file(s)")
+
+	after, err := os.ReadFile(filepath.Join(outputDir, "types.go"))
+	require.NoError(t, err)
+	assert.Equal(t, string(before), string(after), "--check must not write")
+}
+
 // TestHandleGenerate_FileSplittingFlagsHonored verifies file splitting options work.
 func TestHandleGenerate_FileSplittingFlagsHonored(t *testing.T) {
 	spec := `openapi: "3.0.0"
@@ -347,3 +439,68 @@ func TestHandleGenerate_NoGenerationMode(t *testing.T) {
 	err := HandleGenerate([]string{"-o", "./out", "--types=false", "spec.yaml"})
 	assert.Error(t, err)
 }
+
+// TestHandleGenerate_Config verifies --config generates each declared output
+// with its own package and toggles, and applies the import mapping.
+func TestHandleGenerate_Config(t *testing.T) {
+	spec := `openapi: "3.0.0"
+info:
+  title: Test API
+  version: "1.0.0"
+paths:
+  /test:
+    get:
+      operationId: getTest
+      responses:
+        '200':
+          description: Success
+          content:
+            application/json:
+              schema:
+                $ref: 'common.yaml#/components/schemas/Error'
+`
+	config := `input: spec.yaml
+import-mapping:
+  common.yaml: example.com/apis/common
+outputs:
+  - output: client
+    package: testclient
+    client: true
+    readme: false
+  - output: server
+    package: testserver
+    server: true
+    types: true
+`
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "spec.yaml"), []byte(spec), 0600))
+	configFile := filepath.Join(tmpDir, "oastools-gen.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(config), 0600))
+
+	require.NoError(t, HandleGenerate([]string{"--config", configFile}))
+
+	client, err := os.ReadFile(filepath.Join(tmpDir, "client", "client.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(client), "package testclient")
+	assert.Contains(t, string(client), "*common.Error")
+	assert.Contains(t, string(client), `"example.com/apis/common"`)
+	assert.NoFileExists(t, filepath.Join(tmpDir, "client", "README.md"))
+
+	server, err := os.ReadFile(filepath.Join(tmpDir, "server", "server.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(server), "package testserver")
+	assert.NoFileExists(t, filepath.Join(tmpDir, "server", "client.go"))
+}
+
+func TestHandleGenerate_ConfigErrors(t *testing.T) {
+	tmpDir := t.TempDir()
+	configFile := filepath.Join(tmpDir, "oastools-gen.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte("outputs:\n  - output: api\n"), 0600))
+
+	err := HandleGenerate([]string{"--config", configFile})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no specification given")
+
+	err = HandleGenerate([]string{"--config", filepath.Join(tmpDir, "missing.yaml")})
+	require.Error(t, err)
+}
@@ -65,6 +65,34 @@ func (n namespacePrefixFlag) Set(value string) error {
 	return nil
 }
 
+// pathPrefixFlag is a custom flag type for collecting path prefix mappings.
+// It allows the flag to be specified multiple times, each with "source=/prefix" format.
+type pathPrefixFlag map[string]string
+
+// String returns the string representation of the flag value
+func (p pathPrefixFlag) String() string {
+	return namespacePrefixFlag(p).String()
+}
+
+// Set parses a "source=/prefix" value and adds it to the map.
+// An empty prefix is allowed and only folds the source's server path.
+func (p pathPrefixFlag) Set(value string) error {
+	source, prefix, ok := strings.Cut(value, "=")
+	if !ok {
+		return fmt.Errorf("invalid path prefix format: %q (expected source=/prefix)", value)
+	}
+	source = strings.TrimSpace(source)
+	prefix = strings.TrimSpace(prefix)
+	if source == "" {
+		return fmt.Errorf("path prefix requires non-empty source: %q", value)
+	}
+	if prefix != "" && !strings.HasPrefix(prefix, "/") {
+		return fmt.Errorf("path prefix must start with '/': %q", value)
+	}
+	p[source] = prefix
+	return nil
+}
+
 // JoinFlags contains flags for the join command
 type JoinFlags struct {
 	Output            string
@@ -75,6 +103,7 @@ type JoinFlags struct {
 	NoDedupTags       bool
 	Quiet             bool
 	SourceMap         bool
+	PreserveFormat    bool
 	// Advanced collision strategies
 	RenameTemplate  string
 	EquivalenceMode string
@@ -84,6 +113,11 @@ type JoinFlags struct {
 	// Namespace prefix configuration
 	NamespacePrefix namespacePrefixFlag
 	AlwaysPrefix    bool
+	// Path prefix configuration
+	PathPrefix pathPrefixFlag
+	// Provenance configuration
+	SourceExtension string
+	SourceLabel     namespacePrefixFlag
 	// Operation context configuration
 	OperationContext       bool
 	PrimaryOperationPolicy string
@@ -98,6 +132,8 @@ func SetupJoinFlags() (*flag.FlagSet, *JoinFlags) {
 	fs := flag.NewFlagSet("join", flag.ContinueOnError)
 	flags := &JoinFlags{
 		NamespacePrefix: make(namespacePrefixFlag),
+		PathPrefix:      make(pathPrefixFlag),
+		SourceLabel:     make(namespacePrefixFlag),
 	}
 
 	fs.StringVar(&flags.Output, "o", "", "output file path (default: stdout)")
@@ -111,6 +147,7 @@ func SetupJoinFlags() (*flag.FlagSet, *JoinFlags) {
 	fs.BoolVar(&flags.Quiet, "quiet", false, "quiet mode: suppress diagnostic messages (for pipelining)")
 	fs.BoolVar(&flags.SourceMap, "source-map", false, "include line numbers in collision warnings (IDE-friendly format)")
 	fs.BoolVar(&flags.SourceMap, "s", false, "include line numbers in collision warnings (IDE-friendly format)")
+	fs.BoolVar(&flags.PreserveFormat, "preserve-format", false, "keep the comments, anchors, quoting and blank lines of the first YAML input, changing only what the join adds")
 
 	// Advanced collision strategies
 	fs.StringVar(&flags.RenameTemplate, "rename-template", "{{.Name}}_{{.Source}}", "template for renamed schema names")
@@ -124,6 +161,14 @@ func SetupJoinFlags() (*flag.FlagSet, *JoinFlags) {
 	fs.Var(flags.NamespacePrefix, "namespace-prefix", "namespace prefix for source file (format: source=prefix, can be repeated)")
 	fs.BoolVar(&flags.AlwaysPrefix, "always-prefix", false, "apply namespace prefix to all schemas, not just on collision")
 
+	// Path prefix configuration
+	fs.Var(flags.PathPrefix, "path-prefix", "mount a source file's paths under a prefix (format: source=/prefix, can be repeated)")
+
+	// Provenance configuration
+	fs.StringVar(&flags.SourceExtension, "source-extension", "",
+		"stamp path items, operations and components with this extension naming their source (e.g. x-oastools-source)")
+	fs.Var(flags.SourceLabel, "source-label", "value stamped by --source-extension for a source file (format: source=label, can be repeated)")
+
 	// Operation context configuration
 	fs.BoolVar(&flags.OperationContext, "operation-context", false,
 		"enable operation-aware schema renaming (adds Path, Method, OperationID, Tags to templates)")
@@ -159,6 +204,16 @@ func SetupJoinFlags() (*flag.FlagSet, *JoinFlags) {
 		Writef(fs.Output(), "  Format: source=prefix (can be specified multiple times)\n")
 		Writef(fs.Output(), "  By default, prefix is only applied on collision. Use --always-prefix to\n")
 		Writef(fs.Output(), "  apply namespace prefixes to all schemas from the configured sources.\n")
+		Writef(fs.Output(), "\nPath Prefixes:\n")
+		Writef(fs.Output(), "  Use --path-prefix to mount a source's paths under a prefix, e.g. when\n")
+		Writef(fs.Output(), "  composing a gateway spec from microservice specs.\n")
+		Writef(fs.Output(), "  Format: source=/prefix (can be specified multiple times)\n")
+		Writef(fs.Output(), "  The path portion of the source's server URLs (OAS 3.x) or basePath\n")
+		Writef(fs.Output(), "  (OAS 2.0) is folded into its paths, and $refs into the paths are rewritten.\n")
+		Writef(fs.Output(), "\nProvenance:\n")
+		Writef(fs.Output(), "  Use --source-extension to record in the output which source each path item,\n")
+		Writef(fs.Output(), "  operation and component came from. The value is the source file path, or\n")
+		Writef(fs.Output(), "  the label given with --source-label.\n")
 		Writef(fs.Output(), "\nOperation Context:\n")
 		Writef(fs.Output(), "  When --operation-context is enabled, rename templates gain access to:\n")
 		Writef(fs.Output(), "  - {{.Path}}, {{.Method}}, {{.OperationID}}, {{.Tags}}\n")
@@ -194,6 +249,14 @@ func SetupJoinFlags() (*flag.FlagSet, *JoinFlags) {
 		Writef(fs.Output(), "  oastools join --namespace-prefix api2.yaml=V2 --always-prefix \\\n")
 		Writef(fs.Output(), "    -o merged.yaml api1.yaml api2.yaml\n")
 		Writef(fs.Output(), "\n")
+		Writef(fs.Output(), "  # Mount services under path prefixes\n")
+		Writef(fs.Output(), "  oastools join --path-prefix billing.yaml=/billing \\\n")
+		Writef(fs.Output(), "    --path-prefix users.yaml=/users -o gateway.yaml billing.yaml users.yaml\n")
+		Writef(fs.Output(), "\n")
+		Writef(fs.Output(), "  # Record which team's spec each operation came from\n")
+		Writef(fs.Output(), "  oastools join --source-extension x-owner --source-label billing.yaml=team-billing \\\n")
+		Writef(fs.Output(), "    --source-label users.yaml=team-identity -o gateway.yaml billing.yaml users.yaml\n")
+		Writef(fs.Output(), "\n")
 		Writef(fs.Output(), "  # Operation-aware renaming with OperationID\n")
 		Writef(fs.Output(), "  oastools join --schema-strategy rename-right --operation-context \\\n")
 		Writef(fs.Output(), "    --rename-template \"{{.OperationID | pascalCase}}{{.Name}}\" api1.yaml api2.yaml\n")
@@ -212,6 +275,9 @@ func SetupJoinFlags() (*flag.FlagSet, *JoinFlags) {
 		Writef(fs.Output(), "\n")
 		Writef(fs.Output(), "  # Source mapping for IDE-friendly warnings\n")
 		Writef(fs.Output(), "  oastools join -s -o merged.yaml api1.yaml api2.yaml\n")
+		Writef(fs.Output(), "\n")
+		Writef(fs.Output(), "  # Keep the formatting of the base document\n")
+		Writef(fs.Output(), "  oastools join --preserve-format -o merged.yaml base.yaml extensions.yaml\n")
 		Writef(fs.Output(), "\nPipelining:\n")
 		Writef(fs.Output(), "  oastools join -q base.yaml ext.yaml | oastools validate -q -\n")
 		Writef(fs.Output(), "  oastools join -q spec1.yaml spec2.yaml | oastools convert -q -t 3.1.0 -\n")
@@ -219,6 +285,7 @@ func SetupJoinFlags() (*flag.FlagSet, *JoinFlags) {
 		Writef(fs.Output(), "  - All input files must be the same major OAS version (2.0 or 3.x)\n")
 		Writef(fs.Output(), "  - The output will use the version of the first input file\n")
 		Writef(fs.Output(), "  - Info section is taken from the first document by default\n")
+		Writef(fs.Output(), "  - With --preserve-format, YAML output is written as edits to the first input\n")
 		Writef(fs.Output(), "  - When -o is specified, file is written with restrictive permissions (0600)\n")
 	}
 
@@ -264,6 +331,24 @@ func HandleJoin(args []string) error {
 	}
 	config.AlwaysApplyPrefix = flags.AlwaysPrefix
 
+	// Apply path prefix configuration
+	if len(flags.PathPrefix) > 0 {
+		config.PathPrefix = make(map[string]string)
+		maps.Copy(config.PathPrefix, flags.PathPrefix)
+	}
+
+	// Apply provenance configuration
+	if flags.SourceExtension != "" {
+		if !strings.HasPrefix(flags.SourceExtension, "x-") {
+			return fmt.Errorf("source-extension must start with 'x-': %s", flags.SourceExtension)
+		}
+		config.SourceExtension = flags.SourceExtension
+	}
+	if len(flags.SourceLabel) > 0 {
+		config.SourceLabels = make(map[string]string)
+		maps.Copy(config.SourceLabels, flags.SourceLabel)
+	}
+
 	// Validate and parse strategy flags
 	if err := ValidateCollisionStrategy("path-strategy", flags.PathStrategy); err != nil {
 		return err
@@ -311,18 +396,24 @@ func HandleJoin(args []string) error {
 		joiner.WithConfig(config),
 	}
 
-	// Add source map support if requested
-	if flags.SourceMap {
+	// Add source map or preserved formatting support if requested
+	var source *parser.ParseResult
+	if flags.SourceMap || flags.PreserveFormat {
 		parsedDocs := make([]parser.ParseResult, 0, len(filePaths))
 		sourceMaps := make(map[string]*parser.SourceMap)
-		for _, path := range filePaths {
+		for i, path := range filePaths {
 			parseResult, parseErr := parser.ParseWithOptions(
 				parser.WithFilePath(path),
-				parser.WithSourceMap(true),
+				parser.WithSourceMap(flags.SourceMap),
+				// Only the first document's formatting is carried to the output
+				parser.WithPreserveFormatting(flags.PreserveFormat && i == 0),
 			)
 			if parseErr != nil {
 				return fmt.Errorf("parsing %s: %w", path, parseErr)
 			}
+			if i == 0 {
+				source = parseResult
+			}
 			parsedDocs = append(parsedDocs, *parseResult)
 			if parseResult.SourceMap != nil {
 				sourceMaps[path] = parseResult.SourceMap
@@ -332,7 +423,9 @@ func HandleJoin(args []string) error {
 		joinOpts = []joiner.Option{
 			joiner.WithParsed(parsedDocs...),
 			joiner.WithConfig(config),
-			joiner.WithSourceMaps(sourceMaps),
+		}
+		if flags.SourceMap {
+			joinOpts = append(joinOpts, joiner.WithSourceMaps(sourceMaps))
 		}
 	}
 
@@ -395,7 +488,7 @@ func HandleJoin(args []string) error {
 	// Write output
 	if flags.Output != "" {
 		// Write to file with restrictive permissions (matching joiner.WriteResult behavior)
-		data, dataErr := MarshalDocument(result.Document, result.SourceFormat)
+		data, dataErr := MarshalPreservedDocument(source, result.Document, result.SourceFormat)
 		if dataErr != nil {
 			return fmt.Errorf("marshaling joined document: %w", dataErr)
 		}
@@ -416,7 +509,7 @@ func HandleJoin(args []string) error {
 		}
 	} else {
 		// Write to stdout
-		data, dataErr := MarshalDocument(result.Document, result.SourceFormat)
+		data, dataErr := MarshalPreservedDocument(source, result.Document, result.SourceFormat)
 		if dataErr != nil {
 			return fmt.Errorf("marshaling joined document: %w", dataErr)
 		}
@@ -135,6 +135,50 @@ func TestSetupJoinFlags_NamespacePrefix(t *testing.T) {
 	})
 }
 
+func TestSetupJoinFlags_PathPrefix(t *testing.T) {
+	t.Run("parse multiple path prefixes", func(t *testing.T) {
+		fs, flags := SetupJoinFlags()
+		args := []string{
+			"--path-prefix", "billing.yaml=/billing",
+			"--path-prefix", "users.yaml=/users",
+			"f1.yaml", "f2.yaml",
+		}
+		err := fs.Parse(args)
+		require.NoError(t, err)
+		assert.Equal(t, "/billing", flags.PathPrefix["billing.yaml"])
+		assert.Equal(t, "/users", flags.PathPrefix["users.yaml"])
+	})
+
+	t.Run("empty prefix folds server path only", func(t *testing.T) {
+		fs, flags := SetupJoinFlags()
+		err := fs.Parse([]string{"--path-prefix", "api.yaml=", "f1.yaml", "f2.yaml"})
+		require.NoError(t, err)
+		prefix, ok := flags.PathPrefix["api.yaml"]
+		assert.True(t, ok)
+		assert.Empty(t, prefix)
+	})
+
+	t.Run("invalid path prefixes", func(t *testing.T) {
+		for _, value := range []string{"invalid", "=/billing", "api.yaml=billing"} {
+			fs, _ := SetupJoinFlags()
+			err := fs.Parse([]string{"--path-prefix", value, "f1.yaml", "f2.yaml"})
+			assert.Error(t, err, value)
+		}
+	})
+}
+
+func TestSetupJoinFlags_SourceExtension(t *testing.T) {
+	fs, flags := SetupJoinFlags()
+	args := []string{
+		"--source-extension", "x-owner",
+		"--source-label", "billing.yaml=team-billing",
+		"f1.yaml", "f2.yaml",
+	}
+	require.NoError(t, fs.Parse(args))
+	assert.Equal(t, "x-owner", flags.SourceExtension)
+	assert.Equal(t, "team-billing", flags.SourceLabel["billing.yaml"])
+}
+
 func TestNamespacePrefixFlag_String(t *testing.T) {
 	npf := make(namespacePrefixFlag)
 	npf["a.yaml"] = "A"
@@ -21,6 +21,8 @@ type OverlayApplyFlags struct {
 	Strict bool
 	Quiet  bool
 	DryRun bool
+
+	PreserveFormat bool
 }
 
 // OverlayValidateFlags contains flags for the overlay validate command
@@ -28,6 +30,14 @@ type OverlayValidateFlags struct {
 	Quiet bool
 }
 
+// OverlayGenerateFlags contains flags for the overlay generate command
+type OverlayGenerateFlags struct {
+	From   string
+	To     string
+	Output string
+	Quiet  bool
+}
+
 // SetupOverlayApplyFlags creates and configures a FlagSet for the overlay apply command.
 // Returns the FlagSet and an OverlayApplyFlags struct with bound flag variables.
 func SetupOverlayApplyFlags() (*flag.FlagSet, *OverlayApplyFlags) {
@@ -43,6 +53,7 @@ func SetupOverlayApplyFlags() (*flag.FlagSet, *OverlayApplyFlags) {
 	fs.BoolVar(&flags.DryRun, "n", false, "preview changes without applying")
 	fs.BoolVar(&flags.Quiet, "q", false, "quiet mode: only output the document, no diagnostic messages")
 	fs.BoolVar(&flags.Quiet, "quiet", false, "quiet mode: only output the document, no diagnostic messages")
+	fs.BoolVar(&flags.PreserveFormat, "preserve-format", false, "keep the comments, anchors, quoting and blank lines of YAML input, changing only what the overlay changes")
 
 	fs.Usage = func() {
 		Writef(fs.Output(), "Usage: oastools overlay apply [flags] <overlay-file>\n\n")
@@ -54,6 +65,7 @@ func SetupOverlayApplyFlags() (*flag.FlagSet, *OverlayApplyFlags) {
 		Writef(fs.Output(), "  oastools overlay apply -s openapi.yaml -o production.yaml changes.yaml\n")
 		Writef(fs.Output(), "  oastools overlay apply --dry-run -s api.yaml changes.yaml\n")
 		Writef(fs.Output(), "  oastools overlay apply --strict -s api.yaml changes.yaml\n")
+		Writef(fs.Output(), "  oastools overlay apply --preserve-format -s api.yaml -o api.yaml changes.yaml\n")
 		Writef(fs.Output(), "  cat openapi.yaml | oastools overlay apply -s - changes.yaml\n")
 		Writef(fs.Output(), "\nPipelining:\n")
 		Writef(fs.Output(), "  - Use '-' as the spec path to read from stdin\n")
@@ -61,8 +73,10 @@ func SetupOverlayApplyFlags() (*flag.FlagSet, *OverlayApplyFlags) {
 		Writef(fs.Output(), "\nNotes:\n")
 		Writef(fs.Output(), "  - Actions are applied sequentially in order\n")
 		Writef(fs.Output(), "  - Update actions merge content, remove actions delete matched nodes\n")
+		Writef(fs.Output(), "  - Copy actions (overlay 1.1.0) merge the node another JSONPath selects\n")
 		Writef(fs.Output(), "  - When both update and remove are specified, remove takes precedence\n")
 		Writef(fs.Output(), "  - Use --strict to fail if any target matches nothing\n")
+		Writef(fs.Output(), "  - Use --preserve-format to keep the diff of YAML output to the overlaid values\n")
 		Writef(fs.Output(), "\nExit Codes:\n")
 		Writef(fs.Output(), "  0    Overlay applied successfully\n")
 		Writef(fs.Output(), "  1    Overlay application failed\n")
@@ -102,11 +116,47 @@ func SetupOverlayValidateFlags() (*flag.FlagSet, *OverlayValidateFlags) {
 	return fs, flags
 }
 
+// SetupOverlayGenerateFlags creates and configures a FlagSet for the overlay generate command.
+// Returns the FlagSet and an OverlayGenerateFlags struct with bound flag variables.
+func SetupOverlayGenerateFlags() (*flag.FlagSet, *OverlayGenerateFlags) {
+	fs := flag.NewFlagSet("overlay generate", flag.ContinueOnError)
+	flags := &OverlayGenerateFlags{}
+
+	fs.StringVar(&flags.From, "from", "", "OpenAPI specification to start from (required)")
+	fs.StringVar(&flags.To, "to", "", "OpenAPI specification the overlay should produce (required)")
+	fs.StringVar(&flags.Output, "o", "", "output file path (default: stdout)")
+	fs.StringVar(&flags.Output, "output", "", "output file path (default: stdout)")
+	fs.BoolVar(&flags.Quiet, "q", false, "quiet mode: only output the overlay, no diagnostic messages")
+	fs.BoolVar(&flags.Quiet, "quiet", false, "quiet mode: only output the overlay, no diagnostic messages")
+
+	fs.Usage = func() {
+		Writef(fs.Output(), "Usage: oastools overlay generate [flags] --from <spec> --to <spec>\n\n")
+		Writef(fs.Output(), "Generate the overlay that transforms one OpenAPI specification into another.\n\n")
+		Writef(fs.Output(), "Flags:\n")
+		fs.PrintDefaults()
+		Writef(fs.Output(), "\nExamples:\n")
+		Writef(fs.Output(), "  oastools overlay generate --from upstream.yaml --to patched.yaml\n")
+		Writef(fs.Output(), "  oastools overlay generate --from upstream.yaml --to patched.yaml -o patches.overlay.yaml\n")
+		Writef(fs.Output(), "\nNotes:\n")
+		Writef(fs.Output(), "  - Removed properties become remove actions\n")
+		Writef(fs.Output(), "  - Added and changed properties are merged by one update per object\n")
+		Writef(fs.Output(), "  - Items added to or removed from the end of an array are appended or removed;\n")
+		Writef(fs.Output(), "    other array changes replace the array\n")
+		Writef(fs.Output(), "  - Applying the overlay to --from reproduces --to\n")
+		Writef(fs.Output(), "  - Nothing is written when the specifications are identical\n")
+		Writef(fs.Output(), "\nExit Codes:\n")
+		Writef(fs.Output(), "  0    Overlay generated (or specifications are identical)\n")
+		Writef(fs.Output(), "  1    Generation failed\n")
+	}
+
+	return fs, flags
+}
+
 // HandleOverlay executes the overlay command
 func HandleOverlay(args []string) error {
 	if len(args) < 1 {
 		printOverlayUsage()
-		return fmt.Errorf("overlay command requires a subcommand: apply, validate")
+		return fmt.Errorf("overlay command requires a subcommand: apply, validate, generate")
 	}
 
 	switch args[0] {
@@ -114,6 +164,8 @@ func HandleOverlay(args []string) error {
 		return handleOverlayApply(args[1:])
 	case "validate":
 		return handleOverlayValidate(args[1:])
+	case "generate":
+		return handleOverlayGenerate(args[1:])
 	case "-h", flagHelpLong, "help":
 		printOverlayUsage()
 		return nil
@@ -126,11 +178,12 @@ func HandleOverlay(args []string) error {
 func printOverlayUsage() {
 	Writef(os.Stderr, `Usage: oastools overlay <subcommand> [options]
 
-Apply or validate OpenAPI Overlay documents (v1.0.0).
+Apply, validate or generate OpenAPI Overlay documents (v1.0.0 and v1.1.0).
 
 Subcommands:
   apply       Apply an overlay to an OpenAPI specification
   validate    Validate an overlay document
+  generate    Generate an overlay from the difference between two specifications
 
 Run 'oastools overlay <subcommand> --help' for more information.
 `)
@@ -162,12 +215,25 @@ func handleOverlayApply(args []string) error {
 	// Build common options
 	startTime := time.Now()
 	var opts []overlay.Option
+	var source *parser.ParseResult
 	if flags.Spec == StdinFilePath {
 		p := parser.New()
+		p.PreserveFormatting = flags.PreserveFormat
 		parseResult, err := p.ParseReader(os.Stdin)
 		if err != nil {
 			return fmt.Errorf("parsing stdin: %w", err)
 		}
+		source = parseResult
+		opts = append(opts, overlay.WithSpecParsed(*parseResult))
+	} else if flags.PreserveFormat {
+		parseResult, err := parser.ParseWithOptions(
+			parser.WithFilePath(flags.Spec),
+			parser.WithPreserveFormatting(true),
+		)
+		if err != nil {
+			return fmt.Errorf("parsing specification: %w", err)
+		}
+		source = parseResult
 		opts = append(opts, overlay.WithSpecParsed(*parseResult))
 	} else {
 		opts = append(opts, overlay.WithSpecFilePath(flags.Spec))
@@ -231,7 +297,7 @@ func handleOverlayApply(args []string) error {
 	}
 
 	// Write output
-	data, err := MarshalDocument(result.Document, result.SourceFormat)
+	data, err := MarshalPreservedDocument(source, result.Document, result.SourceFormat)
 	if err != nil {
 		return fmt.Errorf("marshaling result document: %w", err)
 	}
@@ -375,3 +441,81 @@ func handleOverlayValidate(args []string) error {
 
 	return nil
 }
+
+func handleOverlayGenerate(args []string) error {
+	fs, flags := SetupOverlayGenerateFlags()
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return fmt.Errorf("overlay generate takes no positional arguments")
+	}
+	if flags.From == "" || flags.To == "" {
+		fs.Usage()
+		return fmt.Errorf("both specifications are required (use --from and --to)")
+	}
+
+	startTime := time.Now()
+	result, err := overlay.NewGenerator().Generate(flags.From, flags.To)
+	if err != nil {
+		return fmt.Errorf("generating overlay: %w", err)
+	}
+	totalTime := time.Since(startTime)
+
+	if !flags.Quiet {
+		Writef(os.Stderr, "OpenAPI Overlay Generation\n")
+		Writef(os.Stderr, "==========================\n\n")
+		Writef(os.Stderr, "oastools version: %s\n", oastools.Version())
+		Writef(os.Stderr, "From: %s\n", flags.From)
+		Writef(os.Stderr, "To: %s\n", flags.To)
+		Writef(os.Stderr, "Total Time: %v\n\n", totalTime)
+
+		if !result.HasActions() {
+			Writef(os.Stderr, "✓ Specifications are identical; no overlay written\n")
+			return nil
+		}
+
+		Writef(os.Stderr, "Actions:\n")
+		for i, action := range result.Overlay.Actions {
+			operation := "update"
+			if action.Remove {
+				operation = "remove"
+			}
+			Writef(os.Stderr, "  [%d] %s: %s\n", i, operation, action.Target)
+		}
+		Writef(os.Stderr, "\n✓ Generated %d action(s)\n", len(result.Overlay.Actions))
+	}
+
+	if !result.HasActions() {
+		return nil
+	}
+
+	data, err := overlay.MarshalOverlay(result.Overlay)
+	if err != nil {
+		return fmt.Errorf("marshaling overlay: %w", err)
+	}
+
+	if flags.Output != "" {
+		cleanedOutput := filepath.Clean(flags.Output)
+		// Reject symlinks to prevent symlink attacks
+		if err := RejectSymlinkOutput(cleanedOutput); err != nil {
+			return err
+		}
+		if err := os.WriteFile(cleanedOutput, data, fileutil.OwnerReadWrite); err != nil { //nolint:gosec // G703 - output path is user-provided CLI flag
+			return fmt.Errorf("writing output file: %w", err)
+		}
+		if !flags.Quiet {
+			Writef(os.Stderr, "\nOutput written to: %s\n", cleanedOutput)
+		}
+	} else if _, err = os.Stdout.Write(data); err != nil {
+		return fmt.Errorf("writing overlay to stdout: %w", err)
+	}
+
+	return nil
+}
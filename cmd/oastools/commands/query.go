@@ -0,0 +1,339 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/erraggy/oastools/jsonpath"
+	"github.com/erraggy/oastools/parser"
+)
+
+// headerValue is the table header for value columns.
+const headerValue = "VALUE"
+
+// maxRefHops bounds how many $ref targets are followed when locating a node,
+// so circular references cannot loop.
+const maxRefHops = 32
+
+// QueryFlags contains flags for the query command.
+type QueryFlags struct {
+	Format      string // Output format: text, json, yaml.
+	Quiet       bool   // Print only the values, one per line.
+	ResolveRefs bool   // Resolve $ref pointers before querying.
+}
+
+// SetupQueryFlags creates and configures a FlagSet for the query command.
+func SetupQueryFlags() (*flag.FlagSet, *QueryFlags) {
+	fs := flag.NewFlagSet("query", flag.ContinueOnError)
+	flags := &QueryFlags{}
+
+	fs.StringVar(&flags.Format, "format", FormatText, "Output format: text, json, yaml")
+	fs.BoolVar(&flags.Quiet, "quiet", false, "Print only the values, one per line")
+	fs.BoolVar(&flags.Quiet, "q", false, "Print only the values, one per line (shorthand)")
+	fs.BoolVar(&flags.ResolveRefs, "resolve-refs", true, "Resolve $ref pointers, including external files, before querying")
+
+	fs.Usage = func() {
+		Writef(fs.Output(), "Usage: oastools query [flags] <expression> <file|url|->\n\n")
+		Writef(fs.Output(), "Evaluate an RFC 9535 JSONPath expression against an OpenAPI specification.\n\n")
+		Writef(fs.Output(), "Flags:\n")
+		fs.PrintDefaults()
+		Writef(fs.Output(), "\nExamples:\n")
+		Writef(fs.Output(), "  oastools query '$.paths[*][?@.deprecated==true].operationId' api.yaml\n")
+		Writef(fs.Output(), "  oastools query --format json '$..[?@.x-internal==true]' api.yaml | jq\n")
+		Writef(fs.Output(), "  oastools query -q '$.paths.*.*[?count(@.parameters[*])>5].operationId' api.yaml\n")
+		Writef(fs.Output(), "\nNotes:\n")
+		Writef(fs.Output(), "  - Each match carries its normalized path and, for local files and stdin,\n")
+		Writef(fs.Output(), "    the file, line and column it was written at\n")
+		Writef(fs.Output(), "  - Nodes reached through a resolved $ref are located in the referenced file\n")
+		Writef(fs.Output(), "  - Flags must precede the expression\n")
+	}
+
+	return fs, flags
+}
+
+// queryMatch is a node selected by a query, with its source location.
+type queryMatch struct {
+	Path   string `json:"path" yaml:"path"`
+	File   string `json:"file,omitempty" yaml:"file,omitempty"`
+	Line   int    `json:"line,omitempty" yaml:"line,omitempty"`
+	Column int    `json:"column,omitempty" yaml:"column,omitempty"`
+	Value  any    `json:"value" yaml:"value"`
+}
+
+// HandleQuery executes the query command.
+func HandleQuery(args []string) error {
+	fs, flags := SetupQueryFlags()
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	if err := ValidateOutputFormat(flags.Format); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return fmt.Errorf("query requires an expression and a spec file argument")
+	}
+
+	path, err := jsonpath.Parse(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("query: %w", err)
+	}
+
+	matches, err := runQuery(path, fs.Arg(1), flags.ResolveRefs)
+	if err != nil {
+		return fmt.Errorf("query: %w", err)
+	}
+
+	if len(matches) == 0 {
+		if !flags.Quiet {
+			Writef(os.Stderr, "No nodes matched the expression.\n")
+		}
+		return nil
+	}
+
+	return renderQueryMatches(os.Stdout, matches, flags)
+}
+
+// runQuery parses the specification and evaluates the path against its
+// generic form, locating each match in the file it was written in.
+func runQuery(path *jsonpath.Path, specPath string, resolveRefs bool) ([]queryMatch, error) {
+	p := parser.New()
+	p.ResolveRefs = resolveRefs
+
+	locator := newSourceLocator(resolveRefs)
+	var result *parser.ParseResult
+	if specPath == StdinFilePath {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, fmt.Errorf("reading stdin: %w", err)
+		}
+		if result, err = p.ParseBytes(data); err != nil {
+			return nil, err
+		}
+		locator.addSource("", data)
+	} else {
+		var err error
+		if result, err = p.Parse(specPath); err != nil {
+			return nil, err
+		}
+	}
+
+	nodes := path.Query(result.Data)
+	matches := make([]queryMatch, 0, len(nodes))
+	for _, node := range nodes {
+		match := queryMatch{Path: node.Location.String(), Value: node.Value}
+		if loc := locator.locate(specPath, node.Location); loc.IsKnown() {
+			match.File, match.Line, match.Column = loc.File, loc.Line, loc.Column
+		}
+		matches = append(matches, match)
+	}
+	return matches, nil
+}
+
+// renderQueryMatches writes matches as a table, as values only in quiet
+// mode, or as a structured list.
+func renderQueryMatches(w io.Writer, matches []queryMatch, flags *QueryFlags) error {
+	if flags.Format != FormatText {
+		return RenderDetail(w, matches, flags.Format)
+	}
+
+	if flags.Quiet {
+		for _, m := range matches {
+			Writef(w, "%s\n", formatQueryValue(m.Value))
+		}
+		return nil
+	}
+
+	rows := make([][]string, 0, len(matches))
+	for _, m := range matches {
+		source := parser.SourceLocation{File: m.File, Line: m.Line, Column: m.Column}.String()
+		rows = append(rows, []string{m.Path, source, formatQueryValue(m.Value)})
+	}
+	RenderSummaryTable(w, []string{headerPath, "SOURCE", headerValue}, rows, false)
+	return nil
+}
+
+// formatQueryValue formats a value on one line: strings as they are, and
+// anything else as compact JSON.
+func formatQueryValue(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+// sourceLocator finds where the nodes of a resolved document were written,
+// following $ref targets into the files they name. It keeps one source map
+// per file, because the parser's merged source map mixes the paths of
+// every file.
+type sourceLocator struct {
+	maps map[string]*parser.SourceMap // by file path; nil when unavailable
+	// followRefs is set when the document's references were resolved.
+	followRefs bool
+}
+
+func newSourceLocator(followRefs bool) *sourceLocator {
+	return &sourceLocator{maps: make(map[string]*parser.SourceMap), followRefs: followRefs}
+}
+
+// addSource records the source map of a document that cannot be re-read,
+// such as stdin.
+func (l *sourceLocator) addSource(file string, data []byte) {
+	sm, _ := parser.NewSourceMapFromBytes(data, file)
+	l.maps[file] = sm
+}
+
+// sourceMap returns the source map of a local file, or nil for URLs and
+// unreadable files.
+func (l *sourceLocator) sourceMap(file string) *parser.SourceMap {
+	if sm, ok := l.maps[file]; ok {
+		return sm
+	}
+	var sm *parser.SourceMap
+	if !strings.HasPrefix(file, "http://") && !strings.HasPrefix(file, "https://") {
+		if data, err := os.ReadFile(file); err == nil {
+			sm, _ = parser.NewSourceMapFromBytes(data, file)
+		}
+	}
+	l.maps[file] = sm
+	return sm
+}
+
+// locate returns the source location of the node at loc in the document
+// read from file. When a $ref on the way to the node was resolved, the
+// node was copied from the ref's target and is located there instead.
+func (l *sourceLocator) locate(file string, loc jsonpath.Location) parser.SourceLocation {
+	if file == StdinFilePath {
+		file = ""
+	}
+	for range maxRefHops {
+		sm := l.sourceMap(file)
+		if sm == nil {
+			return parser.SourceLocation{}
+		}
+
+		paths := sourceMapPaths(sm, loc)
+		i, ref := l.firstRef(sm, paths)
+		if ref == "" {
+			return sm.Get(paths[len(paths)-1])
+		}
+		targetFile, target, ok := splitRef(file, ref)
+		if !ok {
+			return parser.SourceLocation{}
+		}
+		file, loc = targetFile, append(target, loc[i:]...)
+	}
+	return parser.SourceLocation{}
+}
+
+// firstRef returns the first of paths, from the root down, that held a
+// resolved $ref, with the ref's value.
+func (l *sourceLocator) firstRef(sm *parser.SourceMap, paths []string) (int, string) {
+	if !l.followRefs {
+		return 0, ""
+	}
+	for i, path := range paths {
+		if ref := sm.GetRef(path).TargetRef; ref != "" {
+			return i, ref
+		}
+	}
+	return 0, ""
+}
+
+// splitRef resolves a $ref written in file to the file it names and the
+// location its JSON Pointer fragment selects. References to URLs, and to
+// other files from stdin, cannot be followed.
+func splitRef(file, ref string) (string, jsonpath.Location, bool) {
+	refFile, fragment, _ := strings.Cut(ref, "#")
+	if refFile != "" {
+		if strings.Contains(refFile, "://") || file == "" {
+			return "", nil, false
+		}
+		if !filepath.IsAbs(refFile) {
+			refFile = filepath.Join(filepath.Dir(file), refFile)
+		}
+		file = refFile
+	}
+
+	var loc jsonpath.Location
+	if fragment = strings.TrimPrefix(fragment, "/"); fragment == "" {
+		return file, loc, true
+	}
+	for token := range strings.SplitSeq(fragment, "/") {
+		if decoded, err := url.PathUnescape(token); err == nil {
+			token = decoded
+		}
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		loc = append(loc, token)
+	}
+	return file, loc, true
+}
+
+// sourceMapPaths converts a location to the path notation of
+// parser.SourceMap, such as $.paths./pets.get.responses['200'], returning
+// the path of each of its prefixes from the root to the full location.
+// JSON Pointer fragments name array elements with digits, so a name of
+// digits is read as an index when the source map knows it as one.
+func sourceMapPaths(sm *parser.SourceMap, loc jsonpath.Location) []string {
+	paths := make([]string, 0, len(loc)+1)
+	path := "$"
+	paths = append(paths, path)
+	for _, elem := range loc {
+		switch elem := elem.(type) {
+		case int:
+			path = fmt.Sprintf("%s[%d]", path, elem)
+		case string:
+			member := sourceMapMember(path, elem)
+			if index := path + "[" + elem + "]"; isDigits(elem) && !sm.Has(member) && sm.Has(index) {
+				member = index
+			}
+			path = member
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// sourceMapMember appends a member name to a source map path, using the
+// bracket notation the parser uses for names that need it.
+func sourceMapMember(parent, name string) string {
+	needsBrackets := name == "" || isDigit(name[0]) ||
+		strings.ContainsAny(name, ".[]'\" \t\n\r")
+	if needsBrackets {
+		return parent + "['" + strings.ReplaceAll(name, "'", `\'`) + "']"
+	}
+	return parent + "." + name
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := range len(s) {
+		if !isDigit(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigit(ch byte) bool {
+	return ch >= '0' && ch <= '9'
+}
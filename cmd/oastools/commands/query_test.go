@@ -0,0 +1,223 @@
+package commands
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/erraggy/oastools/jsonpath"
+	"github.com/erraggy/oastools/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testQuerySpecYAML references a local schema and one in testQuerySchemasYAML.
+const testQuerySpecYAML = `openapi: "3.0.3"
+info:
+  title: Test
+  version: "1.0"
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      deprecated: true
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pets'
+  /owners:
+    get:
+      operationId: listOwners
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: 'schemas.yaml#/Owner'
+components:
+  schemas:
+    Pets:
+      type: array
+      items:
+        type: object
+        properties:
+          name:
+            type: string
+`
+
+const testQuerySchemasYAML = `Owner:
+  type: object
+  properties:
+    email:
+      type: string
+      format: email
+`
+
+// writeQueryTestSpec writes the multi-file test spec to a temp directory.
+func writeQueryTestSpec(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "schemas.yaml"), []byte(testQuerySchemasYAML), 0o644))
+	specPath := filepath.Join(dir, "api.yaml")
+	require.NoError(t, os.WriteFile(specPath, []byte(testQuerySpecYAML), 0o644))
+	return specPath
+}
+
+func TestHandleQuery_MissingArguments(t *testing.T) {
+	err := HandleQuery([]string{"$.info"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "requires an expression and a spec file")
+}
+
+func TestHandleQuery_InvalidExpression(t *testing.T) {
+	err := HandleQuery([]string{"$.paths[", writeQueryTestSpec(t)})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "jsonpath:")
+}
+
+func TestHandleQuery_InvalidFormat(t *testing.T) {
+	err := HandleQuery([]string{"--format", "xml", "$.info", "api.yaml"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid format")
+}
+
+func TestHandleQuery_Text(t *testing.T) {
+	specPath := writeQueryTestSpec(t)
+
+	output := captureStdout(t, func() {
+		require.NoError(t, HandleQuery([]string{"$.paths[*][?@.deprecated==true].operationId", specPath}))
+	})
+
+	assert.Contains(t, output, "PATH")
+	assert.Contains(t, output, "$['paths']['/pets']['get']['operationId']")
+	assert.Contains(t, output, specPath+":8:20")
+	assert.Contains(t, output, "listPets")
+	assert.NotContains(t, output, "listOwners")
+}
+
+func TestHandleQuery_Quiet(t *testing.T) {
+	specPath := writeQueryTestSpec(t)
+
+	output := captureStdout(t, func() {
+		require.NoError(t, HandleQuery([]string{"-q", "$.paths.*.get.operationId", specPath}))
+	})
+
+	assert.Equal(t, "listOwners\nlistPets\n", output)
+}
+
+func TestHandleQuery_JSON(t *testing.T) {
+	specPath := writeQueryTestSpec(t)
+
+	output := captureStdout(t, func() {
+		require.NoError(t, HandleQuery([]string{"--format", "json", "$..[?@.format=='email']", specPath}))
+	})
+
+	var matches []queryMatch
+	require.NoError(t, json.Unmarshal([]byte(output), &matches))
+	require.Len(t, matches, 1)
+
+	// The schema was resolved from the external file and is located there
+	m := matches[0]
+	assert.Equal(t, "$['paths']['/owners']['get']['responses']['200']['content']['application/json']['schema']['properties']['email']", m.Path)
+	assert.Equal(t, filepath.Join(filepath.Dir(specPath), "schemas.yaml"), m.File)
+	assert.Equal(t, 5, m.Line)
+	assert.Equal(t, map[string]any{"type": "string", "format": "email"}, m.Value)
+}
+
+func TestHandleQuery_YAML(t *testing.T) {
+	specPath := writeQueryTestSpec(t)
+
+	output := captureStdout(t, func() {
+		require.NoError(t, HandleQuery([]string{"--format", "yaml", "$.info.title", specPath}))
+	})
+
+	assert.Contains(t, output, "path: $['info']['title']")
+	assert.Contains(t, output, "line: 3")
+	assert.Contains(t, output, "value: Test")
+}
+
+func TestHandleQuery_NoMatches(t *testing.T) {
+	specPath := writeQueryTestSpec(t)
+
+	output := captureStdout(t, func() {
+		require.NoError(t, HandleQuery([]string{"-q", "$.webhooks", specPath}))
+	})
+
+	assert.Empty(t, output)
+}
+
+func TestRunQuery_LocalRef(t *testing.T) {
+	specPath := writeQueryTestSpec(t)
+
+	matches, err := runQuery(jsonpath.MustParse("$.paths['/pets']..properties.name.type"), specPath, true)
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+
+	// Located at the referenced component schema
+	assert.Equal(t, specPath, matches[0].File)
+	assert.Equal(t, 35, matches[0].Line)
+	assert.Equal(t, "string", matches[0].Value)
+}
+
+func TestRunQuery_WithoutRefResolution(t *testing.T) {
+	specPath := writeQueryTestSpec(t)
+
+	matches, err := runQuery(jsonpath.MustParse("$.paths['/owners'].get..schema['$ref']"), specPath, false)
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+
+	assert.Equal(t, "schemas.yaml#/Owner", matches[0].Value)
+	assert.Equal(t, specPath, matches[0].File)
+	assert.Equal(t, 26, matches[0].Line)
+}
+
+func TestSourceMapPaths(t *testing.T) {
+	sm, err := parser.NewSourceMapFromBytes([]byte(testQuerySpecYAML), "api.yaml")
+	require.NoError(t, err)
+
+	paths := sourceMapPaths(sm, jsonpath.Location{"paths", "/pets", "get", "responses", "200", "content", "application/json"})
+	assert.Equal(t, "$.paths./pets.get.responses['200'].content.application/json", paths[len(paths)-1])
+	assert.Len(t, paths, 8)
+
+	// A JSON Pointer names array elements by digits
+	sm, err = parser.NewSourceMapFromBytes([]byte("tags:\n  - name: a\n"), "tags.yaml")
+	require.NoError(t, err)
+	paths = sourceMapPaths(sm, jsonpath.Location{"tags", "0", "name"})
+	assert.Equal(t, "$.tags[0].name", paths[len(paths)-1])
+}
+
+func TestSplitRef(t *testing.T) {
+	file, loc, ok := splitRef("specs/api.yaml", "#/components/schemas/Pet")
+	require.True(t, ok)
+	assert.Equal(t, "specs/api.yaml", file)
+	assert.Equal(t, jsonpath.Location{"components", "schemas", "Pet"}, loc)
+
+	file, loc, ok = splitRef("specs/api.yaml", "common.yaml#/paths/~1pets~0v2")
+	require.True(t, ok)
+	assert.Equal(t, filepath.Join("specs", "common.yaml"), file)
+	assert.Equal(t, jsonpath.Location{"paths", "/pets~v2"}, loc)
+
+	file, loc, ok = splitRef("specs/api.yaml", "pet.yaml")
+	require.True(t, ok)
+	assert.Equal(t, filepath.Join("specs", "pet.yaml"), file)
+	assert.Empty(t, loc)
+
+	_, _, ok = splitRef("specs/api.yaml", "https://example.com/api.yaml#/Pet")
+	assert.False(t, ok)
+	_, _, ok = splitRef("", "pet.yaml#/Pet")
+	assert.False(t, ok, "refs to other files cannot be followed from stdin")
+}
+
+func TestFormatQueryValue(t *testing.T) {
+	assert.Equal(t, "plain", formatQueryValue("plain"))
+	assert.Equal(t, "42", formatQueryValue(42))
+	assert.Equal(t, `{"a":[1,true]}`, formatQueryValue(map[string]any{"a": []any{1, true}}))
+	assert.Equal(t, "null", formatQueryValue(nil))
+	assert.True(t, strings.HasPrefix(formatQueryValue([]any{"x"}), "["))
+}
@@ -14,7 +14,7 @@ import (
 
 // validCommands lists all valid command names for typo suggestions
 var validCommands = []string{
-	"validate", "fix", "convert", "diff", "generate", "join", "mcp", "overlay", "parse", "walk", "version", "help",
+	"validate", "fix", "convert", "diff", "export", "generate", "join", "mcp", "overlay", "parse", "query", "walk", "version", "help",
 }
 
 // levenshteinDistance calculates the minimum edit distance between two strings
@@ -111,6 +111,11 @@ func main() {
 			commands.Writef(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
+	case "export":
+		if err := commands.HandleExport(os.Args[2:]); err != nil {
+			commands.Writef(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
 	case "generate":
 		if err := commands.HandleGenerate(os.Args[2:]); err != nil {
 			commands.Writef(os.Stderr, "Error: %v\n", err)
@@ -126,6 +131,11 @@ func main() {
 			commands.Writef(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
+	case "query":
+		if err := commands.HandleQuery(os.Args[2:]); err != nil {
+			commands.Writef(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
 	case "walk":
 		if err := commands.HandleWalk(os.Args[2:]); err != nil {
 			commands.Writef(os.Stderr, "Error: %v\n", err)
@@ -160,10 +170,12 @@ Commands:
   fix         Apply automatic fixes to common OpenAPI specification issues
   convert     Convert between OpenAPI specification versions
   diff        Compare two OpenAPI specifications and detect changes
+  export      Export component schemas as JSON Schema or Protobuf
   generate    Generate Go client/server code from an OpenAPI specification
   join        Join multiple OpenAPI specification files
   overlay     Apply or validate OpenAPI Overlay documents
   parse       Parse and display an OpenAPI specification file or URL
+  query       Evaluate a JSONPath expression against a specification
   walk        Query and explore OpenAPI specification documents
   mcp         Start an MCP server over stdio
   version     Show version information
@@ -175,9 +187,11 @@ Examples:
   oastools validate https://example.com/api/openapi.yaml
   oastools convert -t 3.0.3 swagger.yaml -o openapi.yaml
   oastools diff --breaking api-v1.yaml api-v2.yaml
+  oastools export --to jsonschema -o ./schemas openapi.yaml
   oastools generate --client -o ./client openapi.yaml
   oastools join -o merged.yaml base.yaml extensions.yaml
   oastools overlay apply -s openapi.yaml changes.yaml -o production.yaml
+  oastools query '$.paths.*.*[?@.deprecated==true].operationId' api.yaml
   oastools parse https://raw.githubusercontent.com/OAI/OpenAPI-Specification/main/examples/v3.0/petstore.yaml
 
 Run 'oastools <command> --help' for more information on a command.`)
@@ -137,6 +137,9 @@ type Converter struct {
 	// SourceMap provides source location lookup for conversion issues.
 	// When set, issues will include Line, Column, and File information.
 	SourceMap *parser.SourceMap
+	// Lossless preserves the OAS 3.x constructs a conversion to OAS 2.0
+	// would drop in x-oas3-* extensions. See WithLossless.
+	Lossless bool
 	// sourceHeaders holds OAS 3.x component headers during 3.0-to-2.0 conversion
 	sourceHeaders map[string]*parser.Header
 }
@@ -165,6 +168,7 @@ type convertConfig struct {
 	strictMode  bool
 	includeInfo bool
 	userAgent   string
+	lossless    bool
 
 	// Source map for line/column tracking
 	sourceMap *parser.SourceMap
@@ -205,6 +209,7 @@ func ConvertWithOptions(opts ...Option) (*ConversionResult, error) {
 		IncludeInfo: cfg.includeInfo,
 		UserAgent:   cfg.userAgent,
 		SourceMap:   cfg.sourceMap,
+		Lossless:    cfg.lossless,
 	}
 
 	// Check if any overlays are configured
@@ -527,9 +532,14 @@ func (c *Converter) convertOAS3ToOAS3(parseResult parser.ParseResult, targetVers
 	// Add informational message about version update
 	c.addInfoWithContext(result, "openapi", fmt.Sprintf("Updated version from %s to %s", parseResult.Version, result.TargetVersion), "OAS 3.x versions are generally compatible, but verify features are supported")
 
-	// Check for nullable deprecation when converting 3.0.x to 3.1.x
+	// Check for nullable deprecation when converting 3.0.x to 3.1.x, and
+	// convert boolean exclusiveMaximum/exclusiveMinimum to numeric form
 	if c.isOAS30(parseResult.OASVersion) && c.isOAS31OrLater(targetVersion) {
 		c.checkNullableDeprecation(converted, result)
+		visited := make(map[*parser.Schema]bool)
+		forEachDocumentSchema(converted, func(schema *parser.Schema, path string) {
+			fixSchemaExclusiveMinMaxForOAS31(c, schema, result, path, visited)
+		})
 	}
 
 	// Report the 3.2 fixed fields when the target predates them. Gated on the
@@ -554,21 +564,26 @@ func (c *Converter) isOAS31OrLater(v parser.OASVersion) bool {
 
 // checkNullableDeprecation walks the document and warns about nullable usage
 func (c *Converter) checkNullableDeprecation(doc *parser.OAS3Document, result *ConversionResult) {
-	// Check component schemas
+	forEachDocumentSchema(doc, func(schema *parser.Schema, path string) {
+		c.checkSchemaNullable(schema, path, result)
+	})
+}
+
+// forEachDocumentSchema calls fn for each component schema, and for the
+// request body, response and parameter schemas of every operation
+func forEachDocumentSchema(doc *parser.OAS3Document, fn func(schema *parser.Schema, path string)) {
 	if doc.Components != nil && doc.Components.Schemas != nil {
 		for name, schema := range doc.Components.Schemas {
-			c.checkSchemaNullable(schema, fmt.Sprintf("components.schemas.%s", name), result)
+			fn(schema, fmt.Sprintf("components.schemas.%s", name))
 		}
 	}
-
-	// Check paths
 	for pathPattern, pathItem := range doc.Paths {
-		c.checkPathItemNullable(pathItem, fmt.Sprintf("paths.%s", pathPattern), result)
+		forEachPathItemSchema(pathItem, fmt.Sprintf("paths.%s", pathPattern), fn)
 	}
 }
 
-// checkPathItemNullable checks all operations in a path item for nullable schemas
-func (c *Converter) checkPathItemNullable(pathItem *parser.PathItem, pathPrefix string, result *ConversionResult) {
+// forEachPathItemSchema calls forEachOperationSchema for every operation in a path item
+func forEachPathItemSchema(pathItem *parser.PathItem, pathPrefix string, fn func(schema *parser.Schema, path string)) {
 	if pathItem == nil {
 		return
 	}
@@ -584,28 +599,28 @@ func (c *Converter) checkPathItemNullable(pathItem *parser.PathItem, pathPrefix
 	}
 	for method, op := range ops {
 		if op != nil {
-			c.checkOperationNullable(op, fmt.Sprintf("%s.%s", pathPrefix, method), result)
+			forEachOperationSchema(op, fmt.Sprintf("%s.%s", pathPrefix, method), fn)
 		}
 	}
 }
 
-// checkOperationNullable checks request body and responses for nullable schemas
-func (c *Converter) checkOperationNullable(op *parser.Operation, opPath string, result *ConversionResult) {
-	// Check request body
+// forEachOperationSchema calls fn for the request body, response and parameter schemas of an operation
+func forEachOperationSchema(op *parser.Operation, opPath string, fn func(schema *parser.Schema, path string)) {
+	// Request body
 	if op.RequestBody != nil && op.RequestBody.Content != nil {
 		for mediaType, content := range op.RequestBody.Content {
 			if content.Schema != nil {
-				c.checkSchemaNullable(content.Schema, fmt.Sprintf("%s.requestBody.content.%s.schema", opPath, mediaType), result)
+				fn(content.Schema, fmt.Sprintf("%s.requestBody.content.%s.schema", opPath, mediaType))
 			}
 		}
 	}
 
-	// Check responses
+	// Responses
 	if op.Responses != nil {
 		if op.Responses.Default != nil && op.Responses.Default.Content != nil {
 			for mediaType, content := range op.Responses.Default.Content {
 				if content.Schema != nil {
-					c.checkSchemaNullable(content.Schema, fmt.Sprintf("%s.responses.default.content.%s.schema", opPath, mediaType), result)
+					fn(content.Schema, fmt.Sprintf("%s.responses.default.content.%s.schema", opPath, mediaType))
 				}
 			}
 		}
@@ -613,17 +628,17 @@ func (c *Converter) checkOperationNullable(op *parser.Operation, opPath string,
 			if response != nil && response.Content != nil {
 				for mediaType, content := range response.Content {
 					if content.Schema != nil {
-						c.checkSchemaNullable(content.Schema, fmt.Sprintf("%s.responses.%s.content.%s.schema", opPath, code, mediaType), result)
+						fn(content.Schema, fmt.Sprintf("%s.responses.%s.content.%s.schema", opPath, code, mediaType))
 					}
 				}
 			}
 		}
 	}
 
-	// Check parameters
+	// Parameters
 	for i, param := range op.Parameters {
 		if param != nil && param.Schema != nil {
-			c.checkSchemaNullable(param.Schema, fmt.Sprintf("%s.parameters[%d].schema", opPath, i), result)
+			fn(param.Schema, fmt.Sprintf("%s.parameters[%d].schema", opPath, i))
 		}
 	}
 }
@@ -1306,6 +1306,60 @@ func TestNullableDeprecationWarning(t *testing.T) {
 	assert.Equal(t, 3, nullableWarnings, "Expected 3 nullable deprecation warnings")
 }
 
+// TestExclusiveBoundsFor30To31 tests that boolean exclusiveMaximum and
+// exclusiveMinimum become numeric when converting from OAS 3.0.x to OAS 3.1.x
+func TestExclusiveBoundsFor30To31(t *testing.T) {
+	maximum, minimum := 10.0, 1.0
+	oas3Doc := &parser.OAS3Document{
+		OpenAPI:    "3.0.3",
+		OASVersion: parser.OASVersion303,
+		Info:       &parser.Info{Title: "Test API", Version: "1.0.0"},
+		Components: &parser.Components{
+			Schemas: map[string]*parser.Schema{
+				"Bounded": {
+					Type: "object",
+					Properties: map[string]*parser.Schema{
+						"score": {Type: "number", Maximum: &maximum, ExclusiveMaximum: true, Minimum: &minimum, ExclusiveMinimum: false},
+					},
+				},
+				"Unbounded": {Type: "number", ExclusiveMinimum: true},
+			},
+		},
+		Paths: map[string]*parser.PathItem{
+			"/items": {Get: &parser.Operation{
+				Parameters: []*parser.Parameter{{Name: "limit", In: "query", Schema: &parser.Schema{Type: "integer", Maximum: &maximum, ExclusiveMaximum: true}}},
+			}},
+		},
+	}
+
+	result, err := New().ConvertParsed(parser.ParseResult{
+		Document:   oas3Doc,
+		Version:    "3.0.3",
+		OASVersion: parser.OASVersion303,
+	}, "3.1.0")
+	require.NoError(t, err)
+	converted := result.Document.(*parser.OAS3Document)
+
+	score := converted.Components.Schemas["Bounded"].Properties["score"]
+	assert.Equal(t, 10.0, score.ExclusiveMaximum)
+	assert.Nil(t, score.Maximum)
+	assert.Nil(t, score.ExclusiveMinimum)
+	assert.Equal(t, &minimum, score.Minimum)
+	assert.Nil(t, converted.Components.Schemas["Unbounded"].ExclusiveMinimum)
+	assert.Equal(t, 10.0, converted.Paths["/items"].Get.Parameters[0].Schema.ExclusiveMaximum)
+
+	var dropped []string
+	for _, issue := range result.Issues {
+		if strings.Contains(issue.Message, "'exclusiveMinimum: true' but no 'minimum'") {
+			dropped = append(dropped, issue.Path)
+		}
+	}
+	assert.Equal(t, []string{"components.schemas.Unbounded"}, dropped)
+
+	// The source document is not modified
+	assert.Equal(t, true, oas3Doc.Components.Schemas["Bounded"].Properties["score"].ExclusiveMaximum)
+}
+
 // TestNullableDeprecationNotTriggeredFor30To30 tests that nullable warnings
 // are not generated when converting within 3.0.x versions
 func TestNullableDeprecationNotTriggeredFor30To30(t *testing.T) {
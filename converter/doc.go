@@ -39,6 +39,14 @@
 // (collectionFormat, allowEmptyValue) may not map perfectly to OAS 3.x. See the
 // examples in example_test.go for handling issues.
 //
+// # Lossless Downgrades
+//
+// [WithLossless] keeps everything a conversion to OAS 2.0 would drop or alter
+// in x-oas3-* extensions, such as x-oas3-webhooks on the document and
+// x-oas3-callbacks on an operation, and reports the constructs it preserved as
+// info rather than critical. Converting such a document back to OAS 3.x
+// restores them, so the round trip yields a document equal to the source.
+//
 // # What the Converted Document Carries
 //
 // Specification extensions are preserved on every object the converter rebuilds:
@@ -0,0 +1,468 @@
+package converter
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"strings"
+
+	"go.yaml.in/yaml/v4"
+
+	"github.com/erraggy/oastools/parser"
+)
+
+// oas3ExtensionPrefix begins the extensions that carry OAS 3.x constructs
+// through an OAS 2.0 document. Each is named for the field it preserves, so
+// the operation's callbacks travel in x-oas3-callbacks.
+const oas3ExtensionPrefix = "x-oas3-"
+
+// oas3ComponentsExtension holds the components entries an OAS 2.0 document
+// cannot carry, keyed by section as in the Components Object.
+const oas3ComponentsExtension = oas3ExtensionPrefix + "components"
+
+// oas3RequestBodyContentExtension holds a request body's content when the
+// rest of the body survives as a body parameter.
+const oas3RequestBodyContentExtension = oas3ExtensionPrefix + "requestBody-content"
+
+// WithLossless enables or disables lossless conversion to OAS 2.0.
+//
+// When enabled, every OAS 3.x construct that would be dropped or altered by
+// the conversion is preserved in an x-oas3-* extension on the nearest OAS 2.0
+// object: webhooks, callbacks, TRACE and QUERY operations, OpenID Connect
+// schemes, request bodies with several media types, and so on. The issues
+// reported for them are downgraded to info. Converting the result back to
+// OAS 3.x restores them, whether or not this option is set.
+// Default: false
+func WithLossless(enabled bool) Option {
+	return func(cfg *convertConfig) error {
+		cfg.lossless = enabled
+		return nil
+	}
+}
+
+// docField is a field of an OAS 3.x object that is compared across a round
+// trip, and restored by copying it from a decoded stash.
+type docField[T any] struct {
+	key  string
+	copy func(dst, from *T)
+}
+
+var documentFields = []docField[parser.OAS3Document]{
+	{"info", func(dst, from *parser.OAS3Document) { dst.Info = from.Info }},
+	{"servers", func(dst, from *parser.OAS3Document) { dst.Servers = from.Servers }},
+	{"webhooks", func(dst, from *parser.OAS3Document) { dst.Webhooks = from.Webhooks }},
+	{"security", func(dst, from *parser.OAS3Document) { dst.Security = from.Security }},
+	{"tags", func(dst, from *parser.OAS3Document) { dst.Tags = from.Tags }},
+	{"externalDocs", func(dst, from *parser.OAS3Document) { dst.ExternalDocs = from.ExternalDocs }},
+	{"jsonSchemaDialect", func(dst, from *parser.OAS3Document) { dst.JSONSchemaDialect = from.JSONSchemaDialect }},
+	{"$self", func(dst, from *parser.OAS3Document) { dst.Self = from.Self }},
+}
+
+var pathItemFields = []docField[parser.PathItem]{
+	{"$ref", func(dst, from *parser.PathItem) { dst.Ref = from.Ref }},
+	{"summary", func(dst, from *parser.PathItem) { dst.Summary = from.Summary }},
+	{"description", func(dst, from *parser.PathItem) { dst.Description = from.Description }},
+	{"trace", func(dst, from *parser.PathItem) { dst.Trace = from.Trace }},
+	{"query", func(dst, from *parser.PathItem) { dst.Query = from.Query }},
+	{"servers", func(dst, from *parser.PathItem) { dst.Servers = from.Servers }},
+	{"parameters", func(dst, from *parser.PathItem) { dst.Parameters = from.Parameters }},
+	{"additionalOperations", func(dst, from *parser.PathItem) { dst.AdditionalOperations = from.AdditionalOperations }},
+}
+
+var operationFields = []docField[parser.Operation]{
+	{"tags", func(dst, from *parser.Operation) { dst.Tags = from.Tags }},
+	{"summary", func(dst, from *parser.Operation) { dst.Summary = from.Summary }},
+	{"description", func(dst, from *parser.Operation) { dst.Description = from.Description }},
+	{"externalDocs", func(dst, from *parser.Operation) { dst.ExternalDocs = from.ExternalDocs }},
+	{"operationId", func(dst, from *parser.Operation) { dst.OperationID = from.OperationID }},
+	{"parameters", func(dst, from *parser.Operation) { dst.Parameters = from.Parameters }},
+	{"requestBody", func(dst, from *parser.Operation) { dst.RequestBody = from.RequestBody }},
+	{"responses", func(dst, from *parser.Operation) { dst.Responses = from.Responses }},
+	{"callbacks", func(dst, from *parser.Operation) {
+		dst.Callbacks = from.Callbacks
+		dst.CallbackRefs = from.CallbackRefs
+	}},
+	{"deprecated", func(dst, from *parser.Operation) { dst.Deprecated = from.Deprecated }},
+	{"security", func(dst, from *parser.Operation) { dst.Security = from.Security }},
+	{"servers", func(dst, from *parser.Operation) { dst.Servers = from.Servers }},
+}
+
+// Keys of the x-oas3 extensions restored on each object: one per field, plus
+// the extensions that preserve part of a field.
+var (
+	documentStashKeys  = append(fieldKeys(documentFields), "components")
+	pathItemStashKeys  = fieldKeys(pathItemFields)
+	operationStashKeys = append(fieldKeys(operationFields), "requestBody-content")
+)
+
+// fieldKeys returns the key of each field.
+func fieldKeys[T any](fields []docField[T]) []string {
+	keys := make([]string, len(fields))
+	for i, f := range fields {
+		keys[i] = f.key
+	}
+	return keys
+}
+
+// losslessState carries a lossless conversion between its helpers.
+type losslessState struct {
+	format parser.SourceFormat
+	// preserved holds the issue paths whose constructs were preserved.
+	// Issues at or below one of them are downgraded to info.
+	preserved map[string]string
+	// preservedOn holds the paths of objects whose issue is about a
+	// preserved field, as an operation's is about its callbacks. Only
+	// issues at exactly one of them are downgraded.
+	preservedOn map[string]string
+}
+
+// preserveOAS3 stashes in dst every part of src that would not survive a
+// conversion back to OAS 3.x. It finds them by converting dst back and
+// comparing the result with src, field by field, in the generic form the
+// source format decodes to.
+func (c *Converter) preserveOAS3(parseResult parser.ParseResult, src *parser.OAS3Document, dst *parser.OAS2Document, result *ConversionResult) error {
+	s := &losslessState{
+		format:      parseResult.SourceFormat,
+		preserved:   make(map[string]string),
+		preservedOn: make(map[string]string),
+	}
+
+	// Convert with a scratch converter, so the round trip reports nothing.
+	scratch := &ConversionResult{TargetVersion: src.OpenAPI}
+	err := (&Converter{}).convertOAS2ToOAS3(parser.ParseResult{
+		Version:      "2.0",
+		OASVersion:   parser.OASVersion20,
+		SourceFormat: s.format,
+		Document:     dst,
+	}, parseResult.OASVersion, scratch)
+	if err != nil {
+		return fmt.Errorf("lossless round trip: %w", err)
+	}
+	back, _ := scratch.Document.(*parser.OAS3Document)
+
+	want, err := s.toGeneric(src)
+	if err != nil {
+		return err
+	}
+	got, err := s.toGeneric(back)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range documentFields {
+		if !reflect.DeepEqual(want[f.key], got[f.key]) {
+			dst.Extra = s.stash(dst.Extra, f.key, want[f.key], f.key)
+		}
+	}
+	s.preserveComponents(asMap(want["components"]), asMap(got["components"]), dst)
+
+	wantPaths, gotPaths := asMap(want["paths"]), asMap(got["paths"])
+	for pathPattern, item := range dst.Paths {
+		s.preservePathItem(asMap(wantPaths[pathPattern]), asMap(gotPaths[pathPattern]), item, fmt.Sprintf("paths.%s", pathPattern))
+	}
+
+	s.downgradeIssues(result)
+	return nil
+}
+
+// preserveComponents stashes the components entries that do not survive the
+// round trip, which includes every entry of a section OAS 2.0 has no home for.
+func (s *losslessState) preserveComponents(want, got map[string]any, dst *parser.OAS2Document) {
+	stashed := make(map[string]any)
+	for key, value := range want {
+		if strings.HasPrefix(key, "x-") {
+			if !reflect.DeepEqual(value, got[key]) {
+				stashed[key] = value
+			}
+			continue
+		}
+		wantSection, gotSection := asMap(value), asMap(got[key])
+		for name, entry := range wantSection {
+			if reflect.DeepEqual(entry, gotSection[name]) {
+				continue
+			}
+			section := asMap(stashed[key])
+			if section == nil {
+				section = make(map[string]any)
+				stashed[key] = section
+			}
+			section[name] = entry
+			s.preserved[fmt.Sprintf("components.%s.%s", key, name)] = oas3ComponentsExtension
+		}
+	}
+	if len(stashed) > 0 {
+		dst.Extra = s.stash(dst.Extra, "components", stashed, "")
+	}
+}
+
+// preservePathItem stashes the fields of a path item, and of its operations,
+// that do not survive the round trip.
+func (s *losslessState) preservePathItem(want, got map[string]any, dst *parser.PathItem, pathPrefix string) {
+	for _, f := range pathItemFields {
+		if !reflect.DeepEqual(want[f.key], got[f.key]) {
+			dst.Extra = s.stash(dst.Extra, f.key, want[f.key], fmt.Sprintf("%s.%s", pathPrefix, f.key))
+		}
+	}
+	for _, method := range standardHTTPMethods {
+		if op := method.getter(dst); op != nil {
+			s.preserveOperation(asMap(want[method.name]), asMap(got[method.name]), op, fmt.Sprintf("%s.%s", pathPrefix, method.name))
+		}
+	}
+}
+
+// preserveOperation stashes the fields of an operation that do not survive
+// the round trip. A request body that differs only in its content keeps the
+// rest as a body parameter and stashes just the content.
+func (s *losslessState) preserveOperation(want, got map[string]any, dst *parser.Operation, opPath string) {
+	for _, f := range operationFields {
+		if reflect.DeepEqual(want[f.key], got[f.key]) {
+			continue
+		}
+		if f.key == "requestBody" && sameExceptContent(asMap(want[f.key]), asMap(got[f.key])) {
+			dst.Extra = s.stash(dst.Extra, "requestBody-content", asMap(want[f.key])["content"], opPath+".requestBody")
+			continue
+		}
+		dst.Extra = s.stash(dst.Extra, f.key, want[f.key], fmt.Sprintf("%s.%s", opPath, f.key))
+		if f.key == "callbacks" {
+			s.preservedOn[opPath] = oas3ExtensionPrefix + f.key
+		}
+	}
+}
+
+// sameExceptContent reports whether two request bodies agree on everything
+// but their content.
+func sameExceptContent(want, got map[string]any) bool {
+	if want == nil || got == nil {
+		return false
+	}
+	want, got = maps.Clone(want), maps.Clone(got)
+	delete(want, "content")
+	delete(got, "content")
+	return reflect.DeepEqual(want, got)
+}
+
+// stash returns extra with value stored under the x-oas3 extension for key,
+// and records issuePath as preserved when it is set.
+func (s *losslessState) stash(extra map[string]any, key string, value any, issuePath string) map[string]any {
+	if extra == nil {
+		extra = make(map[string]any)
+	}
+	name := oas3ExtensionPrefix + key
+	extra[name] = value
+	if issuePath != "" {
+		s.preserved[issuePath] = name
+	}
+	return extra
+}
+
+// downgradeIssues reports the warnings and critical issues about preserved
+// constructs as info, since nothing was lost.
+func (s *losslessState) downgradeIssues(result *ConversionResult) {
+	for i := range result.Issues {
+		issue := &result.Issues[i]
+		if issue.Severity != SeverityWarning && issue.Severity != SeverityCritical {
+			continue
+		}
+		if name, ok := s.preservedAt(issue.Path); ok {
+			issue.Severity = SeverityInfo
+			issue.Context = fmt.Sprintf("Preserved in the %s extension", name)
+		}
+	}
+}
+
+// preservedAt returns the extension preserving the construct at issuePath,
+// which is either a preserved path or lies below one, or is the path of an
+// object whose issue is about a preserved field.
+func (s *losslessState) preservedAt(issuePath string) (string, bool) {
+	if name, ok := s.preservedOn[issuePath]; ok {
+		return name, true
+	}
+	for path, name := range s.preserved {
+		if issuePath == path || strings.HasPrefix(issuePath, path+".") || strings.HasPrefix(issuePath, path+"[") {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// restoreOAS3 replaces the parts of dst preserved in x-oas3 extensions by a
+// lossless conversion, and removes the extensions.
+func (c *Converter) restoreOAS3(dst *parser.OAS3Document, result *ConversionResult) {
+	s := &losslessState{format: result.SourceFormat}
+
+	stashed := takeStash(&dst.Extra, documentStashKeys)
+	if components, ok := stashed["components"]; ok {
+		delete(stashed, "components")
+		s.restoreComponents(dst, components, result)
+	}
+	restoreFields(s, stashed, dst, documentFields, "document", result)
+
+	for pathPattern, item := range dst.Paths {
+		if item == nil {
+			continue
+		}
+		pathPrefix := fmt.Sprintf("paths.%s", pathPattern)
+		restoreFields(s, takeStash(&item.Extra, pathItemStashKeys), item, pathItemFields, pathPrefix, result)
+		for _, method := range standardHTTPMethods {
+			if op := method.getter(item); op != nil {
+				s.restoreOperation(op, fmt.Sprintf("%s.%s", pathPrefix, method.name), result)
+			}
+		}
+	}
+}
+
+// restoreOperation restores the preserved fields of an operation.
+func (s *losslessState) restoreOperation(op *parser.Operation, opPath string, result *ConversionResult) {
+	stashed := takeStash(&op.Extra, operationStashKeys)
+	if content, ok := stashed["requestBody-content"]; ok {
+		delete(stashed, "requestBody-content")
+		var from parser.Operation
+		err := s.fromGeneric(map[string]any{"requestBody": map[string]any{"content": content}}, &from)
+		switch {
+		case err != nil:
+			s.restoreFailed(result, opPath+".requestBody", oas3RequestBodyContentExtension, err)
+		case op.RequestBody == nil:
+			op.RequestBody = from.RequestBody
+		default:
+			op.RequestBody.Content = from.RequestBody.Content
+		}
+	}
+	restoreFields(s, stashed, op, operationFields, opPath, result)
+}
+
+// restoreComponents merges the preserved components entries into dst.
+func (s *losslessState) restoreComponents(dst *parser.OAS3Document, stashed any, result *ConversionResult) {
+	var from parser.Components
+	if err := s.fromGeneric(stashed, &from); err != nil {
+		s.restoreFailed(result, "components", oas3ComponentsExtension, err)
+		return
+	}
+	if dst.Components == nil {
+		dst.Components = &parser.Components{}
+	}
+	to := dst.Components
+	to.Schemas = mergeEntries(to.Schemas, from.Schemas)
+	to.Responses = mergeEntries(to.Responses, from.Responses)
+	to.Parameters = mergeEntries(to.Parameters, from.Parameters)
+	to.Examples = mergeEntries(to.Examples, from.Examples)
+	to.RequestBodies = mergeEntries(to.RequestBodies, from.RequestBodies)
+	to.Headers = mergeEntries(to.Headers, from.Headers)
+	to.SecuritySchemes = mergeEntries(to.SecuritySchemes, from.SecuritySchemes)
+	to.Links = mergeEntries(to.Links, from.Links)
+	to.Callbacks = mergeEntries(to.Callbacks, from.Callbacks)
+	to.CallbackRefs = mergeEntries(to.CallbackRefs, from.CallbackRefs)
+	to.PathItems = mergeEntries(to.PathItems, from.PathItems)
+	to.MediaTypes = mergeEntries(to.MediaTypes, from.MediaTypes)
+	to.Extra = mergeEntries(to.Extra, from.Extra)
+}
+
+// mergeEntries copies from into to, creating to when needed.
+func mergeEntries[V any](to, from map[string]V) map[string]V {
+	if len(from) == 0 {
+		return to
+	}
+	if to == nil {
+		to = make(map[string]V, len(from))
+	}
+	maps.Copy(to, from)
+	return to
+}
+
+// restoreFields decodes the stashed fields into a fresh T and copies each of
+// them into dst.
+func restoreFields[T any](s *losslessState, stashed map[string]any, dst *T, fields []docField[T], path string, result *ConversionResult) {
+	if len(stashed) == 0 {
+		return
+	}
+	var from T
+	if err := s.fromGeneric(stashed, &from); err != nil {
+		s.restoreFailed(result, path, oas3ExtensionPrefix+"*", err)
+		return
+	}
+	for _, f := range fields {
+		if _, ok := stashed[f.key]; ok {
+			f.copy(dst, &from)
+		}
+	}
+}
+
+// takeStash removes the x-oas3 extensions for keys from *extra and returns
+// their values keyed by the field they preserve. Other extensions, including
+// unknown x-oas3 ones, stay in *extra.
+func takeStash(extra *map[string]any, keys []string) map[string]any {
+	var stashed map[string]any
+	for _, key := range keys {
+		name := oas3ExtensionPrefix + key
+		value, ok := (*extra)[name]
+		if !ok {
+			continue
+		}
+		if stashed == nil {
+			stashed = make(map[string]any)
+		}
+		stashed[key] = value
+		delete(*extra, name)
+	}
+	if stashed != nil && len(*extra) == 0 {
+		*extra = nil
+	}
+	return stashed
+}
+
+// restoreFailed reports an x-oas3 extension that could not be decoded.
+func (s *losslessState) restoreFailed(result *ConversionResult, path, name string, err error) {
+	result.Issues = append(result.Issues, ConversionIssue{
+		Path:     path,
+		Message:  fmt.Sprintf("Could not restore the OAS 3.x content preserved in %s: %v", name, err),
+		Severity: SeverityWarning,
+	})
+}
+
+// toGeneric encodes v the way a document in the state's format is written
+// and parsed again, so that values compare and restore exactly as parsing
+// would produce them.
+func (s *losslessState) toGeneric(v any) (map[string]any, error) {
+	var out map[string]any
+	if s.format == parser.SourceFormatJSON {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("lossless encode: %w", err)
+		}
+		err = json.Unmarshal(data, &out)
+		if err != nil {
+			return nil, fmt.Errorf("lossless decode: %w", err)
+		}
+		return out, nil
+	}
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("lossless encode: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("lossless decode: %w", err)
+	}
+	return out, nil
+}
+
+// fromGeneric decodes a generic value into out using the state's format.
+func (s *losslessState) fromGeneric(v any, out any) error {
+	if s.format == parser.SourceFormatJSON {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(data, out)
+	}
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return yaml.Unmarshal(data, out)
+}
+
+// asMap returns v as a map, or nil when it is not one.
+func asMap(v any) map[string]any {
+	m, _ := v.(map[string]any)
+	return m
+}
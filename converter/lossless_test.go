@@ -0,0 +1,259 @@
+package converter
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v4"
+
+	"github.com/erraggy/oastools/parser"
+)
+
+// losslessSpec31 uses the constructs a conversion to OAS 2.0 drops or alters.
+const losslessSpec31 = `openapi: "3.1.0"
+info:
+  title: Events
+  version: "1.0.0"
+jsonSchemaDialect: https://spec.openapis.org/oas/3.1/dialect/base
+servers:
+  - url: https://{region}.events.example.com/v1
+    variables:
+      region:
+        default: eu
+        enum: [eu, us]
+  - url: https://staging.events.example.com/v1
+paths:
+  /subscriptions:
+    summary: Subscriptions
+    post:
+      operationId: subscribe
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Subscription'
+          text/plain:
+            schema:
+              type: string
+      responses:
+        "201":
+          description: Created
+          links:
+            Unsubscribe:
+              operationId: unsubscribe
+      callbacks:
+        onEvent:
+          '{$request.body#/callbackUrl}':
+            post:
+              requestBody:
+                $ref: '#/components/requestBodies/Event'
+              responses:
+                "200":
+                  description: OK
+    trace:
+      responses:
+        "200":
+          description: OK
+  /subscriptions/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: string
+    delete:
+      operationId: unsubscribe
+      security:
+        - oidc: [subscriptions]
+      responses:
+        "204":
+          description: Deleted
+        4XX:
+          description: Client error
+webhooks:
+  event:
+    post:
+      requestBody:
+        $ref: '#/components/requestBodies/Event'
+      responses:
+        "200":
+          description: OK
+components:
+  schemas:
+    Subscription:
+      type: object
+      required: [callbackUrl]
+      properties:
+        callbackUrl:
+          type: string
+          format: uri
+        retries:
+          type: integer
+          default: 3
+  requestBodies:
+    Event:
+      content:
+        application/json:
+          schema:
+            type: object
+  securitySchemes:
+    oidc:
+      type: openIdConnect
+      openIdConnectUrl: https://auth.example.com/.well-known/openid-configuration
+    token:
+      type: http
+      scheme: bearer
+`
+
+func TestLosslessRoundTrip(t *testing.T) {
+	source, err := parser.ParseWithOptions(parser.WithBytes([]byte(losslessSpec31)))
+	require.NoError(t, err)
+
+	down, err := ConvertWithOptions(
+		WithParsed(*source),
+		WithTargetVersion("2.0"),
+		WithLossless(true),
+	)
+	require.NoError(t, err)
+	assert.True(t, down.Success, "preserved constructs are not critical: %v", down.Issues)
+	assert.Zero(t, down.CriticalCount)
+
+	v2, ok := down.Document.(*parser.OAS2Document)
+	require.True(t, ok)
+	assert.Contains(t, v2.Extra, "x-oas3-webhooks")
+	assert.Contains(t, v2.Extra, "x-oas3-servers")
+	assert.Contains(t, v2.Extra, "x-oas3-components")
+	assert.Contains(t, v2.Paths["/subscriptions"].Extra, "x-oas3-trace")
+	assert.Contains(t, v2.Paths["/subscriptions"].Post.Extra, "x-oas3-callbacks")
+	assert.Contains(t, v2.Paths["/subscriptions"].Post.Extra, "x-oas3-requestBody-content")
+	assert.NotContains(t, v2.Definitions["Subscription"].Extra, "x-oas3-schema",
+		"a schema that survives the round trip is not stashed")
+
+	t.Run("in memory", func(t *testing.T) {
+		up, err := ConvertWithOptions(WithParsed(*down.ToParseResult()), WithTargetVersion("3.1.0"))
+		require.NoError(t, err)
+
+		v3, ok := up.Document.(*parser.OAS3Document)
+		require.True(t, ok)
+		assert.True(t, source.Document.(*parser.OAS3Document).Equals(v3), "round trip is not lossless")
+		assert.NotContains(t, v3.Extra, "x-oas3-webhooks")
+	})
+
+	t.Run("written and parsed again", func(t *testing.T) {
+		data, err := yaml.Marshal(v2)
+		require.NoError(t, err)
+		reparsed, err := parser.ParseWithOptions(parser.WithBytes(data))
+		require.NoError(t, err)
+		require.Equal(t, parser.OASVersion20, reparsed.OASVersion)
+
+		up, err := ConvertWithOptions(WithParsed(*reparsed), WithTargetVersion("3.1.0"))
+		require.NoError(t, err)
+		assert.True(t, source.Document.(*parser.OAS3Document).Equals(up.Document.(*parser.OAS3Document)),
+			"round trip through YAML is not lossless")
+	})
+}
+
+func TestLosslessRoundTrip_JSON(t *testing.T) {
+	yamlSource, err := parser.ParseWithOptions(parser.WithBytes([]byte(losslessSpec31)))
+	require.NoError(t, err)
+	data, err := json.Marshal(yamlSource.Document)
+	require.NoError(t, err)
+	source, err := parser.ParseWithOptions(parser.WithBytes(data))
+	require.NoError(t, err)
+	require.Equal(t, parser.SourceFormatJSON, source.SourceFormat)
+
+	down, err := ConvertWithOptions(WithParsed(*source), WithTargetVersion("2.0"), WithLossless(true))
+	require.NoError(t, err)
+
+	written, err := json.Marshal(down.Document)
+	require.NoError(t, err)
+	reparsed, err := parser.ParseWithOptions(parser.WithBytes(written))
+	require.NoError(t, err)
+
+	up, err := ConvertWithOptions(WithParsed(*reparsed), WithTargetVersion("3.1.0"))
+	require.NoError(t, err)
+	assert.True(t, source.Document.(*parser.OAS3Document).Equals(up.Document.(*parser.OAS3Document)),
+		"round trip through JSON is not lossless")
+}
+
+func TestLossless_DowngradesPreservedIssues(t *testing.T) {
+	source, err := parser.ParseWithOptions(parser.WithBytes([]byte(losslessSpec31)))
+	require.NoError(t, err)
+
+	lossy, err := ConvertWithOptions(WithParsed(*source), WithTargetVersion("2.0"))
+	require.NoError(t, err)
+	assert.Positive(t, lossy.CriticalCount)
+	assert.NotContains(t, lossy.Document.(*parser.OAS2Document).Extra, "x-oas3-webhooks",
+		"extensions are only written in lossless mode")
+
+	lossless, err := ConvertWithOptions(WithParsed(*source), WithTargetVersion("2.0"), WithLossless(true))
+	require.NoError(t, err)
+
+	issueAt := func(issues []ConversionIssue, path string) *ConversionIssue {
+		for i, issue := range issues {
+			if issue.Path == path {
+				return &issues[i]
+			}
+		}
+		return nil
+	}
+
+	webhooks := issueAt(lossless.Issues, "webhooks")
+	require.NotNil(t, webhooks)
+	assert.Equal(t, SeverityInfo, webhooks.Severity)
+	assert.Equal(t, "Preserved in the x-oas3-webhooks extension", webhooks.Context)
+
+	// The callbacks issue is reported on the operation, in both modes
+	lossyCallbacks := issueAt(lossy.Issues, "paths./subscriptions.post")
+	require.NotNil(t, lossyCallbacks)
+	assert.Equal(t, SeverityCritical, lossyCallbacks.Severity)
+	callbacks := issueAt(lossless.Issues, "paths./subscriptions.post")
+	require.NotNil(t, callbacks)
+	assert.Equal(t, SeverityInfo, callbacks.Severity)
+	assert.Equal(t, "Preserved in the x-oas3-callbacks extension", callbacks.Context)
+}
+
+func TestLossless_KeepsUnknownExtensions(t *testing.T) {
+	spec := `swagger: "2.0"
+info:
+  title: Extensions
+  version: "1.0.0"
+x-oas3-foo: document
+x-oas3-webhooks:
+  ping:
+    post:
+      responses:
+        "200":
+          description: OK
+paths:
+  /items:
+    x-oas3-bar: path
+    get:
+      x-oas3-baz: operation
+      x-oas3-summary: Restored summary
+      responses:
+        "200":
+          description: OK
+`
+	source, err := parser.ParseWithOptions(parser.WithBytes([]byte(spec)))
+	require.NoError(t, err)
+
+	up, err := ConvertWithOptions(WithParsed(*source), WithTargetVersion("3.1.0"))
+	require.NoError(t, err)
+	v3, ok := up.Document.(*parser.OAS3Document)
+	require.True(t, ok)
+
+	assert.Contains(t, v3.Webhooks, "ping")
+	assert.NotContains(t, v3.Extra, "x-oas3-webhooks")
+	assert.Equal(t, "document", v3.Extra["x-oas3-foo"])
+
+	item := v3.Paths["/items"]
+	require.NotNil(t, item)
+	assert.Equal(t, "path", item.Extra["x-oas3-bar"])
+	assert.Equal(t, "Restored summary", item.Get.Summary)
+	assert.NotContains(t, item.Get.Extra, "x-oas3-summary")
+	assert.Equal(t, "operation", item.Get.Extra["x-oas3-baz"])
+}
@@ -77,6 +77,10 @@ func (c *Converter) convertOAS2ToOAS3(parseResult parser.ParseResult, targetVers
 	// Rewrite all $ref paths from OAS 2.0 to OAS 3.x format
 	c.rewriteAllRefsOAS2ToOAS3(dst)
 
+	// Restore what a lossless conversion preserved; its refs are already in
+	// OAS 3.x form
+	c.restoreOAS3(dst, result)
+
 	result.Document = dst
 	return nil
 }
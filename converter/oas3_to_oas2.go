@@ -126,6 +126,14 @@ func (c *Converter) convertOAS3ToOAS2(parseResult parser.ParseResult, result *Co
 
 	c.sourceHeaders = nil
 
+	// Stash whatever the conversion lost, once dst is complete, so that the
+	// round trip that finds it sees the document as written
+	if c.Lossless {
+		if err := c.preserveOAS3(parseResult, src, dst, result); err != nil {
+			return err
+		}
+	}
+
 	result.Document = dst
 	return nil
 }
@@ -0,0 +1,351 @@
+package differ
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/erraggy/oastools/internal/maputil"
+	"github.com/erraggy/oastools/parser"
+)
+
+// DefaultSunsetExtension is the extension key read for sunset dates when
+// DeprecationRules.SunsetExtension is empty.
+const DefaultSunsetExtension = "x-sunset"
+
+// DeprecationRules configures a "removal requires prior deprecation" policy.
+//
+// When RequirePriorDeprecation is set, removals of operations, parameters,
+// properties and endpoints are re-evaluated against the source document:
+// removing an element that was already marked `deprecated: true` is downgraded,
+// removing one that was not deprecated is escalated, and removing one whose
+// sunset date has not yet been reached is reported as premature. Elements that
+// are still present in the target document although their sunset date has
+// passed are reported as ChangeTypeSunset changes.
+//
+// The policy is only applied in ModeBreaking.
+//
+// Example:
+//
+//	rules := &differ.BreakingRulesConfig{
+//	    Deprecation: &differ.DeprecationRules{
+//	        RequirePriorDeprecation: true,
+//	    },
+//	}
+type DeprecationRules struct {
+	// RequirePriorDeprecation enables the policy.
+	RequirePriorDeprecation bool
+
+	// SunsetExtension is the extension key holding an element's sunset date.
+	// Dates may be written as "2006-01-02", RFC 3339 or RFC 1123 (the format
+	// of the HTTP Sunset header). Default: "x-sunset"
+	SunsetExtension string
+
+	// Now is the reference time for sunset comparisons.
+	// Default: the time the diff is performed
+	Now time.Time
+
+	// DeprecatedRemoval configures the rule for removing an element that was
+	// deprecated in the source document and whose sunset date (if any) has passed.
+	// Default: SeverityInfo
+	DeprecatedRemoval *BreakingChangeRule
+
+	// UndeprecatedRemoval configures the rule for removing an element that was
+	// not deprecated in the source document.
+	// Default: SeverityError, or the original severity when it is higher
+	UndeprecatedRemoval *BreakingChangeRule
+
+	// RemovedBeforeSunset configures the rule for removing a deprecated element
+	// whose sunset date has not been reached yet.
+	// Default: SeverityError, or the original severity when it is higher
+	RemovedBeforeSunset *BreakingChangeRule
+
+	// SunsetPassed configures the rule for elements that are still present in
+	// the target document after their sunset date.
+	// Default: SeverityWarning
+	SunsetPassed *BreakingChangeRule
+}
+
+// deprecationStatus describes the deprecation state of a single element.
+type deprecationStatus struct {
+	deprecated bool
+	sunset     time.Time // zero if no (parseable) sunset date is declared
+}
+
+// sunsetPassed reports whether the element has a sunset date at or before now.
+func (s deprecationStatus) sunsetPassed(now time.Time) bool {
+	return !s.sunset.IsZero() && !s.sunset.After(now)
+}
+
+// sunsetLayouts lists the accepted sunset date formats, most specific first.
+var sunsetLayouts = []string{
+	time.RFC3339,
+	time.RFC1123,
+	time.DateOnly,
+}
+
+// parseSunset parses a sunset extension value.
+// Returns the zero time if the value is missing or not a recognized date.
+func parseSunset(v any) time.Time {
+	switch val := v.(type) {
+	case time.Time:
+		return val
+	case string:
+		s := strings.TrimSpace(val)
+		for _, layout := range sunsetLayouts {
+			if t, err := time.Parse(layout, s); err == nil {
+				return t
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// sunsetKey returns the configured sunset extension key.
+func (r *DeprecationRules) sunsetKey() string {
+	if r.SunsetExtension != "" {
+		return r.SunsetExtension
+	}
+	return DefaultSunsetExtension
+}
+
+// now returns the configured reference time.
+func (r *DeprecationRules) now() time.Time {
+	if !r.Now.IsZero() {
+		return r.Now
+	}
+	return time.Now()
+}
+
+// status builds the deprecation status of an element from its flag and extensions.
+func (r *DeprecationRules) status(deprecated bool, extra map[string]any) deprecationStatus {
+	return deprecationStatus{
+		deprecated: deprecated,
+		sunset:     parseSunset(extra[r.sunsetKey()]),
+	}
+}
+
+// pathItemStatus derives the status of a path item from its operations.
+// A path item is deprecated only when every operation it defines is deprecated;
+// its sunset is the latest operation sunset, unless the path item declares its own.
+// An operation without a sunset date leaves the path item without one.
+func (r *DeprecationRules) pathItemStatus(item *parser.PathItem, version parser.OASVersion) deprecationStatus {
+	if s := r.status(false, item.Extra); !s.sunset.IsZero() {
+		s.deprecated = true
+		return s
+	}
+
+	var result deprecationStatus
+	count := 0
+	for _, op := range parser.GetOperations(item, version) {
+		if op == nil {
+			continue
+		}
+		s := r.status(op.Deprecated, op.Extra)
+		if !s.deprecated {
+			return deprecationStatus{}
+		}
+		if count == 0 || (!result.sunset.IsZero() && (s.sunset.IsZero() || s.sunset.After(result.sunset))) {
+			result.sunset = s.sunset
+		}
+		count++
+	}
+	result.deprecated = count > 0
+	return result
+}
+
+// removalStatus returns the deprecation status of the element removed by the
+// given change, and false if the change is not a removal the policy applies to.
+// isProperty reports whether the schema walk recorded the change as a removed
+// property, as opposed to any other removed schema.
+func (r *DeprecationRules) removalStatus(c Change, isProperty bool, version parser.OASVersion) (deprecationStatus, bool) {
+	if c.Type != ChangeTypeRemoved {
+		return deprecationStatus{}, false
+	}
+	switch v := c.OldValue.(type) {
+	case *parser.Operation:
+		if c.Category == CategoryOperation && v != nil {
+			return r.status(v.Deprecated, v.Extra), true
+		}
+	case *parser.Parameter:
+		if c.Category == CategoryParameter && v != nil {
+			return r.status(v.Deprecated, v.Extra), true
+		}
+	case *parser.Schema:
+		if c.Category == CategorySchema && v != nil && isProperty {
+			return r.status(v.Deprecated, v.Extra), true
+		}
+	case *parser.PathItem:
+		if c.Category == CategoryEndpoint && v != nil {
+			return r.pathItemStatus(v, version), true
+		}
+	}
+	return deprecationStatus{}, false
+}
+
+// severityRank orders severities from least to most severe. The underlying
+// Severity values are not ordered by impact, so they cannot be compared directly.
+func severityRank(s Severity) int {
+	switch s {
+	case SeverityInfo:
+		return 0
+	case SeverityWarning:
+		return 1
+	case SeverityError:
+		return 2
+	case SeverityCritical:
+		return 3
+	}
+	return -1
+}
+
+// escalate returns the more severe of current and minimum.
+func escalate(current, minimum Severity) Severity {
+	if severityRank(current) > severityRank(minimum) {
+		return current
+	}
+	return minimum
+}
+
+// applyDeprecationPolicy re-evaluates removals against the deprecation policy
+// and reports elements kept past their sunset date.
+func (d *Differ) applyDeprecationPolicy(source, target parser.ParseResult, result *DiffResult) {
+	if d.Mode != ModeBreaking || d.BreakingRules == nil {
+		return
+	}
+	rules := d.BreakingRules.Deprecation
+	if rules == nil || !rules.RequirePriorDeprecation {
+		return
+	}
+	now := rules.now()
+
+	filtered := result.Changes[:0]
+	for i, c := range result.Changes {
+		status, ok := rules.removalStatus(c, result.propertyRemovals[i], source.OASVersion)
+		if !ok {
+			filtered = append(filtered, c)
+			continue
+		}
+
+		var (
+			sev    Severity
+			ignore bool
+			note   string
+		)
+		switch {
+		case !status.deprecated:
+			sev, ignore = rules.UndeprecatedRemoval.ApplyRule(escalate(c.Severity, SeverityError))
+			note = "removed without prior deprecation"
+		case !status.sunset.IsZero() && !status.sunsetPassed(now):
+			sev, ignore = rules.RemovedBeforeSunset.ApplyRule(escalate(c.Severity, SeverityError))
+			note = fmt.Sprintf("removed before its sunset date %s", status.sunset.Format(time.DateOnly))
+		default:
+			sev, ignore = rules.DeprecatedRemoval.ApplyRule(SeverityInfo)
+			note = "previously deprecated"
+		}
+		if ignore {
+			continue
+		}
+		c.Severity = sev
+		c.Message = fmt.Sprintf("%s (%s)", c.Message, note)
+		filtered = append(filtered, c)
+	}
+	result.Changes = filtered
+
+	d.reportSunsetPassed(rules, target, now, result)
+}
+
+// reportSunsetPassed adds a ChangeTypeSunset change for every operation,
+// parameter and property in the target document whose sunset date has passed.
+func (d *Differ) reportSunsetPassed(rules *DeprecationRules, target parser.ParseResult, now time.Time, result *DiffResult) {
+	sev, ignore := rules.SunsetPassed.ApplyRule(SeverityWarning)
+	if ignore {
+		return
+	}
+
+	report := func(path string, category ChangeCategory, what string, status deprecationStatus, value any) {
+		if !status.sunsetPassed(now) {
+			return
+		}
+		change := Change{
+			Path:     path,
+			Type:     ChangeTypeSunset,
+			Category: category,
+			Severity: sev,
+			NewValue: value,
+			Message:  fmt.Sprintf("%s is still present after its sunset date %s", what, status.sunset.Format(time.DateOnly)),
+		}
+		d.populateChangeLocation(&change, ChangeTypeAdded)
+		result.Changes = append(result.Changes, change)
+	}
+
+	var (
+		paths       parser.Paths
+		schemas     map[string]*parser.Schema
+		schemasPath string
+	)
+	if doc, ok := target.OAS2Document(); ok {
+		paths = doc.Paths
+		schemas = doc.Definitions
+		schemasPath = "document.definitions"
+	} else if doc, ok := target.OAS3Document(); ok {
+		paths = doc.Paths
+		if doc.Components != nil {
+			schemas = doc.Components.Schemas
+		}
+		schemasPath = "document.components.schemas"
+	}
+
+	reportParams := func(params []*parser.Parameter, path string) {
+		for _, param := range params {
+			if param == nil {
+				continue
+			}
+			key := param.Name + ":" + param.In
+			report(fmt.Sprintf("%s.parameters[%s]", path, key), CategoryParameter,
+				fmt.Sprintf("parameter %q in %s", param.Name, param.In), rules.status(param.Deprecated, param.Extra), param)
+		}
+	}
+
+	for _, pathName := range maputil.SortedKeys(paths) {
+		item := paths[pathName]
+		if item == nil {
+			continue
+		}
+		itemPath := fmt.Sprintf("document.paths.%s", pathName)
+		reportParams(item.Parameters, itemPath)
+
+		ops := parser.GetOperations(item, target.OASVersion)
+		for _, method := range maputil.SortedKeys(ops) {
+			op := ops[method]
+			if op == nil {
+				continue
+			}
+			opPath := fmt.Sprintf("%s.%s", itemPath, method)
+			report(opPath, CategoryOperation, fmt.Sprintf("operation %s %s", strings.ToUpper(method), pathName),
+				rules.status(op.Deprecated, op.Extra), op)
+			reportParams(op.Parameters, opPath)
+		}
+	}
+
+	visited := make(map[*parser.Schema]bool)
+	var walkProps func(schema *parser.Schema, path string)
+	walkProps = func(schema *parser.Schema, path string) {
+		if schema == nil || visited[schema] {
+			return
+		}
+		visited[schema] = true
+		for _, name := range maputil.SortedKeys(schema.Properties) {
+			prop := schema.Properties[name]
+			if prop == nil {
+				continue
+			}
+			propPath := fmt.Sprintf("%s.properties.%s", path, name)
+			report(propPath, CategorySchema, fmt.Sprintf("property %q", name), rules.status(prop.Deprecated, prop.Extra), prop)
+			walkProps(prop, propPath)
+		}
+	}
+	for _, name := range maputil.SortedKeys(schemas) {
+		walkProps(schemas[name], fmt.Sprintf("%s.%s", schemasPath, name))
+	}
+}
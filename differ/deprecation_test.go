@@ -0,0 +1,383 @@
+package differ
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erraggy/oastools/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// deprecationNow is the fixed reference time used by the deprecation policy tests.
+var deprecationNow = time.Date(2026, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+func oas3Result(doc *parser.OAS3Document) parser.ParseResult {
+	return parser.ParseResult{
+		OASVersion: parser.OASVersion310,
+		Version:    "3.1.0",
+		Document:   doc,
+	}
+}
+
+func oas3Doc(paths parser.Paths, schemas map[string]*parser.Schema) *parser.OAS3Document {
+	return &parser.OAS3Document{
+		OpenAPI:    "3.1.0",
+		Info:       &parser.Info{Title: "Test API", Version: "1.0.0"},
+		Paths:      paths,
+		Components: &parser.Components{Schemas: schemas},
+	}
+}
+
+func diffWithDeprecationPolicy(t *testing.T, source, target *parser.OAS3Document, rules *DeprecationRules) *DiffResult {
+	t.Helper()
+	if rules.Now.IsZero() {
+		rules.Now = deprecationNow
+	}
+	d := New()
+	d.Mode = ModeBreaking
+	d.BreakingRules = &BreakingRulesConfig{Deprecation: rules}
+	result, err := d.DiffParsed(oas3Result(source), oas3Result(target))
+	require.NoError(t, err)
+	return result
+}
+
+func findTypedChange(changes []Change, path string, changeType ChangeType) (Change, bool) {
+	for _, c := range changes {
+		if c.Path == path && c.Type == changeType {
+			return c, true
+		}
+	}
+	return Change{}, false
+}
+
+func TestDeprecationPolicy_Removals(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		sourceOp     *parser.Operation
+		wantSeverity Severity
+		wantMessage  string
+	}{
+		{
+			name:         "removing deprecated operation is downgraded",
+			sourceOp:     &parser.Operation{OperationID: "getPets", Deprecated: true},
+			wantSeverity: SeverityInfo,
+			wantMessage:  "previously deprecated",
+		},
+		{
+			name: "removing deprecated operation after sunset is downgraded",
+			sourceOp: &parser.Operation{
+				OperationID: "getPets",
+				Deprecated:  true,
+				Extra:       map[string]any{"x-sunset": "2026-01-01"},
+			},
+			wantSeverity: SeverityInfo,
+			wantMessage:  "previously deprecated",
+		},
+		{
+			name:         "removing undeprecated operation is escalated",
+			sourceOp:     &parser.Operation{OperationID: "getPets"},
+			wantSeverity: SeverityError,
+			wantMessage:  "removed without prior deprecation",
+		},
+		{
+			name: "removing deprecated operation before sunset is premature",
+			sourceOp: &parser.Operation{
+				OperationID: "getPets",
+				Deprecated:  true,
+				Extra:       map[string]any{"x-sunset": "2027-01-01T00:00:00Z"},
+			},
+			wantSeverity: SeverityError,
+			wantMessage:  "removed before its sunset date 2027-01-01",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			source := oas3Doc(parser.Paths{
+				"/pets": &parser.PathItem{Get: tt.sourceOp, Post: &parser.Operation{OperationID: "createPet"}},
+			}, nil)
+			target := oas3Doc(parser.Paths{
+				"/pets": &parser.PathItem{Post: &parser.Operation{OperationID: "createPet"}},
+			}, nil)
+
+			result := diffWithDeprecationPolicy(t, source, target, &DeprecationRules{RequirePriorDeprecation: true})
+
+			change, ok := findTypedChange(result.Changes, "document.paths./pets.get", ChangeTypeRemoved)
+			require.True(t, ok, "expected removal change")
+			assert.Equal(t, tt.wantSeverity, change.Severity)
+			assert.Contains(t, change.Message, tt.wantMessage)
+		})
+	}
+}
+
+func TestDeprecationPolicy_ParameterAndProperty(t *testing.T) {
+	t.Parallel()
+
+	source := oas3Doc(parser.Paths{
+		"/pets": &parser.PathItem{Get: &parser.Operation{
+			Parameters: []*parser.Parameter{
+				{Name: "limit", In: "query", Deprecated: true},
+				{Name: "offset", In: "query"},
+			},
+		}},
+	}, map[string]*parser.Schema{
+		"Pet": {
+			Type: "object",
+			Properties: map[string]*parser.Schema{
+				"id":  {Type: "string"},
+				"tag": {Type: "string", Deprecated: true},
+				"age": {Type: "integer"},
+			},
+		},
+	})
+	target := oas3Doc(parser.Paths{
+		"/pets": &parser.PathItem{Get: &parser.Operation{}},
+	}, map[string]*parser.Schema{
+		"Pet": {
+			Type:       "object",
+			Properties: map[string]*parser.Schema{"id": {Type: "string"}},
+		},
+	})
+
+	result := diffWithDeprecationPolicy(t, source, target, &DeprecationRules{RequirePriorDeprecation: true})
+
+	tests := []struct {
+		path string
+		want Severity
+	}{
+		{"document.paths./pets.get.parameters[limit:query]", SeverityInfo},
+		{"document.paths./pets.get.parameters[offset:query]", SeverityError},
+		{"document.components.schemas.Pet.properties.tag", SeverityInfo},
+		{"document.components.schemas.Pet.properties.age", SeverityError},
+	}
+	for _, tt := range tests {
+		change, ok := findTypedChange(result.Changes, tt.path, ChangeTypeRemoved)
+		require.True(t, ok, "expected removal of %s", tt.path)
+		assert.Equal(t, tt.want, change.Severity, tt.path)
+	}
+}
+
+// TestDeprecationPolicy_DottedPropertyNames verifies that property names
+// containing dots are still classified as property removals.
+func TestDeprecationPolicy_DottedPropertyNames(t *testing.T) {
+	t.Parallel()
+
+	source := oas3Doc(nil, map[string]*parser.Schema{
+		"Pet": {
+			Type: "object",
+			Properties: map[string]*parser.Schema{
+				"id":               {Type: "string"},
+				"meta.tag":         {Type: "string", Deprecated: true},
+				"legacy.owner":     {Type: "string"},
+				"properties.count": {Type: "integer", Deprecated: true},
+			},
+		},
+	})
+	target := oas3Doc(nil, map[string]*parser.Schema{
+		"Pet": {
+			Type:       "object",
+			Properties: map[string]*parser.Schema{"id": {Type: "string"}},
+		},
+	})
+
+	result := diffWithDeprecationPolicy(t, source, target, &DeprecationRules{RequirePriorDeprecation: true})
+
+	tests := []struct {
+		path string
+		want Severity
+	}{
+		{"document.components.schemas.Pet.properties.meta.tag", SeverityInfo},
+		{"document.components.schemas.Pet.properties.legacy.owner", SeverityError},
+		{"document.components.schemas.Pet.properties.properties.count", SeverityInfo},
+	}
+	for _, tt := range tests {
+		change, ok := findTypedChange(result.Changes, tt.path, ChangeTypeRemoved)
+		require.True(t, ok, "expected removal of %s", tt.path)
+		assert.Equal(t, tt.want, change.Severity, tt.path)
+	}
+}
+
+func TestDeprecationPolicy_EndpointRemoval(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		item *parser.PathItem
+		want Severity
+	}{
+		{
+			name: "all operations deprecated",
+			item: &parser.PathItem{
+				Get:    &parser.Operation{Deprecated: true},
+				Delete: &parser.Operation{Deprecated: true},
+			},
+			want: SeverityInfo,
+		},
+		{
+			name: "some operations not deprecated keeps critical",
+			item: &parser.PathItem{
+				Get:    &parser.Operation{Deprecated: true},
+				Delete: &parser.Operation{},
+			},
+			want: SeverityCritical,
+		},
+		{
+			name: "latest operation sunset not reached",
+			item: &parser.PathItem{
+				Get:    &parser.Operation{Deprecated: true, Extra: map[string]any{"x-sunset": "2026-01-01"}},
+				Delete: &parser.Operation{Deprecated: true, Extra: map[string]any{"x-sunset": "2026-12-01"}},
+			},
+			want: SeverityCritical,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			source := oas3Doc(parser.Paths{"/legacy": tt.item}, nil)
+			target := oas3Doc(parser.Paths{}, nil)
+
+			result := diffWithDeprecationPolicy(t, source, target, &DeprecationRules{RequirePriorDeprecation: true})
+
+			change, ok := findTypedChange(result.Changes, "document.paths./legacy", ChangeTypeRemoved)
+			require.True(t, ok)
+			assert.Equal(t, tt.want, change.Severity)
+		})
+	}
+}
+
+func TestDeprecationPolicy_SunsetPassed(t *testing.T) {
+	t.Parallel()
+
+	doc := oas3Doc(parser.Paths{
+		"/pets": &parser.PathItem{Get: &parser.Operation{
+			Deprecated: true,
+			Extra:      map[string]any{"x-sunset": "Thu, 01 Jan 2026 00:00:00 GMT"},
+			Parameters: []*parser.Parameter{
+				{Name: "page", In: "query", Deprecated: true, Extra: map[string]any{"x-sunset": "2026-03-01"}},
+			},
+		}},
+		"/owners": &parser.PathItem{Get: &parser.Operation{
+			Deprecated: true,
+			Extra:      map[string]any{"x-sunset": "2027-01-01"},
+		}},
+	}, map[string]*parser.Schema{
+		"Pet": {
+			Type: "object",
+			Properties: map[string]*parser.Schema{
+				"legacyId": {Type: "string", Deprecated: true, Extra: map[string]any{"x-sunset": "2025-12-31"}},
+			},
+		},
+	})
+
+	result := diffWithDeprecationPolicy(t, doc, doc, &DeprecationRules{RequirePriorDeprecation: true})
+
+	var paths []string
+	for _, c := range result.Changes {
+		if c.Type == ChangeTypeSunset {
+			assert.Equal(t, SeverityWarning, c.Severity)
+			paths = append(paths, c.Path)
+		}
+	}
+	assert.Equal(t, []string{
+		"document.paths./pets.get",
+		"document.paths./pets.get.parameters[page:query]",
+		"document.components.schemas.Pet.properties.legacyId",
+	}, paths)
+	assert.Equal(t, 3, result.WarningCount)
+	assert.False(t, result.HasBreakingChanges)
+}
+
+func TestDeprecationPolicy_RuleOverrides(t *testing.T) {
+	t.Parallel()
+
+	source := oas3Doc(parser.Paths{
+		"/pets": &parser.PathItem{
+			Get:  &parser.Operation{Deprecated: true},
+			Post: &parser.Operation{},
+			Put:  &parser.Operation{Deprecated: true, Extra: map[string]any{"x-retire": "2020-01-01"}},
+		},
+	}, nil)
+	target := oas3Doc(parser.Paths{
+		"/pets": &parser.PathItem{
+			Put: &parser.Operation{Deprecated: true, Extra: map[string]any{"x-retire": "2020-01-01"}},
+		},
+	}, nil)
+
+	result := diffWithDeprecationPolicy(t, source, target, &DeprecationRules{
+		RequirePriorDeprecation: true,
+		SunsetExtension:         "x-retire",
+		DeprecatedRemoval:       &BreakingChangeRule{Ignore: true},
+		UndeprecatedRemoval:     &BreakingChangeRule{Severity: SeverityPtr(SeverityCritical)},
+		SunsetPassed:            &BreakingChangeRule{Severity: SeverityPtr(SeverityError)},
+	})
+
+	_, ok := findTypedChange(result.Changes, "document.paths./pets.get", ChangeTypeRemoved)
+	assert.False(t, ok, "deprecated removal should be ignored")
+
+	change, ok := findTypedChange(result.Changes, "document.paths./pets.post", ChangeTypeRemoved)
+	require.True(t, ok)
+	assert.Equal(t, SeverityCritical, change.Severity)
+
+	change, ok = findTypedChange(result.Changes, "document.paths./pets.put", ChangeTypeSunset)
+	require.True(t, ok)
+	assert.Equal(t, SeverityError, change.Severity)
+}
+
+func TestDeprecationPolicy_Disabled(t *testing.T) {
+	t.Parallel()
+
+	source := oas3Doc(parser.Paths{
+		"/pets": &parser.PathItem{
+			Get:  &parser.Operation{Deprecated: true, Extra: map[string]any{"x-sunset": "2020-01-01"}},
+			Post: &parser.Operation{},
+		},
+	}, nil)
+	target := oas3Doc(parser.Paths{
+		"/pets": &parser.PathItem{
+			Get: &parser.Operation{Deprecated: true, Extra: map[string]any{"x-sunset": "2020-01-01"}},
+		},
+	}, nil)
+
+	for _, mode := range []DiffMode{ModeSimple, ModeBreaking} {
+		d := New()
+		d.Mode = mode
+		d.BreakingRules = &BreakingRulesConfig{Deprecation: &DeprecationRules{Now: deprecationNow}}
+		result, err := d.DiffParsed(oas3Result(source), oas3Result(target))
+		require.NoError(t, err)
+
+		change, ok := findTypedChange(result.Changes, "document.paths./pets.post", ChangeTypeRemoved)
+		require.True(t, ok)
+		assert.NotContains(t, change.Message, "deprecation")
+		_, ok = findTypedChange(result.Changes, "document.paths./pets.get", ChangeTypeSunset)
+		assert.False(t, ok)
+	}
+}
+
+func TestParseSunset(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		value any
+		want  time.Time
+	}{
+		{"date only", "2026-03-01", time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)},
+		{"RFC 3339", "2026-03-01T12:00:00Z", time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)},
+		{"RFC 1123", "Sun, 01 Mar 2026 12:00:00 UTC", time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)},
+		{"time value", time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)},
+		{"invalid", "soon", time.Time{}},
+		{"missing", nil, time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.True(t, tt.want.Equal(parseSunset(tt.value)), "parseSunset(%v)", tt.value)
+		})
+	}
+}
@@ -28,6 +28,9 @@ const (
 	ChangeTypeRemoved ChangeType = "removed"
 	// ChangeTypeModified indicates an existing element was changed
 	ChangeTypeModified ChangeType = "modified"
+	// ChangeTypeSunset indicates an element is still present after its sunset date.
+	// Only reported when DeprecationRules.RequirePriorDeprecation is enabled.
+	ChangeTypeSunset ChangeType = "sunset"
 )
 
 // ChangeCategory indicates which part of the spec was changed
@@ -167,6 +170,11 @@ type DiffResult struct {
 	InfoCount int
 	// HasBreakingChanges is true if any breaking changes were detected
 	HasBreakingChanges bool
+
+	// propertyRemovals holds the indices into Changes of removed schema
+	// properties, recorded by the schema walk for the deprecation policy.
+	// It is only valid until Changes is filtered.
+	propertyRemovals map[int]bool
 }
 
 // ToParseResult converts the DiffResult to a ParseResult representing the target document.
@@ -511,6 +519,10 @@ func (d *Differ) DiffParsed(source, target parser.ParseResult) (*DiffResult, err
 	// Perform unified diff (handles both ModeSimple and ModeBreaking)
 	d.diffUnified(source, target, result)
 
+	// Apply the deprecation lifecycle policy, if configured
+	d.applyDeprecationPolicy(source, target, result)
+	result.propertyRemovals = nil
+
 	// Filter out info-level changes if not requested
 	if !d.IncludeInfo {
 		filtered := make([]Change, 0, len(result.Changes))
@@ -70,6 +70,10 @@ type BreakingRulesConfig struct {
 
 	// Extension configures rules for extension (x-*) changes
 	Extension *ExtensionRules
+
+	// Deprecation configures the "removal requires prior deprecation" policy.
+	// It is applied after the category rules above.
+	Deprecation *DeprecationRules
 }
 
 // OperationRules configures rules for HTTP operation changes.
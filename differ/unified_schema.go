@@ -397,8 +397,15 @@ func (d *Differ) diffSchemaPropertiesUnified(source, target map[string]*parser.S
 			if d.Mode == ModeBreaking && isPropertyRequired(name, sourceRequired) {
 				severity = SeverityError
 			}
+			idx := len(result.Changes)
 			d.addChange(result, propPath, ChangeTypeRemoved, CategorySchema,
 				severity, sourceSchema, nil, fmt.Sprintf("property %q removed", name))
+			if len(result.Changes) > idx {
+				if result.propertyRemovals == nil {
+					result.propertyRemovals = make(map[int]bool)
+				}
+				result.propertyRemovals[idx] = true
+			}
 		} else {
 			// Property exists in both - recursive comparison
 			d.diffSchemaRecursiveUnified(sourceSchema, targetSchema, propPath, visited, result)
@@ -1,7 +1,7 @@
 // Package oastools provides tools for parsing, validating, fixing, converting, joining,
 // comparing, generating code from, and building OpenAPI Specification (OAS) documents from OAS 2.0 through OAS 3.2.0.
 //
-// The library consists of twelve packages:
+// The library consists of fourteen packages:
 //
 //   - [github.com/erraggy/oastools/parser] - Parse OpenAPI specifications from YAML or JSON
 //   - [github.com/erraggy/oastools/validator] - Validate OpenAPI specifications against their declared version
@@ -11,9 +11,11 @@
 //   - [github.com/erraggy/oastools/overlay] - Apply OpenAPI Overlay transformations with JSONPath targeting
 //   - [github.com/erraggy/oastools/differ] - Compare OpenAPI specifications and detect breaking changes
 //   - [github.com/erraggy/oastools/generator] - Generate idiomatic Go code for API clients and server stubs
+//   - [github.com/erraggy/oastools/exporter] - Export component schemas as JSON Schema 2020-12 or Protobuf definitions
 //   - [github.com/erraggy/oastools/builder] - Programmatically construct OpenAPI specifications with reflection-based schema generation
 //   - [github.com/erraggy/oastools/httpvalidator] - Validate HTTP requests and responses at runtime against OAS
 //   - [github.com/erraggy/oastools/walker] - Traverse OAS documents with typed handlers and post-visit callbacks
+//   - [github.com/erraggy/oastools/jsonpath] - Query decoded documents with RFC 9535 JSONPath expressions
 //   - [github.com/erraggy/oastools/oaserrors] - Structured error types for programmatic handling
 //
 // For installation, CLI usage, and examples, see: https://github.com/erraggy/oastools
@@ -0,0 +1,84 @@
+// Package exporter exports the component schemas of an OpenAPI specification
+// as standalone artifacts: JSON Schema 2020-12 documents, for validating
+// payloads outside HTTP such as events on a message bus, or proto3 message
+// definitions for gRPC services that mirror REST resources.
+//
+// # Quick Start
+//
+// Export one JSON Schema file per component:
+//
+//	result, err := exporter.ExportWithOptions(
+//	    exporter.WithFilePath("openapi.yaml"),
+//	    exporter.WithFormat("jsonschema"),
+//	    exporter.WithBaseURI("https://example.com/schemas/"),
+//	)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if err := result.WriteFiles("./schemas"); err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// Or use a reusable Exporter instance:
+//
+//	e := exporter.New()
+//	e.Format = exporter.FormatProto
+//	e.Package = "orders.v1"
+//	result, err := e.Export("openapi.yaml")
+//
+// # JSON Schema Output
+//
+// Every component schema becomes a file named after it, with "$schema" set to
+// the 2020-12 dialect and an "$id" of BaseURI plus the file name. References
+// between components become relative references to the other file
+// ("Money.json"), so they resolve against the "$id". With Bundle, a single
+// schemas.json holds every component in "$defs" and references point into it
+// ("#/$defs/Money").
+//
+// Whatever the source version, the schemas are brought to the 2020-12 dialect
+// first. OAS 2.0 definitions go through the converter's 2.0-to-3.1 schema
+// conversion, and the OAS 3.0 spellings are rewritten: nullable becomes "null"
+// in the type array, boolean exclusiveMinimum and exclusiveMaximum become the
+// numeric forms, and example becomes examples. The OpenAPI keywords JSON
+// Schema has no counterpart for (discriminator, xml, externalDocs) are dropped.
+//
+// # Proto Output
+//
+// The schemas become a single schemas.proto in proto3 syntax:
+//   - Objects become messages, with the properties of allOf members merged in
+//   - oneOf and anyOf variants become a oneof group
+//   - String enums become enums with an UNSPECIFIED zero value
+//   - Inline objects and enums become nested definitions
+//   - Arrays become repeated fields and additionalProperties become maps
+//   - date-time strings become google.protobuf.Timestamp, and values proto3
+//     cannot type become google.protobuf.Value
+//
+// Other component schemas (scalars, arrays and maps) have no message form, and
+// proto3 has no type aliases, so they are inlined where they are referenced.
+// Property names become snake_case field names, with a json_name option where
+// proto3 JSON would not otherwise spell the property as the schema does.
+//
+// Field numbers are assigned in property name order, so adding a property can
+// renumber the ones after it. Pin a property's number with the x-proto-field
+// extension to keep it stable:
+//
+//	properties:
+//	  id:
+//	    type: string
+//	    x-proto-field: 1
+//
+// # Export Issues
+//
+// Constructs the target format cannot express are reported in
+// [ExportResult.Issues] rather than failing the export: a warning when the
+// output accepts more or less than the schema did (a oneof standing in for
+// anyOf, a tuple typed as google.protobuf.Value), and an info message when
+// only annotations are lost. References outside components.schemas are left
+// as they are and reported.
+//
+// # Related Packages
+//
+//   - [github.com/erraggy/oastools/parser] - Parse specifications before exporting
+//   - [github.com/erraggy/oastools/converter] - Convert specifications between OAS versions
+//   - [github.com/erraggy/oastools/generator] - Generate Go or TypeScript code from specifications
+package exporter
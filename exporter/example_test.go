@@ -0,0 +1,119 @@
+package exporter_test
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/erraggy/oastools/exporter"
+	"github.com/erraggy/oastools/parser"
+)
+
+const exampleSpec = `
+openapi: 3.0.3
+info:
+  title: Orders
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Order:
+      type: object
+      required: [id]
+      properties:
+        id:
+          type: string
+        note:
+          type: string
+          nullable: true
+        total:
+          $ref: '#/components/schemas/Money'
+    Money:
+      type: object
+      properties:
+        amount:
+          type: number
+`
+
+// Example demonstrates exporting component schemas as JSON Schema files.
+func Example() {
+	p := parser.New()
+	parseResult, err := p.ParseBytes([]byte(exampleSpec))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	e := exporter.New()
+	e.BaseURI = "https://example.com/schemas/"
+	result, err := e.ExportParsed(*parseResult)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, file := range result.Files {
+		fmt.Println(file.Name)
+	}
+	fmt.Print(string(result.GetFile("Order.json").Content))
+
+	// Output:
+	// Money.json
+	// Order.json
+	// {
+	//   "$schema": "https://json-schema.org/draft/2020-12/schema",
+	//   "$id": "https://example.com/schemas/Order.json",
+	//   "type": "object",
+	//   "properties": {
+	//     "id": {
+	//       "type": "string"
+	//     },
+	//     "note": {
+	//       "type": [
+	//         "string",
+	//         "null"
+	//       ]
+	//     },
+	//     "total": {
+	//       "$ref": "Money.json"
+	//     }
+	//   },
+	//   "required": [
+	//     "id"
+	//   ]
+	// }
+}
+
+// ExampleExportWithOptions demonstrates exporting proto3 message definitions.
+func ExampleExportWithOptions() {
+	p := parser.New()
+	parseResult, err := p.ParseBytes([]byte(exampleSpec))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	result, err := exporter.ExportWithOptions(
+		exporter.WithParsed(*parseResult),
+		exporter.WithFormat("proto"),
+		exporter.WithPackage("orders.v1"),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Print(string(result.GetFile("schemas.proto").Content))
+
+	// Output:
+	// // Code generated by oastools export. DO NOT EDIT.
+	//
+	// syntax = "proto3";
+	//
+	// package orders.v1;
+	//
+	// message Money {
+	//   optional double amount = 1;
+	// }
+	//
+	// message Order {
+	//   string id = 1;
+	//   optional string note = 2;
+	//   Money total = 3;
+	// }
+}
@@ -0,0 +1,504 @@
+package exporter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/erraggy/oastools/converter"
+	"github.com/erraggy/oastools/internal/issues"
+	"github.com/erraggy/oastools/internal/options"
+	"github.com/erraggy/oastools/internal/severity"
+	"github.com/erraggy/oastools/parser"
+)
+
+// Severity indicates the severity level of an export issue
+type Severity = severity.Severity
+
+const (
+	// SeverityInfo indicates informational messages about export choices
+	SeverityInfo = severity.SeverityInfo
+	// SeverityWarning indicates constructs the target format cannot express exactly
+	SeverityWarning = severity.SeverityWarning
+	// SeverityCritical indicates schemas that could not be exported at all
+	SeverityCritical = severity.SeverityCritical
+)
+
+// Format is an export target.
+type Format string
+
+const (
+	// FormatJSONSchema exports JSON Schema 2020-12 documents
+	FormatJSONSchema Format = "jsonschema"
+	// FormatProto exports proto3 message definitions
+	FormatProto Format = "proto"
+)
+
+const (
+	// defaultPackage is the proto package used when none is configured
+	defaultPackage = "api"
+	// schemasPrefix is the reference prefix of component schemas. OAS 2.0
+	// input is converted first, so its #/definitions/ references arrive here
+	// already rewritten.
+	schemasPrefix = "#/components/schemas/"
+	// schemasPath is the issue path prefix of component schemas
+	schemasPath = "components.schemas"
+)
+
+// ExportIssue represents a construct the target format cannot express
+type ExportIssue = issues.Issue
+
+// ExportedFile represents a single exported file
+type ExportedFile struct {
+	// Name is the file name (e.g., "Pet.json", "schemas.proto")
+	Name string
+	// Content is the file content
+	Content []byte
+}
+
+// ExportResult contains the results of exporting the schemas of an OpenAPI specification
+type ExportResult struct {
+	// Files contains all exported files
+	Files []ExportedFile
+	// Format is the format the schemas were exported to
+	Format Format
+	// SourceVersion is the detected source OAS version string
+	SourceVersion string
+	// SourceOASVersion is the enumerated source OAS version
+	SourceOASVersion parser.OASVersion
+	// SourceFormat is the format of the source file (JSON or YAML)
+	SourceFormat parser.SourceFormat
+	// Issues contains all export issues grouped by severity
+	Issues []ExportIssue
+	// InfoCount is the total number of info messages
+	InfoCount int
+	// WarningCount is the total number of warnings
+	WarningCount int
+	// CriticalCount is the total number of critical issues
+	CriticalCount int
+	// Success is true if export completed without critical issues
+	Success bool
+	// LoadTime is the time taken to load the source data
+	LoadTime time.Duration
+	// ExportTime is the time taken to export the schemas
+	ExportTime time.Duration
+	// SourceSize is the size of the source data in bytes
+	SourceSize int64
+	// Stats contains statistical information about the source document
+	Stats parser.DocumentStats
+	// ExportedSchemas is the count of component schemas exported
+	ExportedSchemas int
+}
+
+// HasCriticalIssues returns true if there are any critical issues
+func (r *ExportResult) HasCriticalIssues() bool {
+	return r.CriticalCount > 0
+}
+
+// HasWarnings returns true if there are any warnings
+func (r *ExportResult) HasWarnings() bool {
+	return r.WarningCount > 0
+}
+
+// GetFile returns the exported file with the given name, or nil if not found
+func (r *ExportResult) GetFile(name string) *ExportedFile {
+	for i := range r.Files {
+		if r.Files[i].Name == name {
+			return &r.Files[i]
+		}
+	}
+	return nil
+}
+
+// Exporter exports the component schemas of OpenAPI specifications
+type Exporter struct {
+	// Format is the format to export to: "jsonschema" or "proto".
+	// Default: "jsonschema"
+	Format Format
+
+	// Bundle writes JSON Schema output as a single schemas.json whose $defs
+	// hold every component, instead of one file per component.
+	// Default: false
+	Bundle bool
+
+	// BaseURI prefixes the $id of each JSON Schema file, so that
+	// "https://example.com/schemas/" gives Pet.json the $id
+	// "https://example.com/schemas/Pet.json". References between files are
+	// relative and resolve against it.
+	// Default: "" (relative $ids)
+	BaseURI string
+
+	// Package is the proto package declared by proto output.
+	// Default: "api"
+	Package string
+
+	// StrictMode causes export to fail on any issues (even warnings)
+	StrictMode bool
+
+	// IncludeInfo determines whether to include informational messages
+	IncludeInfo bool
+
+	// UserAgent is the User-Agent string used when fetching URLs
+	UserAgent string
+}
+
+// New creates a new Exporter instance with default settings
+func New() *Exporter {
+	return &Exporter{
+		Format:      FormatJSONSchema,
+		Package:     defaultPackage,
+		IncludeInfo: true,
+	}
+}
+
+// Option is a function that configures an export operation
+type Option func(*exportConfig) error
+
+// exportConfig holds configuration for an export operation
+type exportConfig struct {
+	// Input source (exactly one must be set)
+	filePath *string
+	parsed   *parser.ParseResult
+
+	// Configuration options
+	format      Format
+	bundle      bool
+	baseURI     string
+	pkg         string
+	strictMode  bool
+	includeInfo bool
+	userAgent   string
+}
+
+// ExportWithOptions exports the component schemas of an OpenAPI specification
+// using functional options.
+//
+// Example:
+//
+//	result, err := exporter.ExportWithOptions(
+//	    exporter.WithFilePath("openapi.yaml"),
+//	    exporter.WithFormat("jsonschema"),
+//	    exporter.WithBaseURI("https://example.com/schemas/"),
+//	)
+func ExportWithOptions(opts ...Option) (*ExportResult, error) {
+	cfg, err := applyOptions(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("exporter: invalid options: %w", err)
+	}
+
+	e := &Exporter{
+		Format:      cfg.format,
+		Bundle:      cfg.bundle,
+		BaseURI:     cfg.baseURI,
+		Package:     cfg.pkg,
+		StrictMode:  cfg.strictMode,
+		IncludeInfo: cfg.includeInfo,
+		UserAgent:   cfg.userAgent,
+	}
+
+	if cfg.parsed != nil {
+		return e.ExportParsed(*cfg.parsed)
+	}
+	return e.Export(*cfg.filePath)
+}
+
+// applyOptions applies option functions and validates configuration
+func applyOptions(opts ...Option) (*exportConfig, error) {
+	cfg := &exportConfig{
+		format:      FormatJSONSchema,
+		pkg:         defaultPackage,
+		includeInfo: true,
+	}
+
+	for _, opt := range opts {
+		if err := opt(cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := options.ValidateSingleInputSource(
+		"must specify an input source (use WithFilePath or WithParsed)",
+		"must specify exactly one input source",
+		cfg.filePath != nil, cfg.parsed != nil,
+	); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// WithFilePath specifies a file path or URL as the input source
+func WithFilePath(path string) Option {
+	return func(cfg *exportConfig) error {
+		cfg.filePath = &path
+		return nil
+	}
+}
+
+// WithParsed specifies a parsed ParseResult as the input source
+func WithParsed(result parser.ParseResult) Option {
+	return func(cfg *exportConfig) error {
+		cfg.parsed = &result
+		return nil
+	}
+}
+
+// WithFormat sets the export format: "jsonschema" or "proto"
+// Default: "jsonschema"
+func WithFormat(format string) Option {
+	return func(cfg *exportConfig) error {
+		if !isValidFormat(Format(format)) {
+			return fmt.Errorf("invalid format %q (must be %q or %q)", format, FormatJSONSchema, FormatProto)
+		}
+		cfg.format = Format(format)
+		return nil
+	}
+}
+
+// WithBundle writes JSON Schema output as a single file with $defs
+// Default: false
+func WithBundle(enabled bool) Option {
+	return func(cfg *exportConfig) error {
+		cfg.bundle = enabled
+		return nil
+	}
+}
+
+// WithBaseURI sets the URI that prefixes the $id of each JSON Schema file
+// Default: "" (relative $ids)
+func WithBaseURI(uri string) Option {
+	return func(cfg *exportConfig) error {
+		cfg.baseURI = uri
+		return nil
+	}
+}
+
+// WithPackage sets the proto package of proto output
+// Default: "api"
+func WithPackage(pkg string) Option {
+	return func(cfg *exportConfig) error {
+		if !protoPackagePattern.MatchString(pkg) {
+			return fmt.Errorf("invalid proto package %q", pkg)
+		}
+		cfg.pkg = pkg
+		return nil
+	}
+}
+
+// WithStrictMode enables or disables strict mode (fail on any issues)
+// Default: false
+func WithStrictMode(enabled bool) Option {
+	return func(cfg *exportConfig) error {
+		cfg.strictMode = enabled
+		return nil
+	}
+}
+
+// WithIncludeInfo enables or disables informational messages
+// Default: true
+func WithIncludeInfo(enabled bool) Option {
+	return func(cfg *exportConfig) error {
+		cfg.includeInfo = enabled
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent string for HTTP requests
+// Default: "" (uses parser default)
+func WithUserAgent(ua string) Option {
+	return func(cfg *exportConfig) error {
+		cfg.userAgent = ua
+		return nil
+	}
+}
+
+// protoPackagePattern matches a proto package: dot-separated identifiers.
+var protoPackagePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*$`)
+
+// isValidFormat reports whether format is a supported export format.
+func isValidFormat(format Format) bool {
+	return format == FormatJSONSchema || format == FormatProto
+}
+
+// Export exports the component schemas of an OpenAPI specification file
+func (e *Exporter) Export(specPath string) (*ExportResult, error) {
+	p := parser.New()
+	if e.UserAgent != "" {
+		p.UserAgent = e.UserAgent
+	}
+
+	parseResult, err := p.Parse(specPath)
+	if err != nil {
+		return nil, fmt.Errorf("exporter: failed to parse specification: %w", err)
+	}
+
+	return e.ExportParsed(*parseResult)
+}
+
+// ExportParsed exports the component schemas of an already-parsed OpenAPI
+// specification.
+//
+// The schemas are brought to the JSON Schema 2020-12 dialect first, whatever
+// the source version, so both formats start from the same model: OAS 2.0
+// definitions go through the converter's 2.0-to-3.1 schema conversion, and
+// the OAS 3.0 keywords 2020-12 spells differently are rewritten here.
+func (e *Exporter) ExportParsed(parseResult parser.ParseResult) (*ExportResult, error) {
+	startTime := time.Now()
+
+	format := e.Format
+	if format == "" {
+		format = FormatJSONSchema
+	}
+	if !isValidFormat(format) {
+		return nil, fmt.Errorf("exporter: invalid format %q", format)
+	}
+	pkg := e.Package
+	if pkg == "" {
+		pkg = defaultPackage
+	}
+	if !protoPackagePattern.MatchString(pkg) {
+		return nil, fmt.Errorf("exporter: invalid proto package %q", pkg)
+	}
+
+	if len(parseResult.Errors) > 0 {
+		return nil, fmt.Errorf("exporter: source document has %d parse error(s), cannot export", len(parseResult.Errors))
+	}
+
+	result := &ExportResult{
+		Format:           format,
+		SourceVersion:    parseResult.Version,
+		SourceOASVersion: parseResult.OASVersion,
+		SourceFormat:     parseResult.SourceFormat,
+		Issues:           make([]ExportIssue, 0),
+		LoadTime:         parseResult.LoadTime,
+		SourceSize:       parseResult.SourceSize,
+		Stats:            parseResult.Stats,
+	}
+
+	// The rewrite to 2020-12 is only visible in JSON Schema output, so only
+	// that output reports what it changed
+	report := result
+	if format != FormatJSONSchema {
+		report = nil
+	}
+	schemas, err := e.componentSchemas(parseResult, report)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case FormatJSONSchema:
+		err = e.exportJSONSchema(schemas, result)
+	case FormatProto:
+		err = exportProto(schemas, pkg, result)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result.ExportTime = time.Since(startTime)
+	e.updateCounts(result)
+	result.Success = result.CriticalCount == 0
+
+	if e.StrictMode && (result.CriticalCount > 0 || result.WarningCount > 0) {
+		return result, fmt.Errorf("export failed in strict mode: %d critical issue(s), %d warning(s)",
+			result.CriticalCount, result.WarningCount)
+	}
+
+	if !e.IncludeInfo {
+		filtered := make([]ExportIssue, 0, len(result.Issues))
+		for _, issue := range result.Issues {
+			if issue.Severity != SeverityInfo {
+				filtered = append(filtered, issue)
+			}
+		}
+		result.Issues = filtered
+		result.InfoCount = 0
+	}
+
+	return result, nil
+}
+
+// nullableDeprecation begins the warning the converter reports for each
+// nullable schema of an OAS 3.0 document converted to OAS 3.1.
+const nullableDeprecation = "'nullable: true' is deprecated"
+
+// componentSchemas returns copies of the component schemas of the document,
+// rewritten to the JSON Schema 2020-12 dialect. What the rewrite drops is
+// reported to result, unless it is nil.
+func (e *Exporter) componentSchemas(parseResult parser.ParseResult, result *ExportResult) (map[string]*parser.Schema, error) {
+	var schemas map[string]*parser.Schema
+
+	// OAS 2.0 and 3.0 schemas are converted to OAS 3.1, whose schemas are
+	// JSON Schema 2020-12 apart from the OpenAPI keywords normalizeSchema
+	// handles
+	if parseResult.OASVersion >= parser.OASVersion20 && parseResult.OASVersion < parser.OASVersion310 {
+		c := converter.New()
+		c.IncludeInfo = false
+		converted, err := c.ConvertParsed(parseResult, parser.OASVersion310.String())
+		if err != nil {
+			return nil, fmt.Errorf("exporter: converting OAS %s schemas: %w", parseResult.Version, err)
+		}
+		// Only what the conversion did to the schemas concerns an export. Its
+		// nullable deprecation warnings do not, since normalizeSchema carries
+		// nullable over to the type array.
+		for _, issue := range converted.Issues {
+			if result != nil && strings.HasPrefix(issue.Path, schemasPath+".") &&
+				!strings.HasPrefix(issue.Message, nullableDeprecation) {
+				result.Issues = append(result.Issues, issue)
+			}
+		}
+		if doc, ok := converted.Document.(*parser.OAS3Document); ok && doc.Components != nil {
+			schemas = doc.Components.Schemas
+		}
+	} else {
+		doc, ok := parseResult.OAS3Document()
+		if !ok {
+			return nil, fmt.Errorf("exporter: unsupported document type %T", parseResult.Document)
+		}
+		if doc.Components != nil {
+			schemas = make(map[string]*parser.Schema, len(doc.Components.Schemas))
+			for name, schema := range doc.Components.Schemas {
+				schemas[name] = schema.DeepCopy()
+			}
+		}
+	}
+
+	for name, schema := range schemas {
+		walkSchema(schema, schemasPath+"."+name, func(s *parser.Schema, path string) {
+			normalizeSchema(s, path, result)
+		})
+	}
+	return schemas, nil
+}
+
+// addIssue records an export issue. A nil result discards it.
+func addIssue(result *ExportResult, path, message string, sev Severity) {
+	if result == nil {
+		return
+	}
+	result.Issues = append(result.Issues, ExportIssue{
+		Path:     path,
+		Message:  message,
+		Severity: sev,
+	})
+}
+
+// updateCounts updates the issue counts in the result
+func (e *Exporter) updateCounts(result *ExportResult) {
+	result.InfoCount = 0
+	result.WarningCount = 0
+	result.CriticalCount = 0
+
+	for _, issue := range result.Issues {
+		switch issue.Severity {
+		case SeverityInfo:
+			result.InfoCount++
+		case SeverityWarning:
+			result.WarningCount++
+		case SeverityCritical:
+			result.CriticalCount++
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package exporter
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/erraggy/oastools/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the export golden files")
+
+// goldenDir holds the specs of the golden tests, and a directory per spec and
+// output with the files exported from it.
+const goldenDir = "../testdata/exporter"
+
+func TestExportGolden(t *testing.T) {
+	tests := []struct {
+		spec   string
+		output string
+		opts   []Option
+	}{
+		{spec: "events-3.0.yaml", output: "jsonschema", opts: []Option{WithFormat("jsonschema")}},
+		{spec: "events-3.0.yaml", output: "bundle", opts: []Option{WithFormat("jsonschema"), WithBundle(true), WithBaseURI("https://example.com/schemas/")}},
+		{spec: "events-3.0.yaml", output: "proto", opts: []Option{WithFormat("proto"), WithPackage("orders.v1")}},
+		{spec: "inventory-2.0.yaml", output: "jsonschema", opts: []Option{WithFormat("jsonschema")}},
+		{spec: "inventory-2.0.yaml", output: "proto", opts: []Option{WithFormat("proto")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec+"/"+tt.output, func(t *testing.T) {
+			result, err := ExportWithOptions(append([]Option{WithFilePath(filepath.Join(goldenDir, tt.spec))}, tt.opts...)...)
+			require.NoError(t, err)
+			assert.True(t, result.Success)
+
+			dir := filepath.Join(goldenDir, strings.TrimSuffix(tt.spec, filepath.Ext(tt.spec)), tt.output)
+			if *updateGolden {
+				require.NoError(t, os.RemoveAll(dir))
+				require.NoError(t, result.WriteFiles(dir))
+			}
+
+			entries, err := os.ReadDir(dir)
+			require.NoError(t, err, "run go test ./exporter -run TestExportGolden -update to create the golden files")
+			var want []string
+			for _, entry := range entries {
+				want = append(want, entry.Name())
+			}
+			var got []string
+			for _, file := range result.Files {
+				got = append(got, file.Name)
+			}
+			assert.ElementsMatch(t, want, got)
+
+			for _, file := range result.Files {
+				golden, err := os.ReadFile(filepath.Join(dir, file.Name))
+				if err != nil {
+					continue
+				}
+				assert.Equal(t, string(golden), string(file.Content), "%s differs from its golden file; run with -update if the change is intended", file.Name)
+			}
+		})
+	}
+}
+
+func TestExport_Issues(t *testing.T) {
+	t.Run("jsonschema", func(t *testing.T) {
+		result, err := ExportWithOptions(WithFilePath(filepath.Join(goldenDir, "inventory-2.0.yaml")))
+		require.NoError(t, err)
+		assert.Equal(t, FormatJSONSchema, result.Format)
+		assert.Equal(t, 1, result.ExportedSchemas)
+		assert.Equal(t, 2, result.WarningCount)
+		assert.Equal(t, 1, result.InfoCount)
+
+		var messages []string
+		for _, issue := range result.Issues {
+			messages = append(messages, issue.Path+": "+issue.Message)
+		}
+		assert.Contains(t, messages, "components.schemas.Item.properties.supplier: external reference suppliers.yaml#/definitions/Supplier is not exported; left unchanged")
+		assert.Contains(t, messages, "components.schemas.Item: discriminator is an OpenAPI keyword with no JSON Schema equivalent; dropped")
+	})
+
+	t.Run("proto reports only its own", func(t *testing.T) {
+		result, err := ExportWithOptions(WithFilePath(filepath.Join(goldenDir, "inventory-2.0.yaml")), WithFormat("proto"))
+		require.NoError(t, err)
+		require.Len(t, result.Issues, 3)
+		for _, issue := range result.Issues {
+			assert.Contains(t, issue.Message, "google.protobuf.Value")
+		}
+	})
+
+	t.Run("without info", func(t *testing.T) {
+		result, err := ExportWithOptions(WithFilePath(filepath.Join(goldenDir, "inventory-2.0.yaml")), WithIncludeInfo(false))
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.InfoCount)
+		for _, issue := range result.Issues {
+			assert.Equal(t, SeverityWarning, issue.Severity)
+		}
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		result, err := ExportWithOptions(WithFilePath(filepath.Join(goldenDir, "inventory-2.0.yaml")), WithStrictMode(true))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "export failed in strict mode")
+		require.NotNil(t, result)
+		assert.True(t, result.HasWarnings())
+	})
+}
+
+func TestExport_Options(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		wantErr string
+	}{
+		{name: "no input", wantErr: "must specify an input source"},
+		{name: "two inputs", opts: []Option{WithFilePath("a.yaml"), WithParsed(parser.ParseResult{})}, wantErr: "exactly one input source"},
+		{name: "format", opts: []Option{WithFilePath("a.yaml"), WithFormat("avro")}, wantErr: `invalid format "avro"`},
+		{name: "package", opts: []Option{WithFilePath("a.yaml"), WithPackage("orders-v1")}, wantErr: `invalid proto package "orders-v1"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ExportWithOptions(tt.opts...)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+
+	t.Run("struct fields", func(t *testing.T) {
+		parsed, err := parser.ParseWithOptions(parser.WithFilePath(filepath.Join(goldenDir, "events-3.0.yaml")))
+		require.NoError(t, err)
+
+		e := New()
+		e.Format = "avro"
+		_, err = e.ExportParsed(*parsed)
+		require.Error(t, err)
+
+		e = New()
+		e.Format = FormatProto
+		e.Package = "1st"
+		_, err = e.ExportParsed(*parsed)
+		require.Error(t, err)
+
+		e = New()
+		result, err := e.ExportParsed(*parsed)
+		require.NoError(t, err)
+		assert.NotNil(t, result.GetFile("Order.json"))
+		assert.Nil(t, result.GetFile("Missing.json"))
+	})
+
+	t.Run("parse errors", func(t *testing.T) {
+		_, err := New().ExportParsed(parser.ParseResult{Errors: []error{assert.AnError}})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "1 parse error(s)")
+	})
+}
+
+func TestWriteFiles(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "out")
+	result := &ExportResult{Files: []ExportedFile{{Name: "Pet.json", Content: []byte("{}\n")}}}
+	require.NoError(t, result.WriteFiles(dir))
+	content, err := os.ReadFile(filepath.Join(dir, "Pet.json"))
+	require.NoError(t, err)
+	assert.Equal(t, "{}\n", string(content))
+
+	result.Files = []ExportedFile{{Name: "../Pet.json"}}
+	require.Error(t, result.WriteFiles(dir))
+}
@@ -0,0 +1,215 @@
+package exporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/erraggy/oastools/internal/maputil"
+	"github.com/erraggy/oastools/parser"
+)
+
+const (
+	// dialect2020 is the $schema of every exported JSON Schema document
+	dialect2020 = "https://json-schema.org/draft/2020-12/schema"
+	// fileNameBundle is the file name of a bundled JSON Schema export
+	fileNameBundle = "schemas.json"
+)
+
+// exportJSONSchema writes the schemas as JSON Schema 2020-12: one file per
+// component, or with Bundle a single file holding them all in $defs.
+func (e *Exporter) exportJSONSchema(schemas map[string]*parser.Schema, result *ExportResult) error {
+	names := maputil.SortedKeys(schemas)
+
+	for _, name := range names {
+		schema := schemas[name]
+		walkSchema(schema, schemasPath+"."+name, func(s *parser.Schema, path string) {
+			if s.Ref != "" {
+				s.Ref = e.rewriteRef(s.Ref, schemas, path, result)
+			}
+		})
+	}
+
+	if e.Bundle {
+		root := &parser.Schema{
+			Schema: dialect2020,
+			ID:     e.BaseURI + fileNameBundle,
+			Defs:   schemas,
+		}
+		content, err := marshalSchema(root)
+		if err != nil {
+			return fmt.Errorf("exporter: marshaling %s: %w", fileNameBundle, err)
+		}
+		result.Files = append(result.Files, ExportedFile{Name: fileNameBundle, Content: content})
+		result.ExportedSchemas = len(names)
+		return nil
+	}
+
+	for _, name := range names {
+		fileName := schemaFileName(name)
+		content, err := e.schemaDocument(schemas[name], e.BaseURI+url.PathEscape(fileName))
+		if err != nil {
+			return fmt.Errorf("exporter: marshaling %s: %w", fileName, err)
+		}
+		result.Files = append(result.Files, ExportedFile{Name: fileName, Content: content})
+	}
+	result.ExportedSchemas = len(names)
+	return nil
+}
+
+// rewriteRef rewrites a component schema reference to where the export puts
+// that schema: into $defs for a bundle, or into its own file. References to
+// anything else have no place in the export and are reported.
+func (e *Exporter) rewriteRef(ref string, schemas map[string]*parser.Schema, path string, result *ExportResult) string {
+	rest, ok := strings.CutPrefix(ref, schemasPrefix)
+	if !ok {
+		if strings.HasPrefix(ref, "#") {
+			addIssue(result, path, fmt.Sprintf("reference %s points outside components.schemas and is not exported; left unchanged", ref), SeverityWarning)
+		} else {
+			addIssue(result, path, fmt.Sprintf("external reference %s is not exported; left unchanged", ref), SeverityWarning)
+		}
+		return ref
+	}
+
+	token, pointer, _ := strings.Cut(rest, "/")
+	name := unescapePointerToken(token)
+	if _, exists := schemas[name]; !exists {
+		addIssue(result, path, fmt.Sprintf("reference %s names an undefined schema", ref), SeverityWarning)
+	}
+
+	if e.Bundle {
+		return "#/$defs/" + rest
+	}
+	target := url.PathEscape(schemaFileName(name))
+	if pointer != "" {
+		target += "#/" + pointer
+	}
+	return target
+}
+
+// schemaDocument renders one component as a standalone JSON Schema document.
+// $schema and $id lead the document, ahead of the keywords, rather than
+// wherever the Schema field order would put them.
+func (e *Exporter) schemaDocument(schema *parser.Schema, id string) ([]byte, error) {
+	body := schema
+	if value, ok := schema.IsBool(); ok {
+		// A boolean schema has no room for $schema and $id, so it is written
+		// as the object schema that means the same
+		body = &parser.Schema{}
+		if !value {
+			body.Not = &parser.Schema{}
+		}
+	}
+	if body.ID != "" {
+		// The component declares its own $id, which its relative references
+		// may depend on, so it is kept
+		id = body.ID
+	}
+	copied := *body
+	copied.Schema = ""
+	copied.ID = ""
+
+	dialect, err := encodeJSON(dialect2020)
+	if err != nil {
+		return nil, err
+	}
+	quotedID, err := encodeJSON(id)
+	if err != nil {
+		return nil, err
+	}
+	keywords, err := encodeJSON(&copied)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc bytes.Buffer
+	doc.WriteString(`{"$schema":`)
+	doc.Write(dialect)
+	doc.WriteString(`,"$id":`)
+	doc.Write(quotedID)
+	if len(keywords) > 2 {
+		doc.WriteByte(',')
+		doc.Write(keywords[1:])
+	} else {
+		doc.WriteByte('}')
+	}
+
+	var out bytes.Buffer
+	if err := json.Indent(&out, doc.Bytes(), "", "  "); err != nil {
+		return nil, err
+	}
+	out.WriteByte('\n')
+	return out.Bytes(), nil
+}
+
+// encodeJSON renders v as compact JSON without HTML escaping
+func encodeJSON(v any) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return unescapeHTML(b), nil
+}
+
+// marshalSchema renders a schema as indented JSON without HTML escaping
+func marshalSchema(schema *parser.Schema) ([]byte, error) {
+	b, err := encodeJSON(schema)
+	if err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, b, "", "  "); err != nil {
+		return nil, err
+	}
+	out.WriteByte('\n')
+	return out.Bytes(), nil
+}
+
+// htmlEscapes are the escapes encoding/json writes for <, > and &. Schema
+// marshals itself with them whatever the caller's encoder is set to, and a
+// pattern such as "^<.*>$" is unreadable with them left in.
+var htmlEscapes = map[string]byte{`u003c`: '<', `u003e`: '>', `u0026`: '&'}
+
+// unescapeHTML undoes the HTML escapes in JSON. Escapes only occur inside
+// strings, so each backslash starts one, and skipping the character after it
+// keeps an escaped backslash from being mistaken for the start of another.
+func unescapeHTML(b []byte) []byte {
+	if !bytes.Contains(b, []byte(`\u00`)) {
+		return b
+	}
+	out := make([]byte, 0, len(b))
+	for i := 0; i < len(b); i++ {
+		if b[i] != '\\' || i+1 >= len(b) {
+			out = append(out, b[i])
+			continue
+		}
+		if i+6 <= len(b) {
+			if c, ok := htmlEscapes[string(b[i+1:i+6])]; ok {
+				out = append(out, c)
+				i += 5
+				continue
+			}
+		}
+		out = append(out, b[i], b[i+1])
+		i++
+	}
+	return out
+}
+
+// schemaFileName returns the name of the file a component is exported to.
+// The specification restricts component names to ^[a-zA-Z0-9.\-_]+$, which
+// keeps them safe as file names; WriteFiles refuses any that are not.
+func schemaFileName(name string) string {
+	return name + ".json"
+}
+
+// unescapePointerToken decodes a JSON Pointer reference token, which within a
+// URI fragment may also be percent-encoded.
+func unescapePointerToken(token string) string {
+	if decoded, err := url.PathUnescape(token); err == nil {
+		token = decoded
+	}
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+}
@@ -0,0 +1,206 @@
+package exporter
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/erraggy/oastools/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// exportSpec parses spec and exports it with opts.
+func exportSpec(t *testing.T, spec string, opts ...Option) *ExportResult {
+	t.Helper()
+	parsed, err := parser.New().ParseBytes([]byte(spec))
+	require.NoError(t, err)
+	result, err := ExportWithOptions(append([]Option{WithParsed(*parsed)}, opts...)...)
+	require.NoError(t, err)
+	return result
+}
+
+// decodeFile decodes an exported JSON file into a map.
+func decodeFile(t *testing.T, result *ExportResult, name string) map[string]any {
+	t.Helper()
+	file := result.GetFile(name)
+	require.NotNil(t, file, "%s not exported", name)
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(file.Content, &doc))
+	return doc
+}
+
+func TestJSONSchema_OAS31(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: Test
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Anything: true
+    Nothing: false
+    Tagged:
+      $id: https://example.com/tagged.json
+      type: object
+      properties:
+        tags:
+          $ref: '#/components/schemas/Strings/items'
+        param:
+          $ref: '#/components/parameters/Limit'
+        missing:
+          $ref: '#/components/schemas/Missing'
+    Strings:
+      type: array
+      items:
+        type: [string, "null"]
+        pattern: '^<[a-z]+>$'
+`
+
+	t.Run("files", func(t *testing.T) {
+		result := exportSpec(t, spec, WithBaseURI("https://example.com/schemas/"))
+		assert.Equal(t, 4, result.ExportedSchemas)
+
+		anything := decodeFile(t, result, "Anything.json")
+		assert.Equal(t, map[string]any{
+			"$schema": dialect2020,
+			"$id":     "https://example.com/schemas/Anything.json",
+		}, anything)
+
+		nothing := decodeFile(t, result, "Nothing.json")
+		assert.Equal(t, map[string]any{}, nothing["not"])
+
+		// A component's own $id wins over the generated one
+		tagged := decodeFile(t, result, "Tagged.json")
+		assert.Equal(t, "https://example.com/tagged.json", tagged["$id"])
+		props := tagged["properties"].(map[string]any)
+		assert.Equal(t, "Strings.json#/items", props["tags"].(map[string]any)["$ref"])
+		assert.Equal(t, "#/components/parameters/Limit", props["param"].(map[string]any)["$ref"])
+
+		assert.Contains(t, string(result.GetFile("Strings.json").Content), `"^<[a-z]+>$"`)
+
+		var messages []string
+		for _, issue := range result.Issues {
+			messages = append(messages, issue.Message)
+		}
+		assert.ElementsMatch(t, []string{
+			"reference #/components/schemas/Missing names an undefined schema",
+			"reference #/components/parameters/Limit points outside components.schemas and is not exported; left unchanged",
+		}, messages)
+	})
+
+	t.Run("bundle", func(t *testing.T) {
+		result := exportSpec(t, spec, WithBundle(true))
+		require.Len(t, result.Files, 1)
+		bundle := decodeFile(t, result, "schemas.json")
+		assert.Equal(t, "schemas.json", bundle["$id"])
+		defs := bundle["$defs"].(map[string]any)
+		assert.Equal(t, true, defs["Anything"])
+		assert.Equal(t, false, defs["Nothing"])
+		props := defs["Tagged"].(map[string]any)["properties"].(map[string]any)
+		assert.Equal(t, "#/$defs/Strings/items", props["tags"].(map[string]any)["$ref"])
+	})
+}
+
+func TestJSONSchema_OAS30(t *testing.T) {
+	spec := `openapi: 3.0.3
+info:
+  title: Test
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Reading:
+      type: object
+      properties:
+        value:
+          type: number
+          maximum: 100
+          exclusiveMaximum: true
+          minimum: 0
+          exclusiveMinimum: false
+        floor:
+          type: number
+          exclusiveMinimum: true
+        note:
+          type: string
+          nullable: true
+`
+	result := exportSpec(t, spec)
+	props := decodeFile(t, result, "Reading.json")["properties"].(map[string]any)
+	assert.Equal(t, map[string]any{"type": "number", "exclusiveMaximum": 100.0, "minimum": 0.0}, props["value"])
+	assert.Equal(t, map[string]any{"type": "number"}, props["floor"])
+	assert.Equal(t, []any{"string", "null"}, props["note"].(map[string]any)["type"])
+
+	var messages []string
+	for _, issue := range result.Issues {
+		messages = append(messages, issue.Path+": "+issue.Message)
+	}
+	assert.Equal(t, []string{
+		"components.schemas.Reading.properties.floor: Schema has 'exclusiveMinimum: true' but no 'minimum' value; constraint dropped in OAS 3.1 conversion",
+	}, messages, "the conversion's nullable deprecation warnings are not reported")
+}
+
+func TestNormalizeSchema(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema *parser.Schema
+		want   *parser.Schema
+		issues int
+	}{
+		{
+			name:   "nullable",
+			schema: &parser.Schema{Type: "string", Nullable: true},
+			want:   &parser.Schema{Type: []any{"string", "null"}},
+		},
+		{
+			name:   "nullable without type",
+			schema: &parser.Schema{Nullable: true},
+			want:   &parser.Schema{},
+		},
+		{
+			name:   "nullable already in the type array",
+			schema: &parser.Schema{Type: []any{"string", "null"}, Nullable: true},
+			want:   &parser.Schema{Type: []any{"string", "null"}},
+		},
+		{
+			name:   "example",
+			schema: &parser.Schema{Example: "a"},
+			want:   &parser.Schema{Examples: []any{"a"}},
+		},
+		{
+			name:   "example beside examples",
+			schema: &parser.Schema{Example: "a", Examples: []any{"b"}},
+			want:   &parser.Schema{Examples: []any{"b"}},
+		},
+		{
+			name:   "OpenAPI keywords",
+			schema: &parser.Schema{Discriminator: &parser.Discriminator{PropertyName: "kind"}, XML: &parser.XML{Name: "x"}, ExternalDocs: &parser.ExternalDocs{URL: "https://example.com"}},
+			want:   &parser.Schema{},
+			issues: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := &ExportResult{}
+			normalizeSchema(tt.schema, "components.schemas.X", result)
+			assert.Equal(t, tt.want, tt.schema)
+			assert.Len(t, result.Issues, tt.issues)
+		})
+	}
+}
+
+func TestUnescapeHTML(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{`"plain"`, `"plain"`},
+		{`"<a> &"`, `"<a> &"`},
+		// An escaped backslash followed by text that only looks like an escape
+		{`"\\u003c"`, `"\\u003c"`},
+		{`"é"`, `"é"`},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, string(unescapeHTML([]byte(tt.in))), tt.in)
+	}
+}
@@ -0,0 +1,732 @@
+package exporter
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/erraggy/oastools/internal/maputil"
+	"github.com/erraggy/oastools/internal/schemautil"
+	"github.com/erraggy/oastools/parser"
+)
+
+const (
+	// fileNameProto is the file name of a proto export
+	fileNameProto = "schemas.proto"
+
+	// protoFieldExtension pins the field number of a property, which proto
+	// compatibility needs to stay stable as properties are added
+	protoFieldExtension = "x-proto-field"
+
+	// Well-known types standing in for JSON the proto type system cannot name
+	protoValue  = "google.protobuf.Value"
+	protoStruct = "google.protobuf.Struct"
+	protoTime   = "google.protobuf.Timestamp"
+
+	importStruct    = "google/protobuf/struct.proto"
+	importTimestamp = "google/protobuf/timestamp.proto"
+
+	// Field numbers 19000 to 19999 are reserved for the protobuf implementation
+	protoReservedFirst = 19000
+	protoReservedLast  = 19999
+	protoMaxField      = 1<<29 - 1
+)
+
+// protoMessage is a message definition, top-level or nested
+type protoMessage struct {
+	name     string
+	comment  string
+	fields   []*protoField
+	oneof    []*protoField // fields of the message's single oneof group
+	messages []*protoMessage
+	enums    []*protoEnum
+}
+
+// protoField is a field of a message
+type protoField struct {
+	comment    string
+	label      string // "", "optional" or "repeated"
+	typ        string
+	name       string
+	number     int
+	jsonName   string // set when the proto3 JSON name would differ from the property
+	deprecated bool
+}
+
+// protoEnum is an enum definition, top-level or nested
+type protoEnum struct {
+	name    string
+	comment string
+	values  []string
+}
+
+// protoType is what a schema maps to as the type of a field
+type protoType struct {
+	name     string
+	repeated bool
+	isMap    bool // name is the whole map<K, V> type
+	message  bool // name is a message, so the field has presence without a label
+}
+
+// protoGenerator maps component schemas to proto3 definitions
+type protoGenerator struct {
+	schemas map[string]*parser.Schema
+	result  *ExportResult
+	imports map[string]bool
+	// types maps the components exported as a message or enum to their name
+	types map[string]string
+	// topNames holds every top-level name, which nested names must not shadow
+	topNames map[string]bool
+	// inlining holds the components being inlined, to stop on a cycle
+	inlining map[string]bool
+}
+
+// exportProto writes the schemas as proto3 messages and enums in a single
+// schemas.proto. Objects become messages, string enums become enums, and
+// everything else is inlined where it is referenced, since proto has no
+// type aliases.
+func exportProto(schemas map[string]*parser.Schema, pkg string, result *ExportResult) error {
+	g := &protoGenerator{
+		schemas:  schemas,
+		result:   result,
+		imports:  make(map[string]bool),
+		types:    make(map[string]string),
+		topNames: make(map[string]bool),
+		inlining: make(map[string]bool),
+	}
+
+	names := maputil.SortedKeys(schemas)
+	for _, name := range names {
+		schema := schemas[name]
+		if !isProtoMessage(schema) && !isProtoEnum(schema) {
+			continue
+		}
+		typeName := uniqueName(protoTypeName(name), g.topNames)
+		g.types[name] = typeName
+		g.topNames[typeName] = true
+	}
+
+	var messages []*protoMessage
+	var enums []*protoEnum
+	for _, name := range names {
+		schema := schemas[name]
+		path := schemasPath + "." + name
+		typeName, ok := g.types[name]
+		switch {
+		case !ok:
+			addIssue(result, path, "schema is not an object or string enum and proto3 has no type aliases; inlined where referenced", SeverityInfo)
+		case isProtoEnum(schema):
+			enums = append(enums, g.buildEnum(typeName, schema, path))
+		default:
+			messages = append(messages, g.buildMessage(typeName, schema, path))
+		}
+	}
+	result.ExportedSchemas = len(messages) + len(enums)
+
+	var b strings.Builder
+	b.WriteString("// Code generated by oastools export. DO NOT EDIT.\n\n")
+	b.WriteString("syntax = \"proto3\";\n\n")
+	fmt.Fprintf(&b, "package %s;\n", pkg)
+	if len(g.imports) > 0 {
+		b.WriteString("\n")
+		for _, imp := range maputil.SortedKeys(g.imports) {
+			fmt.Fprintf(&b, "import %q;\n", imp)
+		}
+	}
+	for _, enum := range enums {
+		b.WriteString("\n")
+		writeProtoEnum(&b, enum, "")
+	}
+	for _, msg := range messages {
+		b.WriteString("\n")
+		writeProtoMessage(&b, msg, "")
+	}
+
+	result.Files = append(result.Files, ExportedFile{Name: fileNameProto, Content: []byte(b.String())})
+	return nil
+}
+
+// isProtoMessage reports whether a schema is exported as a message
+func isProtoMessage(s *parser.Schema) bool {
+	if s == nil || s.Ref != "" {
+		return false
+	}
+	if _, ok := s.IsBool(); ok {
+		return false
+	}
+	if len(s.Properties) > 0 || len(s.AllOf) > 0 || len(s.OneOf) > 0 || len(s.AnyOf) > 0 {
+		return true
+	}
+	// An object without properties is a map or free-form JSON, not a message
+	return false
+}
+
+// isProtoEnum reports whether a schema is exported as an enum: a string enum
+func isProtoEnum(s *parser.Schema) bool {
+	if s == nil || len(s.Enum) == 0 {
+		return false
+	}
+	primary := schemautil.GetPrimaryType(s)
+	if primary != "" && primary != "string" {
+		return false
+	}
+	for _, v := range s.Enum {
+		if _, ok := v.(string); !ok && v != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// buildMessage maps an object schema to a message. Properties of allOf
+// members are merged in, and oneOf or anyOf variants become a oneof group.
+func (g *protoGenerator) buildMessage(name string, s *parser.Schema, path string) *protoMessage {
+	msg := &protoMessage{name: name, comment: schemaComment(s)}
+	g.reportUnsupported(s, path)
+
+	properties := make(map[string]*parser.Schema)
+	propertyPaths := make(map[string]string)
+	required := make(map[string]bool)
+	g.collectProperties(s, path, properties, propertyPaths, required, make(map[*parser.Schema]bool))
+
+	if s.AdditionalProperties != nil && len(properties) > 0 {
+		if allowed, ok := s.AdditionalProperties.(bool); !ok || allowed {
+			addIssue(g.result, path+".additionalProperties", "additional properties beside declared ones have no proto3 equivalent; dropped", SeverityWarning)
+		}
+	}
+
+	used := make(map[int]bool)
+	fieldNames := make(map[string]bool)
+	pinned := make(map[string]int)
+	for _, prop := range maputil.SortedKeys(properties) {
+		if number, ok := g.pinnedFieldNumber(properties[prop], propertyPaths[prop]); ok {
+			if used[number] {
+				addIssue(g.result, propertyPaths[prop], fmt.Sprintf("%s %d is already taken in this message; a free number is assigned instead", protoFieldExtension, number), SeverityWarning)
+				continue
+			}
+			used[number] = true
+			pinned[prop] = number
+		}
+	}
+	next := 1
+	nextFree := func() int {
+		for used[next] || (next >= protoReservedFirst && next <= protoReservedLast) {
+			next++
+		}
+		used[next] = true
+		return next
+	}
+
+	for _, prop := range maputil.SortedKeys(properties) {
+		propSchema := properties[prop]
+		propPath := propertyPaths[prop]
+		t := g.fieldType(propSchema, propPath, msg, protoTypeName(prop))
+
+		field := &protoField{
+			comment:    schemaComment(propSchema),
+			typ:        t.name,
+			name:       uniqueName(protoFieldName(prop), fieldNames),
+			deprecated: propSchema != nil && propSchema.Deprecated,
+		}
+		fieldNames[field.name] = true
+		if protoJSONName(field.name) != prop {
+			field.jsonName = prop
+		}
+		switch {
+		case t.repeated:
+			field.label = "repeated"
+		case t.isMap:
+		case !required[prop] || schemautil.IsNullable(propSchema):
+			if !t.message {
+				field.label = "optional"
+			}
+		}
+		if number, ok := pinned[prop]; ok {
+			field.number = number
+		} else {
+			field.number = nextFree()
+		}
+		msg.fields = append(msg.fields, field)
+	}
+
+	variants, field := s.OneOf, "oneOf"
+	if len(variants) == 0 && len(s.AnyOf) > 0 {
+		variants, field = s.AnyOf, "anyOf"
+		addIssue(g.result, path+".anyOf", "anyOf is exported as a oneof, which holds exactly one of the variants", SeverityWarning)
+	}
+	for i, variant := range variants {
+		variantPath := fmt.Sprintf("%s.%s[%d]", path, field, i)
+		hint := fmt.Sprintf("Variant%d", i+1)
+		if variant != nil && variant.Ref != "" {
+			if component, ok := componentName(variant.Ref); ok {
+				hint = protoTypeName(component)
+			}
+		}
+		t := g.fieldType(variant, variantPath, msg, hint)
+		if t.repeated || t.isMap {
+			addIssue(g.result, variantPath, "a oneof cannot hold a repeated or map field; variant dropped", SeverityWarning)
+			continue
+		}
+		oneofField := &protoField{
+			comment: schemaComment(variant),
+			typ:     t.name,
+			name:    uniqueName(protoFieldName(hint), fieldNames),
+			number:  nextFree(),
+		}
+		fieldNames[oneofField.name] = true
+		msg.oneof = append(msg.oneof, oneofField)
+	}
+
+	slices.SortStableFunc(msg.fields, func(a, b *protoField) int { return a.number - b.number })
+	return msg
+}
+
+// collectProperties gathers the properties and required names of a schema
+// and, recursively, of its allOf members, following references to
+// components. A property declared more than once keeps its first schema.
+func (g *protoGenerator) collectProperties(s *parser.Schema, path string, properties map[string]*parser.Schema, paths map[string]string, required map[string]bool, visited map[*parser.Schema]bool) {
+	if s == nil || visited[s] {
+		return
+	}
+	visited[s] = true
+
+	if s.Ref != "" {
+		name, ok := componentName(s.Ref)
+		if !ok || g.schemas[name] == nil {
+			addIssue(g.result, path, fmt.Sprintf("unresolved reference %s; its properties are not merged", s.Ref), SeverityWarning)
+			return
+		}
+		g.collectProperties(g.schemas[name], schemasPath+"."+name, properties, paths, required, visited)
+		return
+	}
+
+	for _, prop := range maputil.SortedKeys(s.Properties) {
+		if _, exists := properties[prop]; !exists {
+			properties[prop] = s.Properties[prop]
+			paths[prop] = path + ".properties." + prop
+		}
+	}
+	for _, name := range s.Required {
+		required[name] = true
+	}
+	for i, member := range s.AllOf {
+		memberPath := fmt.Sprintf("%s.allOf[%d]", path, i)
+		if member != nil && member.Ref == "" && (len(member.OneOf) > 0 || len(member.AnyOf) > 0) {
+			addIssue(g.result, memberPath, "variants inside an allOf member have no proto3 equivalent; dropped", SeverityWarning)
+		}
+		g.collectProperties(member, memberPath, properties, paths, required, visited)
+	}
+}
+
+// pinnedFieldNumber reads the x-proto-field extension of a property.
+func (g *protoGenerator) pinnedFieldNumber(s *parser.Schema, path string) (int, bool) {
+	if s == nil {
+		return 0, false
+	}
+	raw, ok := s.Extra[protoFieldExtension]
+	if !ok {
+		return 0, false
+	}
+	var number int
+	switch v := raw.(type) {
+	case int:
+		number = v
+	case int64:
+		number = int(v)
+	case uint64:
+		number = int(min(v, math.MaxInt32))
+	case float64:
+		if v != math.Trunc(v) {
+			number = -1
+		} else {
+			number = int(v)
+		}
+	default:
+		number = -1
+	}
+	if number < 1 || number > protoMaxField || (number >= protoReservedFirst && number <= protoReservedLast) {
+		addIssue(g.result, path, fmt.Sprintf("%s must be an integer from 1 to %d outside %d-%d; a free number is assigned instead",
+			protoFieldExtension, protoMaxField, protoReservedFirst, protoReservedLast), SeverityWarning)
+		return 0, false
+	}
+	return number, true
+}
+
+// fieldType maps a schema to the type of a field. Inline objects and enums
+// become definitions nested in scope, named after hint.
+func (g *protoGenerator) fieldType(s *parser.Schema, path string, scope *protoMessage, hint string) protoType {
+	if s == nil {
+		return g.valueType()
+	}
+	if _, ok := s.IsBool(); ok {
+		return g.valueType()
+	}
+
+	if s.Ref != "" {
+		name, ok := componentName(s.Ref)
+		if !ok || g.schemas[name] == nil {
+			addIssue(g.result, path, fmt.Sprintf("unresolved reference %s; typed as %s", s.Ref, protoValue), SeverityWarning)
+			return g.valueType()
+		}
+		if typeName, ok := g.types[name]; ok {
+			return protoType{name: typeName, message: isProtoMessage(g.schemas[name])}
+		}
+		if g.inlining[name] {
+			addIssue(g.result, path, fmt.Sprintf("%s refers to itself without an object in between; typed as %s", s.Ref, protoValue), SeverityWarning)
+			return g.valueType()
+		}
+		g.inlining[name] = true
+		defer delete(g.inlining, name)
+		return g.fieldType(g.schemas[name], schemasPath+"."+name, scope, hint)
+	}
+
+	// An allOf wrapping a single reference, usually to give it a description
+	if len(s.AllOf) == 1 && len(s.Properties) == 0 && len(s.OneOf) == 0 && len(s.AnyOf) == 0 && s.AllOf[0] != nil && s.AllOf[0].Ref != "" {
+		return g.fieldType(s.AllOf[0], path+".allOf[0]", scope, hint)
+	}
+
+	if isProtoMessage(s) {
+		nested := g.buildMessage(g.nestedName(hint, scope), s, path)
+		scope.messages = append(scope.messages, nested)
+		return protoType{name: nested.name, message: true}
+	}
+	if isProtoEnum(s) {
+		nested := g.buildEnum(g.nestedName(hint, scope), s, path)
+		scope.enums = append(scope.enums, nested)
+		return protoType{name: nested.name}
+	}
+
+	g.reportUnsupported(s, path)
+	types := schemautil.GetSchemaTypes(s)
+	nonNull := slices.DeleteFunc(slices.Clone(types), func(t string) bool { return t == "null" })
+	if len(nonNull) > 1 {
+		addIssue(g.result, path, fmt.Sprintf("a value of several types (%s) is typed as %s", strings.Join(nonNull, ", "), protoValue), SeverityWarning)
+		return g.valueType()
+	}
+	if len(s.Enum) > 0 {
+		addIssue(g.result, path, "only string enums become proto enums; the enum values are not carried over", SeverityInfo)
+	}
+
+	switch schemautil.GetPrimaryType(s) {
+	case "string":
+		switch s.Format {
+		case "byte", "binary":
+			return protoType{name: "bytes"}
+		case "date-time":
+			g.imports[importTimestamp] = true
+			return protoType{name: protoTime, message: true}
+		}
+		return protoType{name: "string"}
+	case "integer":
+		if s.Format == "int32" {
+			return protoType{name: "int32"}
+		}
+		return protoType{name: "int64"}
+	case "number":
+		if s.Format == "float" {
+			return protoType{name: "float"}
+		}
+		return protoType{name: "double"}
+	case "boolean":
+		return protoType{name: "bool"}
+	case "array":
+		return g.arrayType(s, path, scope, hint)
+	case "object":
+		return g.mapType(s, path, scope, hint)
+	}
+	return g.valueType()
+}
+
+// arrayType maps an array schema to a repeated field.
+func (g *protoGenerator) arrayType(s *parser.Schema, path string, scope *protoMessage, hint string) protoType {
+	if len(s.PrefixItems) > 0 {
+		addIssue(g.result, path+".prefixItems", "tuple positions have no proto3 equivalent; elements are typed as "+protoValue, SeverityWarning)
+		return protoType{name: g.valueType().name, repeated: true}
+	}
+	items, _ := s.Items.(*parser.Schema)
+	element := g.fieldType(items, path+".items", scope, hint+"Item")
+	if element.repeated || element.isMap {
+		addIssue(g.result, path+".items", "proto3 has no nested repeated or map fields; elements are typed as "+protoValue, SeverityWarning)
+		return protoType{name: g.valueType().name, repeated: true}
+	}
+	return protoType{name: element.name, repeated: true}
+}
+
+// mapType maps an object schema without properties to a map field, or to
+// google.protobuf.Struct when its values are unconstrained.
+func (g *protoGenerator) mapType(s *parser.Schema, path string, scope *protoMessage, hint string) protoType {
+	values, ok := s.AdditionalProperties.(*parser.Schema)
+	if !ok || values == nil {
+		g.imports[importStruct] = true
+		return protoType{name: protoStruct, message: true}
+	}
+	value := g.fieldType(values, path+".additionalProperties", scope, hint+"Value")
+	if value.repeated || value.isMap {
+		addIssue(g.result, path+".additionalProperties", "proto3 map values cannot be repeated or maps; values are typed as "+protoValue, SeverityWarning)
+		value = g.valueType()
+	}
+	return protoType{name: fmt.Sprintf("map<string, %s>", value.name), isMap: true}
+}
+
+// valueType is google.protobuf.Value, which holds any JSON value
+func (g *protoGenerator) valueType() protoType {
+	g.imports[importStruct] = true
+	return protoType{name: protoValue, message: true}
+}
+
+// buildEnum maps a string enum to an enum. Values are prefixed with the enum
+// name, since proto3 enum values share the scope of their enum, and value 0
+// is the required UNSPECIFIED default.
+func (g *protoGenerator) buildEnum(name string, s *parser.Schema, path string) *protoEnum {
+	prefix := protoEnumValueName(name)
+	enum := &protoEnum{name: name, comment: schemaComment(s)}
+	seen := map[string]bool{prefix + "_UNSPECIFIED": true}
+	enum.values = append(enum.values, prefix+"_UNSPECIFIED")
+	for _, v := range s.Enum {
+		str, ok := v.(string)
+		if !ok {
+			continue
+		}
+		value := uniqueName(prefix+"_"+protoEnumValueName(str), seen)
+		seen[value] = true
+		enum.values = append(enum.values, value)
+	}
+	addIssue(g.result, path, fmt.Sprintf("proto3 JSON writes enum values by name, such as %s, not as the schema's values", enum.values[len(enum.values)-1]), SeverityInfo)
+	return enum
+}
+
+// reportUnsupported reports the keywords of a schema that shape its data in
+// ways proto3 cannot express. Validation keywords such as pattern or maximum
+// are not reported: proto3 drops every one of them alike.
+func (g *protoGenerator) reportUnsupported(s *parser.Schema, path string) {
+	unsupported := []struct {
+		present bool
+		keyword string
+	}{
+		{s.Not != nil, "not"},
+		{s.If != nil || s.Then != nil || s.Else != nil, "if/then/else"},
+		{len(s.PatternProperties) > 0, "patternProperties"},
+		{len(s.DependentSchemas) > 0, "dependentSchemas"},
+	}
+	for _, u := range unsupported {
+		if u.present {
+			addIssue(g.result, path, u.keyword+" has no proto3 equivalent; dropped", SeverityWarning)
+		}
+	}
+}
+
+// nestedName returns a name for a definition nested in scope that shadows
+// neither a top-level name nor a sibling.
+func (g *protoGenerator) nestedName(hint string, scope *protoMessage) string {
+	taken := make(map[string]bool, len(g.topNames))
+	for name := range g.topNames {
+		taken[name] = true
+	}
+	taken[scope.name] = true
+	for _, m := range scope.messages {
+		taken[m.name] = true
+	}
+	for _, e := range scope.enums {
+		taken[e.name] = true
+	}
+	return uniqueName(hint, taken)
+}
+
+// componentName returns the component a reference names, if it names one
+func componentName(ref string) (string, bool) {
+	rest, ok := strings.CutPrefix(ref, schemasPrefix)
+	if !ok || strings.Contains(rest, "/") {
+		return "", false
+	}
+	return unescapePointerToken(rest), true
+}
+
+// writeProtoMessage writes a message and its nested definitions
+func writeProtoMessage(b *strings.Builder, msg *protoMessage, indent string) {
+	writeProtoComment(b, msg.comment, indent)
+	fmt.Fprintf(b, "%smessage %s {\n", indent, msg.name)
+	inner := indent + "  "
+	for _, enum := range msg.enums {
+		writeProtoEnum(b, enum, inner)
+		b.WriteString("\n")
+	}
+	for _, nested := range msg.messages {
+		writeProtoMessage(b, nested, inner)
+		b.WriteString("\n")
+	}
+	for _, field := range msg.fields {
+		writeProtoField(b, field, inner)
+	}
+	if len(msg.oneof) > 0 {
+		fmt.Fprintf(b, "%soneof variant {\n", inner)
+		for _, field := range msg.oneof {
+			writeProtoField(b, field, inner+"  ")
+		}
+		fmt.Fprintf(b, "%s}\n", inner)
+	}
+	fmt.Fprintf(b, "%s}\n", indent)
+}
+
+// writeProtoField writes a field declaration
+func writeProtoField(b *strings.Builder, field *protoField, indent string) {
+	writeProtoComment(b, field.comment, indent)
+	b.WriteString(indent)
+	if field.label != "" {
+		b.WriteString(field.label + " ")
+	}
+	fmt.Fprintf(b, "%s %s = %d", field.typ, field.name, field.number)
+	var opts []string
+	if field.jsonName != "" {
+		opts = append(opts, fmt.Sprintf("json_name = %q", field.jsonName))
+	}
+	if field.deprecated {
+		opts = append(opts, "deprecated = true")
+	}
+	if len(opts) > 0 {
+		fmt.Fprintf(b, " [%s]", strings.Join(opts, ", "))
+	}
+	b.WriteString(";\n")
+}
+
+// writeProtoEnum writes an enum declaration
+func writeProtoEnum(b *strings.Builder, enum *protoEnum, indent string) {
+	writeProtoComment(b, enum.comment, indent)
+	fmt.Fprintf(b, "%senum %s {\n", indent, enum.name)
+	for i, value := range enum.values {
+		fmt.Fprintf(b, "%s  %s = %d;\n", indent, value, i)
+	}
+	fmt.Fprintf(b, "%s}\n", indent)
+}
+
+// writeProtoComment writes a comment line per line of text
+func writeProtoComment(b *strings.Builder, text, indent string) {
+	if text == "" {
+		return
+	}
+	for line := range strings.SplitSeq(text, "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		if line == "" {
+			fmt.Fprintf(b, "%s//\n", indent)
+		} else {
+			fmt.Fprintf(b, "%s// %s\n", indent, line)
+		}
+	}
+}
+
+// schemaComment returns the text a schema is documented with
+func schemaComment(s *parser.Schema) string {
+	if s == nil {
+		return ""
+	}
+	if s.Description != "" {
+		return strings.TrimSpace(s.Description)
+	}
+	return strings.TrimSpace(s.Title)
+}
+
+// protoTypeName turns a schema or property name into a message or enum name
+func protoTypeName(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if !isProtoIdentRune(r) || r == '_' {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	ident := b.String()
+	if ident == "" || unicode.IsDigit(rune(ident[0])) {
+		ident = "Schema" + ident
+	}
+	return ident
+}
+
+// protoFieldName turns a property name into a snake_case field name.
+// Acronyms stay together: "petID" becomes "pet_id", "URLPath" "url_path".
+func protoFieldName(name string) string {
+	ident := snakeCase(name)
+	if ident == "" || unicode.IsDigit(rune(ident[0])) {
+		ident = "field_" + ident
+	}
+	return ident
+}
+
+// protoEnumValueName turns a name or enum value into UPPER_SNAKE_CASE. The
+// result follows a prefix, so it may start with a digit.
+func protoEnumValueName(name string) string {
+	ident := strings.ToUpper(snakeCase(name))
+	if ident == "" {
+		return "EMPTY"
+	}
+	return ident
+}
+
+// snakeCase splits name into lowercase words joined by underscores, breaking
+// at case changes and at every rune a proto identifier cannot hold.
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if !isProtoIdentRune(r) {
+			r = '_'
+		}
+		if unicode.IsUpper(r) && i > 0 {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				b.WriteRune('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	ident := strings.Trim(b.String(), "_")
+	for strings.Contains(ident, "__") {
+		ident = strings.ReplaceAll(ident, "__", "_")
+	}
+	return ident
+}
+
+// protoJSONName returns the JSON name protoc derives from a field name:
+// underscores are dropped and the letter after each is capitalized.
+func protoJSONName(field string) string {
+	var b strings.Builder
+	upper := false
+	for _, r := range field {
+		if r == '_' {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// isProtoIdentRune reports whether r may appear in a proto identifier
+func isProtoIdentRune(r rune) bool {
+	return r == '_' || (r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)))
+}
+
+// uniqueName returns name, or name with the lowest numeric suffix from 2 that
+// is not taken
+func uniqueName(name string, taken map[string]bool) string {
+	if !taken[name] {
+		return name
+	}
+	for i := 2; ; i++ {
+		candidate := fmt.Sprintf("%s%d", name, i)
+		if !taken[candidate] {
+			return candidate
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package exporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProto_Mapping(t *testing.T) {
+	result := exportSpec(t, `openapi: 3.1.0
+info:
+  title: Test
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Shape:
+      anyOf:
+        - $ref: '#/components/schemas/Circle'
+        - type: object
+          properties:
+            side:
+              type: number
+        - type: array
+          items:
+            type: string
+    Circle:
+      type: object
+      required: [radius]
+      properties:
+        radius:
+          type: [number, "null"]
+          format: float
+        label:
+          type: [string, integer]
+        payload:
+          type: string
+          format: byte
+        extra:
+          type: object
+          additionalProperties: true
+        ids:
+          $ref: '#/components/schemas/Ids'
+        self:
+          $ref: '#/components/schemas/Circle'
+        kind:
+          not:
+            type: string
+      additionalProperties:
+        type: string
+    Ids:
+      type: array
+      items:
+        type: integer
+        format: int32
+    Level:
+      type: integer
+      enum: [1, 2, 3]
+`, WithFormat("proto"))
+
+	content := string(result.GetFile("schemas.proto").Content)
+	assert.Contains(t, content, `import "google/protobuf/struct.proto";`)
+	assert.Contains(t, content, "optional float radius = ")
+	assert.Contains(t, content, "google.protobuf.Value label = ")
+	assert.Contains(t, content, "optional bytes payload = ")
+	assert.Contains(t, content, "google.protobuf.Struct extra = ")
+	assert.Contains(t, content, "repeated int32 ids = ")
+	assert.Contains(t, content, "Circle self = ")
+	assert.Contains(t, content, `  oneof variant {
+    Circle circle = 1;
+    Variant2 variant2 = 2;
+  }`)
+	assert.Contains(t, content, "  message Variant2 {\n    optional double side = 1;\n  }")
+	assert.Equal(t, 2, result.ExportedSchemas)
+
+	var messages []string
+	for _, issue := range result.Issues {
+		messages = append(messages, issue.Path+": "+issue.Message)
+	}
+	assert.ElementsMatch(t, []string{
+		"components.schemas.Circle.additionalProperties: additional properties beside declared ones have no proto3 equivalent; dropped",
+		"components.schemas.Circle.properties.label: a value of several types (string, integer) is typed as google.protobuf.Value",
+		"components.schemas.Circle.properties.kind: not has no proto3 equivalent; dropped",
+		"components.schemas.Ids: schema is not an object or string enum and proto3 has no type aliases; inlined where referenced",
+		"components.schemas.Level: schema is not an object or string enum and proto3 has no type aliases; inlined where referenced",
+		"components.schemas.Shape.anyOf: anyOf is exported as a oneof, which holds exactly one of the variants",
+		"components.schemas.Shape.anyOf[2]: a oneof cannot hold a repeated or map field; variant dropped",
+	}, messages)
+}
+
+func TestProto_FieldNumbers(t *testing.T) {
+	result := exportSpec(t, `openapi: 3.0.3
+info:
+  title: Test
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+          x-proto-field: 1
+        age:
+          type: integer
+        owner:
+          type: string
+          x-proto-field: 1
+        color:
+          type: string
+          x-proto-field: 19500
+        weight:
+          type: number
+          x-proto-field: 3
+`, WithFormat("proto"))
+
+	content := string(result.GetFile("schemas.proto").Content)
+	assert.Contains(t, content, `message Pet {
+  optional string name = 1;
+  optional int64 age = 2;
+  optional double weight = 3;
+  optional string color = 4;
+  optional string owner = 5;
+}`)
+	require.Len(t, result.Issues, 2)
+	assert.Equal(t, "components.schemas.Pet.properties.color", result.Issues[0].Path)
+	assert.Contains(t, result.Issues[0].Message, "must be an integer from 1 to")
+	assert.Equal(t, "components.schemas.Pet.properties.owner", result.Issues[1].Path)
+	assert.Contains(t, result.Issues[1].Message, "x-proto-field 1 is already taken")
+}
+
+func TestProto_Names(t *testing.T) {
+	fields := map[string]string{
+		"name":       "name",
+		"petID":      "pet_id",
+		"URLPath":    "url_path",
+		"created-at": "created_at",
+		"pet_id":     "pet_id",
+		"2fa":        "field_2fa",
+		"$ref":       "ref",
+	}
+	for in, want := range fields {
+		assert.Equal(t, want, protoFieldName(in), in)
+	}
+
+	types := map[string]string{
+		"Pet":         "Pet",
+		"pet_owner":   "PetOwner",
+		"v1.Pet":      "V1Pet",
+		"2xxResponse": "Schema2xxResponse",
+	}
+	for in, want := range types {
+		assert.Equal(t, want, protoTypeName(in), in)
+	}
+
+	assert.Equal(t, "IN_PROGRESS", protoEnumValueName("in-progress"))
+	assert.Equal(t, "2XX", protoEnumValueName("2xx"))
+	assert.Equal(t, "EMPTY", protoEnumValueName(""))
+
+	assert.Equal(t, "petId", protoJSONName("pet_id"))
+	assert.Equal(t, "Pet2", uniqueName("Pet", map[string]bool{"Pet": true}))
+}
+
+func TestProto_JSONName(t *testing.T) {
+	result := exportSpec(t, `openapi: 3.0.3
+info:
+  title: Test
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [pet_id, petName, ID]
+      properties:
+        pet_id:
+          type: string
+        petName:
+          type: string
+        ID:
+          type: string
+`, WithFormat("proto"))
+
+	content := string(result.GetFile("schemas.proto").Content)
+	assert.Contains(t, content, `string id = 1 [json_name = "ID"];`)
+	assert.Contains(t, content, "string pet_name = 2;\n")
+	assert.Contains(t, content, `string pet_id = 3 [json_name = "pet_id"];`)
+}
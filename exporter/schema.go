@@ -0,0 +1,101 @@
+package exporter
+
+import (
+	"github.com/erraggy/oastools/internal/maputil"
+	"github.com/erraggy/oastools/internal/schemautil"
+	"github.com/erraggy/oastools/parser"
+)
+
+// walkSchema calls fn for schema and every schema nested in it, parents before
+// children. Each schema is visited once, so shared or cyclic subtrees are safe.
+func walkSchema(schema *parser.Schema, path string, fn func(s *parser.Schema, path string)) {
+	walkSchemaVisited(schema, path, fn, make(map[*parser.Schema]bool))
+}
+
+// walkSchemaVisited is walkSchema, skipping the schemas already in visited.
+func walkSchemaVisited(s *parser.Schema, path string, fn func(s *parser.Schema, path string), visited map[*parser.Schema]bool) {
+	if s == nil || visited[s] {
+		return
+	}
+	visited[s] = true
+	fn(s, path)
+
+	walk := func(child *parser.Schema, childPath string) {
+		walkSchemaVisited(child, childPath, fn, visited)
+	}
+	walkList := func(list []*parser.Schema, field string) {
+		for i, child := range list {
+			walk(child, path+"."+field+schemautil.IndexSuffix(i))
+		}
+	}
+	walkMap := func(m map[string]*parser.Schema, field string) {
+		for _, name := range maputil.SortedKeys(m) {
+			walk(m[name], path+"."+field+"."+name)
+		}
+	}
+	walkSchemaOrBool := func(field any, name string) {
+		for i, child := range schemautil.SchemaOrBoolSchemas(field) {
+			walk(child, path+"."+name+schemautil.IndexSuffix(i))
+		}
+	}
+
+	walkSchemaOrBool(s.Items, "items")
+	walkList(s.PrefixItems, "prefixItems")
+	walkSchemaOrBool(s.AdditionalItems, "additionalItems")
+	walkSchemaOrBool(s.UnevaluatedItems, "unevaluatedItems")
+	walk(s.Contains, path+".contains")
+	walkMap(s.Properties, "properties")
+	walkMap(s.PatternProperties, "patternProperties")
+	walkSchemaOrBool(s.AdditionalProperties, "additionalProperties")
+	walkSchemaOrBool(s.UnevaluatedProperties, "unevaluatedProperties")
+	walk(s.PropertyNames, path+".propertyNames")
+	walkMap(s.DependentSchemas, "dependentSchemas")
+	walk(s.If, path+".if")
+	walk(s.Then, path+".then")
+	walk(s.Else, path+".else")
+	walkList(s.AllOf, "allOf")
+	walkList(s.AnyOf, "anyOf")
+	walkList(s.OneOf, "oneOf")
+	walk(s.Not, path+".not")
+	walk(s.ContentSchema, path+".contentSchema")
+	walkMap(s.Defs, "$defs")
+}
+
+// normalizeSchema rewrites the OAS keywords of a single schema that the
+// conversion to OAS 3.1 leaves in place into their JSON Schema 2020-12
+// spelling, and drops the ones 2020-12 has no keyword for. Nested schemas are
+// left to the caller's walk.
+func normalizeSchema(s *parser.Schema, path string, result *ExportResult) {
+	// OAS 3.0 nullable becomes "null" in the type array. Without a type it
+	// never admitted null, so there is nothing to carry over.
+	if s.Nullable {
+		if types := schemautil.GetSchemaTypes(s); len(types) > 0 && !schemautil.IsNullable(s) {
+			typeArray := make([]any, 0, len(types)+1)
+			for _, t := range types {
+				typeArray = append(typeArray, t)
+			}
+			s.Type = append(typeArray, "null")
+		}
+		s.Nullable = false
+	}
+
+	if s.Example != nil {
+		if len(s.Examples) == 0 {
+			s.Examples = []any{s.Example}
+		}
+		s.Example = nil
+	}
+
+	if s.Discriminator != nil {
+		addIssue(result, path, "discriminator is an OpenAPI keyword with no JSON Schema equivalent; dropped", SeverityInfo)
+		s.Discriminator = nil
+	}
+	if s.XML != nil {
+		addIssue(result, path, "xml is an OpenAPI keyword with no JSON Schema equivalent; dropped", SeverityInfo)
+		s.XML = nil
+	}
+	if s.ExternalDocs != nil {
+		addIssue(result, path, "externalDocs is an OpenAPI keyword with no JSON Schema equivalent; dropped", SeverityInfo)
+		s.ExternalDocs = nil
+	}
+}
@@ -0,0 +1,32 @@
+package exporter
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/erraggy/oastools/internal/fileutil"
+)
+
+// WriteFiles writes all exported files to the specified output directory.
+// The directory is created if it doesn't exist.
+func (r *ExportResult) WriteFiles(outputDir string) error {
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	for _, file := range r.Files {
+		// Component names become file names, so one the specification
+		// would reject must not escape the directory
+		safeName := filepath.Base(file.Name)
+		if safeName != file.Name {
+			return fmt.Errorf("invalid file name %q: must not contain path separators", file.Name)
+		}
+		filePath := filepath.Join(outputDir, safeName)
+		if err := os.WriteFile(filePath, file.Content, fileutil.ReadableByAll); err != nil {
+			return fmt.Errorf("failed to write file %s: %w", file.Name, err)
+		}
+	}
+
+	return nil
+}
@@ -18,6 +18,15 @@ type baseCodeGenerator struct {
 	generatedTypes map[string]bool   // tracks which type names have been generated
 	splitPlan      *SplitPlan        // file splitting plan for large APIs
 
+	// Import mapping for $refs into external documents
+	importMapping map[string]mappedImport // keyed by cleaned document path
+	usedImports   map[mappedImport]bool   // mapped imports referenced so far
+
+	// Optional and Nullable field types
+	optionalTypesChecked bool // optionalTypes has been decided
+	optionalTypes        bool // fields use the wrappers
+	optionalTypesUsed    bool // some field used a wrapper
+
 	// Version-agnostic document access (set in constructor)
 	paths       parser.Paths
 	oasVersion  parser.OASVersion
@@ -34,6 +43,7 @@ func (b *baseCodeGenerator) initBase(g *Generator, result *GenerateResult) {
 	b.result = result
 	b.schemaNames = make(map[string]string)
 	b.generatedTypes = make(map[string]bool)
+	b.importMapping, _ = parseImportMapping(g.ImportMapping) // checked by GenerateParsed
 }
 
 // securityContext returns a securityGenerationContext for shared security generation functions.
@@ -75,6 +85,9 @@ func (b *baseCodeGenerator) resolveRef(ref string) string {
 	if typeName, ok := b.schemaNames[ref]; ok {
 		return typeName
 	}
+	if typeName, ok := b.resolveMappedRef(ref); ok {
+		return typeName
+	}
 	// Extract name from ref path
 	parts := strings.Split(ref, "/")
 	if len(parts) > 0 {
@@ -164,10 +177,22 @@ func (b *baseCodeGenerator) schemaToGoTypeBase(schema *parser.Schema, required b
 		return "any"
 	}
 
+	// x-go-type-skip-optional-pointer keeps optional values as plain types
+	usePointers := b.g.UsePointers && !skipOptionalPointer(schema)
+
+	// x-go-type replaces the derived type, but keeps the pointer rules
+	if goType := goTypeOverride(schema); goType != "" {
+		if (!required || isNullable) && usePointers && !strings.HasPrefix(goType, "[]") &&
+			!strings.HasPrefix(goType, "map[") && !strings.HasPrefix(goType, "*") {
+			return "*" + goType
+		}
+		return goType
+	}
+
 	// Handle $ref
 	if schema.Ref != "" {
 		refType := b.resolveRef(schema.Ref)
-		if !required && b.g.UsePointers {
+		if !required && usePointers {
 			return "*" + refType
 		}
 		return refType
@@ -199,12 +224,12 @@ func (b *baseCodeGenerator) schemaToGoTypeBase(schema *parser.Schema, required b
 	}
 
 	// Handle optional fields with pointers
-	if !required && b.g.UsePointers && !strings.HasPrefix(goType, "[]") && !strings.HasPrefix(goType, "map") {
+	if !required && usePointers && !strings.HasPrefix(goType, "[]") && !strings.HasPrefix(goType, "map") {
 		return "*" + goType
 	}
 
 	// Handle nullable with pointers (OAS 3.x)
-	if isNullable && b.g.UsePointers && !strings.HasPrefix(goType, "[]") && !strings.HasPrefix(goType, "map") && !strings.HasPrefix(goType, "*") {
+	if isNullable && usePointers && !strings.HasPrefix(goType, "[]") && !strings.HasPrefix(goType, "map") && !strings.HasPrefix(goType, "*") {
 		return "*" + goType
 	}
 
@@ -221,6 +246,9 @@ func (b *baseCodeGenerator) generateServerResponses() error {
 	if len(b.paths) == 0 {
 		return nil
 	}
+	if b.g.ServerStrict {
+		return b.generateStrictServerResponses()
+	}
 
 	// Build template data
 	data := ServerResponsesFileData{
@@ -269,12 +297,12 @@ func (b *baseCodeGenerator) generateServerResponses() error {
 	// Execute template
 	formatted, err := executeTemplate("responses.go.tmpl", data)
 	if err != nil {
-		b.addIssue("server_responses.go", fmt.Sprintf("failed to execute template: %v", err), SeverityWarning)
+		b.addIssue(fileNameServerResponses, fmt.Sprintf("failed to execute template: %v", err), SeverityWarning)
 		return err
 	}
 
 	b.result.Files = append(b.result.Files, GeneratedFile{
-		Name:    "server_responses.go",
+		Name:    fileNameServerResponses,
 		Content: formatted,
 	})
 
@@ -351,7 +379,11 @@ func (b *baseCodeGenerator) generateServerStubs() error {
 		schemaTypes: b.generatedTypes,
 		result:      b.result,
 		addIssue:    b.addIssue,
+		strict:      b.g.ServerStrict,
 		getResponseType: func(methodName string) string {
+			if b.g.ServerStrict {
+				return strictResponseType(methodName)
+			}
 			if !b.g.ServerResponses {
 				return "any"
 			}
@@ -22,6 +22,8 @@ func writeClientStruct(buf *bytes.Buffer) {
 	buf.WriteString("\tUserAgent string\n")
 	buf.WriteString("\t// RequestEditors are functions that can modify requests before sending.\n")
 	buf.WriteString("\tRequestEditors []RequestEditorFn\n")
+	buf.WriteString("\t// RetryPolicy retries failed idempotent requests; nil disables retries.\n")
+	buf.WriteString("\tRetryPolicy *RetryPolicy\n")
 	buf.WriteString("}\n\n")
 }
 
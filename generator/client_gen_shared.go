@@ -118,7 +118,7 @@ func writeRequestEditors(buf *bytes.Buffer, responseType string) {
 
 // writeRequestExecution writes the request execution and error handling code.
 func writeRequestExecution(buf *bytes.Buffer, responseType string) {
-	buf.WriteString("\tresp, err := c.HTTPClient.Do(req)\n")
+	buf.WriteString("\tresp, err := c.do(req)\n")
 	buf.WriteString("\tif err != nil {\n")
 	fmt.Fprintf(buf, "\t\treturn %s, fmt.Errorf(\"execute request: %%w\", err)\n", zeroValue(responseType))
 	buf.WriteString("\t}\n")
@@ -164,13 +164,13 @@ func writeParamsStruct(buf *bytes.Buffer, methodName string, queryParams []*pars
 	fmt.Fprintf(buf, "// %sParams contains query parameters for %s.\n", methodName, methodName)
 	fmt.Fprintf(buf, "type %sParams struct {\n", methodName)
 	for _, param := range queryParams {
-		goType := paramToGoType(param)
+		goType := paramsFieldType(param, paramToGoType)
 		fieldName := toFieldName(param.Name)
 		if param.Description != "" {
 			fmt.Fprintf(buf, "\t// %s\n", cleanDescription(param.Description))
 		}
 		if !param.Required {
-			fmt.Fprintf(buf, "\t%s *%s `json:%q`\n", fieldName, goType, param.Name+",omitempty")
+			fmt.Fprintf(buf, "\t%s %s `json:%q`\n", fieldName, goType, param.Name+",omitempty")
 		} else {
 			fmt.Fprintf(buf, "\t%s %s `json:%q`\n", fieldName, goType, param.Name)
 		}
@@ -178,6 +178,17 @@ func writeParamsStruct(buf *bytes.Buffer, methodName string, queryParams []*pars
 	buf.WriteString("}\n\n")
 }
 
+// paramsFieldType returns the type of a query parameter's field in a params
+// struct. Optional parameters are pointers, which paramToGoType may already
+// have made them.
+func paramsFieldType(param *parser.Parameter, paramToGoType func(*parser.Parameter) string) string {
+	goType := paramToGoType(param)
+	if !param.Required && !strings.HasPrefix(goType, "*") {
+		return "*" + goType
+	}
+	return goType
+}
+
 // writeClientMethod writes all the shared client method code.
 // This is identical between OAS 2.0 and OAS 3.x generators.
 func writeClientMethod(buf *bytes.Buffer, op *parser.Operation, methodName, method, path string,
@@ -0,0 +1,458 @@
+// This file implements iterators over paginated operations, which generated
+// clients get for operations marked with the x-pagination extension.
+
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/erraggy/oastools/internal/pathutil"
+	"github.com/erraggy/oastools/parser"
+)
+
+// extPagination marks a paginated operation. Its value is an object:
+//
+//	style:        cursor, offset, or link (required)
+//	items:        response property holding a page's items; omitted when
+//	              the response is itself an array
+//	cursorParam:  query parameter taking the cursor (cursor style, default "cursor")
+//	nextCursor:   response property holding the next cursor (cursor style,
+//	              default "next_cursor")
+//	offsetParam:  query parameter taking the offset (offset style, default "offset")
+//	limitParam:   query parameter taking the page size (offset style, optional);
+//	              a page shorter than the limit is the last
+//
+// Link style follows the rel="next" URL of each response's Link header.
+const extPagination = "x-pagination"
+
+// Pagination styles.
+const (
+	paginationCursor = "cursor"
+	paginationOffset = "offset"
+	paginationLink   = "link"
+)
+
+// paginationConfig is the parsed x-pagination of an operation.
+type paginationConfig struct {
+	style       string
+	items       string
+	cursorParam string
+	nextCursor  string
+	offsetParam string
+	limitParam  string
+}
+
+// parsePagination returns the operation's x-pagination, and false if it has
+// none. An invalid value is reported as an error.
+func parsePagination(op *parser.Operation) (paginationConfig, bool, error) {
+	raw, ok := op.Extra[extPagination]
+	if !ok {
+		return paginationConfig{}, false, nil
+	}
+	values, ok := raw.(map[string]any)
+	if !ok {
+		return paginationConfig{}, true, fmt.Errorf("%s must be an object", extPagination)
+	}
+	get := func(key, fallback string) string {
+		if s, ok := values[key].(string); ok && s != "" {
+			return s
+		}
+		return fallback
+	}
+	cfg := paginationConfig{
+		style:       get("style", ""),
+		items:       get("items", ""),
+		cursorParam: get("cursorParam", "cursor"),
+		nextCursor:  get("nextCursor", "next_cursor"),
+		offsetParam: get("offsetParam", "offset"),
+		limitParam:  get("limitParam", ""),
+	}
+	switch cfg.style {
+	case paginationCursor, paginationOffset, paginationLink:
+		return cfg, true, nil
+	}
+	return cfg, true, fmt.Errorf("%s style must be cursor, offset, or link, not %q", extPagination, cfg.style)
+}
+
+// clientIteratorData describes the client method an iterator pages through.
+type clientIteratorData struct {
+	location     string // issue location, e.g. "paths./pets.get"
+	methodName   string
+	path         string
+	params       []string // the method's parameters, e.g. "ctx context.Context"
+	pathParams   []pathParam
+	queryParams  []*parser.Parameter
+	hasBody      bool
+	responseType string
+	// respSchema is the success response schema, with a $ref resolved
+	respSchema *parser.Schema
+
+	paramToGoType  func(*parser.Parameter) string
+	schemaToGoType func(*parser.Schema, bool) string
+}
+
+// successResponseSchema returns the schema of the success media type
+// successMediaType picks, following a $ref into components.schemas.
+func (cg *oas3CodeGenerator) successResponseSchema(mediaType *parser.MediaType) *parser.Schema {
+	if mediaType == nil {
+		return nil
+	}
+	return cg.resolveSchema(mediaType.Schema)
+}
+
+// resolveSchema follows a reference into components.schemas, returning
+// schema itself when it is not a resolvable reference.
+func (cg *oas3CodeGenerator) resolveSchema(schema *parser.Schema) *parser.Schema {
+	if schema.Ref == "" || cg.doc.Components == nil {
+		return schema
+	}
+	name, ok := strings.CutPrefix(schema.Ref, pathutil.RefPrefixSchemas)
+	if target := cg.doc.Components.Schemas[name]; ok && target != nil {
+		return target
+	}
+	return schema
+}
+
+// successResponseSchema returns the schema of the response getResponseType
+// types, following a $ref into definitions.
+func (cg *oas2CodeGenerator) successResponseSchema(op *parser.Operation) *parser.Schema {
+	if op.Responses == nil {
+		return nil
+	}
+	for _, resp := range []*parser.Response{op.Responses.Codes["200"], op.Responses.Codes["201"], op.Responses.Default} {
+		if resp == nil || resp.Schema == nil {
+			continue
+		}
+		schema := resp.Schema
+		if name, ok := strings.CutPrefix(schema.Ref, pathutil.RefPrefixDefinitions); ok && cg.doc.Definitions[name] != nil {
+			return cg.doc.Definitions[name]
+		}
+		return schema
+	}
+	return nil
+}
+
+// writeClientIterator writes the {Method}Iter method for a paginated
+// operation. Operations without x-pagination get nothing, and one whose
+// pagination cannot be generated gets a warning.
+func (b *baseCodeGenerator) writeClientIterator(buf *bytes.Buffer, op *parser.Operation, data clientIteratorData) {
+	cfg, ok, err := parsePagination(op)
+	if !ok {
+		return
+	}
+	if err == nil {
+		var code bytes.Buffer
+		if err = b.writeIterator(&code, cfg, data); err == nil {
+			buf.Write(code.Bytes())
+			return
+		}
+	}
+	b.addIssue(data.location, fmt.Sprintf("skipping %sIter: %v", data.methodName, err), SeverityWarning)
+}
+
+// writeIterator writes the iterator for cfg's pagination style.
+func (b *baseCodeGenerator) writeIterator(buf *bytes.Buffer, cfg paginationConfig, data clientIteratorData) error {
+	itemsExpr, itemType, err := b.pageItems(cfg, data)
+	if err != nil {
+		return err
+	}
+
+	var describe string
+	switch cfg.style {
+	case paginationCursor:
+		describe = fmt.Sprintf("passing each page's %s as %s", cfg.nextCursor, cfg.cursorParam)
+	case paginationOffset:
+		describe = fmt.Sprintf("advancing %s by each page's size", cfg.offsetParam)
+	case paginationLink:
+		describe = `following each response's Link rel="next"`
+	}
+	fmt.Fprintf(buf, "// %sIter iterates over the items of every page of %s, %s.\n", data.methodName, data.methodName, describe)
+	buf.WriteString("// Iteration stops after the first error, which is yielded with a zero item.\n")
+	fmt.Fprintf(buf, "func (c *Client) %sIter(%s) iter.Seq2[%s, error] {\n", data.methodName, strings.Join(data.params, ", "), itemType)
+	fmt.Fprintf(buf, "\treturn func(yield func(%s, error) bool) {\n", itemType)
+	fmt.Fprintf(buf, "\t\tvar zero %s\n", itemType)
+
+	var body bytes.Buffer
+	switch cfg.style {
+	case paginationCursor:
+		err = b.writeCursorLoop(&body, cfg, data, itemsExpr)
+	case paginationOffset:
+		err = b.writeOffsetLoop(&body, cfg, data, itemsExpr)
+	case paginationLink:
+		err = writeLinkLoop(&body, data, itemsExpr)
+	}
+	if err != nil {
+		return err
+	}
+	for line := range strings.Lines(body.String()) {
+		buf.WriteString("\t" + line)
+	}
+	buf.WriteString("\t}\n}\n\n")
+	return nil
+}
+
+// pageItems returns the expression for the items of a page held in the
+// variable page, and the Go type of an item.
+func (b *baseCodeGenerator) pageItems(cfg paginationConfig, data clientIteratorData) (expr, itemType string, err error) {
+	if cfg.items == "" {
+		if elem, ok := strings.CutPrefix(data.responseType, "[]"); ok {
+			return "page", elem, nil
+		}
+		return "", "", fmt.Errorf("the response is not an array, so x-pagination needs items")
+	}
+	field, goType, err := b.responseField(data, cfg.items)
+	if err != nil {
+		return "", "", err
+	}
+	elem, ok := strings.CutPrefix(goType, "[]")
+	if !ok {
+		return "", "", fmt.Errorf("response property %q is not an array", cfg.items)
+	}
+	return "page." + field, elem, nil
+}
+
+// responseField returns the Go field name and type of a property of the
+// success response.
+func (b *baseCodeGenerator) responseField(data clientIteratorData, propName string) (field, goType string, err error) {
+	if data.respSchema == nil || !strings.HasPrefix(data.responseType, "*") {
+		return "", "", fmt.Errorf("the success response is not a JSON object")
+	}
+	propSchema := data.respSchema.Properties[propName]
+	if propSchema == nil {
+		return "", "", fmt.Errorf("the success response has no property %q", propName)
+	}
+	required := isRequired(data.respSchema.Required, propName)
+	return goFieldName(propName, propSchema), b.fieldGoType(propSchema, required, false, data.schemaToGoType), nil
+}
+
+// queryParamField returns the params struct field name and type for the
+// query parameter called name.
+func queryParamField(data clientIteratorData, name string) (field, goType string, err error) {
+	for _, param := range data.queryParams {
+		if param.Name == name {
+			return toFieldName(param.Name), paramsFieldType(param, data.paramToGoType), nil
+		}
+	}
+	return "", "", fmt.Errorf("%s has no query parameter %q", data.methodName, name)
+}
+
+// writeCursorLoop writes the loop of a cursor-style iterator.
+func (b *baseCodeGenerator) writeCursorLoop(buf *bytes.Buffer, cfg paginationConfig, data clientIteratorData, itemsExpr string) error {
+	paramField, paramType, err := queryParamField(data, cfg.cursorParam)
+	if err != nil {
+		return err
+	}
+	nextField, nextType, err := b.responseField(data, cfg.nextCursor)
+	if err != nil {
+		return err
+	}
+	cursorType := strings.TrimPrefix(paramType, "*")
+	if _, valueType := optionalWrapper(strings.TrimPrefix(nextType, "*")); valueType != cursorType {
+		return fmt.Errorf("response property %q is not of the %s parameter's type %s", cfg.nextCursor, cfg.cursorParam, cursorType)
+	}
+
+	writeParamsCopy(buf, data.methodName)
+	buf.WriteString("\tfor {\n")
+	writePageCall(buf, data)
+	writeYieldItems(buf, itemsExpr)
+	writeValueOrReturn(buf, "next", "page."+nextField, nextType)
+	fmt.Fprintf(buf, "\t\tif next == %s {\n\t\t\treturn\n\t\t}\n", zeroValue(cursorType))
+	if strings.HasPrefix(paramType, "*") {
+		fmt.Fprintf(buf, "\t\tp.%s = &next\n", paramField)
+	} else {
+		fmt.Fprintf(buf, "\t\tp.%s = next\n", paramField)
+	}
+	buf.WriteString("\t}\n")
+	return nil
+}
+
+// writeOffsetLoop writes the loop of an offset-style iterator.
+func (b *baseCodeGenerator) writeOffsetLoop(buf *bytes.Buffer, cfg paginationConfig, data clientIteratorData, itemsExpr string) error {
+	offsetField, offsetType, err := queryParamField(data, cfg.offsetParam)
+	if err != nil {
+		return err
+	}
+	valueType := strings.TrimPrefix(offsetType, "*")
+	if !isIntegerGoType(valueType) {
+		return fmt.Errorf("the %s parameter is not an integer", cfg.offsetParam)
+	}
+	var limitField, limitType string
+	if cfg.limitParam != "" {
+		if limitField, limitType, err = queryParamField(data, cfg.limitParam); err != nil {
+			return err
+		}
+		if !isIntegerGoType(strings.TrimPrefix(limitType, "*")) {
+			return fmt.Errorf("the %s parameter is not an integer", cfg.limitParam)
+		}
+	}
+
+	writeParamsCopy(buf, data.methodName)
+	if strings.HasPrefix(offsetType, "*") {
+		fmt.Fprintf(buf, "\tvar offset %s\n\tif p.%s != nil {\n\t\toffset = *p.%s\n\t}\n", valueType, offsetField, offsetField)
+	} else {
+		fmt.Fprintf(buf, "\toffset := p.%s\n", offsetField)
+	}
+	buf.WriteString("\tfor {\n")
+	if strings.HasPrefix(offsetType, "*") {
+		fmt.Fprintf(buf, "\t\tp.%s = &offset\n", offsetField)
+	} else {
+		fmt.Fprintf(buf, "\t\tp.%s = offset\n", offsetField)
+	}
+	writePageCall(buf, data)
+	writeYieldItems(buf, itemsExpr)
+	fmt.Fprintf(buf, "\t\tif len(%s) == 0 {\n\t\t\treturn\n\t\t}\n", itemsExpr)
+	switch {
+	case limitField == "":
+	case strings.HasPrefix(limitType, "*"):
+		fmt.Fprintf(buf, "\t\tif p.%s != nil && len(%s) < int(*p.%s) {\n\t\t\treturn\n\t\t}\n", limitField, itemsExpr, limitField)
+	default:
+		fmt.Fprintf(buf, "\t\tif len(%s) < int(p.%s) {\n\t\t\treturn\n\t\t}\n", itemsExpr, limitField)
+	}
+	fmt.Fprintf(buf, "\t\toffset += %s(len(%s))\n", valueType, itemsExpr)
+	buf.WriteString("\t}\n")
+	return nil
+}
+
+// writeLinkLoop writes the loop of a Link-header iterator, which requests
+// each page itself since the client method does not expose headers.
+func writeLinkLoop(buf *bytes.Buffer, data clientIteratorData, itemsExpr string) error {
+	if data.hasBody {
+		return fmt.Errorf("link pagination needs an operation without a request body")
+	}
+	if data.responseType == httpResponseType {
+		return fmt.Errorf("the success response is not JSON")
+	}
+	// The iterator shares the method's parameters, so the method's URL
+	// building code applies as is
+	writeURLBuilding(buf, data.path, data.pathParams)
+	writeQueryStringBuilding(buf, data.queryParams)
+	buf.WriteString("\tnext := c.BaseURL + path\n")
+	buf.WriteString("\tfor next != \"\" {\n")
+	fmt.Fprintf(buf, "\t\tvar page %s\n", strings.TrimPrefix(data.responseType, "*"))
+	buf.WriteString("\t\tlink, err := c.getPage(ctx, next, &page)\n")
+	writeYieldError(buf)
+	writeYieldItems(buf, itemsExpr)
+	buf.WriteString("\t\tnext = link\n")
+	buf.WriteString("\t}\n")
+	return nil
+}
+
+// writeParamsCopy writes a copy of the params argument the iterator can
+// advance without changing the caller's.
+func writeParamsCopy(buf *bytes.Buffer, methodName string) {
+	fmt.Fprintf(buf, "\tvar p %sParams\n\tif params != nil {\n\t\tp = *params\n\t}\n", methodName)
+}
+
+// writePageCall writes a call of the client method for one page, passing
+// the iterator's arguments with the advanced params copy.
+func writePageCall(buf *bytes.Buffer, data clientIteratorData) {
+	args := make([]string, 0, len(data.params))
+	for _, param := range data.params {
+		name, _, _ := strings.Cut(param, " ")
+		if name == "params" {
+			name = "&p"
+		}
+		args = append(args, name)
+	}
+	fmt.Fprintf(buf, "\t\tpage, err := c.%s(%s)\n", data.methodName, strings.Join(args, ", "))
+	writeYieldError(buf)
+}
+
+// writeYieldError writes the check that yields err and ends iteration.
+func writeYieldError(buf *bytes.Buffer) {
+	buf.WriteString("\t\tif err != nil {\n")
+	buf.WriteString("\t\t\tyield(zero, err)\n")
+	buf.WriteString("\t\t\treturn\n")
+	buf.WriteString("\t\t}\n")
+}
+
+// writeYieldItems writes the loop yielding the items of a page.
+func writeYieldItems(buf *bytes.Buffer, itemsExpr string) {
+	fmt.Fprintf(buf, "\t\tfor _, item := range %s {\n", itemsExpr)
+	buf.WriteString("\t\t\tif !yield(item, nil) {\n\t\t\t\treturn\n\t\t\t}\n")
+	buf.WriteString("\t\t}\n")
+}
+
+// writeValueOrReturn writes code declaring name as the value of expr, of Go
+// type goType, and ending iteration when the value is absent.
+func writeValueOrReturn(buf *bytes.Buffer, name, expr, goType string) {
+	if wrapper, _ := optionalWrapper(goType); wrapper != "" {
+		fmt.Fprintf(buf, "\t\t%s, ok := %s.Get()\n\t\tif !ok {\n\t\t\treturn\n\t\t}\n", name, expr)
+		return
+	}
+	if strings.HasPrefix(goType, "*") {
+		fmt.Fprintf(buf, "\t\tif %s == nil {\n\t\t\treturn\n\t\t}\n\t\t%s := *%s\n", expr, name, expr)
+		return
+	}
+	fmt.Fprintf(buf, "\t\t%s := %s\n", name, expr)
+}
+
+// isIntegerGoType reports whether goType is a Go integer type.
+func isIntegerGoType(goType string) bool {
+	switch goType {
+	case "int", "int8", "int16", "int32", goTypeInt64, "uint", "uint8", "uint16", "uint32", "uint64":
+		return true
+	}
+	return false
+}
+
+// clientPageHelpers declares the page fetching used by Link-header iterators.
+const clientPageHelpers = `
+// getPage fetches the JSON page at rawURL into v and returns the URL of the
+// next page from the Link header, or "" if there is none.
+func (c *Client) getPage(ctx context.Context, rawURL string, v any) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+	for _, editor := range c.RequestEditors {
+		if err := editor(ctx, req); err != nil {
+			return "", fmt.Errorf("request editor: %w", err)
+		}
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return "", fmt.Errorf("execute request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		return "", &APIError{StatusCode: resp.StatusCode, Body: body}
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return "", fmt.Errorf("decode response: %w", err)
+	}
+	return nextLink(resp)
+}
+
+// nextLink returns the rel="next" URL of the response's Link header,
+// resolved against the request URL, or "" if there is none.
+func nextLink(resp *http.Response) (string, error) {
+	for _, header := range resp.Header.Values("Link") {
+		for _, link := range strings.Split(header, ",") {
+			target, params, ok := strings.Cut(strings.TrimSpace(link), ";")
+			if !ok {
+				continue
+			}
+			for _, param := range strings.Split(params, ";") {
+				name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+				if !strings.EqualFold(name, "rel") || !slices.Contains(strings.Fields(strings.Trim(value, "\"")), "next") {
+					continue
+				}
+				next, err := resp.Request.URL.Parse(strings.Trim(target, "<>"))
+				if err != nil {
+					return "", fmt.Errorf("parse Link header: %w", err)
+				}
+				return next.String(), nil
+			}
+		}
+	}
+	return "", nil
+}
+`
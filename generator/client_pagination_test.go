@@ -0,0 +1,328 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const paginationSpec = `openapi: 3.0.3
+info:
+  title: Pagination
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      x-pagination:
+        style: cursor
+        items: data
+      parameters:
+        - {name: cursor, in: query, schema: {type: string}}
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/PetPage'
+  /owners/{ownerId}/pets:
+    get:
+      operationId: listOwnerPets
+      x-pagination:
+        style: offset
+        limitParam: limit
+      parameters:
+        - {name: ownerId, in: path, required: true, schema: {type: string}}
+        - {name: offset, in: query, schema: {type: integer}}
+        - {name: limit, in: query, schema: {type: integer}}
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+  /tags:
+    get:
+      operationId: listTags
+      x-pagination:
+        style: link
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  type: string
+  /flaky:
+    get:
+      operationId: getFlaky
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+    post:
+      operationId: postFlaky
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+    PetPage:
+      type: object
+      properties:
+        data:
+          type: array
+          items:
+            $ref: '#/components/schemas/Pet'
+        next_cursor:
+          type: string
+`
+
+func TestClientIterators_Generated(t *testing.T) {
+	result := generateFromSpec(t, paginationSpec, WithClient(true))
+
+	file := result.GetFile("client.go")
+	require.NotNil(t, file)
+	content := string(file.Content)
+
+	assert.Contains(t, content, "func (c *Client) ListPetsIter(ctx context.Context, params *ListPetsParams) iter.Seq2[Pet, error]")
+	assert.Contains(t, content, "func (c *Client) ListOwnerPetsIter(ctx context.Context, ownerId string, params *ListOwnerPetsParams) iter.Seq2[Pet, error]")
+	assert.Contains(t, content, "func (c *Client) ListTagsIter(ctx context.Context) iter.Seq2[string, error]")
+	assert.NotContains(t, content, "GetFlakyIter")
+	assert.Contains(t, content, "p.Cursor = &next")
+	assert.Contains(t, content, "offset += int64(len(page))")
+
+	assert.Contains(t, content, "RetryPolicy *RetryPolicy")
+	assert.Contains(t, content, "func WithRetryPolicy(policy RetryPolicy) ClientOption")
+	assert.Contains(t, content, "resp, err := c.do(req)")
+	// Optional query parameters are single pointers
+	assert.Contains(t, content, "Cursor *string")
+	assert.NotContains(t, content, "**")
+}
+
+func TestClientIterators_OAS2(t *testing.T) {
+	result := generateFromSpec(t, `swagger: "2.0"
+info:
+  title: Pagination
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      x-pagination:
+        style: offset
+        items: items
+      parameters:
+        - {name: offset, in: query, type: integer, format: int32}
+      responses:
+        '200':
+          description: OK
+          schema:
+            $ref: '#/definitions/PetPage'
+definitions:
+  PetPage:
+    type: object
+    properties:
+      items:
+        type: array
+        items:
+          type: string
+`, WithClient(true))
+	content := string(result.GetFile("client.go").Content)
+	assert.Contains(t, content, "func (c *Client) ListPetsIter(ctx context.Context, params *ListPetsParams) iter.Seq2[string, error]")
+	assert.Contains(t, content, "offset += int32(len(page.Items))")
+}
+
+func TestClientIterators_SeveralJSONMediaTypes(t *testing.T) {
+	spec := strings.Replace(paginationSpec, `            application/json:
+              schema:
+                $ref: '#/components/schemas/PetPage'`, `            application/json:
+              schema:
+                $ref: '#/components/schemas/PetPage'
+            application/hal+json:
+              schema:
+                $ref: '#/components/schemas/NamePage'`, 1)
+	spec += `    NamePage:
+      type: object
+      properties:
+        data:
+          type: array
+          items:
+            type: string
+        next_cursor:
+          type: string
+`
+	// Content maps are unordered, so generate more than once: the response
+	// type and the iterator must agree on the first JSON media type every time
+	for range 5 {
+		result := generateFromSpec(t, spec, WithClient(true))
+		content := string(result.GetFile("client.go").Content)
+		assert.Contains(t, content, "func (c *Client) ListPets(ctx context.Context, params *ListPetsParams) (*NamePage, error)")
+		assert.Contains(t, content, "func (c *Client) ListPetsIter(ctx context.Context, params *ListPetsParams) iter.Seq2[string, error]")
+	}
+}
+
+func TestClientIterators_Invalid(t *testing.T) {
+	tests := []struct {
+		name       string
+		pagination string
+		message    string
+	}{
+		{"unknown style", "{style: pages}", "style must be cursor, offset, or link"},
+		{"not an object", "cursor", "must be an object"},
+		{"missing param", "{style: cursor, items: data, cursorParam: after}", `no query parameter "after"`},
+		{"missing items", "{style: cursor}", "needs items"},
+		{"items not array", "{style: cursor, items: next_cursor}", "is not an array"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := strings.Replace(paginationSpec, `x-pagination:
+        style: cursor
+        items: data`, "x-pagination: "+tt.pagination, 1)
+			result := generateFromSpec(t, spec, WithClient(true))
+			assert.NotContains(t, string(result.GetFile("client.go").Content), "ListPetsIter")
+
+			var messages []string
+			for _, issue := range result.Issues {
+				messages = append(messages, issue.Message)
+			}
+			assert.Contains(t, strings.Join(messages, "\n"), tt.message)
+		})
+	}
+}
+
+// TestGeneratedClientIteratorsRun pages through a test server with each
+// iterator style and retries a flaky endpoint.
+func TestGeneratedClientIteratorsRun(t *testing.T) {
+	result := generateFromSpec(t, paginationSpec, WithClient(true))
+
+	mainSrc := `package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"time"
+
+	"example.com/gen/api"
+)
+
+func main() {
+	names := []string{"a", "b", "c", "d", "e"}
+	calls := map[string]int{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /pets", func(w http.ResponseWriter, r *http.Request) {
+		start, _ := strconv.Atoi(r.URL.Query().Get("cursor"))
+		page := api.PetPage{}
+		for _, name := range names[start:min(start+2, len(names))] {
+			page.Data = append(page.Data, api.Pet{Name: &name})
+		}
+		if start+2 < len(names) {
+			next := strconv.Itoa(start + 2)
+			page.NextCursor = &next
+		}
+		json.NewEncoder(w).Encode(page)
+	})
+	mux.HandleFunc("GET /owners/{id}/pets", func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		var page []api.Pet
+		for _, name := range names[min(offset, len(names)):min(offset+limit, len(names))] {
+			name := r.PathValue("id") + name
+			page = append(page, api.Pet{Name: &name})
+		}
+		calls["offset"]++
+		json.NewEncoder(w).Encode(page)
+	})
+	mux.HandleFunc("GET /tags", func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page < 2 {
+			w.Header().Set("Link", fmt.Sprintf("</tags?page=%d>; rel=\"next\"", page+1))
+		}
+		json.NewEncoder(w).Encode([]string{"t" + strconv.Itoa(page)})
+	})
+	mux.HandleFunc("/flaky", func(w http.ResponseWriter, r *http.Request) {
+		calls[r.Method]++
+		if calls[r.Method] < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(api.Pet{})
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client, err := api.NewClient(srv.URL, api.WithRetryPolicy(api.RetryPolicy{InitialBackoff: time.Millisecond}))
+	if err != nil {
+		panic(err)
+	}
+	ctx := context.Background()
+
+	var got []string
+	for pet, err := range client.ListPetsIter(ctx, nil) {
+		if err != nil {
+			panic(err)
+		}
+		got = append(got, *pet.Name)
+	}
+	fmt.Println("cursor:", got)
+
+	got = nil
+	limit := int64(2)
+	for pet, err := range client.ListOwnerPetsIter(ctx, "o", &api.ListOwnerPetsParams{Limit: &limit}) {
+		if err != nil {
+			panic(err)
+		}
+		got = append(got, *pet.Name)
+	}
+	fmt.Println("offset:", got, calls["offset"])
+
+	got = nil
+	for tag, err := range client.ListTagsIter(ctx) {
+		if err != nil {
+			panic(err)
+		}
+		got = append(got, tag)
+		if len(got) == 2 {
+			break
+		}
+	}
+	fmt.Println("link:", got)
+
+	_, err = client.GetFlaky(ctx)
+	fmt.Println("get:", err, calls["GET"])
+	_, err = client.PostFlaky(ctx)
+	fmt.Println("post:", err, calls["POST"])
+}
+`
+	out := runGeneratedModule(t, result, mainSrc)
+	assert.Contains(t, out, "cursor: [a b c d e]")
+	assert.Contains(t, out, "offset: [oa ob oc od oe] 3")
+	assert.Contains(t, out, "link: [t0 t1]")
+	assert.Contains(t, out, "get: <nil> 3")
+	assert.Contains(t, out, "post: API error: status 503:  1")
+}
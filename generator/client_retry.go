@@ -0,0 +1,137 @@
+// This file holds the retry support of generated clients: a RetryPolicy set
+// with WithRetryPolicy, applied to idempotent requests by Client.do.
+
+package generator
+
+// clientRetryHelpers declares RetryPolicy and the Client.do method every
+// generated client method sends its request through.
+const clientRetryHelpers = `
+// RetryPolicy configures how the client retries failed requests. Only
+// idempotent methods (GET, HEAD, OPTIONS, TRACE, PUT, and DELETE) are
+// retried, so a request is never applied twice by accident.
+type RetryPolicy struct {
+	// MaxAttempts is the most times a request is sent, counting the first
+	// (default: 3).
+	MaxAttempts int
+	// InitialBackoff is the base wait before the first retry; each later
+	// retry doubles it (default: 100ms).
+	InitialBackoff time.Duration
+	// MaxBackoff caps each wait, including one a Retry-After header asks
+	// for (default: 30s).
+	MaxBackoff time.Duration
+	// ShouldRetry reports whether to retry after a response or transport
+	// error. The default retries transport errors and 429, 502, 503, and
+	// 504 responses.
+	ShouldRetry func(resp *http.Response, err error) bool
+}
+
+// WithRetryPolicy makes the client retry idempotent requests as policy
+// describes, with exponential backoff and jitter, honoring Retry-After.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) error {
+		if policy.MaxAttempts <= 0 {
+			policy.MaxAttempts = 3
+		}
+		if policy.InitialBackoff <= 0 {
+			policy.InitialBackoff = 100 * time.Millisecond
+		}
+		if policy.MaxBackoff <= 0 {
+			policy.MaxBackoff = 30 * time.Second
+		}
+		if policy.ShouldRetry == nil {
+			policy.ShouldRetry = defaultShouldRetry
+		}
+		c.RetryPolicy = &policy
+		return nil
+	}
+}
+
+// defaultShouldRetry retries transport errors and responses that signal a
+// temporary condition.
+func defaultShouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// do sends req, retrying it under the client's RetryPolicy.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	policy := c.RetryPolicy
+	if policy == nil || !isIdempotentMethod(req.Method) || (req.Body != nil && req.GetBody == nil) {
+		return c.HTTPClient.Do(req)
+	}
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		resp, err := c.HTTPClient.Do(req)
+		if attempt >= policy.MaxAttempts || ctx.Err() != nil || !policy.ShouldRetry(resp, err) {
+			return resp, err
+		}
+		wait := policy.backoff(attempt, resp)
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff returns the wait before retry number attempt: the response's
+// Retry-After if it has one, or else an exponential backoff with jitter.
+func (p *RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			return min(wait, p.MaxBackoff)
+		}
+	}
+	wait := p.InitialBackoff
+	for i := 1; i < attempt && wait < p.MaxBackoff; i++ {
+		wait *= 2
+	}
+	wait = min(wait, p.MaxBackoff)
+	// Equal jitter: half the wait, plus a random part of the other half
+	half := wait / 2
+	return half + rand.N(half+1)
+}
+
+// retryAfter parses a Retry-After header, given in seconds or as an HTTP date.
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
+
+// isIdempotentMethod reports whether requests with the HTTP method can be
+// repeated safely.
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+`
@@ -0,0 +1,220 @@
+// This file implements generator configuration files, which declare the
+// outputs to generate from one specification in place of command-line flags.
+
+package generator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// ConfigFile is a generator configuration file. It names the specification to
+// generate from, the import mapping shared by every output, and one or more
+// outputs, each with its own directory, package, and feature toggles.
+//
+// Example:
+//
+//	input: openapi.yaml
+//	import-mapping:
+//	  common.yaml: github.com/acme/apis/common
+//	outputs:
+//	  - output: ./client
+//	    package: petclient
+//	    client: true
+//	  - output: ./server
+//	    package: petserver
+//	    server: true
+//	    server-all: true
+type ConfigFile struct {
+	// Input is the specification path or URL. A relative path is relative
+	// to the configuration file.
+	Input string `yaml:"input"`
+
+	// ImportMapping maps external documents referenced by $ref to Go import
+	// paths. See Generator.ImportMapping.
+	ImportMapping map[string]string `yaml:"import-mapping"`
+
+	// Outputs lists the packages to generate.
+	Outputs []OutputConfig `yaml:"outputs"`
+}
+
+// OutputConfig configures one generated package. Unset toggles keep the
+// generator defaults.
+type OutputConfig struct {
+	// Output is the directory to write files to. A relative path is
+	// relative to the configuration file.
+	Output string `yaml:"output"`
+
+	// Package is the Go package name (default: "api").
+	Package string `yaml:"package"`
+
+	// Lang is the language to generate: "go" (default) or "typescript".
+	Lang string `yaml:"lang"`
+
+	// Generation modes
+	Client *bool `yaml:"client"`
+	Server *bool `yaml:"server"`
+	Types  *bool `yaml:"types"`
+
+	// Type options
+	Pointers        *bool `yaml:"pointers"`
+	OptionalTypes   *bool `yaml:"optional-types"`
+	Validation      *bool `yaml:"validation"`
+	ValidateMethods *bool `yaml:"validate-methods"`
+	Strict          *bool `yaml:"strict"`
+
+	// Security options
+	Security        *bool `yaml:"security"`
+	OAuth2Flows     *bool `yaml:"oauth2-flows"`
+	CredentialMgmt  *bool `yaml:"credential-mgmt"`
+	SecurityEnforce *bool `yaml:"security-enforce"`
+	OIDCDiscovery   *bool `yaml:"oidc-discovery"`
+	Readme          *bool `yaml:"readme"`
+	Webhooks        *bool `yaml:"webhooks"`
+
+	// File splitting options
+	MaxLinesPerFile *int  `yaml:"max-lines-per-file"`
+	MaxTypesPerFile *int  `yaml:"max-types-per-file"`
+	MaxOpsPerFile   *int  `yaml:"max-ops-per-file"`
+	SplitByTag      *bool `yaml:"split-by-tag"`
+	SplitByPath     *bool `yaml:"split-by-path"`
+
+	// Server generation options
+	ServerRouter     string `yaml:"server-router"`
+	ServerResponses  *bool  `yaml:"server-responses"`
+	ServerBinder     *bool  `yaml:"server-binder"`
+	ServerMiddleware *bool  `yaml:"server-middleware"`
+	ServerStubs      *bool  `yaml:"server-stubs"`
+	ServerEmbedSpec  *bool  `yaml:"server-embed-spec"`
+	ServerStrict     *bool  `yaml:"server-strict"`
+	ServerAll        bool   `yaml:"server-all"`
+}
+
+// LoadConfigFile reads and validates a generator configuration file. Unknown
+// keys are errors, so a misspelled toggle is not silently ignored. Relative
+// input and output paths are resolved against the file's directory.
+func LoadConfigFile(path string) (*ConfigFile, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: config path is user-provided by design
+	if err != nil {
+		return nil, fmt.Errorf("generator: reading config file: %w", err)
+	}
+	cfg, err := ParseConfigFile(data)
+	if err != nil {
+		return nil, fmt.Errorf("generator: config file %s: %w", path, err)
+	}
+
+	dir := filepath.Dir(path)
+	if cfg.Input != "" && !isURL(cfg.Input) && !filepath.IsAbs(cfg.Input) {
+		cfg.Input = filepath.Join(dir, cfg.Input)
+	}
+	for i := range cfg.Outputs {
+		if !filepath.IsAbs(cfg.Outputs[i].Output) {
+			cfg.Outputs[i].Output = filepath.Join(dir, cfg.Outputs[i].Output)
+		}
+	}
+	return cfg, nil
+}
+
+// ParseConfigFile parses and validates generator configuration file content.
+// Paths are returned as written.
+func ParseConfigFile(data []byte) (*ConfigFile, error) {
+	var cfg ConfigFile
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("parsing config: %w", err)
+	}
+
+	if len(cfg.Outputs) == 0 {
+		return nil, fmt.Errorf("config declares no outputs")
+	}
+	for i, out := range cfg.Outputs {
+		if out.Output == "" {
+			return nil, fmt.Errorf("outputs[%d]: output directory is required", i)
+		}
+	}
+	return &cfg, nil
+}
+
+// Options returns the generator options for out, including the file's
+// import mapping. The caller adds the input source.
+func (c *ConfigFile) Options(out OutputConfig) []Option {
+	var opts []Option
+	if len(c.ImportMapping) > 0 {
+		opts = append(opts, WithImportMapping(c.ImportMapping))
+	}
+	if out.Package != "" {
+		opts = append(opts, WithPackageName(out.Package))
+	}
+	if out.Lang != "" {
+		opts = append(opts, WithLanguage(out.Lang))
+	}
+
+	boolOpts := []struct {
+		value  *bool
+		option func(bool) Option
+	}{
+		{out.Client, WithClient},
+		{out.Server, WithServer},
+		{out.Types, WithTypes},
+		{out.Pointers, WithPointers},
+		{out.OptionalTypes, WithOptionalTypes},
+		{out.Validation, WithValidation},
+		{out.ValidateMethods, WithValidateMethods},
+		{out.Strict, WithStrictMode},
+		{out.Security, WithSecurity},
+		{out.OAuth2Flows, WithOAuth2Flows},
+		{out.CredentialMgmt, WithCredentialMgmt},
+		{out.SecurityEnforce, WithSecurityEnforce},
+		{out.OIDCDiscovery, WithOIDCDiscovery},
+		{out.Readme, WithReadme},
+		{out.Webhooks, WithWebhooks},
+		{out.SplitByTag, WithSplitByTag},
+		{out.SplitByPath, WithSplitByPathPrefix},
+		{out.ServerResponses, WithServerResponses},
+		{out.ServerBinder, WithServerBinder},
+		{out.ServerMiddleware, WithServerMiddleware},
+		{out.ServerStubs, WithServerStubs},
+		{out.ServerEmbedSpec, WithServerEmbedSpec},
+		{out.ServerStrict, WithServerStrict},
+	}
+	for _, b := range boolOpts {
+		if b.value != nil {
+			opts = append(opts, b.option(*b.value))
+		}
+	}
+
+	intOpts := []struct {
+		value  *int
+		option func(int) Option
+	}{
+		{out.MaxLinesPerFile, WithMaxLinesPerFile},
+		{out.MaxTypesPerFile, WithMaxTypesPerFile},
+		{out.MaxOpsPerFile, WithMaxOperationsPerFile},
+	}
+	for _, n := range intOpts {
+		if n.value != nil {
+			opts = append(opts, n.option(*n.value))
+		}
+	}
+
+	if out.ServerRouter != "" {
+		opts = append(opts, WithServerRouter(out.ServerRouter))
+	}
+	if out.ServerAll {
+		opts = append(opts, WithServerAll())
+	}
+	return opts
+}
+
+// isURL reports whether s is an http or https URL.
+func isURL(s string) bool {
+	return strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://")
+}
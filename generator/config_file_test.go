@@ -0,0 +1,108 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/erraggy/oastools/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConfigFile(t *testing.T) {
+	cfg, err := ParseConfigFile([]byte(`input: openapi.yaml
+import-mapping:
+  common.yaml: github.com/acme/apis/common
+outputs:
+  - output: ./client
+    package: petclient
+    client: true
+    security: false
+    max-lines-per-file: 0
+  - output: ./server
+    server: true
+    server-all: true
+  - output: ./web
+    lang: typescript
+`))
+	require.NoError(t, err)
+	assert.Equal(t, "openapi.yaml", cfg.Input)
+	assert.Equal(t, map[string]string{"common.yaml": "github.com/acme/apis/common"}, cfg.ImportMapping)
+	require.Len(t, cfg.Outputs, 3)
+
+	client := cfg.Outputs[0]
+	assert.Equal(t, "./client", client.Output)
+	assert.Equal(t, "petclient", client.Package)
+	require.NotNil(t, client.Client)
+	assert.True(t, *client.Client)
+	require.NotNil(t, client.Security)
+	assert.False(t, *client.Security)
+	require.NotNil(t, client.MaxLinesPerFile)
+	assert.Equal(t, 0, *client.MaxLinesPerFile)
+	assert.Nil(t, client.Server)
+	assert.True(t, cfg.Outputs[1].ServerAll)
+	assert.Equal(t, "typescript", cfg.Outputs[2].Lang)
+}
+
+func TestParseConfigFile_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"no outputs", "input: openapi.yaml\n", "no outputs"},
+		{"missing output directory", "outputs:\n  - package: api\n", "outputs[0]: output directory is required"},
+		{"unknown key", "outputs:\n  - output: ./api\n    clinet: true\n", "clinet"},
+		{"invalid yaml", "outputs: [", "parsing config"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseConfigFile([]byte(tt.content))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestLoadConfigFile_ResolvesPaths(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "oastools-gen.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`input: specs/openapi.yaml
+outputs:
+  - output: gen/api
+  - output: /abs/api
+`), 0600))
+
+	cfg, err := LoadConfigFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "specs", "openapi.yaml"), cfg.Input)
+	assert.Equal(t, filepath.Join(dir, "gen", "api"), cfg.Outputs[0].Output)
+	assert.Equal(t, "/abs/api", cfg.Outputs[1].Output)
+
+	_, err = LoadConfigFile(filepath.Join(dir, "missing.yaml"))
+	require.Error(t, err)
+}
+
+func TestConfigFile_Options(t *testing.T) {
+	cfg, err := ParseConfigFile([]byte(`import-mapping:
+  common.yaml: example.com/apis/common
+outputs:
+  - output: ./client
+    package: petclient
+    client: true
+    readme: false
+    webhooks: false
+`))
+	require.NoError(t, err)
+
+	parsed, err := parser.New().ParseBytes([]byte(mappedSpec))
+	require.NoError(t, err)
+	result, err := GenerateWithOptions(append([]Option{WithParsed(*parsed)}, cfg.Options(cfg.Outputs[0])...)...)
+	require.NoError(t, err)
+
+	assert.Equal(t, "petclient", result.PackageName)
+	assert.NotNil(t, result.GetFile("client.go"))
+	assert.Nil(t, result.GetFile("README.md"))
+	assert.Contains(t, string(result.GetFile("types.go").Content), "common.Page")
+}
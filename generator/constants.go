@@ -12,6 +12,8 @@ const (
 	fileNameTypes  = "types.go"
 	fileNameClient = "client.go"
 	fileNameServer = "server.go"
+
+	fileNameServerResponses = "server_responses.go"
 )
 
 // OpenAPI format and value constants.
@@ -168,6 +168,91 @@
 //   - Security configuration examples
 //   - Regeneration command
 //
+// # Webhooks and Callbacks
+//
+// When an OAS 3.x document declares webhooks or operation callbacks, client
+// and server generation also produce the code to receive and send them.
+// Client generation writes webhook_receiver.go, with a WebhookReceiver
+// interface and NewWebhookRouter to mount it. Server generation writes
+// event_senders.go, with a WebhookSender that posts each webhook to a
+// subscriber URL and a CallbackSender that evaluates callback URL runtime
+// expressions against the original request:
+//
+//	sender := &api.CallbackSender{}
+//	src := &api.CallbackSource{Request: r, RequestBody: sub}
+//	err := sender.CreateSubscriptionOnEvent(ctx, src, event)
+//
+// Disable this with WithWebhooks(false).
+//
+// # Validate Methods
+//
+// WithValidateMethods(true) generates validate.go, giving each generated struct,
+// allOf, union, enum, and array type a Validate() error method. The methods
+// check required fields, string lengths and patterns, enums, numeric bounds and
+// multipleOf, and array and map sizes, recursing into nested types, using only
+// the standard library. The error is a ConstraintErrors listing every
+// violation, each with the JSON pointer path of the offending value:
+//
+//	if err := pet.Validate(); err != nil {
+//		fmt.Println(err) // /name: length must be at least 2; /tags: is required
+//	}
+//
+// # Optional and Nullable Fields
+//
+// A pointer cannot tell an absent property from an explicit null, which JSON
+// Merge Patch bodies depend on. WithOptionalTypes(true) generates optional.go
+// with the generic Optional[T] and Nullable[T] types and uses them for struct
+// fields: Nullable[T] for nullable properties (nullable: true, or "null" in
+// the type array) and Optional[T] for other optional properties. Both are
+// tagged omitzero, so absent values are left out while an explicit null is
+// still written:
+//
+//	patch := api.PetPatch{Name: api.OptionalOf("Rex"), Owner: api.NullOf[string]()}
+//	// {"name":"Rex","owner":null}
+//
+// # Pagination and Retries
+//
+// An operation with an x-pagination extension (style cursor, offset, or link)
+// also gets an iterator method on the client, returning iter.Seq2[Item, error]
+// and fetching pages as the loop asks for items:
+//
+//	for pet, err := range client.ListPetsIter(ctx, nil) {
+//		...
+//	}
+//
+// Generated clients send requests through an optional RetryPolicy. The
+// WithRetryPolicy ClientOption retries idempotent requests on transport
+// errors and 429, 502, 503, and 504 responses, with exponential backoff and
+// jitter, honoring Retry-After.
+//
+// # Import Mapping and Config Files
+//
+// WithImportMapping maps external documents referenced by $ref to Go packages
+// that already hold their types, so schemas shared between APIs are imported
+// rather than generated into every package:
+//
+//	result, err := generator.GenerateWithOptions(
+//		generator.WithFilePath("pets.yaml"),
+//		generator.WithImportMapping(map[string]string{
+//			"common.yaml": "github.com/acme/apis/common",
+//		}),
+//	)
+//
+// A $ref to common.yaml#/components/schemas/Error then generates common.Error.
+// LoadConfigFile reads a YAML file declaring the input, import mapping, and
+// outputs, as used by oastools generate --config; ConfigFile.Options turns
+// each output into generator options.
+//
+// # TypeScript Output
+//
+// WithLanguage("typescript") generates TypeScript instead of Go, reusing the
+// same type naming, file splitting, and discriminator handling: types.ts
+// declares an interface per object schema and unions, intersections, and
+// literal types for the rest, and with WithClient(true) client.ts holds a
+// fetch-based Client with an async method per operation. Discriminated
+// oneOf variants are narrowed to their discriminator value, so a switch on
+// it narrows the union. Server generation is not supported for TypeScript.
+//
 // # Server Extensions
 //
 // When generating server code, additional extensions provide a complete server
@@ -186,6 +271,7 @@
 //   - WithServerMiddleware(bool): Validation middleware for request/response validation
 //   - WithServerRouter(string): HTTP router generation ("stdlib", "chi")
 //   - WithServerStubs(bool): Configurable stub implementations for testing
+//   - WithServerStrict(bool): Typed per-status response unions returned by server methods
 //   - WithServerAll(): Enable all server extensions at once
 //
 // Generated server extension files:
@@ -220,6 +306,13 @@
 // Optional fields use pointers, and nullable fields in OAS 3.1+ are handled
 // with pointer types or generic Option[T] types (configurable).
 //
+// Schemas can override the derived Go code with x-go-* extensions:
+//   - x-go-type: the Go type to use, e.g. decimal.Decimal
+//   - x-go-type-import: the import path for x-go-type, or {path, name}
+//   - x-go-name: the type name of a component schema or field name of a property
+//   - x-omitempty: whether the field's JSON tag has omitempty
+//   - x-go-type-skip-optional-pointer: keep an optional field a plain value
+//
 // # Generated Files
 //
 // The generator produces the following files:
@@ -231,8 +324,13 @@
 //   - credentials.go: Credential provider interfaces (when GenerateCredentialMgmt is true)
 //   - security_enforce.go: Security validation (when GenerateSecurityEnforce is true)
 //   - oidc_discovery.go: OIDC discovery client (when GenerateOIDCDiscovery is true)
+//   - validate.go: Validate methods (when GenerateValidateMethods is true)
+//   - optional.go: Optional and Nullable field types (when UseOptionalTypes is true)
 //   - README.md: Documentation (when GenerateReadme is true)
-//   - server_responses.go: Response types (when ServerResponses or ServerAll is set)
+//   - types.ts and client.ts: TypeScript types and fetch client (when Language is "typescript")
+//   - webhook_receiver.go: Webhook receiver (client, when the spec declares webhooks)
+//   - event_senders.go: Webhook and callback senders (server, when the spec declares webhooks or callbacks)
+//   - server_responses.go: Response types (when ServerResponses, ServerStrict, or ServerAll is set)
 //   - server_binder.go: Request binding (when ServerBinder or ServerAll is set)
 //   - server_middleware.go: Validation middleware (when ServerMiddleware or ServerAll is set)
 //   - server_router.go: HTTP router (when ServerRouter or ServerAll is set)
@@ -240,6 +338,27 @@
 //
 // See the exported GenerateResult and GenerateIssue types for complete details.
 //
+// # Checking for Drift
+//
+// Generation renders in memory; only [GenerateResult.WriteFiles] touches disk.
+// [GenerateResult.CheckFiles] compares the rendered files against an output
+// directory without writing, for catching committed code that was not
+// regenerated after a spec edit. It reports stale files with a unified diff,
+// missing files, and orphaned files that carry the generated-code header, or
+// a README.md that carries the generated README's note, but would no longer be
+// generated. The README's Generated timestamp row is not compared:
+//
+//	report, err := result.CheckFiles("./generated")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	if report.HasDrift() {
+//		for _, file := range report.Files {
+//			fmt.Print(file.Diff)
+//		}
+//		os.Exit(1)
+//	}
+//
 // # Related Packages
 //
 // The generator integrates with other oastools packages:
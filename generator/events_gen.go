@@ -0,0 +1,719 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/erraggy/oastools/internal/maputil"
+	"github.com/erraggy/oastools/parser"
+)
+
+// Generated file names for webhooks and callbacks.
+const (
+	fileNameWebhookReceiver = "webhook_receiver.go"
+	fileNameEventSenders    = "event_senders.go"
+)
+
+// webhookReceiverRuntimeNames names the declarations of the webhook receiver
+// runtime.
+var webhookReceiverRuntimeNames = []string{"WebhookReceiver", "UnimplementedWebhookReceiver", "ErrWebhookNotImplemented", "NewWebhookRouter"}
+
+// Names declared by the event sender runtime: for every sender file, for
+// webhook senders, and for callback senders.
+var (
+	eventSenderRuntimeNames    = []string{"DeliveryError"}
+	webhookSenderRuntimeNames  = []string{"WebhookSender"}
+	callbackSenderRuntimeNames = []string{"CallbackSender", "CallbackSource", "EvaluateCallbackURL"}
+)
+
+// callbackRefPrefix is the prefix of a reference to a reusable callback.
+const callbackRefPrefix = "#/components/callbacks/"
+
+// eventOperation is a webhook or callback operation flattened for generation.
+type eventOperation struct {
+	// name is the Go method name.
+	name string
+	// key is the webhook name or the callback URL expression.
+	key string
+	// method is the lowercase HTTP method.
+	method string
+	op     *parser.Operation
+	// location identifies the operation in generation issues.
+	location string
+}
+
+// generateWebhooks generates webhook and callback code. Clients receive the
+// webhooks an API sends, so client generation produces a receiver interface
+// and router; servers send webhooks and callbacks, so server generation
+// produces typed senders.
+func (cg *oas3CodeGenerator) generateWebhooks() error {
+	webhooks := cg.collectWebhookOperations()
+	callbacks := cg.collectCallbackOperations()
+
+	if cg.g.GenerateClient && len(webhooks) > 0 {
+		if name, ok := cg.runtimeNameConflict(webhookReceiverRuntimeNames); ok {
+			cg.addIssue(fileNameWebhookReceiver, fmt.Sprintf("schema type %s conflicts with the webhook receiver runtime - skipping webhook receiver", name), SeverityWarning)
+		} else {
+			cg.generateWebhookReceiver(webhooks)
+		}
+	}
+	if cg.g.GenerateServer && (len(webhooks) > 0 || len(callbacks) > 0) {
+		names := slices.Clone(eventSenderRuntimeNames)
+		if len(webhooks) > 0 {
+			names = append(names, webhookSenderRuntimeNames...)
+		}
+		if len(callbacks) > 0 {
+			names = append(names, callbackSenderRuntimeNames...)
+		}
+		if name, ok := cg.runtimeNameConflict(names); ok {
+			cg.addIssue(fileNameEventSenders, fmt.Sprintf("schema type %s conflicts with the event sender runtime - skipping webhook and callback senders", name), SeverityWarning)
+		} else {
+			cg.generateEventSenders(webhooks, callbacks)
+		}
+	}
+	return nil
+}
+
+// runtimeNameConflict returns the first of names that a schema type already
+// uses.
+func (cg *oas3CodeGenerator) runtimeNameConflict(names []string) (string, bool) {
+	for _, name := range names {
+		if cg.generatedTypes[name] {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// collectWebhookOperations returns the operations of every webhook, sorted by
+// webhook name and method.
+func (cg *oas3CodeGenerator) collectWebhookOperations() []eventOperation {
+	var ops []eventOperation
+	seen := make(map[string]bool)
+	for _, name := range maputil.SortedKeys(cg.doc.Webhooks) {
+		pathItem := cg.doc.Webhooks[name]
+		if pathItem == nil {
+			continue
+		}
+		operations := cg.pathItemOperations(pathItem)
+		for _, method := range httpMethods {
+			op := operations[method]
+			if op == nil {
+				continue
+			}
+			base := toTypeName(name)
+			if len(operations) > 1 {
+				base += toTypeName(method)
+			}
+			location := fmt.Sprintf("webhooks.%s.%s", name, method)
+			ops = append(ops, eventOperation{
+				name:     cg.uniqueEventName(seen, eventMethodName(op, base), location),
+				key:      name,
+				method:   method,
+				op:       op,
+				location: location,
+			})
+		}
+	}
+	return ops
+}
+
+// collectCallbackOperations returns the operations of every callback declared
+// by a path operation, in path, method, callback name, and expression order.
+// Callbacks written as references are resolved against components.callbacks.
+func (cg *oas3CodeGenerator) collectCallbackOperations() []eventOperation {
+	var ops []eventOperation
+	seen := make(map[string]bool)
+	for _, path := range maputil.SortedKeys(cg.doc.Paths) {
+		pathItem := cg.doc.Paths[path]
+		if pathItem == nil {
+			continue
+		}
+		operations := parser.GetOperations(pathItem, cg.doc.OASVersion)
+		for _, method := range httpMethods {
+			parent := operations[method]
+			if parent == nil {
+				continue
+			}
+			parentName := operationToMethodName(parent, path, method)
+			for _, name := range cg.operationCallbackNames(parent) {
+				callback, ok := cg.resolveCallback(parent, name)
+				if !ok {
+					cg.addIssue(fmt.Sprintf("paths.%s.%s.callbacks.%s", path, method, name),
+						"callback reference could not be resolved; no sender generated", SeverityWarning)
+					continue
+				}
+				ops = append(ops, cg.callbackOperations(seen, callback, parentName+toTypeName(name),
+					fmt.Sprintf("paths.%s.%s.callbacks.%s", path, method, name))...)
+			}
+		}
+	}
+	return ops
+}
+
+// callbackOperations flattens the operations of one callback.
+func (cg *oas3CodeGenerator) callbackOperations(seen map[string]bool, callback *parser.Callback, base, location string) []eventOperation {
+	count := 0
+	for _, pathItem := range *callback {
+		if pathItem != nil {
+			count += len(cg.pathItemOperations(pathItem))
+		}
+	}
+
+	var ops []eventOperation
+	for _, expr := range maputil.SortedKeys(*callback) {
+		pathItem := (*callback)[expr]
+		if pathItem == nil {
+			continue
+		}
+		operations := cg.pathItemOperations(pathItem)
+		for _, method := range httpMethods {
+			op := operations[method]
+			if op == nil {
+				continue
+			}
+			name := base
+			if count > 1 {
+				name += toTypeName(method)
+			}
+			opLocation := fmt.Sprintf("%s.%s.%s", location, expr, method)
+			ops = append(ops, eventOperation{
+				name:     cg.uniqueEventName(seen, eventMethodName(op, name), opLocation),
+				key:      expr,
+				method:   method,
+				op:       op,
+				location: opLocation,
+			})
+		}
+	}
+	return ops
+}
+
+// operationCallbackNames returns the sorted names of op's inline and
+// referenced callbacks.
+func (cg *oas3CodeGenerator) operationCallbackNames(op *parser.Operation) []string {
+	names := maputil.SortedKeys(op.Callbacks)
+	for _, name := range maputil.SortedKeys(op.CallbackRefs) {
+		if _, inline := op.Callbacks[name]; !inline {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// resolveCallback returns op's callback with the given name, following a
+// reference into components.callbacks.
+func (cg *oas3CodeGenerator) resolveCallback(op *parser.Operation, name string) (*parser.Callback, bool) {
+	if callback := op.Callbacks[name]; callback != nil {
+		return callback, true
+	}
+	ref := op.CallbackRefs[name]
+	if ref == nil || !strings.HasPrefix(ref.Ref, callbackRefPrefix) || cg.doc.Components == nil {
+		return nil, false
+	}
+	callback := cg.doc.Components.Callbacks[strings.TrimPrefix(ref.Ref, callbackRefPrefix)]
+	return callback, callback != nil
+}
+
+// pathItemOperations returns the non-nil operations of pathItem by method.
+func (cg *oas3CodeGenerator) pathItemOperations(pathItem *parser.PathItem) map[string]*parser.Operation {
+	operations := parser.GetOperations(pathItem, cg.doc.OASVersion)
+	for method, op := range operations {
+		if op == nil {
+			delete(operations, method)
+		}
+	}
+	return operations
+}
+
+// eventMethodName returns the Go method name for a webhook or callback
+// operation: its operationId when set, or the name derived from its location.
+func eventMethodName(op *parser.Operation, base string) string {
+	if op.OperationID != "" {
+		return toTypeName(op.OperationID)
+	}
+	return base
+}
+
+// uniqueEventName returns name, or name with a numeric suffix if another
+// operation of the same kind already uses it.
+func (cg *oas3CodeGenerator) uniqueEventName(seen map[string]bool, name, location string) string {
+	unique := name
+	for i := 2; seen[unique]; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+	if unique != name {
+		cg.addIssue(location, fmt.Sprintf("method name %s is already used; generated as %s", name, unique), SeverityWarning)
+	}
+	seen[unique] = true
+	return unique
+}
+
+// eventBody describes how a webhook or callback request body is carried.
+type eventBody struct {
+	// goType is the Go type of the body, or "" when the operation has none.
+	goType string
+	// contentType is the media type the body is sent as.
+	contentType string
+	// json is true when the body is JSON and goType is its decoded type;
+	// other bodies are carried as raw bytes.
+	json bool
+	// required is true when the request body is required.
+	required bool
+}
+
+// eventRequestBody returns how op's request body is generated.
+func (cg *oas3CodeGenerator) eventRequestBody(op *parser.Operation) eventBody {
+	rb := op.RequestBody
+	if rb == nil || len(rb.Content) == 0 {
+		return eventBody{}
+	}
+	contentType := cg.getRequestBodyContentType(rb)
+	if strings.Contains(contentType, "json") {
+		return eventBody{goType: cg.getRequestBodyType(rb), contentType: contentType, json: true, required: rb.Required}
+	}
+	return eventBody{goType: "[]byte", contentType: contentType, required: rb.Required}
+}
+
+// eventSuccessStatus returns the lowest 2xx status code op declares, or 200.
+func eventSuccessStatus(op *parser.Operation) int {
+	status := 0
+	if op.Responses != nil {
+		for code := range op.Responses.Codes {
+			n, err := strconv.Atoi(code)
+			if err == nil && n >= 200 && n < 300 && (status == 0 || n < status) {
+				status = n
+			}
+		}
+	}
+	if status == 0 {
+		return 200
+	}
+	return status
+}
+
+// writeEventComment writes the doc comment for a webhook or callback method.
+func writeEventComment(buf *bytes.Buffer, ev eventOperation, fallback, indent string) {
+	switch {
+	case ev.op.Summary != "":
+		buf.WriteString(formatMultilineComment(ev.op.Summary, ev.name, indent))
+	case ev.op.Description != "":
+		buf.WriteString(formatMultilineComment(ev.op.Description, ev.name, indent))
+	default:
+		fmt.Fprintf(buf, "%s// %s %s\n", indent, ev.name, fallback)
+	}
+	if ev.op.Deprecated {
+		fmt.Fprintf(buf, "%s// Deprecated: This operation is deprecated.\n", indent)
+	}
+}
+
+// generateWebhookReceiver generates the consumer side of webhooks: a
+// receiver interface, request types, per-webhook handlers, and a router.
+func (cg *oas3CodeGenerator) generateWebhookReceiver(webhooks []eventOperation) {
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by oastools. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", cg.result.PackageName)
+	buf.WriteString("import (\n\t\"context\"\n\t\"encoding/json\"\n\t\"errors\"\n\t\"io\"\n\t\"net/http\"\n)\n\n")
+
+	// Receiver interface
+	buf.WriteString("// WebhookReceiver handles the webhooks this API sends to subscribers.\n")
+	buf.WriteString("type WebhookReceiver interface {\n")
+	for _, ev := range webhooks {
+		writeEventComment(&buf, ev, fmt.Sprintf("handles the %s webhook.", ev.key), "\t")
+		fmt.Fprintf(&buf, "\t%s(ctx context.Context, req *%s) error\n", ev.name, cg.webhookRequestName(ev))
+	}
+	buf.WriteString("}\n\n")
+
+	// Unimplemented receiver
+	buf.WriteString("// ErrWebhookNotImplemented is returned by UnimplementedWebhookReceiver methods.\n")
+	buf.WriteString("var ErrWebhookNotImplemented = errors.New(\"webhook not implemented\")\n\n")
+	buf.WriteString("// UnimplementedWebhookReceiver can be embedded in a WebhookReceiver to\n")
+	buf.WriteString("// handle only some webhooks; the rest return ErrWebhookNotImplemented.\n")
+	buf.WriteString("type UnimplementedWebhookReceiver struct{}\n\n")
+	for _, ev := range webhooks {
+		fmt.Fprintf(&buf, "// %s returns ErrWebhookNotImplemented.\n", ev.name)
+		fmt.Fprintf(&buf, "func (UnimplementedWebhookReceiver) %s(ctx context.Context, req *%s) error {\n", ev.name, cg.webhookRequestName(ev))
+		buf.WriteString("\treturn ErrWebhookNotImplemented\n}\n\n")
+	}
+
+	// Request types and handlers
+	for _, ev := range webhooks {
+		cg.writeWebhookRequestType(&buf, ev)
+		cg.writeWebhookHandler(&buf, ev)
+	}
+
+	// Router
+	buf.WriteString("// NewWebhookRouter returns a router that serves each webhook at /{name},\n")
+	buf.WriteString("// the webhook's name in the API description, with its declared method.\n")
+	buf.WriteString("// To serve webhooks at other URLs, mount the per-webhook handlers instead.\n")
+	buf.WriteString("func NewWebhookRouter(receiver WebhookReceiver) *http.ServeMux {\n")
+	buf.WriteString("\tmux := http.NewServeMux()\n")
+	for _, ev := range webhooks {
+		pattern := strings.ToUpper(ev.method) + " /" + url.PathEscape(ev.key)
+		fmt.Fprintf(&buf, "\tmux.Handle(%q, %sWebhookHandler(receiver))\n", pattern, ev.name)
+	}
+	buf.WriteString("\treturn mux\n}\n")
+
+	buf.WriteString(webhookReceiverHelpers)
+
+	appendFormattedFile(cg.result, fileNameWebhookReceiver, &buf, cg.addIssue)
+}
+
+// webhookRequestName returns the request type name for a webhook.
+func (cg *oas3CodeGenerator) webhookRequestName(ev eventOperation) string {
+	return resolveWrapperName(ev.name+"Webhook", cg.generatedTypes)
+}
+
+// writeWebhookRequestType writes the request struct passed to a receiver.
+func (cg *oas3CodeGenerator) writeWebhookRequestType(buf *bytes.Buffer, ev eventOperation) {
+	name := cg.webhookRequestName(ev)
+	body := cg.eventRequestBody(ev.op)
+
+	fmt.Fprintf(buf, "// %s contains the request data for the %s webhook.\n", name, ev.name)
+	fmt.Fprintf(buf, "type %s struct {\n", name)
+	if body.goType != "" {
+		fmt.Fprintf(buf, "\t// Body is the decoded %s request body.\n", body.contentType)
+		fmt.Fprintf(buf, "\tBody %s\n", body.goType)
+	}
+	buf.WriteString("\t// HTTPRequest is the webhook request as received.\n")
+	buf.WriteString("\tHTTPRequest *http.Request\n")
+	buf.WriteString("}\n\n")
+}
+
+// writeWebhookHandler writes the http.Handler that decodes a webhook request
+// and dispatches it to the receiver.
+func (cg *oas3CodeGenerator) writeWebhookHandler(buf *bytes.Buffer, ev eventOperation) {
+	name := cg.webhookRequestName(ev)
+	body := cg.eventRequestBody(ev.op)
+
+	fmt.Fprintf(buf, "// %sWebhookHandler returns an http.Handler that decodes %s webhook\n", ev.name, ev.name)
+	buf.WriteString("// requests and passes them to receiver.\n")
+	fmt.Fprintf(buf, "func %sWebhookHandler(receiver WebhookReceiver) http.Handler {\n", ev.name)
+	buf.WriteString("\treturn http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {\n")
+	fmt.Fprintf(buf, "\t\treq := &%s{HTTPRequest: r}\n", name)
+	switch {
+	case body.json:
+		fmt.Fprintf(buf, "\t\tif err := decodeWebhookBody(r, &req.Body, %t); err != nil {\n", body.required)
+		buf.WriteString("\t\t\thttp.Error(w, \"invalid request body: \"+err.Error(), http.StatusBadRequest)\n")
+		buf.WriteString("\t\t\treturn\n\t\t}\n")
+	case body.goType != "":
+		buf.WriteString("\t\tdata, err := io.ReadAll(r.Body)\n")
+		buf.WriteString("\t\tif err != nil {\n")
+		buf.WriteString("\t\t\thttp.Error(w, \"invalid request body: \"+err.Error(), http.StatusBadRequest)\n")
+		buf.WriteString("\t\t\treturn\n\t\t}\n")
+		buf.WriteString("\t\treq.Body = data\n")
+	}
+	fmt.Fprintf(buf, "\t\tif err := receiver.%s(r.Context(), req); err != nil {\n", ev.name)
+	buf.WriteString("\t\t\twriteWebhookError(w, err)\n")
+	buf.WriteString("\t\t\treturn\n\t\t}\n")
+	fmt.Fprintf(buf, "\t\tw.WriteHeader(%d)\n", eventSuccessStatus(ev.op))
+	buf.WriteString("\t})\n}\n\n")
+}
+
+// generateEventSenders generates the provider side of webhooks and
+// callbacks: typed senders that deliver requests to subscriber URLs.
+func (cg *oas3CodeGenerator) generateEventSenders(webhooks, callbacks []eventOperation) {
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by oastools. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", cg.result.PackageName)
+	buf.WriteString("import (\n\t\"bytes\"\n\t\"context\"\n\t\"encoding/json\"\n\t\"fmt\"\n\t\"io\"\n\t\"net/http\"\n\t\"strconv\"\n\t\"strings\"\n)\n\n")
+
+	if len(webhooks) > 0 {
+		buf.WriteString("// WebhookSender delivers this API's webhooks to subscriber URLs.\n")
+		buf.WriteString("type WebhookSender struct {\n")
+		buf.WriteString("\t// HTTPClient sends the requests. If nil, http.DefaultClient is used.\n")
+		buf.WriteString("\tHTTPClient *http.Client\n")
+		buf.WriteString("}\n\n")
+		for _, ev := range webhooks {
+			body := cg.eventRequestBody(ev.op)
+			writeEventComment(&buf, ev, fmt.Sprintf("sends the %s webhook.", ev.key), "")
+			fmt.Fprintf(&buf, "// The request is sent to targetURL, the subscriber's URL for this webhook.\n")
+			fmt.Fprintf(&buf, "func (s *WebhookSender) %s(ctx context.Context, targetURL string%s) error {\n", ev.name, bodyParam(body))
+			fmt.Fprintf(&buf, "\treturn sendEvent(ctx, s.HTTPClient, %q, targetURL, %q, %s)\n", strings.ToUpper(ev.method), body.contentType, bodyArg(body))
+			buf.WriteString("}\n\n")
+		}
+	}
+
+	if len(callbacks) > 0 {
+		buf.WriteString("// CallbackSender delivers the callbacks declared by this API's operations.\n")
+		buf.WriteString("// Each callback URL is evaluated from its runtime expression against the\n")
+		buf.WriteString("// originating request.\n")
+		buf.WriteString("type CallbackSender struct {\n")
+		buf.WriteString("\t// HTTPClient sends the requests. If nil, http.DefaultClient is used.\n")
+		buf.WriteString("\tHTTPClient *http.Client\n")
+		buf.WriteString("}\n\n")
+		for _, ev := range callbacks {
+			body := cg.eventRequestBody(ev.op)
+			writeEventComment(&buf, ev, "sends a callback.", "")
+			fmt.Fprintf(&buf, "// The target URL is %s, evaluated against src.\n", strings.ReplaceAll(ev.key, "\n", " "))
+			fmt.Fprintf(&buf, "func (s *CallbackSender) %s(ctx context.Context, src *CallbackSource%s) error {\n", ev.name, bodyParam(body))
+			fmt.Fprintf(&buf, "\ttargetURL, err := EvaluateCallbackURL(%q, src)\n", ev.key)
+			buf.WriteString("\tif err != nil {\n")
+			fmt.Fprintf(&buf, "\t\treturn fmt.Errorf(\"callback %s: %%w\", err)\n", ev.name)
+			buf.WriteString("\t}\n")
+			fmt.Fprintf(&buf, "\treturn sendEvent(ctx, s.HTTPClient, %q, targetURL, %q, %s)\n", strings.ToUpper(ev.method), body.contentType, bodyArg(body))
+			buf.WriteString("}\n\n")
+		}
+		buf.WriteString(callbackExpressionHelpers)
+	}
+
+	buf.WriteString(eventSenderHelpers)
+
+	appendFormattedFile(cg.result, fileNameEventSenders, &buf, cg.addIssue)
+}
+
+// bodyParam returns the sender method parameter for a request body.
+func bodyParam(body eventBody) string {
+	if body.goType == "" {
+		return ""
+	}
+	return ", body " + body.goType
+}
+
+// bodyArg returns the sendEvent argument for a request body.
+func bodyArg(body eventBody) string {
+	switch {
+	case body.goType == "":
+		return "nil"
+	case body.json:
+		return "body"
+	default:
+		return "bytes.NewReader(body)"
+	}
+}
+
+// webhookReceiverHelpers is appended to the generated webhook receiver file.
+const webhookReceiverHelpers = `
+// decodeWebhookBody decodes a JSON request body into v. An empty body is
+// accepted when the body is optional.
+func decodeWebhookBody(r *http.Request, v any, required bool) error {
+	err := json.NewDecoder(r.Body).Decode(v)
+	if errors.Is(err, io.EOF) && !required {
+		return nil
+	}
+	return err
+}
+
+// writeWebhookError writes the response for an error returned by a receiver.
+func writeWebhookError(w http.ResponseWriter, err error) {
+	if errors.Is(err, ErrWebhookNotImplemented) {
+		http.Error(w, err.Error(), http.StatusNotImplemented)
+		return
+	}
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
+`
+
+// callbackExpressionHelpers is appended to the generated senders file when
+// the API declares callbacks.
+const callbackExpressionHelpers = `
+// CallbackSource is the originating exchange that callback URL expressions
+// are evaluated against.
+type CallbackSource struct {
+	// Request is the originating request. $url, $method, and the
+	// $request.header, $request.query, and $request.path sources read it.
+	Request *http.Request
+	// RequestBody is the originating request body, such as the decoded
+	// request struct or a json.RawMessage. $request.body expressions read
+	// it after marshaling it to JSON.
+	RequestBody any
+	// StatusCode is the status of the response to the originating request.
+	StatusCode int
+	// ResponseHeader holds the headers of the response to the originating request.
+	ResponseHeader http.Header
+	// ResponseBody is the body of the response to the originating request.
+	ResponseBody any
+}
+
+// EvaluateCallbackURL evaluates a callback URL template such as
+// "{$request.body#/callbackUrl}/events" against src, replacing each embedded
+// runtime expression with its value. A template that is itself a runtime
+// expression is evaluated directly.
+func EvaluateCallbackURL(template string, src *CallbackSource) (string, error) {
+	if strings.HasPrefix(template, "$") {
+		return evaluateRuntimeExpression(template, src)
+	}
+	var b strings.Builder
+	for {
+		start := strings.IndexByte(template, '{')
+		if start < 0 {
+			b.WriteString(template)
+			return b.String(), nil
+		}
+		end := strings.IndexByte(template[start:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("unterminated expression in %q", template)
+		}
+		value, err := evaluateRuntimeExpression(template[start+1:start+end], src)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(template[:start])
+		b.WriteString(value)
+		template = template[start+end+1:]
+	}
+}
+
+// evaluateRuntimeExpression evaluates one OpenAPI runtime expression.
+func evaluateRuntimeExpression(expr string, src *CallbackSource) (string, error) {
+	if src == nil {
+		return "", fmt.Errorf("no callback source to evaluate %s", expr)
+	}
+	switch {
+	case expr == "$statusCode":
+		return strconv.Itoa(src.StatusCode), nil
+	case strings.HasPrefix(expr, "$response."):
+		source := strings.TrimPrefix(expr, "$response.")
+		if name, ok := strings.CutPrefix(source, "header."); ok {
+			return src.ResponseHeader.Get(name), nil
+		}
+		if pointer, ok := strings.CutPrefix(source, "body"); ok {
+			return evaluateBodyPointer(src.ResponseBody, pointer, expr)
+		}
+		return "", fmt.Errorf("unsupported runtime expression %s", expr)
+	}
+
+	r := src.Request
+	if r == nil {
+		return "", fmt.Errorf("no originating request to evaluate %s", expr)
+	}
+	switch {
+	case expr == "$url":
+		scheme := "http"
+		if r.TLS != nil {
+			scheme = "https"
+		}
+		return scheme + "://" + r.Host + r.URL.RequestURI(), nil
+	case expr == "$method":
+		return r.Method, nil
+	case strings.HasPrefix(expr, "$request."):
+		source := strings.TrimPrefix(expr, "$request.")
+		if name, ok := strings.CutPrefix(source, "header."); ok {
+			return r.Header.Get(name), nil
+		}
+		if name, ok := strings.CutPrefix(source, "query."); ok {
+			return r.URL.Query().Get(name), nil
+		}
+		if name, ok := strings.CutPrefix(source, "path."); ok {
+			return r.PathValue(name), nil
+		}
+		if pointer, ok := strings.CutPrefix(source, "body"); ok {
+			return evaluateBodyPointer(src.RequestBody, pointer, expr)
+		}
+	}
+	return "", fmt.Errorf("unsupported runtime expression %s", expr)
+}
+
+// evaluateBodyPointer resolves a "#/json/pointer" fragment against body.
+func evaluateBodyPointer(body any, fragment, expr string) (string, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return "", fmt.Errorf("evaluating %s: %w", expr, err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		return "", fmt.Errorf("evaluating %s: %w", expr, err)
+	}
+
+	pointer := strings.TrimPrefix(fragment, "#")
+	if pointer != "" {
+		for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+			token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+			switch v := value.(type) {
+			case map[string]any:
+				next, ok := v[token]
+				if !ok {
+					return "", fmt.Errorf("evaluating %s: no member %q", expr, token)
+				}
+				value = next
+			case []any:
+				i, err := strconv.Atoi(token)
+				if err != nil || i < 0 || i >= len(v) {
+					return "", fmt.Errorf("evaluating %s: no element %q", expr, token)
+				}
+				value = v[i]
+			default:
+				return "", fmt.Errorf("evaluating %s: cannot index %T with %q", expr, value, token)
+			}
+		}
+	}
+
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case nil:
+		return "", nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	}
+	out, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("evaluating %s: %w", expr, err)
+	}
+	return string(out), nil
+}
+`
+
+// eventSenderHelpers is appended to the generated senders file.
+const eventSenderHelpers = `
+// DeliveryError reports a webhook or callback request that the receiver
+// answered with a non-2xx status.
+type DeliveryError struct {
+	URL        string
+	StatusCode int
+	Body       []byte
+}
+
+func (e *DeliveryError) Error() string {
+	return fmt.Sprintf("delivery to %s failed: status %d: %s", e.URL, e.StatusCode, string(e.Body))
+}
+
+// sendEvent sends a webhook or callback request and reports non-2xx responses
+// as a *DeliveryError. A body that is an io.Reader is sent as is; any other
+// non-nil body is encoded as JSON.
+func sendEvent(ctx context.Context, client *http.Client, method, targetURL, contentType string, body any) error {
+	var reader io.Reader
+	switch b := body.(type) {
+	case nil:
+	case io.Reader:
+		reader = b
+	default:
+		data, err := json.Marshal(b)
+		if err != nil {
+			return fmt.Errorf("encoding request body: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, targetURL, reader)
+	if err != nil {
+		return err
+	}
+	if reader != nil && contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return &DeliveryError{URL: targetURL, StatusCode: resp.StatusCode, Body: data}
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+`
@@ -0,0 +1,272 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const eventsSpec = `openapi: 3.1.0
+info:
+  title: Events API
+  version: 1.0.0
+paths:
+  /subscriptions:
+    post:
+      operationId: createSubscription
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Subscription'
+      responses:
+        '201':
+          description: Created
+      callbacks:
+        onEvent:
+          '{$request.body#/callbackUrl}/events?id={$request.body#/id}':
+            post:
+              requestBody:
+                content:
+                  application/json:
+                    schema:
+                      $ref: '#/components/schemas/Event'
+              responses:
+                '200':
+                  description: OK
+        onStatus:
+          $ref: '#/components/callbacks/StatusCallback'
+webhooks:
+  newPet:
+    post:
+      summary: A pet was added
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        '202':
+          description: Accepted
+  petDeleted:
+    post:
+      operationId: onPetDeleted
+      responses:
+        '200':
+          description: OK
+components:
+  callbacks:
+    StatusCallback:
+      '$request.header.X-Status-Url':
+        put:
+          requestBody:
+            content:
+              text/plain:
+                schema:
+                  type: string
+          responses:
+            '204':
+              description: No Content
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+    Subscription:
+      type: object
+      required: [id, callbackUrl]
+      properties:
+        id:
+          type: integer
+        callbackUrl:
+          type: string
+    Event:
+      type: object
+      properties:
+        kind:
+          type: string
+`
+
+// eventsRuntimeTest exercises the generated webhook and callback code end to end.
+const eventsRuntimeTest = `package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type petReceiver struct {
+	UnimplementedWebhookReceiver
+	got chan *NewPetWebhookRequest
+}
+
+func (r *petReceiver) NewPet(ctx context.Context, req *NewPetWebhookRequest) error {
+	r.got <- req
+	return nil
+}
+
+func TestWebhookRoundTrip(t *testing.T) {
+	receiver := &petReceiver{got: make(chan *NewPetWebhookRequest, 1)}
+	srv := httptest.NewServer(NewWebhookRouter(receiver))
+	defer srv.Close()
+
+	sender := &WebhookSender{}
+	if err := sender.NewPet(context.Background(), srv.URL+"/newPet", Pet{Name: "Rex"}); err != nil {
+		t.Fatalf("NewPet: %v", err)
+	}
+	if req := <-receiver.got; req.Body.Name != "Rex" {
+		t.Fatalf("received %+v", req.Body)
+	}
+
+	err := sender.OnPetDeleted(context.Background(), srv.URL+"/petDeleted")
+	var delivery *DeliveryError
+	if !errors.As(err, &delivery) || delivery.StatusCode != http.StatusNotImplemented {
+		t.Fatalf("OnPetDeleted error = %v, want 501 DeliveryError", err)
+	}
+}
+
+func TestCallbackSender(t *testing.T) {
+	var method, path, query, contentType, body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		method, path, query = r.Method, r.URL.Path, r.URL.RawQuery
+		contentType, body = r.Header.Get("Content-Type"), string(data)
+	}))
+	defer srv.Close()
+
+	orig := httptest.NewRequest(http.MethodPost, "/subscriptions", nil)
+	orig.Header.Set("X-Status-Url", srv.URL+"/status")
+	src := &CallbackSource{Request: orig, RequestBody: Subscription{Id: 7, CallbackUrl: srv.URL}}
+
+	sender := &CallbackSender{}
+	kind := "created"
+	if err := sender.CreateSubscriptionOnEvent(context.Background(), src, Event{Kind: &kind}); err != nil {
+		t.Fatalf("CreateSubscriptionOnEvent: %v", err)
+	}
+	if method != http.MethodPost || path != "/events" || query != "id=7" || body != "{\"kind\":\"created\"}" {
+		t.Fatalf("onEvent request = %s %s?%s %s", method, path, query, body)
+	}
+
+	if err := sender.CreateSubscriptionOnStatus(context.Background(), src, []byte("done")); err != nil {
+		t.Fatalf("CreateSubscriptionOnStatus: %v", err)
+	}
+	if method != http.MethodPut || path != "/status" || contentType != "text/plain" || body != "done" {
+		t.Fatalf("onStatus request = %s %s (%s) %s", method, path, contentType, body)
+	}
+
+	if _, err := EvaluateCallbackURL("{$request.body#/missing}", src); err == nil {
+		t.Fatal("expected an error for a missing body member")
+	}
+	got, err := EvaluateCallbackURL("$url", src)
+	if err != nil || got != "http://example.com/subscriptions" {
+		t.Fatalf("$url = %q, %v", got, err)
+	}
+}
+`
+
+func TestGenerateWebhooks_Files(t *testing.T) {
+	t.Run("client generates the receiver", func(t *testing.T) {
+		result := generateFromSpec(t, eventsSpec, WithClient(true))
+		receiver := result.GetFile(fileNameWebhookReceiver)
+		require.NotNil(t, receiver)
+		assert.Nil(t, result.GetFile(fileNameEventSenders))
+
+		content := string(receiver.Content)
+		assert.Contains(t, content, "type WebhookReceiver interface")
+		assert.Contains(t, content, "NewPet(ctx context.Context, req *NewPetWebhookRequest) error")
+		assert.Contains(t, content, "OnPetDeleted(ctx context.Context, req *OnPetDeletedWebhookRequest) error")
+		assert.Contains(t, content, `mux.Handle("POST /newPet", NewPetWebhookHandler(receiver))`)
+		assert.Contains(t, content, "w.WriteHeader(202)")
+	})
+
+	t.Run("server generates the senders", func(t *testing.T) {
+		result := generateFromSpec(t, eventsSpec, WithServer(true))
+		assert.Nil(t, result.GetFile(fileNameWebhookReceiver))
+		senders := result.GetFile(fileNameEventSenders)
+		require.NotNil(t, senders)
+
+		content := string(senders.Content)
+		assert.Contains(t, content, "func (s *WebhookSender) NewPet(ctx context.Context, targetURL string, body Pet) error")
+		assert.Contains(t, content, "func (s *CallbackSender) CreateSubscriptionOnEvent(ctx context.Context, src *CallbackSource, body Event) error")
+		assert.Contains(t, content, `EvaluateCallbackURL("{$request.body#/callbackUrl}/events?id={$request.body#/id}", src)`)
+		assert.Contains(t, content, "func (s *CallbackSender) CreateSubscriptionOnStatus(ctx context.Context, src *CallbackSource, body []byte) error")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		result := generateFromSpec(t, eventsSpec, WithClient(true), WithServer(true), WithWebhooks(false))
+		assert.Nil(t, result.GetFile(fileNameWebhookReceiver))
+		assert.Nil(t, result.GetFile(fileNameEventSenders))
+	})
+
+	t.Run("types only", func(t *testing.T) {
+		result := generateFromSpec(t, eventsSpec)
+		assert.Nil(t, result.GetFile(fileNameWebhookReceiver))
+		assert.Nil(t, result.GetFile(fileNameEventSenders))
+	})
+}
+
+func TestGenerateWebhooks_UnresolvedCallbackRef(t *testing.T) {
+	spec := `openapi: 3.0.3
+info:
+  title: Test
+  version: 1.0.0
+paths:
+  /jobs:
+    post:
+      operationId: createJob
+      responses:
+        '202':
+          description: Accepted
+      callbacks:
+        done:
+          $ref: '#/components/callbacks/Missing'
+`
+	result := generateFromSpec(t, spec, WithServer(true))
+
+	assert.Nil(t, result.GetFile(fileNameEventSenders))
+	var found bool
+	for _, issue := range result.Issues {
+		if issue.Path == "paths./jobs.post.callbacks.done" {
+			found = true
+			assert.Equal(t, SeverityWarning, issue.Severity)
+		}
+	}
+	assert.True(t, found, "expected a warning for the unresolved callback")
+}
+
+func TestGenerateWebhooks_RuntimeNameConflict(t *testing.T) {
+	spec := strings.Replace(eventsSpec, "    Pet:\n", "    DeliveryError:\n      type: string\n    WebhookReceiver:\n      type: string\n    Pet:\n", 1)
+	result := generateFromSpec(t, spec, WithClient(true), WithServer(true))
+
+	assert.Nil(t, result.GetFile(fileNameWebhookReceiver))
+	assert.Nil(t, result.GetFile(fileNameEventSenders))
+	types := result.GetFile("types.go")
+	require.NotNil(t, types)
+	assert.Contains(t, string(types.Content), "type DeliveryError = string")
+
+	conflicts := map[string]string{}
+	for _, issue := range result.Issues {
+		if issue.Severity == SeverityWarning && strings.Contains(issue.Message, "conflicts with") {
+			conflicts[issue.Path] = issue.Message
+		}
+	}
+	assert.Contains(t, conflicts[fileNameWebhookReceiver], "schema type WebhookReceiver conflicts with the webhook receiver runtime")
+	assert.Contains(t, conflicts[fileNameEventSenders], "schema type DeliveryError conflicts with the event sender runtime")
+}
+
+// TestGeneratedWebhooksRun compiles the generated client and server together
+// and runs a webhook and callback round trip against the generated code.
+func TestGeneratedWebhooksRun(t *testing.T) {
+	result := generateFromSpec(t, eventsSpec, WithClient(true), WithServer(true))
+	testGeneratedModule(t, result, eventsRuntimeTest)
+}
@@ -0,0 +1,92 @@
+package generator
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/erraggy/oastools/parser"
+	"github.com/stretchr/testify/require"
+)
+
+// generatedModulePath is the module runGeneratedModule writes. The generated
+// package is imported by main as generatedModulePath + "/api".
+const generatedModulePath = "example.com/gen"
+
+// oastoolsModulePath is this repository's module path.
+const oastoolsModulePath = "github.com/erraggy/oastools"
+
+// generateFromSpec parses spec and generates package api without a README.
+// opts are applied after those defaults, so they can override them.
+func generateFromSpec(t *testing.T, spec string, opts ...Option) *GenerateResult {
+	t.Helper()
+	parsed, err := parser.New().ParseBytes([]byte(spec))
+	require.NoError(t, err)
+	result, err := GenerateWithOptions(append([]Option{
+		WithParsed(*parsed),
+		WithPackageName("api"),
+		WithReadme(false),
+	}, opts...)...)
+	require.NoError(t, err)
+	return result
+}
+
+// runGeneratedModule writes result as the api package of a temporary module,
+// runs mainSrc as the module's cmd package, and returns the combined output.
+func runGeneratedModule(t *testing.T, result *GenerateResult, mainSrc string) string {
+	t.Helper()
+	return goInGeneratedModule(t, result, map[string]string{"cmd/main.go": mainSrc}, "run", "./cmd")
+}
+
+// testGeneratedModule writes result as the api package of a temporary module,
+// adds testSrc to it as an in-package test file, and runs the module's tests.
+func testGeneratedModule(t *testing.T, result *GenerateResult, testSrc string) string {
+	t.Helper()
+	return goInGeneratedModule(t, result, map[string]string{"api/generated_test.go": testSrc}, "test", "./...")
+}
+
+// goInGeneratedModule writes result as the api package of a temporary module,
+// along with files keyed by their module-relative path, then runs the go
+// command with args in it and returns the combined output. When the generated
+// code or one of files imports oastools, the module requires this repository
+// through a replace directive.
+func goInGeneratedModule(t *testing.T, result *GenerateResult, files map[string]string, args ...string) string {
+	t.Helper()
+
+	moduleDir := t.TempDir()
+	apiDir := filepath.Join(moduleDir, "api")
+	require.NoError(t, os.MkdirAll(apiDir, 0755))
+	usesRepo := false
+	for _, file := range result.Files {
+		require.NoError(t, os.WriteFile(filepath.Join(apiDir, file.Name), file.Content, 0644))
+		usesRepo = usesRepo || strings.Contains(string(file.Content), oastoolsModulePath)
+	}
+	for name, src := range files {
+		filePath := filepath.Join(moduleDir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(filePath), 0755))
+		require.NoError(t, os.WriteFile(filePath, []byte(src), 0644))
+		usesRepo = usesRepo || strings.Contains(src, oastoolsModulePath)
+	}
+
+	goMod := "module " + generatedModulePath + "\n\ngo 1.25\n"
+	if usesRepo {
+		repoRoot, err := filepath.Abs("..")
+		require.NoError(t, err)
+		goMod += "\nrequire " + oastoolsModulePath + " v0.0.0\n\nreplace " + oastoolsModulePath + " => " + repoRoot + "\n"
+		goSum, err := os.ReadFile(filepath.Join(repoRoot, "go.sum"))
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(moduleDir, "go.sum"), goSum, 0644))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(moduleDir, "go.mod"), []byte(goMod), 0644))
+
+	cmd := exec.Command("go", args...)
+	cmd.Dir = moduleDir
+	if usesRepo {
+		cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod")
+	}
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, "go %s failed for the generated module.\nOutput:\n%s", strings.Join(args, " "), string(output))
+	return string(output)
+}
@@ -97,6 +97,13 @@ type Generator struct {
 	// If empty, defaults to "api"
 	PackageName string
 
+	// Language is the language to generate: "go" or "typescript".
+	// TypeScript output is types.ts with interfaces and unions for the
+	// schemas and, with GenerateClient, client.ts with a fetch client.
+	// Server generation and the Go-specific options don't apply to it.
+	// Default: "go"
+	Language string
+
 	// GenerateClient enables HTTP client generation
 	GenerateClient bool
 
@@ -111,6 +118,15 @@ type Generator struct {
 	// Default: true
 	UsePointers bool
 
+	// UseOptionalTypes types optional and nullable struct fields with the
+	// generic Optional[T] and Nullable[T] wrappers generated in optional.go,
+	// which keep an absent value apart from an explicit null (as JSON Merge
+	// Patch bodies need). Nullable properties (nullable: true, or "null" in
+	// the type array) are Nullable[T]; other optional properties are
+	// Optional[T]. Optional slices and maps keep their plain types.
+	// Default: false
+	UseOptionalTypes bool
+
 	// IncludeValidation adds validation tags to generated structs
 	// Default: true
 	IncludeValidation bool
@@ -212,6 +228,37 @@ type Generator struct {
 	// Default: false
 	ServerEmbedSpec bool
 
+	// ServerStrict makes each ServerInterface method return a sealed response
+	// interface with one type per status the operation declares, carrying a
+	// typed body and typed headers. The router writes whichever one the
+	// handler returns, so the compiler checks that handlers follow the spec.
+	// Implies ServerResponses.
+	// Default: false
+	ServerStrict bool
+
+	// GenerateWebhooks enables code generation for webhooks (OAS 3.1+) and
+	// operation callbacks (OAS 3.0+). Client generation adds a webhook receiver
+	// interface and router; server generation adds typed webhook and callback senders.
+	// Default: true
+	GenerateWebhooks bool
+
+	// GenerateValidateMethods adds a Validate() error method to each generated
+	// type that checks the constraints of its schema (required fields, lengths,
+	// patterns, enums, numeric bounds, and array and map sizes) without any
+	// third-party validation library. Errors carry JSON pointer paths.
+	// Default: false
+	GenerateValidateMethods bool
+
+	// ImportMapping maps external documents referenced by $ref to the Go
+	// packages that already hold their types. A $ref into a mapped document
+	// generates a qualified type such as common.Error, and the import is added
+	// to each file that uses it, instead of generating the type again.
+	// Keys are document paths as written in the $ref, such as "common.yaml".
+	// Values are import paths, optionally preceded by a package name and a
+	// space when it differs from the last path element (or, for a path ending
+	// in a major version such as /v2, the element before it).
+	ImportMapping map[string]string
+
 	// SourceMap provides source location lookup for generation issues.
 	// When set, issues will include Line, Column, and File information.
 	SourceMap *parser.SourceMap
@@ -221,6 +268,7 @@ type Generator struct {
 func New() *Generator {
 	return &Generator{
 		PackageName:       "api",
+		Language:          languageGo,
 		GenerateClient:    false,
 		GenerateServer:    false,
 		GenerateTypes:     true,
@@ -248,6 +296,8 @@ func New() *Generator {
 		ServerResponses:  false,
 		ServerStubs:      false,
 		ServerEmbedSpec:  false,
+		ServerStrict:     false,
+		GenerateWebhooks: true,
 	}
 }
 
@@ -262,10 +312,12 @@ type generateConfig struct {
 
 	// Configuration options
 	packageName       string
+	language          string
 	generateClient    bool
 	generateServer    bool
 	generateTypes     bool
 	usePointers       bool
+	useOptionalTypes  bool
 	includeValidation bool
 	strictMode        bool
 	includeInfo       bool
@@ -293,6 +345,16 @@ type generateConfig struct {
 	serverResponses  bool
 	serverStubs      bool
 	serverEmbedSpec  bool
+	serverStrict     bool
+
+	// Event generation options
+	generateWebhooks bool
+
+	// Validate method generation
+	generateValidateMethods bool
+
+	// External document to Go import path mapping
+	importMapping map[string]string
 
 	// Source map for line/column tracking
 	sourceMap *parser.SourceMap
@@ -317,10 +379,12 @@ func GenerateWithOptions(opts ...Option) (*GenerateResult, error) {
 
 	g := &Generator{
 		PackageName:       cfg.packageName,
+		Language:          cfg.language,
 		GenerateClient:    cfg.generateClient,
 		GenerateServer:    cfg.generateServer,
 		GenerateTypes:     cfg.generateTypes,
 		UsePointers:       cfg.usePointers,
+		UseOptionalTypes:  cfg.useOptionalTypes,
 		IncludeValidation: cfg.includeValidation,
 		StrictMode:        cfg.strictMode,
 		IncludeInfo:       cfg.includeInfo,
@@ -345,6 +409,13 @@ func GenerateWithOptions(opts ...Option) (*GenerateResult, error) {
 		ServerResponses:  cfg.serverResponses,
 		ServerStubs:      cfg.serverStubs,
 		ServerEmbedSpec:  cfg.serverEmbedSpec,
+		ServerStrict:     cfg.serverStrict,
+		// Webhooks and callbacks
+		GenerateWebhooks: cfg.generateWebhooks,
+		// Validate methods
+		GenerateValidateMethods: cfg.generateValidateMethods,
+		// Import mapping
+		ImportMapping: cfg.importMapping,
 		// Source map
 		SourceMap: cfg.sourceMap,
 	}
@@ -367,6 +438,7 @@ func applyOptions(opts ...Option) (*generateConfig, error) {
 	cfg := &generateConfig{
 		// Set defaults
 		packageName:       "api",
+		language:          languageGo,
 		generateClient:    false,
 		generateServer:    false,
 		generateTypes:     true,
@@ -395,6 +467,9 @@ func applyOptions(opts ...Option) (*generateConfig, error) {
 		serverResponses:  false,
 		serverStubs:      false,
 		serverEmbedSpec:  false,
+		serverStrict:     false,
+		// Event generation defaults
+		generateWebhooks: true,
 	}
 
 	for _, opt := range opts {
@@ -428,6 +503,11 @@ func applyOptions(opts ...Option) (*generateConfig, error) {
 		return nil, fmt.Errorf("generator: invalid server router %q (valid values: stdlib, chi)", cfg.serverRouter)
 	}
 
+	// Validate the output language
+	if !isValidLanguage(cfg.language) {
+		return nil, fmt.Errorf("generator: invalid language %q (valid values: go, typescript)", cfg.language)
+	}
+
 	return cfg, nil
 }
 
@@ -459,6 +539,16 @@ func WithPackageName(name string) Option {
 	}
 }
 
+// WithLanguage sets the language to generate: "go" (the default) or
+// "typescript". TypeScript output has types and a fetch client; server
+// generation is not supported for it.
+func WithLanguage(lang string) Option {
+	return func(cfg *generateConfig) error {
+		cfg.language = lang
+		return nil
+	}
+}
+
 // WithClient enables or disables HTTP client generation
 // Default: false
 func WithClient(enabled bool) Option {
@@ -496,6 +586,18 @@ func WithPointers(enabled bool) Option {
 	}
 }
 
+// WithOptionalTypes enables the generic Optional[T] and Nullable[T] field
+// types. Nullable properties become Nullable[T], which tells an absent value
+// from an explicit null, and other optional properties become Optional[T].
+// Wrapped fields use the omitzero JSON tag option and get no validate tags.
+// Default: false
+func WithOptionalTypes(enabled bool) Option {
+	return func(cfg *generateConfig) error {
+		cfg.useOptionalTypes = enabled
+		return nil
+	}
+}
+
 // WithValidation enables or disables validation tags in generated structs
 // Default: true
 func WithValidation(enabled bool) Option {
@@ -744,6 +846,64 @@ func WithServerEmbedSpec(enabled bool) Option {
 	}
 }
 
+// WithServerStrict enables strict server mode. Each ServerInterface method
+// returns a sealed response interface, such as CreatePetResponse, implemented
+// by one type per declared status (CreatePet201Response, CreatePet409Response)
+// with a typed Body and a field per declared response header. The router
+// writes whichever one the handler returns. Strict mode implies server
+// responses generation.
+// Default: false
+func WithServerStrict(enabled bool) Option {
+	return func(cfg *generateConfig) error {
+		cfg.serverStrict = enabled
+		return nil
+	}
+}
+
+// WithWebhooks enables or disables code generation for webhooks and callbacks.
+// Client generation adds a WebhookReceiver interface and router for the webhooks
+// an API sends; server generation adds a WebhookSender and a CallbackSender that
+// evaluates each callback URL against the originating request.
+// Default: true
+func WithWebhooks(enabled bool) Option {
+	return func(cfg *generateConfig) error {
+		cfg.generateWebhooks = enabled
+		return nil
+	}
+}
+
+// WithValidateMethods enables generation of a Validate() error method on each
+// generated type. The methods check required fields, string lengths and
+// patterns, enums, numeric bounds and multipleOf, and array and map sizes,
+// recursing into nested generated types, and report every violation as a
+// ConstraintError with a JSON pointer path. The generated code depends only
+// on the standard library.
+// Default: false
+func WithValidateMethods(enabled bool) Option {
+	return func(cfg *generateConfig) error {
+		cfg.generateValidateMethods = enabled
+		return nil
+	}
+}
+
+// WithImportMapping maps external documents referenced by $ref to the Go
+// packages that already hold their types, so shared schemas are imported
+// rather than generated into every package. Keys are document paths as
+// written in the $ref; values are import paths, optionally preceded by a
+// package name and a space.
+//
+// Example:
+//
+//	generator.WithImportMapping(map[string]string{
+//		"common.yaml": "github.com/acme/apis/common",
+//	})
+func WithImportMapping(mapping map[string]string) Option {
+	return func(cfg *generateConfig) error {
+		cfg.importMapping = mapping
+		return nil
+	}
+}
+
 // WithServerAll enables all server generation options with stdlib router.
 // This is a convenience option for generating a complete server implementation.
 func WithServerAll() Option {
@@ -801,6 +961,16 @@ func (g *Generator) GenerateParsed(parseResult parser.ParseResult) (*GenerateRes
 		result.PackageName = "api"
 	}
 
+	if !isValidLanguage(g.Language) {
+		return nil, fmt.Errorf("generator: invalid language %q (valid values: go, typescript)", g.Language)
+	}
+	if g.Language == languageTypeScript && g.GenerateServer {
+		return nil, fmt.Errorf("generator: server generation is not supported for TypeScript")
+	}
+	if _, err := parseImportMapping(g.ImportMapping); err != nil {
+		return nil, fmt.Errorf("generator: %w", err)
+	}
+
 	// Create code generator based on OAS version
 	var cg codeGenerator
 	if doc, ok := parseResult.OAS2Document(); ok {
@@ -811,11 +981,27 @@ func (g *Generator) GenerateParsed(parseResult parser.ParseResult) (*GenerateRes
 		return nil, fmt.Errorf("generator: unsupported OAS version: %s", parseResult.Version)
 	}
 
+	// TypeScript output replaces all of the Go output
+	if g.Language == languageTypeScript {
+		if g.GenerateTypes || g.GenerateClient {
+			if err := cg.generateTypeScript(); err != nil {
+				return nil, fmt.Errorf("generator: failed to generate TypeScript: %w", err)
+			}
+		}
+		return g.finishResult(result, startTime)
+	}
+
 	// Generate types if enabled
 	if g.GenerateTypes || g.GenerateClient || g.GenerateServer {
 		if err := cg.generateTypes(); err != nil {
 			return nil, fmt.Errorf("generator: failed to generate types: %w", err)
 		}
+		cg.generateOptionalTypes()
+		if g.GenerateValidateMethods {
+			if err := cg.generateValidateMethods(); err != nil {
+				return nil, fmt.Errorf("generator: failed to generate validate methods: %w", err)
+			}
+		}
 	}
 
 	// Generate client if enabled
@@ -832,7 +1018,7 @@ func (g *Generator) GenerateParsed(parseResult parser.ParseResult) (*GenerateRes
 		}
 
 		// Generate additional server files based on options
-		if g.ServerResponses {
+		if g.ServerResponses || g.ServerStrict {
 			if err := cg.generateServerResponses(); err != nil {
 				return nil, fmt.Errorf("generator: failed to generate server responses: %w", err)
 			}
@@ -859,9 +1045,25 @@ func (g *Generator) GenerateParsed(parseResult parser.ParseResult) (*GenerateRes
 		}
 	}
 
+	// Generate webhook and callback code if enabled
+	if g.GenerateWebhooks && (g.GenerateClient || g.GenerateServer) {
+		if err := cg.generateWebhooks(); err != nil {
+			return nil, fmt.Errorf("generator: failed to generate webhooks: %w", err)
+		}
+	}
+
 	// Generate security helpers and related files
 	cg.generateSecurityHelpers()
 
+	// Import the packages of mapped external types
+	cg.addMappedImports()
+
+	return g.finishResult(result, startTime)
+}
+
+// finishResult records the timing and issue counts of a generation run, and
+// applies StrictMode and IncludeInfo.
+func (g *Generator) finishResult(result *GenerateResult, startTime time.Time) (*GenerateResult, error) {
 	// Update counts and timing
 	result.GenerateTime = time.Since(startTime)
 	g.updateCounts(result)
@@ -918,4 +1120,14 @@ type codeGenerator interface {
 	generateServerMiddleware() error
 	generateServerRouter() error
 	generateServerStubs() error
+	// Webhook and callback generation
+	generateWebhooks() error
+	// Validate methods for generated types
+	generateValidateMethods() error
+	// Optional and Nullable field types
+	generateOptionalTypes()
+	// Import mapping for external types
+	addMappedImports()
+	// TypeScript output
+	generateTypeScript() error
 }
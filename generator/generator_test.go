@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/erraggy/oastools/parser"
 	"github.com/stretchr/testify/assert"
@@ -153,6 +154,104 @@ func TestWriteFilesPathTraversal(t *testing.T) {
 	assert.Contains(t, err.Error(), "must not contain path separators")
 }
 
+func TestGenerateResult_CheckFiles(t *testing.T) {
+	header := "// Code generated by oastools. DO NOT EDIT.\n\n"
+	result := &GenerateResult{
+		Files: []GeneratedFile{
+			{Name: "types.go", Content: []byte(header + "package test\n\ntype Foo struct{}\n")},
+			{Name: "client.go", Content: []byte(header + "package test\n\nfunc NewClient() {}\n")},
+		},
+	}
+
+	t.Run("up to date", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, result.WriteFiles(dir))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "custom.go"), []byte("package test\n"), 0o600))
+
+		report, err := result.CheckFiles(dir)
+		require.NoError(t, err)
+		assert.False(t, report.HasDrift(), "hand-written files are not drift: %+v", report.Files)
+	})
+
+	t.Run("stale, missing and orphaned", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "types.go"), []byte(header+"package test\n\ntype Bar struct{}\n"), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "server.go"), []byte(header+"package test\n"), 0o600))
+
+		report, err := result.CheckFiles(dir)
+		require.NoError(t, err)
+		require.True(t, report.HasDrift())
+		require.Len(t, report.Files, 3)
+
+		assert.Equal(t, "client.go", report.Files[0].Name)
+		assert.Equal(t, DriftMissing, report.Files[0].Status)
+		assert.Contains(t, report.Files[0].Diff, "+func NewClient() {}\n")
+
+		assert.Equal(t, "server.go", report.Files[1].Name)
+		assert.Equal(t, DriftOrphaned, report.Files[1].Status)
+		assert.Empty(t, report.Files[1].Diff)
+
+		assert.Equal(t, "types.go", report.Files[2].Name)
+		assert.Equal(t, DriftStale, report.Files[2].Status)
+		assert.Contains(t, report.Files[2].Diff, "-type Bar struct{}\n+type Foo struct{}\n")
+	})
+
+	t.Run("README timestamp is ignored", func(t *testing.T) {
+		readme := func(stamp, title string) []byte {
+			return []byte("# " + title + "\n\n| Generated | " + stamp + " |\n")
+		}
+		withReadme := &GenerateResult{Files: []GeneratedFile{
+			{Name: "README.md", Content: readme("2024-01-15T10:30:00Z", "api")},
+		}}
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), readme("2023-06-01T08:00:00Z", "api"), 0o600))
+
+		report, err := withReadme.CheckFiles(dir)
+		require.NoError(t, err)
+		assert.False(t, report.HasDrift(), "only the timestamp differs: %+v", report.Files)
+
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), readme("2023-06-01T08:00:00Z", "old"), 0o600))
+		report, err = withReadme.CheckFiles(dir)
+		require.NoError(t, err)
+		require.Len(t, report.Files, 1)
+		assert.Equal(t, DriftStale, report.Files[0].Status)
+	})
+
+	t.Run("orphaned README", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, result.WriteFiles(dir))
+		readme := NewReadmeGenerator().GenerateReadme(&ReadmeContext{PackageName: "test", Timestamp: time.Now()})
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte(readme), 0o600))
+
+		report, err := result.CheckFiles(dir)
+		require.NoError(t, err)
+		require.Len(t, report.Files, 1)
+		assert.Equal(t, "README.md", report.Files[0].Name)
+		assert.Equal(t, DriftOrphaned, report.Files[0].Status)
+
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("# My API\n\nHand-written notes.\n"), 0o600))
+		report, err = result.CheckFiles(dir)
+		require.NoError(t, err)
+		assert.False(t, report.HasDrift(), "a hand-written README is not drift: %+v", report.Files)
+	})
+
+	t.Run("missing directory", func(t *testing.T) {
+		report, err := result.CheckFiles(filepath.Join(t.TempDir(), "absent"))
+		require.NoError(t, err)
+		require.Len(t, report.Files, 2)
+		for _, file := range report.Files {
+			assert.Equal(t, DriftMissing, file.Status)
+		}
+	})
+
+	t.Run("nothing is written", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "out")
+		_, err := result.CheckFiles(dir)
+		require.NoError(t, err)
+		assert.NoDirExists(t, dir)
+	})
+}
+
 func TestGenerateResult_GetFile(t *testing.T) {
 	result := &GenerateResult{
 		Files: []GeneratedFile{
@@ -0,0 +1,168 @@
+// This file implements the x-go-* schema extensions that override the Go type,
+// name, import, and JSON tag options the generator would otherwise derive from
+// a schema.
+
+package generator
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/erraggy/oastools/internal/schemautil"
+	"github.com/erraggy/oastools/parser"
+)
+
+// Schema extensions that customize generated Go code.
+const (
+	// extGoType replaces the generated Go type, e.g. "decimal.Decimal".
+	extGoType = "x-go-type"
+	// extGoTypeImport names the package extGoType refers to, either as an
+	// import path string or as an object with "path" and optional "name".
+	extGoTypeImport = "x-go-type-import"
+	// extGoName replaces the generated type or field name.
+	extGoName = "x-go-name"
+	// extOmitEmpty forces ",omitempty" on or off in a field's JSON tag.
+	extOmitEmpty = "x-omitempty"
+	// extGoTypeSkipOptionalPointer keeps an optional field from being a pointer.
+	extGoTypeSkipOptionalPointer = "x-go-type-skip-optional-pointer"
+)
+
+// goTypeOverride returns the schema's x-go-type, or "" if it has none.
+func goTypeOverride(schema *parser.Schema) string {
+	if schema == nil {
+		return ""
+	}
+	goType, _ := schema.Extra[extGoType].(string)
+	return strings.TrimSpace(goType)
+}
+
+// goNameOverride returns the schema's x-go-name, or "" if it has none.
+func goNameOverride(schema *parser.Schema) string {
+	if schema == nil {
+		return ""
+	}
+	name, _ := schema.Extra[extGoName].(string)
+	return strings.TrimSpace(name)
+}
+
+// omitEmptyOverride returns the schema's x-omitempty and whether it is set.
+func omitEmptyOverride(schema *parser.Schema) (omitEmpty, ok bool) {
+	if schema == nil {
+		return false, false
+	}
+	omitEmpty, ok = schema.Extra[extOmitEmpty].(bool)
+	return omitEmpty, ok
+}
+
+// skipOptionalPointer reports whether the schema opts out of pointer types
+// for optional values.
+func skipOptionalPointer(schema *parser.Schema) bool {
+	if schema == nil {
+		return false
+	}
+	skip, _ := schema.Extra[extGoTypeSkipOptionalPointer].(bool)
+	return skip
+}
+
+// schemaTypeName returns the Go type name for the component schema called
+// name, honoring x-go-name.
+func schemaTypeName(name string, schema *parser.Schema) string {
+	if goName := goNameOverride(schema); goName != "" {
+		return goName
+	}
+	return toTypeName(name)
+}
+
+// goFieldName returns the Go field name for the property called propName,
+// honoring x-go-name.
+func goFieldName(propName string, propSchema *parser.Schema) string {
+	if goName := goNameOverride(propSchema); goName != "" {
+		return goName
+	}
+	return toFieldName(propName)
+}
+
+// jsonTagValue returns the json struct tag value for the property called
+// propName. Optional properties are omitempty unless x-omitempty says
+// otherwise.
+func jsonTagValue(propName string, propSchema *parser.Schema, required bool) string {
+	omitEmpty := !required
+	if override, ok := omitEmptyOverride(propSchema); ok {
+		omitEmpty = override
+	}
+	if omitEmpty {
+		return propName + ",omitempty"
+	}
+	return propName
+}
+
+// goTypeImport returns the import spec for the schema's x-go-type-import:
+// the unquoted import path, prefixed by the package name and a space when
+// one is given. It returns "" if the schema has no import.
+func goTypeImport(schema *parser.Schema) string {
+	if schema == nil {
+		return ""
+	}
+	var path, name string
+	switch imp := schema.Extra[extGoTypeImport].(type) {
+	case string:
+		path = imp
+	case map[string]any:
+		path, _ = imp["path"].(string)
+		name, _ = imp["name"].(string)
+	}
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return ""
+	}
+	if name = strings.TrimSpace(name); name != "" {
+		return name + " " + path
+	}
+	return path
+}
+
+// addGoTypeImports records the x-go-type-import of schema and of every schema
+// nested in it. Unused imports are removed when the file is formatted, so
+// this errs on the side of adding too many.
+func addGoTypeImports(schema *parser.Schema, imports map[string]bool) {
+	addGoTypeImportsVisited(schema, imports, make(map[*parser.Schema]bool))
+}
+
+func addGoTypeImportsVisited(schema *parser.Schema, imports map[string]bool, visited map[*parser.Schema]bool) {
+	if schema == nil || visited[schema] {
+		return
+	}
+	visited[schema] = true
+
+	if imp := goTypeImport(schema); imp != "" {
+		imports[imp] = true
+	}
+	for _, prop := range schema.Properties {
+		addGoTypeImportsVisited(prop, imports, visited)
+	}
+	if items, ok := schema.Items.(*parser.Schema); ok {
+		addGoTypeImportsVisited(items, imports, visited)
+	}
+	if tuple, ok := schemautil.SchemaTuple(schema.Items); ok {
+		for _, elem := range tuple {
+			addGoTypeImportsVisited(elem, imports, visited)
+		}
+	}
+	if additional, ok := schema.AdditionalProperties.(*parser.Schema); ok {
+		addGoTypeImportsVisited(additional, imports, visited)
+	}
+	for _, group := range [][]*parser.Schema{schema.AllOf, schema.OneOf, schema.AnyOf, schema.PrefixItems} {
+		for _, sub := range group {
+			addGoTypeImportsVisited(sub, imports, visited)
+		}
+	}
+}
+
+// importSpec formats an import recorded by goTypeImport, or a plain import
+// path, as it appears in an import block.
+func importSpec(imp string) string {
+	if name, path, ok := strings.Cut(imp, " "); ok {
+		return name + " " + strconv.Quote(path)
+	}
+	return strconv.Quote(imp)
+}
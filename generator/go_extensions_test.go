@@ -0,0 +1,197 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const goExtensionsSpec = `openapi: 3.0.3
+info:
+  title: Extensions API
+  version: 1.0.0
+paths:
+  /hosts:
+    get:
+      operationId: listHosts
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/host_record'
+components:
+  schemas:
+    IPAddress:
+      type: string
+      format: ipv4
+      x-go-type: netip.Addr
+      x-go-type-import: net/netip
+    host_record:
+      type: object
+      x-go-name: Host
+      required: [addr, kind]
+      properties:
+        kind:
+          type: string
+        addr:
+          $ref: '#/components/schemas/IPAddress'
+        weight:
+          type: integer
+          x-go-type: bigmath.Int
+          x-go-type-import:
+            path: math/big
+            name: bigmath
+        label:
+          type: string
+          maxLength: 10
+          x-go-name: DisplayName
+          x-omitempty: false
+        tags:
+          type: array
+          items:
+            type: string
+          x-go-type-skip-optional-pointer: true
+        note:
+          type: string
+          x-go-type-skip-optional-pointer: true
+        parent:
+          $ref: '#/components/schemas/host_record'
+    cname_record:
+      type: object
+      x-go-name: Alias
+      required: [kind]
+      properties:
+        kind:
+          type: string
+        target:
+          type: string
+    Record:
+      oneOf:
+        - $ref: '#/components/schemas/host_record'
+        - $ref: '#/components/schemas/cname_record'
+      discriminator:
+        propertyName: kind
+        mapping:
+          host: '#/components/schemas/host_record'
+          alias: '#/components/schemas/cname_record'
+`
+
+// goExtensionsRuntimeTest decodes through the overridden types and the
+// discriminated union that references them.
+const goExtensionsRuntimeTest = `package api
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOverrides(t *testing.T) {
+	var rec Record
+	data := []byte(` + "`" + `{"kind":"host","addr":"10.0.0.1","weight":12345678901234567890,"label":"a"}` + "`" + `)
+	if err := json.Unmarshal(data, &rec); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if rec.Host == nil || rec.Host.Addr.String() != "10.0.0.1" || rec.Host.Weight.String() != "12345678901234567890" {
+		t.Fatalf("decoded %+v", rec.Host)
+	}
+	if rec.Host.DisplayName == nil || *rec.Host.DisplayName != "a" {
+		t.Fatalf("DisplayName = %v", rec.Host.DisplayName)
+	}
+
+	out, err := json.Marshal(Host{Kind: "host", Addr: rec.Host.Addr})
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if got := string(out); got != ` + "`" + `{"addr":"10.0.0.1","kind":"host","label":null}` + "`" + ` {
+		t.Fatalf("Marshal = %s", got)
+	}
+
+	if err := json.Unmarshal([]byte(` + "`" + `{"kind":"alias","target":"example.com"}` + "`" + `), &rec); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if rec.Alias == nil || rec.Alias.Target == nil || *rec.Alias.Target != "example.com" {
+		t.Fatalf("decoded %+v", rec.Alias)
+	}
+}
+`
+
+func TestGoExtensions_OAS3(t *testing.T) {
+	result := generateFromSpec(t, goExtensionsSpec, WithClient(true))
+	types := result.GetFile("types.go")
+	require.NotNil(t, types)
+	content := string(types.Content)
+
+	assert.Contains(t, content, `bigmath "math/big"`)
+	assert.Contains(t, content, `"net/netip"`)
+	assert.Contains(t, content, "type IPAddress = netip.Addr")
+	assert.Contains(t, content, "type Host struct")
+	assert.Contains(t, content, "type Alias struct")
+	assert.NotContains(t, content, "HostRecord")
+
+	assert.Regexp(t, `Addr\s+IPAddress\s+`+"`"+`json:"addr" validate:"required"`+"`", content)
+	assert.Regexp(t, `Weight\s+\*bigmath\.Int\s+`+"`"+`json:"weight,omitempty"`+"`", content)
+	assert.Regexp(t, `DisplayName\s+\*string\s+`+"`"+`json:"label" validate:"max=10"`+"`", content)
+	assert.Regexp(t, `Tags\s+\[\]string\s+`+"`"+`json:"tags,omitempty"`+"`", content)
+	assert.Regexp(t, `Note\s+string\s+`+"`"+`json:"note,omitempty"`+"`", content)
+	assert.Regexp(t, `Parent\s+\*Host\s+`, content)
+
+	// The union and its UnmarshalJSON use the overridden type names
+	assert.Regexp(t, `Host\s+\*Host\s+`+"`"+`json:"-"`+"`", content)
+	assert.Contains(t, content, "u.Alias = new(Alias)")
+
+	client := result.GetFile("client.go")
+	require.NotNil(t, client)
+	assert.Contains(t, string(client.Content), "[]Host")
+}
+
+func TestGoExtensions_OAS2(t *testing.T) {
+	spec := `swagger: "2.0"
+info:
+  title: Extensions API
+  version: 1.0.0
+paths: {}
+definitions:
+  money:
+    type: string
+    x-go-type: bigmath.Float
+    x-go-type-import:
+      path: math/big
+      name: bigmath
+  line_item:
+    type: object
+    x-go-name: LineItem
+    required: [price]
+    properties:
+      price:
+        $ref: '#/definitions/money'
+      sku:
+        type: string
+        x-go-name: SKU
+        x-omitempty: false
+      next:
+        $ref: '#/definitions/line_item'
+`
+	result := generateFromSpec(t, spec)
+
+	types := result.GetFile("types.go")
+	require.NotNil(t, types)
+	content := string(types.Content)
+	assert.Contains(t, content, `bigmath "math/big"`)
+	assert.Contains(t, content, "type Money = bigmath.Float")
+	assert.Contains(t, content, "type LineItem struct")
+	assert.Regexp(t, `Price\s+Money\s+`+"`"+`json:"price"`+"`", content)
+	assert.Regexp(t, `SKU\s+\*string\s+`+"`"+`json:"sku"`+"`", content)
+	assert.Regexp(t, `Next\s+\*LineItem\s+`, content)
+}
+
+// TestGeneratedGoExtensionsRun compiles code generated with x-go-* overrides
+// and decodes a discriminated union through the overridden types.
+func TestGeneratedGoExtensionsRun(t *testing.T) {
+	result := generateFromSpec(t, goExtensionsSpec, WithClient(true), WithServer(true))
+	testGeneratedModule(t, result, goExtensionsRuntimeTest)
+}
@@ -0,0 +1,198 @@
+// This file implements import mapping, which resolves $refs into external
+// documents to types in existing Go packages instead of generating them.
+
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// mappedImport is a Go package that types from an external document live in.
+type mappedImport struct {
+	name string // package name used to qualify types
+	path string // import path
+}
+
+// parseImportMapping converts an import mapping from external document paths
+// to import specs into lookups keyed by the cleaned document path. An import
+// spec is an import path, optionally preceded by a package name and a space,
+// as in "common github.com/acme/common/v2". Without a name, the package name
+// is derived from the import path by importPackageName. It fails when the
+// package name is not a Go identifier.
+func parseImportMapping(mapping map[string]string) (map[string]mappedImport, error) {
+	if len(mapping) == 0 {
+		return nil, nil
+	}
+	parsed := make(map[string]mappedImport, len(mapping))
+	for doc, spec := range mapping {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+		imp := mappedImport{path: spec}
+		if name, importPath, ok := strings.Cut(spec, " "); ok {
+			imp.name, imp.path = name, strings.TrimSpace(importPath)
+			if !token.IsIdentifier(imp.name) {
+				return nil, fmt.Errorf("import mapping for %q: package name %q is not a Go identifier", doc, imp.name)
+			}
+		} else {
+			imp.name = importPackageName(spec)
+			if !token.IsIdentifier(imp.name) {
+				return nil, fmt.Errorf("import mapping for %q: package name %q derived from %q is not a Go identifier; precede the import path with a package name and a space", doc, imp.name, spec)
+			}
+		}
+		parsed[cleanDocumentPath(doc)] = imp
+	}
+	return parsed, nil
+}
+
+// majorVersionSuffix matches the major version element of a module path.
+var majorVersionSuffix = regexp.MustCompile(`^v[0-9]+$`)
+
+// importPackageName returns the package name an import path is expected to
+// declare: its last element, or the one before it when the last is a major
+// version suffix such as v2.
+func importPackageName(importPath string) string {
+	name := path.Base(importPath)
+	if dir := path.Dir(importPath); majorVersionSuffix.MatchString(name) && dir != "." {
+		name = path.Base(dir)
+	}
+	return name
+}
+
+// cleanDocumentPath normalizes the document part of a $ref so that
+// "./common.yaml" and "common.yaml" match the same mapping.
+func cleanDocumentPath(doc string) string {
+	if strings.Contains(doc, "://") {
+		return doc
+	}
+	return path.Clean(doc)
+}
+
+// resolveMappedRef returns the qualified Go type for a $ref into an external
+// document with an import mapping, and records that the import is used.
+func (b *baseCodeGenerator) resolveMappedRef(ref string) (string, bool) {
+	doc, fragment, found := strings.Cut(ref, "#")
+	if !found || doc == "" || len(b.importMapping) == 0 {
+		return "", false
+	}
+	imp, ok := b.importMapping[cleanDocumentPath(doc)]
+	if !ok {
+		return "", false
+	}
+	name := fragment[strings.LastIndex(fragment, "/")+1:]
+	if b.usedImports == nil {
+		b.usedImports = make(map[mappedImport]bool)
+	}
+	b.usedImports[imp] = true
+	return imp.name + "." + toTypeName(name), true
+}
+
+// unqualifiedTypeName strips the package qualifier from a type name, giving
+// a name usable as a struct field.
+func unqualifiedTypeName(typeName string) string {
+	return typeName[strings.LastIndex(typeName, ".")+1:]
+}
+
+// addMappedImports adds the imports of mapped external types to each
+// generated Go file that refers to them. It runs after formatting, since the
+// formatter cannot find packages outside the module being generated, and
+// replaces any import it guessed for the same package name.
+func (b *baseCodeGenerator) addMappedImports() {
+	if len(b.usedImports) == 0 {
+		return
+	}
+	for i := range b.result.Files {
+		file := &b.result.Files[i]
+		if !strings.HasSuffix(file.Name, ".go") {
+			continue
+		}
+		content, err := addImportsToSource(file.Content, b.usedImports)
+		if err != nil {
+			b.addIssue(file.Name, "failed to add mapped imports: "+err.Error(), SeverityWarning)
+			continue
+		}
+		file.Content = content
+	}
+}
+
+// addImportsToSource adds each import in imports whose package name src uses
+// but does not import, and rewrites an existing import of that name that
+// points elsewhere.
+func addImportsToSource(src []byte, imports map[mappedImport]bool) ([]byte, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "generated.go", src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	changed := false
+	for imp := range imports {
+		if existing := importWithName(f, imp.name); existing != "" {
+			if existing != imp.path {
+				changed = astutil.RewriteImport(fset, f, existing, imp.path) || changed
+			}
+			continue
+		}
+		if !usesUnresolved(f, imp.name) {
+			continue
+		}
+		if imp.name == importPackageName(imp.path) {
+			astutil.AddImport(fset, f, imp.path)
+		} else {
+			astutil.AddNamedImport(fset, f, imp.name, imp.path)
+		}
+		changed = true
+	}
+	if !changed {
+		return src, nil
+	}
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, f); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// importWithName returns the path f imports under the package name name, or
+// "" if it has no such import.
+func importWithName(f *ast.File, name string) string {
+	for _, spec := range f.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		if spec.Name != nil {
+			if spec.Name.Name == name {
+				return importPath
+			}
+			continue
+		}
+		if importPackageName(importPath) == name {
+			return importPath
+		}
+	}
+	return ""
+}
+
+// usesUnresolved reports whether f refers to name without declaring it.
+func usesUnresolved(f *ast.File, name string) bool {
+	for _, ident := range f.Unresolved {
+		if ident.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,214 @@
+package generator
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/erraggy/oastools/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const commonSpec = `openapi: 3.0.3
+info:
+  title: Common
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Error:
+      type: object
+      required: [message]
+      properties:
+        message:
+          type: string
+    Page:
+      type: object
+      properties:
+        next:
+          type: string
+`
+
+const mappedSpec = `openapi: 3.0.3
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/PetList'
+        default:
+          description: Error
+          content:
+            application/json:
+              schema:
+                $ref: './common.yaml#/components/schemas/Error'
+components:
+  schemas:
+    PetList:
+      type: object
+      properties:
+        page:
+          $ref: 'common.yaml#/components/schemas/Page'
+        items:
+          type: array
+          items:
+            type: string
+    Result:
+      oneOf:
+        - $ref: '#/components/schemas/PetList'
+        - $ref: 'common.yaml#/components/schemas/Error'
+      discriminator:
+        propertyName: kind
+        mapping:
+          list: '#/components/schemas/PetList'
+          error: 'common.yaml#/components/schemas/Error'
+`
+
+func TestParseImportMapping(t *testing.T) {
+	mapping, err := parseImportMapping(map[string]string{
+		"./common.yaml":   "github.com/acme/apis/common",
+		"shared/v2.yaml":  "sharedv2 github.com/acme/apis/shared/v2",
+		"billing.yaml":    "github.com/acme/apis/billing/v3",
+		"blank.yaml":      " ",
+		"https://x/a.yml": "github.com/acme/a",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]mappedImport{
+		"common.yaml":     {name: "common", path: "github.com/acme/apis/common"},
+		"shared/v2.yaml":  {name: "sharedv2", path: "github.com/acme/apis/shared/v2"},
+		"billing.yaml":    {name: "billing", path: "github.com/acme/apis/billing/v3"},
+		"https://x/a.yml": {name: "a", path: "github.com/acme/a"},
+	}, mapping)
+
+	mapping, err = parseImportMapping(nil)
+	require.NoError(t, err)
+	assert.Nil(t, mapping)
+}
+
+func TestParseImportMapping_InvalidPackageName(t *testing.T) {
+	_, err := parseImportMapping(map[string]string{"common.yaml": "github.com/acme/go-common"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `package name "go-common" derived from "github.com/acme/go-common" is not a Go identifier`)
+
+	_, err = parseImportMapping(map[string]string{"common.yaml": "go-common github.com/acme/go-common"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `package name "go-common" is not a Go identifier`)
+
+	mapping, err := parseImportMapping(map[string]string{"common.yaml": "common github.com/acme/go-common"})
+	require.NoError(t, err)
+	assert.Equal(t, mappedImport{name: "common", path: "github.com/acme/go-common"}, mapping["common.yaml"])
+
+	parsed, err := parser.New().ParseBytes([]byte(mappedSpec))
+	require.NoError(t, err)
+	_, err = GenerateWithOptions(
+		WithParsed(*parsed),
+		WithReadme(false),
+		WithImportMapping(map[string]string{"common.yaml": "github.com/acme/go-common"}),
+	)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "generator: import mapping for \"common.yaml\"")
+}
+
+func TestImportPackageName(t *testing.T) {
+	assert.Equal(t, "common", importPackageName("github.com/acme/common"))
+	assert.Equal(t, "common", importPackageName("github.com/acme/common/v2"))
+	assert.Equal(t, "v2", importPackageName("v2"))
+	assert.Equal(t, "version", importPackageName("github.com/acme/version"))
+}
+
+func TestImportMapping_QualifiesExternalTypes(t *testing.T) {
+	result := generateFromSpec(t, mappedSpec,
+		WithPackageName("pets"),
+		WithClient(true),
+		WithImportMapping(map[string]string{"common.yaml": "example.com/apis/common"}),
+	)
+
+	types := result.GetFile("types.go")
+	require.NotNil(t, types)
+	content := string(types.Content)
+	assert.Contains(t, content, `"example.com/apis/common"`)
+	assert.Regexp(t, `Page\s+\*common\.Page\s+`, content)
+	assert.NotContains(t, content, "type Error struct")
+	assert.Regexp(t, `Error\s+\*common\.Error\s+`+"`"+`json:"-"`+"`", content)
+	assert.Contains(t, content, "u.Error = new(common.Error)")
+
+	// Files that don't use a mapped type don't import its package
+	client := result.GetFile("client.go")
+	require.NotNil(t, client)
+	assert.NotContains(t, string(client.Content), `"example.com/apis/common"`)
+}
+
+func TestImportMapping_Unmapped(t *testing.T) {
+	result := generateFromSpec(t, mappedSpec, WithPackageName("pets"))
+
+	content := string(result.GetFile("types.go").Content)
+	assert.NotContains(t, content, "common.")
+	assert.Regexp(t, `Page\s+\*Page\s+`, content)
+}
+
+func TestAddImportsToSource(t *testing.T) {
+	imports := map[mappedImport]bool{
+		{name: "common", path: "example.com/apis/common"}:        true,
+		{name: "shared", path: "example.com/apis/shared/v2"}:     true,
+		{name: "unused", path: "example.com/apis/unused"}:        true,
+		{name: "guessed", path: "example.com/apis/guessed/real"}: true,
+	}
+	src := []byte(`package api
+
+import "example.com/wrong/guessed/v2"
+
+type T struct {
+	A common.Error
+	B shared.Page
+	C guessed.Thing
+}
+`)
+	out, err := addImportsToSource(src, imports)
+	require.NoError(t, err)
+	content := string(out)
+	assert.Contains(t, content, `"example.com/apis/common"`)
+	assert.Contains(t, content, `"example.com/apis/shared/v2"`)
+	assert.NotContains(t, content, `shared "example.com/apis/shared/v2"`)
+	assert.Contains(t, content, `"example.com/apis/guessed/real"`)
+	assert.NotContains(t, content, "example.com/wrong/guessed")
+	assert.NotContains(t, content, "unused")
+}
+
+// TestGeneratedImportMappingCompiles generates a shared package under a
+// major version path and a package that maps its $refs to it, and builds both
+// in one module.
+func TestGeneratedImportMappingCompiles(t *testing.T) {
+	moduleDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(moduleDir, "go.mod"), []byte("module example.com/apis\n\ngo 1.25\n"), 0644))
+
+	generate := func(spec, dir string, opts ...Option) {
+		result := generateFromSpec(t, spec, opts...)
+		dir = filepath.Join(moduleDir, filepath.FromSlash(dir))
+		require.NoError(t, os.MkdirAll(dir, 0755))
+		for _, file := range result.Files {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, file.Name), file.Content, 0644))
+		}
+	}
+	generate(commonSpec, "common/v2", WithPackageName("common"))
+	generate(mappedSpec, "pets",
+		WithPackageName("pets"),
+		WithClient(true),
+		WithServer(true),
+		WithImportMapping(map[string]string{"common.yaml": "example.com/apis/common/v2"}),
+	)
+
+	cmd := exec.Command("go", "build", "./...")
+	cmd.Dir = moduleDir
+	output, err := cmd.CombinedOutput()
+	assert.NoError(t, err, "generated packages should build.\nOutput:\n%s", string(output))
+}
@@ -67,7 +67,7 @@ func (cg *oas2CodeGenerator) collectSchemas() []oas2SchemaEntry {
 				continue
 			}
 			// Check for duplicate type names (e.g., "user_profile" and "UserProfile" both become "UserProfile")
-			typeName := toTypeName(name)
+			typeName := schemaTypeName(name, schema)
 			if cg.generatedTypes[typeName] {
 				cg.addIssue(fmt.Sprintf("definitions.%s", name),
 					fmt.Sprintf("duplicate type name %s - skipping", typeName), SeverityWarning)
@@ -108,6 +108,7 @@ func (cg *oas2CodeGenerator) generateSingleTypes() error {
 			imports["time"] = true
 		}
 		addTupleImports(entry.schema, imports)
+		addGoTypeImports(entry.schema, imports)
 	}
 
 	// Write imports
@@ -119,7 +120,7 @@ func (cg *oas2CodeGenerator) generateSingleTypes() error {
 		}
 		sort.Strings(importList)
 		for _, imp := range importList {
-			fmt.Fprintf(&buf, "\t%q\n", imp)
+			fmt.Fprintf(&buf, "\t%s\n", importSpec(imp))
 		}
 		buf.WriteString(")\n\n")
 	}
@@ -181,7 +182,7 @@ func (cg *oas2CodeGenerator) generateOAS2TypesFile(fileName, comment string, all
 	// Build filtered types list (pre-allocate with reasonable capacity)
 	filteredSchemas := make([]oas2SchemaEntry, 0, len(includeTypes))
 	for _, entry := range allSchemas {
-		typeName := toTypeName(entry.name)
+		typeName := schemaTypeName(entry.name, entry.schema)
 		if includeTypes[typeName] || includeTypes[entry.name] {
 			filteredSchemas = append(filteredSchemas, entry)
 		}
@@ -208,6 +209,7 @@ func (cg *oas2CodeGenerator) generateOAS2TypesFile(fileName, comment string, all
 			imports["time"] = true
 		}
 		addTupleImports(entry.schema, imports)
+		addGoTypeImports(entry.schema, imports)
 	}
 
 	// Write imports, sorted so the generated file does not depend on map order.
@@ -219,7 +221,7 @@ func (cg *oas2CodeGenerator) generateOAS2TypesFile(fileName, comment string, all
 		}
 		sort.Strings(importList)
 		for _, imp := range importList {
-			fmt.Fprintf(&buf, "\t%q\n", imp)
+			fmt.Fprintf(&buf, "\t%s\n", importSpec(imp))
 		}
 		buf.WriteString(")\n\n")
 	}
@@ -251,7 +253,14 @@ type oas2SchemaEntry struct {
 func (cg *oas2CodeGenerator) generateSchemaType(name string, schema *parser.Schema) (string, error) {
 	var buf bytes.Buffer
 
-	typeName := toTypeName(name)
+	typeName := schemaTypeName(name, schema)
+
+	// x-go-type maps the schema onto an existing Go type
+	if goType := goTypeOverride(schema); goType != "" {
+		fmt.Fprintf(&buf, "// %s is an alias for %s.\n", typeName, goType)
+		fmt.Fprintf(&buf, "type %s = %s\n", typeName, goType)
+		return buf.String(), nil
+	}
 
 	// Handle $ref
 	if schema.Ref != "" {
@@ -276,7 +285,7 @@ func (cg *oas2CodeGenerator) generateSchemaType(name string, schema *parser.Sche
 		// Generate struct
 		fmt.Fprintf(&buf, "type %s struct {\n", typeName)
 		if schema.Properties != nil {
-			cg.writeStructFields(&buf, schema, typeName, true)
+			cg.writeStructFields(&buf, schema, toTypeName(name), true)
 		}
 		buf.WriteString("}\n")
 
@@ -328,7 +337,7 @@ func (cg *oas2CodeGenerator) generateSchemaType(name string, schema *parser.Sche
 	default:
 		// Handle allOf
 		if len(schema.AllOf) > 0 {
-			return cg.generateAllOfType(typeName, schema)
+			return cg.generateAllOfType(typeName, name, schema)
 		}
 		// Default to any
 		fmt.Fprintf(&buf, "type %s = any\n", typeName)
@@ -338,7 +347,7 @@ func (cg *oas2CodeGenerator) generateSchemaType(name string, schema *parser.Sche
 }
 
 // generateAllOfType generates a type for allOf composition
-func (cg *oas2CodeGenerator) generateAllOfType(typeName string, schema *parser.Schema) (string, error) {
+func (cg *oas2CodeGenerator) generateAllOfType(typeName, name string, schema *parser.Schema) (string, error) {
 	var buf bytes.Buffer
 
 	fmt.Fprintf(&buf, "// %s combines multiple schemas.\n", typeName)
@@ -350,7 +359,7 @@ func (cg *oas2CodeGenerator) generateAllOfType(typeName string, schema *parser.S
 			fmt.Fprintf(&buf, "\t%s\n", refType)
 		} else if subSchema.Properties != nil {
 			// Inline properties (no description comments for allOf inline properties)
-			cg.writeStructFields(&buf, subSchema, typeName, false)
+			cg.writeStructFields(&buf, subSchema, toTypeName(name), false)
 		}
 	}
 
@@ -359,8 +368,10 @@ func (cg *oas2CodeGenerator) generateAllOfType(typeName string, schema *parser.S
 }
 
 // writeStructFields writes struct fields for properties in sorted order.
-// includeDescription controls whether to emit field description comments.
-func (cg *oas2CodeGenerator) writeStructFields(buf *bytes.Buffer, schema *parser.Schema, typeName string, includeDescription bool) {
+// refTypeName is the type name $refs to the enclosing schema derive, used to
+// detect recursion. includeDescription controls whether to emit field
+// description comments.
+func (cg *oas2CodeGenerator) writeStructFields(buf *bytes.Buffer, schema *parser.Schema, refTypeName string, includeDescription bool) {
 	propNames := make([]string, 0, len(schema.Properties))
 	for propName := range schema.Properties {
 		propNames = append(propNames, propName)
@@ -373,19 +384,20 @@ func (cg *oas2CodeGenerator) writeStructFields(buf *bytes.Buffer, schema *parser
 			continue
 		}
 
-		goType := cg.schemaToGoType(propSchema, isRequired(schema.Required, propName))
+		selfRef := isSelfReference(propSchema, refTypeName)
+		goType := cg.fieldGoType(propSchema, isRequired(schema.Required, propName), selfRef, cg.schemaToGoType)
 
 		// Check for self-reference (recursive type) - needs pointer indirection
-		if isSelfReference(propSchema, typeName) &&
+		if selfRef &&
 			!strings.HasPrefix(goType, "*") &&
 			!strings.HasPrefix(goType, "[]") {
 			goType = "*" + goType
 		}
 
-		fieldName := toFieldName(propName)
-		jsonTag := propName
-		if !isRequired(schema.Required, propName) {
-			jsonTag += ",omitempty"
+		fieldName := goFieldName(propName, propSchema)
+		jsonTag := jsonTagValue(propName, propSchema, isRequired(schema.Required, propName))
+		if wrapper, _ := optionalWrapper(goType); wrapper != "" {
+			jsonTag = optionalJSONTag(jsonTag)
 		}
 
 		if includeDescription && propSchema.Description != "" {
@@ -432,8 +444,12 @@ func (cg *oas2CodeGenerator) generateSingleClient() error {
 	buf.WriteString("\t\"encoding/json\"\n")
 	buf.WriteString("\t\"fmt\"\n")
 	buf.WriteString("\t\"io\"\n")
+	buf.WriteString("\t\"iter\"\n")
+	buf.WriteString("\t\"math/rand/v2\"\n")
 	buf.WriteString("\t\"net/http\"\n")
 	buf.WriteString("\t\"net/url\"\n")
+	buf.WriteString("\t\"slices\"\n")
+	buf.WriteString("\t\"strconv\"\n")
 	buf.WriteString("\t\"strings\"\n")
 	buf.WriteString(")\n\n")
 
@@ -525,6 +541,7 @@ func (cg *oas2CodeGenerator) generateOAS2ClientGroupFile(group FileGroup, opToPa
 	buf.WriteString("\t\"encoding/json\"\n")
 	buf.WriteString("\t\"fmt\"\n")
 	buf.WriteString("\t\"io\"\n")
+	buf.WriteString("\t\"iter\"\n")
 	buf.WriteString("\t\"net/http\"\n")
 	buf.WriteString("\t\"net/url\"\n")
 	buf.WriteString("\t\"strings\"\n")
@@ -593,6 +610,19 @@ func (cg *oas2CodeGenerator) generateClientMethod(path, method string, op *parse
 
 	writeClientMethod(&buf, op, methodName, method, path, params, pathParams, queryParams,
 		hasBody, contentType, responseType, cg.paramToGoType)
+	cg.writeClientIterator(&buf, op, clientIteratorData{
+		location:       fmt.Sprintf("paths.%s.%s", path, method),
+		methodName:     methodName,
+		path:           path,
+		params:         params,
+		pathParams:     pathParams,
+		queryParams:    queryParams,
+		hasBody:        hasBody,
+		responseType:   responseType,
+		respSchema:     cg.successResponseSchema(op),
+		paramToGoType:  cg.paramToGoType,
+		schemaToGoType: cg.schemaToGoType,
+	})
 
 	return buf.String(), nil
 }
@@ -656,6 +686,7 @@ func (cg *oas2CodeGenerator) generateSingleServer() error {
 		needsTime:               false, // OAS 2.0 doesn't support date-time in the same way
 		result:                  cg.result,
 		addIssue:                cg.addIssue,
+		strict:                  cg.g.ServerStrict,
 		generateMethodSignature: cg.generateServerMethodSignature,
 		getResponseType:         cg.getResponseType,
 		generateRequestTypes:    cg.writeRequestTypes,
@@ -721,6 +752,7 @@ func (cg *oas2CodeGenerator) generateOAS2BaseServer() (map[string]bool, error) {
 		schemaTypes:             cg.generatedTypes,
 		result:                  cg.result,
 		addIssue:                cg.addIssue,
+		strict:                  cg.g.ServerStrict,
 		generateMethodSignature: cg.generateServerMethodSignature,
 		getResponseType:         cg.getResponseType,
 	})
@@ -728,7 +760,7 @@ func (cg *oas2CodeGenerator) generateOAS2BaseServer() (map[string]bool, error) {
 
 // generateServerMethodSignature generates the interface method signature
 func (cg *oas2CodeGenerator) generateServerMethodSignature(path, method string, op *parser.Operation) string {
-	return buildServerMethodSignature(path, method, op, cg.getResponseType(op), cg.generatedTypes)
+	return buildServerMethodSignature(path, method, op, cg.serverResponseType(path, method, op, cg.getResponseType), cg.generatedTypes)
 }
 
 // generateRequestType generates a request struct for an operation
@@ -864,7 +896,7 @@ func (cg *oas2CodeGenerator) generateReadmeFile(schemes map[string]*parser.Secur
 	content := g.GenerateReadme(ctx)
 
 	cg.result.Files = append(cg.result.Files, GeneratedFile{
-		Name:    "README.md",
+		Name:    readmeFileName,
 		Content: []byte(content),
 	})
 }
@@ -885,6 +917,9 @@ var oas2HttpMethods = []string{
 func (cg *oas2CodeGenerator) buildStatusCodeData(code string, resp *parser.Response) StatusCodeData {
 	// Parse status code metadata using shared helper
 	statusData := parseStatusCodeMetadata(code)
+	if name, ok := strings.CutPrefix(resp.Ref, pathutil.RefPrefixResponses); ok && cg.doc.Responses[name] != nil {
+		resp = cg.doc.Responses[name]
+	}
 	statusData.Description = resp.Description
 
 	// OAS 2.0: Response has direct Schema field (not Content map)
@@ -894,6 +929,10 @@ func (cg *oas2CodeGenerator) buildStatusCodeData(code string, resp *parser.Respo
 		statusData.BodyType = cg.schemaToGoType(resp.Schema, true)
 	}
 
+	statusData.Headers = buildResponseHeaders(resp.Headers, func(header *parser.Header) string {
+		return headerGoType(header.Type, header.Format)
+	})
+
 	return statusData
 }
 
@@ -985,6 +1024,7 @@ func (cg *oas2CodeGenerator) generateServerRouter() error {
 		httpMethods:  oas2HttpMethods,
 		packageName:  cg.result.PackageName,
 		serverRouter: cg.g.ServerRouter,
+		strict:       cg.g.ServerStrict,
 		schemaTypes:  cg.generatedTypes,
 		result:       cg.result,
 		addIssue:     cg.addIssue,
@@ -999,3 +1039,8 @@ func (cg *oas2CodeGenerator) generateServerRouter() error {
 		},
 	})
 }
+
+// generateWebhooks is a no-op: OAS 2.0 has no webhooks or callbacks.
+func (cg *oas2CodeGenerator) generateWebhooks() error {
+	return nil
+}
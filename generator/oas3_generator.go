@@ -114,7 +114,7 @@ func (cg *oas3CodeGenerator) generateTypesFile(fileName, comment string, allSche
 	// Build filtered types list (pre-allocate with reasonable capacity)
 	filteredSchemas := make([]schemaEntry, 0, len(includeTypes))
 	for _, entry := range allSchemas {
-		typeName := toTypeName(entry.name)
+		typeName := schemaTypeName(entry.name, entry.schema)
 		if includeTypes[typeName] || includeTypes[entry.name] {
 			filteredSchemas = append(filteredSchemas, entry)
 		}
@@ -155,6 +155,7 @@ func (cg *oas3CodeGenerator) buildTypesFileDataForSchemas(schemas []schemaEntry,
 		if hasDiscriminator(entry.schema) {
 			imports["encoding/json"] = true
 		}
+		addGoTypeImports(entry.schema, imports)
 	}
 
 	importList := make([]string, 0, len(imports))
@@ -198,7 +199,7 @@ func (cg *oas3CodeGenerator) collectSchemas() []schemaEntry {
 			continue
 		}
 		// Check for duplicate type names (e.g., "user_profile" and "UserProfile" both become "UserProfile")
-		typeName := toTypeName(name)
+		typeName := schemaTypeName(name, schema)
 		if cg.generatedTypes[typeName] {
 			cg.addIssue(fmt.Sprintf("components.schemas.%s", name),
 				fmt.Sprintf("duplicate type name %s - skipping", typeName), SeverityWarning)
@@ -341,8 +342,12 @@ func (cg *oas3CodeGenerator) generateSingleClient() error {
 	buf.WriteString("\t\"encoding/json\"\n")
 	buf.WriteString("\t\"fmt\"\n")
 	buf.WriteString("\t\"io\"\n")
+	buf.WriteString("\t\"iter\"\n")
+	buf.WriteString("\t\"math/rand/v2\"\n")
 	buf.WriteString("\t\"net/http\"\n")
 	buf.WriteString("\t\"net/url\"\n")
+	buf.WriteString("\t\"slices\"\n")
+	buf.WriteString("\t\"strconv\"\n")
 	buf.WriteString("\t\"strings\"\n")
 	// time is always needed: writeClientBoilerplate emits a default HTTP timeout using time.Second.
 	buf.WriteString("\t\"time\"\n")
@@ -481,6 +486,7 @@ func (cg *oas3CodeGenerator) generateClientGroupFile(group FileGroup, opToPathMe
 	}
 	buf.WriteString("\t\"fmt\"\n")
 	buf.WriteString("\t\"io\"\n")
+	buf.WriteString("\t\"iter\"\n")
 	buf.WriteString("\t\"net/http\"\n")
 	if needsURL {
 		buf.WriteString("\t\"net/url\"\n")
@@ -543,11 +549,26 @@ func (cg *oas3CodeGenerator) generateClientMethod(path, method string, op *parse
 		contentType = cg.getRequestBodyContentType(op.RequestBody)
 	}
 
-	// Generate method using shared helpers
-	responseType := cg.getResponseType(op)
+	// Generate method using shared helpers. The iterator reads the same
+	// media type the response is typed with.
+	respMediaType := successMediaType(op)
+	responseType := cg.responseGoType(respMediaType)
 
 	writeClientMethod(&buf, op, methodName, method, path, params, pathParams, queryParams,
 		hasBody, contentType, responseType, cg.paramToGoType)
+	cg.writeClientIterator(&buf, op, clientIteratorData{
+		location:       fmt.Sprintf("paths.%s.%s", path, method),
+		methodName:     methodName,
+		path:           path,
+		params:         params,
+		pathParams:     pathParams,
+		queryParams:    queryParams,
+		hasBody:        hasBody,
+		responseType:   responseType,
+		respSchema:     cg.successResponseSchema(respMediaType),
+		paramToGoType:  cg.paramToGoType,
+		schemaToGoType: cg.schemaToGoType,
+	})
 
 	return buf.String(), nil
 }
@@ -593,44 +614,41 @@ func (cg *oas3CodeGenerator) getRequestBodyContentType(rb *parser.RequestBody) s
 	return "application/json"
 }
 
-// findJSONSchemaType searches a content map for a JSON schema and returns its Go type.
-// Returns the type string and true if found, or empty string and false if not found.
-func (cg *oas3CodeGenerator) findJSONSchemaType(content map[string]*parser.MediaType) (string, bool) {
-	for contentType, mediaType := range content {
-		if strings.Contains(contentType, "json") && mediaType != nil && mediaType.Schema != nil {
-			goType := cg.schemaToGoType(mediaType.Schema, true)
-			if !strings.HasPrefix(goType, "*") && !strings.HasPrefix(goType, "[]") && !strings.HasPrefix(goType, "map") {
-				return "*" + goType, true
-			}
-			return goType, true
+// successMediaType returns the media type of the success response: the JSON
+// media type selectMediaType picks from the first of the 200, 201, 2XX and
+// default responses that has one with a schema.
+func successMediaType(op *parser.Operation) *parser.MediaType {
+	if op.Responses == nil {
+		return nil
+	}
+	for _, resp := range []*parser.Response{op.Responses.Codes["200"], op.Responses.Codes["201"], op.Responses.Codes["2XX"], op.Responses.Default} {
+		if resp == nil {
+			continue
+		}
+		contentType, mediaType, ok := selectMediaType(resp.Content)
+		if ok && strings.Contains(contentType, "json") && mediaType != nil && mediaType.Schema != nil {
+			return mediaType
 		}
 	}
-	return "", false
+	return nil
 }
 
-// getResponseType determines the Go type for the success response
-func (cg *oas3CodeGenerator) getResponseType(op *parser.Operation) string {
-	if op.Responses == nil {
+// responseGoType returns the Go type of a success response with the given
+// media type, or httpResponseType when there is none.
+func (cg *oas3CodeGenerator) responseGoType(mediaType *parser.MediaType) string {
+	if mediaType == nil {
 		return httpResponseType
 	}
-
-	// Check for 200, 201, 2XX responses
-	for _, code := range []string{"200", "201", "2XX"} {
-		if resp := op.Responses.Codes[code]; resp != nil {
-			if goType, found := cg.findJSONSchemaType(resp.Content); found {
-				return goType
-			}
-		}
-	}
-
-	// Check default response
-	if op.Responses.Default != nil {
-		if goType, found := cg.findJSONSchemaType(op.Responses.Default.Content); found {
-			return goType
-		}
+	goType := cg.schemaToGoType(mediaType.Schema, true)
+	if !strings.HasPrefix(goType, "*") && !strings.HasPrefix(goType, "[]") && !strings.HasPrefix(goType, "map") {
+		return "*" + goType
 	}
+	return goType
+}
 
-	return httpResponseType
+// getResponseType determines the Go type for the success response
+func (cg *oas3CodeGenerator) getResponseType(op *parser.Operation) string {
+	return cg.responseGoType(successMediaType(op))
 }
 
 // generateServer generates server interface code
@@ -654,6 +672,7 @@ func (cg *oas3CodeGenerator) generateSingleServer() error {
 		needsTime:               cg.operationsNeedTimeImport(),
 		result:                  cg.result,
 		addIssue:                cg.addIssue,
+		strict:                  cg.g.ServerStrict,
 		generateMethodSignature: cg.generateServerMethodSignature,
 		getResponseType:         cg.getResponseType,
 		generateRequestTypes:    cg.writeRequestTypes,
@@ -719,6 +738,7 @@ func (cg *oas3CodeGenerator) generateBaseServer() (map[string]bool, error) {
 		schemaTypes:             cg.generatedTypes,
 		result:                  cg.result,
 		addIssue:                cg.addIssue,
+		strict:                  cg.g.ServerStrict,
 		generateMethodSignature: cg.generateServerMethodSignature,
 		getResponseType:         cg.getResponseType,
 	})
@@ -726,7 +746,7 @@ func (cg *oas3CodeGenerator) generateBaseServer() (map[string]bool, error) {
 
 // generateServerMethodSignature generates the interface method signature
 func (cg *oas3CodeGenerator) generateServerMethodSignature(path, method string, op *parser.Operation) string {
-	return buildServerMethodSignature(path, method, op, cg.getResponseType(op), cg.generatedTypes)
+	return buildServerMethodSignature(path, method, op, cg.serverResponseType(path, method, op, cg.getResponseType), cg.generatedTypes)
 }
 
 // generateRequestType generates a request struct for an operation
@@ -885,7 +905,7 @@ type APIError struct {
 func (e *APIError) Error() string {
 	return fmt.Sprintf("API error: status %d: %s", e.StatusCode, string(e.Body))
 }
-`
+` + clientRetryHelpers + clientPageHelpers
 
 // generateSecurityHelpers generates security helper code based on configuration
 func (cg *oas3CodeGenerator) generateSecurityHelpers() {
@@ -947,7 +967,7 @@ func (cg *oas3CodeGenerator) generateReadmeFile(schemes map[string]*parser.Secur
 	content := g.GenerateReadme(ctx)
 
 	cg.result.Files = append(cg.result.Files, GeneratedFile{
-		Name:    "README.md",
+		Name:    readmeFileName,
 		Content: []byte(content),
 	})
 }
@@ -969,6 +989,10 @@ func getFileDescription(name string) string {
 		return "Security enforcement and validation"
 	case "oidc_discovery.go":
 		return "OpenID Connect discovery client"
+	case fileNameWebhookReceiver:
+		return "Webhook receiver interface and router"
+	case fileNameEventSenders:
+		return "Webhook and callback senders"
 	default:
 		if strings.HasPrefix(name, "oauth2_") {
 			return "OAuth2 token flow management"
@@ -1119,23 +1143,70 @@ func (cg *oas3CodeGenerator) getSchemaType(schema *parser.Schema) string {
 func (cg *oas3CodeGenerator) buildStatusCodeData(code string, resp *parser.Response) StatusCodeData {
 	// Parse status code metadata using shared helper
 	statusData := parseStatusCodeMetadata(code)
+	resp = cg.resolveResponse(resp)
 	statusData.Description = resp.Description
 
-	// OAS 3.x: Determine body type from response content map
-	if resp.Content != nil {
-		for contentType, mediaType := range resp.Content {
-			if mediaType != nil && mediaType.Schema != nil {
-				statusData.HasBody = true
-				statusData.ContentType = contentType
-				statusData.BodyType = cg.schemaToGoType(mediaType.Schema, true)
-				break // Use first content type
-			}
+	// OAS 3.x: Determine body type from response content map, preferring JSON
+	if contentType, mediaType, ok := selectMediaType(resp.Content); ok {
+		statusData.ContentType = contentType
+		if mediaType != nil && mediaType.Schema != nil {
+			statusData.HasBody = true
+			statusData.BodyType = cg.schemaToGoType(mediaType.Schema, true)
 		}
 	}
 
+	statusData.Headers = buildResponseHeaders(resp.Headers, func(header *parser.Header) string {
+		header = cg.resolveHeader(header)
+		if header.Schema == nil {
+			return "string"
+		}
+		return headerGoType(getSchemaType(header.Schema), header.Schema.Format)
+	})
+
 	return statusData
 }
 
+// resolveResponse follows a reference into components.responses, returning
+// resp itself when it is not a resolvable reference.
+func (cg *oas3CodeGenerator) resolveResponse(resp *parser.Response) *parser.Response {
+	if resp.Ref == "" || cg.doc.Components == nil {
+		return resp
+	}
+	name, ok := strings.CutPrefix(resp.Ref, pathutil.RefPrefixResponses3)
+	if target := cg.doc.Components.Responses[name]; ok && target != nil {
+		return target
+	}
+	return resp
+}
+
+// resolveHeader follows a reference into components.headers, returning
+// header itself when it is not a resolvable reference.
+func (cg *oas3CodeGenerator) resolveHeader(header *parser.Header) *parser.Header {
+	if header.Ref == "" || cg.doc.Components == nil {
+		return header
+	}
+	name, ok := strings.CutPrefix(header.Ref, pathutil.RefPrefixHeaders)
+	if target := cg.doc.Components.Headers[name]; ok && target != nil {
+		return target
+	}
+	return header
+}
+
+// selectMediaType returns the media type a response is written as: the first
+// JSON media type in sorted order, or else the first media type.
+func selectMediaType(content map[string]*parser.MediaType) (string, *parser.MediaType, bool) {
+	if len(content) == 0 {
+		return "", nil, false
+	}
+	contentTypes := maputil.SortedKeys(content)
+	for _, contentType := range contentTypes {
+		if strings.Contains(contentType, "json") {
+			return contentType, content[contentType], true
+		}
+	}
+	return contentTypes[0], content[contentTypes[0]], true
+}
+
 // generateServerRouter generates HTTP router code
 func (cg *oas3CodeGenerator) generateServerRouter() error {
 	return generateServerRouterShared(&serverRouterContext{
@@ -1144,6 +1215,7 @@ func (cg *oas3CodeGenerator) generateServerRouter() error {
 		httpMethods:  httpMethods,
 		packageName:  cg.result.PackageName,
 		serverRouter: cg.g.ServerRouter,
+		strict:       cg.g.ServerStrict,
 		schemaTypes:  cg.generatedTypes,
 		result:       cg.result,
 		addIssue:     cg.addIssue,
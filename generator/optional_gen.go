@@ -0,0 +1,205 @@
+// This file implements the generic Optional and Nullable field types, which
+// keep "absent", "null", and "value" apart where pointers cannot.
+
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/erraggy/oastools/parser"
+)
+
+// fileNameOptional is the file holding the Optional and Nullable types.
+const fileNameOptional = "optional.go"
+
+// optionalRuntimeNames names the declarations of the optional runtime.
+var optionalRuntimeNames = []string{"Optional", "Nullable", "OptionalOf", "NullableOf", "NullOf"}
+
+// optionalTypesEnabled reports whether struct fields use the Optional and
+// Nullable wrappers. It is decided on first use, once collectSchemas has
+// named every schema type, and is off when a schema type would collide with
+// the runtime.
+func (b *baseCodeGenerator) optionalTypesEnabled() bool {
+	if !b.g.UseOptionalTypes {
+		return false
+	}
+	if !b.optionalTypesChecked {
+		b.optionalTypesChecked = true
+		b.optionalTypes = true
+		for _, name := range optionalRuntimeNames {
+			if b.generatedTypes[name] {
+				b.addIssue(fileNameOptional, fmt.Sprintf("schema type %s conflicts with the optional types runtime - using plain field types", name), SeverityWarning)
+				b.optionalTypes = false
+				break
+			}
+		}
+	}
+	return b.optionalTypes
+}
+
+// fieldGoType returns the Go type of the struct field for a property. With
+// optional types enabled, nullable properties are Nullable[T] and optional
+// ones are Optional[T]; optional slices and maps, properties with
+// x-go-type-skip-optional-pointer, and self references (which must stay
+// pointers) keep the type schemaToGoType derives.
+func (b *baseCodeGenerator) fieldGoType(propSchema *parser.Schema, required, selfRef bool, schemaToGoType func(*parser.Schema, bool) string) string {
+	if !b.optionalTypesEnabled() || propSchema == nil || selfRef {
+		return schemaToGoType(propSchema, required)
+	}
+
+	// The value type is derived as if required and not nullable, so it
+	// carries no pointer of its own
+	valueType := b.schemaToGoTypeBase(propSchema, true, false, schemaToGoType)
+	switch {
+	case isNullableSchema(propSchema):
+		b.optionalTypesUsed = true
+		return "Nullable[" + valueType + "]"
+	case !required && !skipOptionalPointer(propSchema) &&
+		!strings.HasPrefix(valueType, "[]") && !strings.HasPrefix(valueType, "map["):
+		b.optionalTypesUsed = true
+		return "Optional[" + valueType + "]"
+	}
+	return schemaToGoType(propSchema, required)
+}
+
+// optionalWrapper splits a field type into the wrapper it uses, "Optional"
+// or "Nullable", and the wrapped value type. wrapper is "" for other types.
+func optionalWrapper(goType string) (wrapper, valueType string) {
+	for _, name := range []string{"Optional", "Nullable"} {
+		if inner, ok := strings.CutPrefix(goType, name+"["); ok && strings.HasSuffix(inner, "]") {
+			return name, inner[:len(inner)-1]
+		}
+	}
+	return "", goType
+}
+
+// optionalJSONTag returns the json tag value for a wrapped field: omitzero
+// takes the place of omitempty, which has no effect on structs.
+func optionalJSONTag(tag string) string {
+	if name, ok := strings.CutSuffix(tag, ",omitempty"); ok {
+		return name + ",omitzero"
+	}
+	return tag
+}
+
+// generateOptionalTypes writes optional.go when any generated field uses the
+// Optional or Nullable wrappers.
+func (b *baseCodeGenerator) generateOptionalTypes() {
+	if !b.optionalTypesUsed {
+		return
+	}
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by oastools. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", b.result.PackageName)
+	buf.WriteString(optionalRuntime)
+	appendFormattedFile(b.result, fileNameOptional, &buf, b.addIssue)
+}
+
+// optionalRuntime declares the Optional and Nullable types. It relies on the
+// omitzero tag option, so the generated code needs Go 1.24 or later.
+const optionalRuntime = `import (
+	"bytes"
+	"encoding/json"
+)
+
+// Optional is a value that may be absent. The zero Optional is absent, and
+// fields tagged omitzero leave absent values out of the JSON.
+type Optional[T any] struct {
+	Value T
+	Set   bool
+}
+
+// OptionalOf returns an Optional holding v.
+func OptionalOf[T any](v T) Optional[T] {
+	return Optional[T]{Value: v, Set: true}
+}
+
+// Get returns the value and whether it is present.
+func (o Optional[T]) Get() (T, bool) {
+	return o.Value, o.Set
+}
+
+// IsZero reports whether the value is absent.
+func (o Optional[T]) IsZero() bool {
+	return !o.Set
+}
+
+// MarshalJSON encodes the value, or null if it is absent.
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.Set {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.Value)
+}
+
+// UnmarshalJSON decodes a present value. A JSON null leaves it absent.
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*o = Optional[T]{}
+		return nil
+	}
+	if err := json.Unmarshal(data, &o.Value); err != nil {
+		return err
+	}
+	o.Set = true
+	return nil
+}
+
+// Nullable is a value that may be absent, explicitly null, or present. The
+// zero Nullable is absent, and fields tagged omitzero leave absent values
+// out of the JSON while still encoding an explicit null.
+type Nullable[T any] struct {
+	Value T
+	Set   bool // the value is present or explicitly null
+	Null  bool // the value is explicitly null
+}
+
+// NullableOf returns a Nullable holding v.
+func NullableOf[T any](v T) Nullable[T] {
+	return Nullable[T]{Value: v, Set: true}
+}
+
+// NullOf returns an explicitly null Nullable.
+func NullOf[T any]() Nullable[T] {
+	return Nullable[T]{Set: true, Null: true}
+}
+
+// Get returns the value and whether it is present and not null.
+func (n Nullable[T]) Get() (T, bool) {
+	return n.Value, n.Set && !n.Null
+}
+
+// IsNull reports whether the value is explicitly null.
+func (n Nullable[T]) IsNull() bool {
+	return n.Set && n.Null
+}
+
+// IsZero reports whether the value is absent.
+func (n Nullable[T]) IsZero() bool {
+	return !n.Set
+}
+
+// MarshalJSON encodes the value, or null if it is null or absent.
+func (n Nullable[T]) MarshalJSON() ([]byte, error) {
+	if !n.Set || n.Null {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.Value)
+}
+
+// UnmarshalJSON decodes a value or an explicit null.
+func (n *Nullable[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*n = NullOf[T]()
+		return nil
+	}
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*n = NullableOf(v)
+	return nil
+}
+`
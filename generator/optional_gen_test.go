@@ -0,0 +1,167 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/erraggy/oastools/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const optionalSpec = `openapi: 3.1.0
+info:
+  title: Optional
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    PetPatch:
+      type: object
+      required: [id, owner]
+      properties:
+        id:
+          type: integer
+        name:
+          type: string
+          minLength: 2
+        nickname:
+          type: [string, "null"]
+          maxLength: 5
+        owner:
+          type: [string, "null"]
+        tags:
+          type: array
+          items:
+            type: string
+        age:
+          type: integer
+          x-go-type-skip-optional-pointer: true
+        parent:
+          $ref: '#/components/schemas/PetPatch'
+`
+
+func TestOptionalTypes_Generated(t *testing.T) {
+	result := generateFromSpec(t, optionalSpec, WithOptionalTypes(true))
+
+	types := result.GetFile("types.go")
+	require.NotNil(t, types)
+	content := string(types.Content)
+	assert.Regexp(t, "Id +int64 +`json:\"id\" validate:\"required\"`", content)
+	assert.Regexp(t, "Name +Optional\\[string\\] +`json:\"name,omitzero\"`", content)
+	assert.Regexp(t, "Nickname +Nullable\\[string\\] +`json:\"nickname,omitzero\"`", content)
+	assert.Regexp(t, "Owner +Nullable\\[string\\] +`json:\"owner\"`", content)
+	assert.Regexp(t, "Tags +\\[\\]string", content)
+	assert.Regexp(t, "Age +int64", content)
+	// Self references stay pointers
+	assert.Regexp(t, "Parent +\\*PetPatch", content)
+
+	optional := result.GetFile("optional.go")
+	require.NotNil(t, optional)
+	assert.Contains(t, string(optional.Content), "type Nullable[T any] struct")
+	assert.Contains(t, string(optional.Content), "func NullOf[T any]() Nullable[T]")
+}
+
+func TestOptionalTypes_Disabled(t *testing.T) {
+	parsed, err := parser.New().ParseBytes([]byte(optionalSpec))
+	require.NoError(t, err)
+	result, err := GenerateWithOptions(WithParsed(*parsed), WithReadme(false))
+	require.NoError(t, err)
+	assert.Nil(t, result.GetFile("optional.go"))
+	assert.Regexp(t, "Nickname +\\*string", string(result.GetFile("types.go").Content))
+}
+
+func TestOptionalTypes_OAS2(t *testing.T) {
+	result := generateFromSpec(t, `swagger: "2.0"
+info:
+  title: Optional
+  version: 1.0.0
+paths: {}
+definitions:
+  Pet:
+    type: object
+    required: [id]
+    properties:
+      id:
+        type: integer
+      name:
+        type: string
+`, WithOptionalTypes(true))
+	content := string(result.GetFile("types.go").Content)
+	assert.Regexp(t, "Name +Optional\\[string\\] +`json:\"name,omitzero\"`", content)
+	assert.Regexp(t, "Id +int64 +`json:\"id\"`", content)
+	assert.NotNil(t, result.GetFile("optional.go"))
+}
+
+func TestOptionalTypes_RuntimeNameCollision(t *testing.T) {
+	result := generateFromSpec(t, `openapi: 3.0.3
+info:
+  title: Optional
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Optional:
+      type: object
+      properties:
+        name:
+          type: string
+`, WithOptionalTypes(true))
+	assert.Nil(t, result.GetFile("optional.go"))
+	assert.Regexp(t, "Name +\\*string", string(result.GetFile("types.go").Content))
+	require.NotEmpty(t, result.Issues)
+	assert.Contains(t, result.Issues[0].Message, "conflicts with the optional types runtime")
+}
+
+func TestOptionalWrapper(t *testing.T) {
+	wrapper, valueType := optionalWrapper("Nullable[[]Pet]")
+	assert.Equal(t, "Nullable", wrapper)
+	assert.Equal(t, "[]Pet", valueType)
+	wrapper, valueType = optionalWrapper("*Pet")
+	assert.Empty(t, wrapper)
+	assert.Equal(t, "*Pet", valueType)
+	assert.Equal(t, "name,omitzero", optionalJSONTag("name,omitempty"))
+	assert.Equal(t, "name", optionalJSONTag("name"))
+}
+
+// TestGeneratedOptionalTypesRun round-trips absent, null, and present values
+// through the generated types and checks the Validate methods see them.
+func TestGeneratedOptionalTypesRun(t *testing.T) {
+	result := generateFromSpec(t, optionalSpec, WithOptionalTypes(true), WithValidateMethods(true))
+
+	mainSrc := `package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"example.com/gen/api"
+)
+
+func main() {
+	for _, in := range []string{
+		` + "`" + `{"id":1,"owner":null}` + "`" + `,
+		` + "`" + `{"id":1,"owner":"ann","nickname":null,"name":"Rex"}` + "`" + `,
+		` + "`" + `{"id":1,"nickname":"toolong","name":"R"}` + "`" + `,
+	} {
+		var p api.PetPatch
+		if err := json.Unmarshal([]byte(in), &p); err != nil {
+			panic(err)
+		}
+		out, err := json.Marshal(p)
+		if err != nil {
+			panic(err)
+		}
+		nick, ok := p.Nickname.Get()
+		fmt.Printf("%s nickname=%q/%v/%v validate=%v\n", out, nick, ok, p.Nickname.IsNull(), p.Validate())
+	}
+	patch := api.PetPatch{Id: 2, Owner: api.NullOf[string](), Name: api.OptionalOf("Max")}
+	out, _ := json.Marshal(patch)
+	fmt.Println(string(out))
+}
+`
+	out := runGeneratedModule(t, result, mainSrc)
+	assert.Contains(t, out, `{"id":1,"owner":null} nickname=""/false/false validate=<nil>`)
+	assert.Contains(t, out, `{"id":1,"name":"Rex","nickname":null,"owner":"ann"} nickname=""/false/true validate=<nil>`)
+	assert.Contains(t, out, "validate=/name: length must be at least 2; /nickname: length must be at most 5; /owner: is required")
+	assert.Contains(t, out, `{"id":2,"name":"Max","owner":null}`)
+}
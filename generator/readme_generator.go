@@ -25,6 +25,10 @@ func NewReadmeGenerator() *ReadmeGenerator {
 	return &ReadmeGenerator{}
 }
 
+// readmeGeneratedNote opens the overview of a generated README. It marks a
+// README.md in an output directory as generated when checking for drift.
+const readmeGeneratedNote = "This package was generated by [oastools](https://github.com/erraggy/oastools) from an OpenAPI specification."
+
 // ReadmeContext contains all information needed to generate a README.
 type ReadmeContext struct {
 	// Timestamp is when the code was generated.
@@ -196,7 +200,7 @@ func (g *ReadmeGenerator) generateOverview(ctx *ReadmeContext) string {
 
 	buf.WriteString("## Overview\n\n")
 
-	buf.WriteString("This package was generated by [oastools](https://github.com/erraggy/oastools) from an OpenAPI specification.\n\n")
+	buf.WriteString(readmeGeneratedNote + "\n\n")
 
 	buf.WriteString("| Property | Value |\n")
 	buf.WriteString("|----------|-------|\n")
@@ -43,6 +43,7 @@ func TestReadmeGenerator_GenerateReadme_Basic(t *testing.T) {
 	assert.Contains(t, result, "| OpenAPI Version | 3.0.3 |", "expected OAS version in table")
 	assert.Contains(t, result, "| Package | `myapi` |", "expected package name in table")
 	assert.Contains(t, result, "| Generator Version | 1.0.0 |", "expected generator version in table")
+	assert.Contains(t, result, "| Generated | 2024-01-15T10:30:00Z |", "expected timestamp in table")
 
 	// Check files section
 	assert.Contains(t, result, "## Generated Files", "expected Generated Files section")
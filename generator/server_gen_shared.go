@@ -133,6 +133,7 @@ type serverRouterContext struct {
 	schemaTypes  map[string]bool
 	result       *GenerateResult
 	addIssue     issueAdder
+	strict       bool // handlers return strict response interfaces
 	// paramToBindData converts a parameter to ParamBindData (version-specific)
 	paramToBindData func(param *parser.Parameter) ParamBindData
 }
@@ -155,6 +156,7 @@ func generateServerRouterShared(ctx *serverRouterContext) error {
 		Header: HeaderData{
 			PackageName: ctx.packageName,
 		},
+		Strict:     ctx.strict,
 		Operations: make([]RouterOperationData, 0),
 	}
 
@@ -223,6 +225,7 @@ type serverStubsContext struct {
 	schemaTypes map[string]bool
 	result      *GenerateResult
 	addIssue    issueAdder
+	strict      bool // responses are strict response interfaces, whose zero value is nil
 	// getResponseType returns the Go type for the operation's response given the method name
 	getResponseType func(methodName string) string
 }
@@ -272,6 +275,10 @@ func generateServerStubsShared(ctx *serverStubsContext) error {
 				RequestType:  resolveWrapperName(methodName, ctx.schemaTypes),
 				ResponseType: ctx.getResponseType(methodName),
 			}
+			opData.ZeroValue = zeroValue(opData.ResponseType)
+			if ctx.strict {
+				opData.ZeroValue = "nil"
+			}
 
 			data.Operations = append(data.Operations, opData)
 		}
@@ -300,6 +307,7 @@ type baseServerContext struct {
 	schemaTypes map[string]bool
 	result      *GenerateResult
 	addIssue    issueAdder
+	strict      bool // methods return strict response interfaces
 	// generateMethodSignature generates a server method signature for the interface
 	generateMethodSignature func(path, method string, op *parser.Operation) string
 	// getResponseType returns the Go type for the operation's response
@@ -406,11 +414,15 @@ func generateBaseServerShared(ctx *baseServerContext) (map[string]bool, error) {
 				generatedUnimplemented[methodName] = true
 
 				responseType := ctx.getResponseType(op)
+				zero := zeroValue(responseType)
+				if ctx.strict {
+					responseType, zero = strictResponseType(methodName), "nil"
+				}
 				wrapperName := resolveWrapperName(methodName, ctx.schemaTypes)
 
 				fmt.Fprintf(&buf, "func (s *UnimplementedServer) %s(ctx context.Context, req *%s) (%s, error) {\n",
 					methodName, wrapperName, responseType)
-				fmt.Fprintf(&buf, "\treturn %s, ErrNotImplemented\n", zeroValue(responseType))
+				fmt.Fprintf(&buf, "\treturn %s, ErrNotImplemented\n", zero)
 				buf.WriteString("}\n\n")
 			}
 		}
@@ -549,9 +561,14 @@ func generateBaseClientShared(packageName string, info *parser.Info, result *Gen
 	buf.WriteString("\t\"encoding/json\"\n")
 	buf.WriteString("\t\"fmt\"\n")
 	buf.WriteString("\t\"io\"\n")
+	buf.WriteString("\t\"iter\"\n")
+	buf.WriteString("\t\"math/rand/v2\"\n")
 	buf.WriteString("\t\"net/http\"\n")
 	buf.WriteString("\t\"net/url\"\n")
+	buf.WriteString("\t\"slices\"\n")
+	buf.WriteString("\t\"strconv\"\n")
 	buf.WriteString("\t\"strings\"\n")
+	buf.WriteString("\t\"time\"\n")
 	buf.WriteString(")\n\n")
 
 	// Write client struct, types, constructor, and options using shared boilerplate
@@ -0,0 +1,265 @@
+// This file implements strict server mode, in which each operation returns a
+// sealed response interface with one concrete type per declared status.
+
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/erraggy/oastools/internal/maputil"
+	"github.com/erraggy/oastools/parser"
+)
+
+// strictResponseType returns the name of the response interface the server
+// method methodName returns in strict mode.
+func strictResponseType(methodName string) string {
+	return methodName + "Response"
+}
+
+// serverResponseType returns the type the server method for op returns: its
+// strict response interface in strict mode, or getResponseType's result.
+func (b *baseCodeGenerator) serverResponseType(path, method string, op *parser.Operation, getResponseType func(*parser.Operation) string) string {
+	if b.g.ServerStrict {
+		return strictResponseType(operationToMethodName(op, path, method))
+	}
+	return getResponseType(op)
+}
+
+// strictVariantType returns the name of the response type for one status of
+// the server method methodName, e.g. CreatePet201Response.
+func strictVariantType(methodName string, status StatusCodeData) string {
+	code := strings.ToUpper(status.Code)
+	if status.IsDefault {
+		code = "Default"
+	}
+	return methodName + code + "Response"
+}
+
+// generateStrictServerResponses generates server_responses.go for strict
+// server mode: the shared response helpers plus, for each operation, a
+// response interface and a type per declared status.
+func (b *baseCodeGenerator) generateStrictServerResponses() error {
+	helpers, err := executeTemplate("responses.go.tmpl", ServerResponsesFileData{
+		Header: HeaderData{PackageName: b.result.PackageName},
+	})
+	if err != nil {
+		b.addIssue(fileNameServerResponses, fmt.Sprintf("failed to execute template: %v", err), SeverityWarning)
+		return err
+	}
+
+	var buf bytes.Buffer
+	buf.Write(helpers)
+	buf.WriteString("\n// ErrNoResponse is reported to the router's error handler when a strict\n")
+	buf.WriteString("// handler returns neither a response nor an error.\n")
+	buf.WriteString("var ErrNoResponse = errors.New(\"handler returned no response\")\n")
+
+	generatedMethods := make(map[string]bool)
+	for _, path := range maputil.SortedKeys(b.paths) {
+		pathItem := b.paths[path]
+		if pathItem == nil {
+			continue
+		}
+		operations := parser.GetOperations(pathItem, b.oasVersion)
+		for _, method := range b.httpMethods {
+			op := operations[method]
+			if op == nil {
+				continue
+			}
+			methodName := operationToMethodName(op, path, method)
+			if generatedMethods[methodName] {
+				continue
+			}
+			generatedMethods[methodName] = true
+
+			b.writeStrictResponses(&buf, fmt.Sprintf("paths.%s.%s", path, method), methodName, b.buildStatusCodes(op))
+		}
+	}
+
+	appendFormattedFile(b.result, fileNameServerResponses, &buf, b.addIssue)
+	return nil
+}
+
+// writeStrictResponses writes the response interface of one operation and a
+// type implementing it for each of its statuses.
+func (b *baseCodeGenerator) writeStrictResponses(buf *bytes.Buffer, location, methodName string, statuses []StatusCodeData) {
+	ifaceName := strictResponseType(methodName)
+	sealName := strings.ToLower(ifaceName[:1]) + ifaceName[1:]
+
+	variants := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		variants = append(variants, strictVariantType(methodName, status))
+	}
+
+	fmt.Fprintf(buf, "\n// %s is a response of %s.", ifaceName, methodName)
+	if len(variants) > 0 {
+		fmt.Fprintf(buf, " It is one of %s.", strings.Join(variants, ", "))
+	}
+	fmt.Fprintf(buf, "\ntype %s interface {\n", ifaceName)
+	buf.WriteString("\t// WriteTo writes the response status, headers, and body to w.\n")
+	buf.WriteString("\tWriteTo(w http.ResponseWriter) error\n")
+	fmt.Fprintf(buf, "\t%s()\n}\n", sealName)
+
+	for i, status := range statuses {
+		typeName := variants[i]
+		if b.generatedTypes[typeName] {
+			b.addIssue(location, fmt.Sprintf("response type %s conflicts with a schema type", typeName), SeverityWarning)
+		}
+		b.writeStrictVariant(buf, typeName, sealName, methodName, status)
+	}
+}
+
+// writeStrictVariant writes the response type for one status.
+func (b *baseCodeGenerator) writeStrictVariant(buf *bytes.Buffer, typeName, sealName, methodName string, status StatusCodeData) {
+	fmt.Fprintf(buf, "\n// %s is the %s response of %s.", typeName, status.Code, methodName)
+	if status.Description != "" {
+		fmt.Fprintf(buf, "\n// %s", cleanDescription(status.Description))
+	}
+	fmt.Fprintf(buf, "\ntype %s struct {\n", typeName)
+
+	settableStatus := status.IsDefault || status.IsWildcard
+	if settableStatus {
+		fmt.Fprintf(buf, "\t// StatusCode is the status to send (default: %d).\n\tStatusCode int\n", status.StatusCodeInt)
+	}
+	bodyType := strictBodyType(status)
+	if bodyType != "" {
+		fmt.Fprintf(buf, "\tBody %s\n", bodyType)
+	}
+	fields := strictHeaderFields(status.Headers)
+	for i, header := range status.Headers {
+		if header.Description != "" {
+			fmt.Fprintf(buf, "\t// %s\n", cleanDescription(header.Description))
+		}
+		fmt.Fprintf(buf, "\t%s %s\n", fields[i], strictHeaderGoType(header))
+	}
+	buf.WriteString("}\n\n")
+
+	fmt.Fprintf(buf, "func (%s) %s() {}\n\n", typeName, sealName)
+
+	buf.WriteString("// WriteTo writes the response to w.\n")
+	fmt.Fprintf(buf, "func (r %s) WriteTo(w http.ResponseWriter) error {\n", typeName)
+	for i, header := range status.Headers {
+		field := "r." + fields[i]
+		if header.Required {
+			fmt.Fprintf(buf, "\tw.Header().Set(%q, %s)\n", header.Name, headerValueExpr(header.GoType, field))
+		} else {
+			fmt.Fprintf(buf, "\tif %s != nil {\n\t\tw.Header().Set(%q, %s)\n\t}\n",
+				field, header.Name, headerValueExpr(header.GoType, "*"+field))
+		}
+	}
+	if bodyType != "" {
+		fmt.Fprintf(buf, "\tw.Header().Set(\"Content-Type\", %q)\n", status.ContentType)
+	}
+	if settableStatus {
+		fmt.Fprintf(buf, "\tstatus := r.StatusCode\n\tif status == 0 {\n\t\tstatus = %d\n\t}\n\tw.WriteHeader(status)\n", status.StatusCodeInt)
+	} else {
+		fmt.Fprintf(buf, "\tw.WriteHeader(%d)\n", status.StatusCodeInt)
+	}
+	switch {
+	case bodyType == "":
+		buf.WriteString("\treturn nil\n")
+	case strings.Contains(status.ContentType, "json"):
+		buf.WriteString("\treturn json.NewEncoder(w).Encode(r.Body)\n")
+	default:
+		buf.WriteString("\t_, err := w.Write(r.Body)\n\treturn err\n")
+	}
+	buf.WriteString("}\n")
+}
+
+// strictBodyType returns the Go type of a response body: the decoded type for
+// JSON, raw bytes for other media types, or "" when there is no body.
+func strictBodyType(status StatusCodeData) string {
+	if status.ContentType == "" {
+		return ""
+	}
+	if strings.Contains(status.ContentType, "json") {
+		if !status.HasBody {
+			return "any"
+		}
+		return status.BodyType
+	}
+	return "[]byte"
+}
+
+// strictHeaderFields returns the struct field name for each header, keeping
+// clear of the StatusCode and Body fields and of each other.
+func strictHeaderFields(headers []ResponseHeaderData) []string {
+	used := map[string]bool{"StatusCode": true, "Body": true}
+	fields := make([]string, len(headers))
+	for i, header := range headers {
+		name := header.FieldName
+		if used[name] {
+			name += "Header"
+		}
+		for n := 2; used[name]; n++ {
+			name = fmt.Sprintf("%sHeader%d", header.FieldName, n)
+		}
+		used[name] = true
+		fields[i] = name
+	}
+	return fields
+}
+
+// strictHeaderGoType returns the field type of a header: its value type when
+// required, or a pointer that is nil when the header is omitted.
+func strictHeaderGoType(header ResponseHeaderData) string {
+	if header.Required || strings.HasPrefix(header.GoType, "[]") {
+		return header.GoType
+	}
+	return "*" + header.GoType
+}
+
+// headerGoType returns the Go type of a header value with the given schema
+// type and format. Headers are plain strings on the wire, so only scalars and
+// lists of strings are typed.
+func headerGoType(schemaType, format string) string {
+	switch schemaType {
+	case "integer":
+		return integerFormatToGoType(format)
+	case "number":
+		return numberFormatToGoType(format)
+	case "boolean":
+		return goTypeBool
+	case "array":
+		return "[]string"
+	}
+	return "string"
+}
+
+// headerValueExpr returns an expression formatting the value expr of the
+// given Go type as a header value.
+func headerValueExpr(goType, expr string) string {
+	switch goType {
+	case "int32", goTypeInt64:
+		return fmt.Sprintf("strconv.FormatInt(int64(%s), 10)", expr)
+	case "float32", goTypeFloat64:
+		return fmt.Sprintf("strconv.FormatFloat(float64(%s), 'g', -1, 64)", expr)
+	case goTypeBool:
+		return fmt.Sprintf("strconv.FormatBool(%s)", expr)
+	case "[]string":
+		return fmt.Sprintf("strings.Join(%s, \",\")", strings.TrimPrefix(expr, "*"))
+	}
+	return expr
+}
+
+// buildResponseHeaders returns the headers a response declares, sorted by
+// name. Content-Type is left out, as the response's media type sets it.
+// headerType returns the Go type of a header.
+func buildResponseHeaders(headers map[string]*parser.Header, headerType func(*parser.Header) string) []ResponseHeaderData {
+	result := make([]ResponseHeaderData, 0, len(headers))
+	for _, name := range maputil.SortedKeys(headers) {
+		header := headers[name]
+		if header == nil || strings.EqualFold(name, "Content-Type") {
+			continue
+		}
+		result = append(result, ResponseHeaderData{
+			Name:        name,
+			FieldName:   toFieldName(name),
+			GoType:      headerType(header),
+			Required:    header.Required,
+			Description: header.Description,
+		})
+	}
+	return result
+}
@@ -0,0 +1,251 @@
+package generator
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/erraggy/oastools/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const strictSpec = `openapi: 3.0.3
+info:
+  title: Strict
+  version: 1.0.0
+paths:
+  /pets:
+    post:
+      operationId: createPet
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        '201':
+          description: Created
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+        '409':
+          $ref: '#/components/responses/Conflict'
+        '4XX':
+          description: Client error
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Error'
+  /pets/{id}:
+    delete:
+      operationId: deletePet
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+      responses:
+        '204':
+          description: Deleted
+          headers:
+            X-Request-Id:
+              required: true
+              schema:
+                type: string
+        default:
+          description: Error
+          content:
+            text/plain:
+              schema:
+                type: string
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+    Error:
+      type: object
+      properties:
+        message:
+          type: string
+  responses:
+    Conflict:
+      description: Already exists
+      headers:
+        Retry-After:
+          schema:
+            type: integer
+            format: int64
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/Error'
+`
+
+func TestServerStrict_Generated(t *testing.T) {
+	result := generateFromSpec(t, strictSpec, WithServer(true), WithServerStrict(true))
+
+	server := result.GetFile("server.go")
+	require.NotNil(t, server)
+	assert.Contains(t, string(server.Content), "CreatePet(ctx context.Context, req *CreatePetRequest) (CreatePetResponse, error)")
+
+	file := result.GetFile("server_responses.go")
+	require.NotNil(t, file)
+	content := string(file.Content)
+
+	assert.Contains(t, content, "type CreatePetResponse interface")
+	assert.Contains(t, content, "createPetResponse()")
+	assert.Contains(t, content, "type CreatePet201Response struct")
+	assert.Contains(t, content, "type CreatePet409Response struct")
+	assert.Contains(t, content, "type CreatePet4XXResponse struct")
+	assert.Contains(t, content, "RetryAfter *int64")
+	assert.Contains(t, content, `w.Header().Set("Retry-After", strconv.FormatInt(int64(*r.RetryAfter), 10))`)
+	assert.Contains(t, content, "type DeletePet204Response struct")
+	assert.Contains(t, content, "XRequestId string")
+	assert.Contains(t, content, "type DeletePetDefaultResponse struct")
+	assert.Contains(t, content, "Body       []byte")
+	assert.Contains(t, content, "var ErrNoResponse")
+	assert.Contains(t, content, "func WriteJSON(")
+	// The builder-style response structs are replaced by the interfaces
+	assert.NotContains(t, content, "Status201(")
+}
+
+func TestServerStrict_OAS2(t *testing.T) {
+	result := generateFromSpec(t, `swagger: "2.0"
+info:
+  title: Strict
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      produces: [application/json]
+      responses:
+        '200':
+          description: OK
+          headers:
+            X-Total:
+              type: integer
+          schema:
+            type: array
+            items:
+              $ref: '#/definitions/Pet'
+        default:
+          $ref: '#/responses/Error'
+definitions:
+  Pet:
+    type: object
+    properties:
+      name:
+        type: string
+responses:
+  Error:
+    description: Error
+    schema:
+      type: object
+      properties:
+        message:
+          type: string
+`, WithServer(true), WithServerStrict(true))
+	file := result.GetFile("server_responses.go")
+	require.NotNil(t, file)
+	content := string(file.Content)
+	assert.Contains(t, content, "type ListPetsResponse interface")
+	assert.Contains(t, content, "Body   []Pet")
+	assert.Contains(t, content, "XTotal *int64")
+	assert.Contains(t, content, "type ListPetsDefaultResponse struct")
+}
+
+func TestServerStrict_Disabled(t *testing.T) {
+	parsed, err := parser.New().ParseBytes([]byte(strictSpec))
+	require.NoError(t, err)
+	result, err := GenerateWithOptions(WithParsed(*parsed), WithServer(true), WithReadme(false), WithServerResponses(true))
+	require.NoError(t, err)
+	file := result.GetFile("server_responses.go")
+	require.NotNil(t, file)
+	assert.NotContains(t, string(file.Content), "CreatePet201Response")
+}
+
+// TestGeneratedStrictServerRun serves requests through the generated router
+// with a strict handler and prints the responses.
+func TestGeneratedStrictServerRun(t *testing.T) {
+	result := generateFromSpec(t, strictSpec, WithServer(true), WithServerStrict(true), WithServerRouter("stdlib"))
+
+	mainSrc := `package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"example.com/gen/api"
+	"github.com/erraggy/oastools/parser"
+)
+
+const spec = ` + strconv.Quote(strictSpec) + `
+
+type server struct {
+	api.UnimplementedServer
+	names map[string]bool
+}
+
+func (s *server) CreatePet(ctx context.Context, req *api.CreatePetRequest) (api.CreatePetResponse, error) {
+	var pet api.Pet
+	if err := json.NewDecoder(req.HTTPRequest.Body).Decode(&pet); err != nil {
+		return api.CreatePet4XXResponse{Body: api.Error{}}, nil
+	}
+	if s.names[pet.Name] {
+		retry, message := int64(30), "exists"
+		return api.CreatePet409Response{Body: api.Error{Message: &message}, RetryAfter: &retry}, nil
+	}
+	s.names[pet.Name] = true
+	return api.CreatePet201Response{Body: pet}, nil
+}
+
+func (s *server) DeletePet(ctx context.Context, req *api.DeletePetRequest) (api.DeletePetResponse, error) {
+	if req.Id == 0 {
+		return nil, nil
+	}
+	return api.DeletePet204Response{XRequestId: "abc"}, nil
+}
+
+func main() {
+	parsed, err := parser.ParseWithOptions(parser.WithBytes([]byte(spec)))
+	if err != nil {
+		panic(err)
+	}
+	router, err := api.NewServerRouter(&server{names: map[string]bool{}}, parsed,
+		api.WithErrorHandler(func(r *http.Request, err error) { fmt.Println("handler error:", err) }))
+	if err != nil {
+		panic(err)
+	}
+	do := func(method, path, body string) {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		fmt.Printf("%s %s: %d retry=%q id=%q %s\n", method, path, rec.Code,
+			rec.Header().Get("Retry-After"), rec.Header().Get("X-Request-Id"), strings.TrimSpace(rec.Body.String()))
+	}
+	do("POST", "/pets", ` + "`" + `{"name":"Rex"}` + "`" + `)
+	do("POST", "/pets", ` + "`" + `{"name":"Rex"}` + "`" + `)
+	do("DELETE", "/pets/7", "")
+	do("DELETE", "/pets/0", "")
+}
+`
+	out := runGeneratedModule(t, result, mainSrc)
+	assert.Contains(t, out, `POST /pets: 201 retry="" id="" {"name":"Rex"}`)
+	assert.Contains(t, out, `POST /pets: 409 retry="30" id="" {"message":"exists"}`)
+	assert.Contains(t, out, `DELETE /pets/7: 204 retry="" id="abc"`)
+	assert.Contains(t, out, "handler error: handler returned no response")
+	assert.Contains(t, out, "DELETE /pets/0: 500")
+}
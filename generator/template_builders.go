@@ -43,6 +43,7 @@ func (cg *oas3CodeGenerator) buildHeaderData() HeaderData {
 			if hasDiscriminator(schema) {
 				imports["encoding/json"] = true
 			}
+			addGoTypeImports(schema, imports)
 		}
 	}
 
@@ -76,7 +77,12 @@ func hasDiscriminator(schema *parser.Schema) bool {
 // buildTypeDefinition builds a TypeDefinition from a schema.
 // This determines which kind of type to generate and calls the appropriate builder.
 func (cg *oas3CodeGenerator) buildTypeDefinition(name string, schema *parser.Schema) TypeDefinition {
-	typeName := toTypeName(name)
+	typeName := schemaTypeName(name, schema)
+
+	// x-go-type maps the schema onto an existing Go type
+	if goType := goTypeOverride(schema); goType != "" {
+		return cg.buildGoTypeAliasDefinition(typeName, goType)
+	}
 
 	// Handle $ref - creates alias
 	if schema.Ref != "" {
@@ -151,11 +157,12 @@ func (cg *oas3CodeGenerator) buildStructTypeDefinition(typeName, originalName st
 				continue
 			}
 
-			field := cg.buildFieldData(propName, propSchema, isRequired(schema.Required, propName))
+			selfRef := isSelfReference(propSchema, toTypeName(originalName))
+			field := cg.buildFieldData(propName, propSchema, isRequired(schema.Required, propName), selfRef)
 
 			// Check for self-reference (recursive type) - needs pointer indirection
 			// e.g., type UserGroup struct { Children UserGroup } is invalid, needs *UserGroup
-			if isSelfReference(propSchema, typeName) &&
+			if selfRef &&
 				!strings.HasPrefix(field.Type, "*") &&
 				!strings.HasPrefix(field.Type, "[]") {
 				field.Type = "*" + field.Type
@@ -204,19 +211,29 @@ func (cg *oas3CodeGenerator) buildStructTypeDefinition(typeName, originalName st
 }
 
 // buildFieldData builds field data for a struct field.
-func (cg *oas3CodeGenerator) buildFieldData(propName string, propSchema *parser.Schema, required bool) FieldData {
-	goType := cg.schemaToGoType(propSchema, required)
-	fieldName := toFieldName(propName)
-
-	jsonTag := propName
-	if !required {
-		jsonTag += ",omitempty"
-	}
+// selfRef marks a property referring to the enclosing type.
+func (cg *oas3CodeGenerator) buildFieldData(propName string, propSchema *parser.Schema, required, selfRef bool) FieldData {
+	goType := cg.fieldGoType(propSchema, required, selfRef, cg.schemaToGoType)
+	fieldName := goFieldName(propName, propSchema)
+	wrapper, _ := optionalWrapper(goType)
 
 	// Build struct tags
+	jsonTag := jsonTagValue(propName, propSchema, required)
+	if wrapper != "" {
+		jsonTag = optionalJSONTag(jsonTag)
+	}
 	tags := fmt.Sprintf("json:%q", jsonTag)
-	if cg.g.IncludeValidation {
-		if validateTag := cg.buildValidateTag(propSchema, required); validateTag != "" {
+	// Validation tags cannot see inside the Optional and Nullable wrappers
+	if cg.g.IncludeValidation && wrapper == "" {
+		validateTag := cg.buildValidateTag(propSchema, required)
+		// The schema's constraints describe the wire format, not an x-go-type
+		if goTypeOverride(propSchema) != "" {
+			validateTag = ""
+			if required {
+				validateTag = "required"
+			}
+		}
+		if validateTag != "" {
 			tags += fmt.Sprintf(" validate:%q", validateTag)
 		}
 	}
@@ -279,6 +296,19 @@ func (cg *oas3CodeGenerator) buildAliasTypeDefinition(typeName string, schema *p
 	}
 }
 
+// buildGoTypeAliasDefinition builds a type alias for a schema whose x-go-type
+// names an existing Go type.
+func (cg *oas3CodeGenerator) buildGoTypeAliasDefinition(typeName, goType string) TypeDefinition {
+	return TypeDefinition{
+		Kind: kindAlias,
+		Alias: &AliasData{
+			TypeName:   typeName,
+			TargetType: goType,
+			Comment:    fmt.Sprintf("is an alias for %s.", goType),
+		},
+	}
+}
+
 // buildArrayAliasTypeDefinition builds a defined type (not alias) for array types.
 func (cg *oas3CodeGenerator) buildArrayAliasTypeDefinition(typeName string, schema *parser.Schema) TypeDefinition {
 	itemType := cg.getArrayItemType(schema)
@@ -416,7 +446,8 @@ func (cg *oas3CodeGenerator) buildAllOfTypeDefinition(typeName string, schema *p
 				if propSchema == nil {
 					continue
 				}
-				field := cg.buildFieldData(propName, propSchema, isRequired(subSchema.Required, propName))
+				field := cg.buildFieldData(propName, propSchema, isRequired(subSchema.Required, propName),
+					isSelfReference(propSchema, typeName))
 				allOfData.Fields = append(allOfData.Fields, field)
 			}
 		}
@@ -452,8 +483,9 @@ func (cg *oas3CodeGenerator) buildOneOfTypeDefinition(typeName, originalName str
 			for value, ref := range schema.Discriminator.Mapping {
 				typeName := cg.resolveRef(ref)
 				oneOfData.UnmarshalCases = append(oneOfData.UnmarshalCases, UnmarshalCase{
-					Value:    value,
-					TypeName: typeName,
+					Value:     value,
+					TypeName:  typeName,
+					FieldName: unqualifiedTypeName(typeName),
 				})
 			}
 			// Sort for deterministic output
@@ -468,7 +500,7 @@ func (cg *oas3CodeGenerator) buildOneOfTypeDefinition(typeName, originalName str
 		if subSchema.Ref != "" {
 			refType := cg.resolveRef(subSchema.Ref)
 			oneOfData.Variants = append(oneOfData.Variants, OneOfVariant{
-				Name: refType,
+				Name: unqualifiedTypeName(refType),
 				Type: "*" + refType,
 			})
 		} else {
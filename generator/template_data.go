@@ -76,8 +76,9 @@ type OneOfVariant struct {
 
 // UnmarshalCase contains data for an unmarshal case
 type UnmarshalCase struct {
-	Value    string
-	TypeName string
+	Value     string
+	TypeName  string
+	FieldName string
 }
 
 // TypesFileData contains all data for a types.go file
@@ -166,12 +167,22 @@ type StatusCodeData struct {
 	MethodName    string // e.g., "Status200"
 	BodyType      string // e.g., "[]Pet", "*Error"
 	HasBody       bool
-	IsSuccess     bool   // true for 2XX codes
-	Description   string // From OpenAPI description
-	ContentType   string // e.g., "application/json"
-	IsDefault     bool   // true for "default" response
-	IsWildcard    bool   // true for "2XX", "4XX", etc.
-	StatusCodeInt int    // numeric value for non-wildcard codes (0 for wildcard/default)
+	IsSuccess     bool                 // true for 2XX codes
+	Description   string               // From OpenAPI description
+	ContentType   string               // e.g., "application/json"
+	IsDefault     bool                 // true for "default" response
+	IsWildcard    bool                 // true for "2XX", "4XX", etc.
+	StatusCodeInt int                  // numeric value for non-wildcard codes (0 for wildcard/default)
+	Headers       []ResponseHeaderData // declared response headers, sorted by name
+}
+
+// ResponseHeaderData contains data for a header a response declares
+type ResponseHeaderData struct {
+	Name        string // e.g., "Retry-After"
+	FieldName   string // e.g., "RetryAfter"
+	GoType      string // e.g., "int64"
+	Required    bool
+	Description string
 }
 
 // ServerBinderFileData contains data for server_binder.go
@@ -211,6 +222,7 @@ type ServerMiddlewareFileData struct {
 type ServerRouterFileData struct {
 	Header     HeaderData
 	Framework  string // "stdlib"
+	Strict     bool   // handlers return strict response interfaces
 	Routes     []RouteData
 	Operations []RouterOperationData
 }
@@ -48,6 +48,7 @@ var templateFuncs = template.FuncMap{
 	"zeroValue":       zeroValue,
 	"cleanDesc":       cleanDescription,
 	"toTypeName":      toTypeName,
+	"importSpec":      importSpec,
 	"toFieldName":     toFieldName,
 	"toParamName":     toParamName,
 	"trimPointer":     trimPointer,
@@ -0,0 +1,900 @@
+// This file implements TypeScript generation: interfaces and unions for the
+// schemas and a minimal fetch client, derived with the same type naming, file
+// splitting, and discriminator handling as the Go output.
+
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/erraggy/oastools/internal/maputil"
+	"github.com/erraggy/oastools/internal/schemautil"
+	"github.com/erraggy/oastools/parser"
+)
+
+// Languages accepted by Generator.Language.
+const (
+	languageGo         = "go"
+	languageTypeScript = "typescript"
+)
+
+// TypeScript file names
+const (
+	fileNameTSTypes  = "types.ts"
+	fileNameTSClient = "client.ts"
+)
+
+// tsIdentifierPattern matches names usable as TypeScript identifiers and
+// unquoted property keys.
+var tsIdentifierPattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// tsReservedWords holds the names a generated parameter cannot take: the
+// reserved words of TypeScript's strict mode, and the names of the
+// arguments every client method declares.
+var tsReservedWords = map[string]bool{
+	"await": true, "break": true, "case": true, "catch": true, "class": true,
+	"const": true, "continue": true, "debugger": true, "default": true, "delete": true,
+	"do": true, "else": true, "enum": true, "export": true, "extends": true,
+	"false": true, "finally": true, "for": true, "function": true, "if": true,
+	"implements": true, "import": true, "in": true, "instanceof": true, "interface": true,
+	"let": true, "new": true, "null": true, "package": true, "private": true,
+	"protected": true, "public": true, "return": true, "static": true, "super": true,
+	"switch": true, "this": true, "throw": true, "true": true, "try": true,
+	"typeof": true, "var": true, "void": true, "while": true, "with": true,
+	"yield": true,
+	// Client method arguments
+	"body": true, "init": true, "params": true,
+}
+
+// tsClientMembers holds the names of the Client members generated methods
+// must not replace.
+var tsClientMembers = map[string]bool{
+	"constructor": true, "baseUrl": true, "options": true, "send": true,
+}
+
+// tsClientGlobals holds the names client.ts uses besides the schema types.
+// Schema types with these names are imported under an alias with a Schema
+// suffix.
+var tsClientGlobals = map[string]bool{
+	"ApiError": true, "Array": true, "BodyInit": true, "Client": true, "ClientOptions": true,
+	"Error": true, "Headers": true, "HeadersInit": true, "JSON": true, "Object": true,
+	"Promise": true, "Record": true, "RequestInit": true, "RequestParts": true, "Response": true,
+	"String": true, "URLSearchParams": true,
+}
+
+// isValidLanguage reports whether lang names a supported output language.
+func isValidLanguage(lang string) bool {
+	return lang == "" || lang == languageGo || lang == languageTypeScript
+}
+
+// tsFile is a TypeScript file being generated, with the schema types its
+// code refers to so their imports can be written.
+type tsFile struct {
+	name    string
+	buf     bytes.Buffer
+	refs    map[string]bool
+	globals map[string]bool // names the file's own code uses, which imports must not shadow
+}
+
+// newTSFile returns an empty tsFile called name.
+func newTSFile(name string) *tsFile {
+	return &tsFile{name: name, refs: make(map[string]bool)}
+}
+
+// tsGenerator writes the TypeScript files for a document.
+type tsGenerator struct {
+	b          *baseCodeGenerator
+	typeFiles  map[string]string // type name to the file declaring it
+	unresolved map[string]bool   // references already reported
+}
+
+// generateTypeScriptFiles writes types.ts (split into types_<group>.ts files
+// when the document needs splitting) and, with GenerateClient, client.ts.
+// schemas must come from collectSchemas, which names every schema type.
+func (b *baseCodeGenerator) generateTypeScriptFiles(schemas []schemaEntry) error {
+	t := &tsGenerator{b: b, typeFiles: make(map[string]string), unresolved: make(map[string]bool)}
+
+	// Schema types go to their group's file when the document is split, and
+	// to types.ts otherwise
+	if b.splitPlan != nil && b.splitPlan.NeedsSplit {
+		sharedTypes, groupTypes := buildTypeGroupMaps(b.splitPlan)
+		for _, entry := range schemas {
+			typeName := schemaTypeName(entry.name, entry.schema)
+			if sharedTypes[typeName] || sharedTypes[entry.name] {
+				continue
+			}
+			for _, group := range b.splitPlan.Groups {
+				if types := groupTypes[group.Name]; types[typeName] || types[entry.name] {
+					t.typeFiles[typeName] = fmt.Sprintf("types_%s.ts", group.Name)
+					break
+				}
+			}
+		}
+	}
+
+	files := make(map[string]*tsFile)
+	for _, entry := range schemas {
+		typeName := schemaTypeName(entry.name, entry.schema)
+		fileName := t.typeFile(typeName)
+		f := files[fileName]
+		if f == nil {
+			f = newTSFile(fileName)
+			files[fileName] = f
+		}
+		t.writeDeclaration(f, typeName, entry.schema)
+		b.result.GeneratedTypes++
+	}
+
+	// types.ts first, then the group files in name order
+	for _, name := range maputil.SortedKeys(files) {
+		if name == fileNameTSTypes {
+			t.appendFile(files[name])
+		}
+	}
+	for _, name := range maputil.SortedKeys(files) {
+		if name != fileNameTSTypes {
+			t.appendFile(files[name])
+		}
+	}
+
+	if b.g.GenerateClient {
+		t.appendFile(t.client())
+	}
+	return nil
+}
+
+// generateTypeScript writes the TypeScript files for an OAS 3.x document.
+func (cg *oas3CodeGenerator) generateTypeScript() error {
+	return cg.generateTypeScriptFiles(cg.collectSchemas())
+}
+
+// generateTypeScript writes the TypeScript files for an OAS 2.0 document.
+func (cg *oas2CodeGenerator) generateTypeScript() error {
+	definitions := cg.collectSchemas()
+	schemas := make([]schemaEntry, 0, len(definitions))
+	for _, entry := range definitions {
+		schemas = append(schemas, schemaEntry{name: entry.name, schema: entry.schema})
+	}
+	return cg.generateTypeScriptFiles(schemas)
+}
+
+// typeFile returns the name of the file declaring the schema type typeName.
+func (t *tsGenerator) typeFile(typeName string) string {
+	if name, ok := t.typeFiles[typeName]; ok {
+		return name
+	}
+	return fileNameTSTypes
+}
+
+// appendFile adds f to the result, preceded by the header and the imports of
+// the types it refers to from other files.
+func (t *tsGenerator) appendFile(f *tsFile) {
+	var out bytes.Buffer
+	out.WriteString("// Code generated by oastools. DO NOT EDIT.\n\n")
+
+	imports := make(map[string][]string)
+	for _, typeName := range maputil.SortedKeys(f.refs) {
+		if from := t.typeFile(typeName); from != f.name {
+			imports[from] = append(imports[from], typeName)
+		}
+	}
+	for _, from := range maputil.SortedKeys(imports) {
+		names := imports[from]
+		for i, name := range names {
+			if f.globals[name] {
+				names[i] = name + " as " + name + "Schema"
+			}
+		}
+		fmt.Fprintf(&out, "import type { %s } from \"./%s\";\n", strings.Join(names, ", "), strings.TrimSuffix(from, ".ts"))
+	}
+	if len(imports) > 0 {
+		out.WriteString("\n")
+	}
+
+	out.Write(bytes.TrimRight(f.buf.Bytes(), "\n"))
+	out.WriteString("\n")
+	t.b.result.Files = append(t.b.result.Files, GeneratedFile{Name: f.name, Content: out.Bytes()})
+}
+
+// writeDeclaration declares the schema type typeName: an interface for an
+// object with properties, and a type alias for anything else.
+func (t *tsGenerator) writeDeclaration(f *tsFile, typeName string, schema *parser.Schema) {
+	if f.buf.Len() > 0 {
+		f.buf.WriteString("\n")
+	}
+	writeTSDoc(&f.buf, "", schema.Description, schema.Deprecated)
+
+	if isTSInterface(schema) {
+		fmt.Fprintf(&f.buf, "export interface %s {\n", typeName)
+		t.writeProperties(f, schema, "  ")
+		f.buf.WriteString("}\n")
+		return
+	}
+	fmt.Fprintf(&f.buf, "export type %s = %s;\n", typeName, t.tsType(f, schema))
+}
+
+// isTSInterface reports whether schema declares a plain object with
+// properties, which is written as an interface.
+func isTSInterface(schema *parser.Schema) bool {
+	return schema.Ref == "" && len(schema.Properties) > 0 && getSchemaType(schema) == "object" &&
+		len(schema.AllOf) == 0 && len(schema.OneOf) == 0 && len(schema.AnyOf) == 0 &&
+		len(schema.Enum) == 0 && schema.Const == nil && !isNullableSchema(schema)
+}
+
+// writeProperties writes one interface member per property of schema, and
+// an index signature when it also allows additional properties.
+func (t *tsGenerator) writeProperties(f *tsFile, schema *parser.Schema, indent string) {
+	for _, propName := range maputil.SortedKeys(schema.Properties) {
+		propSchema := schema.Properties[propName]
+		if propSchema == nil {
+			continue
+		}
+		writeTSDoc(&f.buf, indent, propSchema.Description, propSchema.Deprecated)
+		optional := ""
+		if !isRequired(schema.Required, propName) {
+			optional = "?"
+		}
+		fmt.Fprintf(&f.buf, "%s%s%s: %s;\n", indent, tsPropertyKey(propName), optional, t.tsType(f, propSchema))
+	}
+	if allowsAdditionalProperties(schema) {
+		fmt.Fprintf(&f.buf, "%s[key: string]: unknown;\n", indent)
+	}
+}
+
+// allowsAdditionalProperties reports whether an object schema with
+// properties declares that it may carry others.
+func allowsAdditionalProperties(schema *parser.Schema) bool {
+	switch addProps := schema.AdditionalProperties.(type) {
+	case *parser.Schema, map[string]any:
+		return true
+	case bool:
+		return addProps
+	}
+	return false
+}
+
+// tsType returns the TypeScript type for schema, recording the schema types
+// it refers to in f.
+func (t *tsGenerator) tsType(f *tsFile, schema *parser.Schema) string {
+	if schema == nil {
+		return "unknown"
+	}
+	typ := t.tsBaseType(f, schema)
+	if isNullableSchema(schema) && typ != "unknown" && typ != "null" {
+		return tsUnion([]string{typ, "null"})
+	}
+	return typ
+}
+
+// tsBaseType returns the TypeScript type for schema, leaving out nullability.
+func (t *tsGenerator) tsBaseType(f *tsFile, schema *parser.Schema) string {
+	switch {
+	case schema.Ref != "":
+		return t.refType(f, schema.Ref)
+	case schema.Const != nil:
+		return tsLiteral(schema.Const)
+	case len(schema.Enum) > 0:
+		literals := make([]string, 0, len(schema.Enum))
+		for _, value := range schema.Enum {
+			literals = append(literals, tsLiteral(value))
+		}
+		return tsUnion(literals)
+	case len(schema.AllOf) > 0:
+		return t.intersectionType(f, schema)
+	case len(schema.OneOf) > 0 || len(schema.AnyOf) > 0:
+		return t.unionType(f, schema)
+	}
+
+	var types []string
+	for _, schemaType := range schemautil.GetSchemaTypes(schema) {
+		if schemaType != "null" {
+			types = append(types, schemaType)
+		}
+	}
+	if len(types) == 0 {
+		if inferred := getSchemaType(schema); inferred != "" && inferred != "null" {
+			types = []string{inferred}
+		}
+	}
+	if len(types) == 0 {
+		return "unknown"
+	}
+
+	parts := make([]string, 0, len(types))
+	for _, schemaType := range types {
+		switch schemaType {
+		case "string":
+			parts = append(parts, "string")
+		case "integer", "number":
+			parts = append(parts, "number")
+		case "boolean":
+			parts = append(parts, "boolean")
+		case "array":
+			parts = append(parts, t.arrayType(f, schema))
+		case "object":
+			parts = append(parts, t.objectType(f, schema))
+		default:
+			parts = append(parts, "unknown")
+		}
+	}
+	return tsUnion(parts)
+}
+
+// refType returns the type name a $ref resolves to. References to schemas
+// the document doesn't declare are reported and typed as unknown.
+func (t *tsGenerator) refType(f *tsFile, ref string) string {
+	typeName, ok := t.b.schemaNames[ref]
+	if !ok {
+		// A duplicate schema name was skipped in favor of the type it
+		// collides with, which the Go output refers to as well
+		if name := toTypeName(ref[strings.LastIndex(ref, "/")+1:]); t.b.generatedTypes[name] && strings.HasPrefix(ref, "#/") {
+			typeName, ok = name, true
+		}
+	}
+	if !ok {
+		if !t.unresolved[ref] {
+			t.unresolved[ref] = true
+			t.b.addIssue(f.name, fmt.Sprintf("unresolved reference %s - typed as unknown", ref), SeverityWarning)
+		}
+		return "unknown"
+	}
+	f.refs[typeName] = true
+	if f.globals[typeName] {
+		return typeName + "Schema"
+	}
+	return typeName
+}
+
+// intersectionType returns the intersection of an allOf's schemas, including
+// the properties declared beside them.
+func (t *tsGenerator) intersectionType(f *tsFile, schema *parser.Schema) string {
+	parts := make([]string, 0, len(schema.AllOf)+1)
+	for _, sub := range schema.AllOf {
+		parts = append(parts, tsGroup(t.tsType(f, sub)))
+	}
+	if len(schema.Properties) > 0 {
+		parts = append(parts, t.objectType(f, schema))
+	}
+	return strings.Join(parts, " & ")
+}
+
+// unionType returns the union of a oneOf's or anyOf's schemas. With a
+// discriminator, each referenced variant is narrowed to its discriminator
+// value: the mapping key naming it, or else its schema name.
+func (t *tsGenerator) unionType(f *tsFile, schema *parser.Schema) string {
+	variants := schema.OneOf
+	if len(variants) == 0 {
+		variants = schema.AnyOf
+	}
+
+	var propName string
+	values := make(map[string]string) // variant type name to discriminator value
+	if schema.Discriminator != nil && schema.Discriminator.PropertyName != "" {
+		propName = schema.Discriminator.PropertyName
+		for _, value := range maputil.SortedKeys(schema.Discriminator.Mapping) {
+			typeName := t.b.resolveRef(schema.Discriminator.Mapping[value])
+			if _, ok := values[typeName]; !ok {
+				values[typeName] = value
+			}
+		}
+	}
+
+	parts := make([]string, 0, len(variants))
+	for _, variant := range variants {
+		typ := t.tsType(f, variant)
+		if propName != "" && variant != nil && variant.Ref != "" && typ != "unknown" {
+			value, ok := values[typ]
+			if !ok {
+				value = variant.Ref[strings.LastIndex(variant.Ref, "/")+1:]
+			}
+			typ = fmt.Sprintf("(%s & { %s: %s })", typ, tsPropertyKey(propName), tsLiteral(value))
+		}
+		parts = append(parts, typ)
+	}
+	return tsUnion(parts)
+}
+
+// arrayType returns the TypeScript type for an array schema: a tuple for
+// prefixItems or the OAS 2.0 tuple form, and T[] otherwise.
+func (t *tsGenerator) arrayType(f *tsFile, schema *parser.Schema) string {
+	if len(schema.PrefixItems) > 0 {
+		return t.tupleType(f, schema.PrefixItems)
+	}
+	switch items := schema.Items.(type) {
+	case *parser.Schema:
+		return tsGroup(t.tsType(f, items)) + "[]"
+	case []*parser.Schema:
+		return t.tupleType(f, items)
+	case map[string]any:
+		return tsGroup(t.mapType(f, items)) + "[]"
+	}
+	return "unknown[]"
+}
+
+// tupleType returns a tuple of the item schemas.
+func (t *tsGenerator) tupleType(f *tsFile, items []*parser.Schema) string {
+	parts := make([]string, 0, len(items))
+	for _, item := range items {
+		parts = append(parts, t.tsType(f, item))
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
+
+// mapType returns the TypeScript type for a schema left as a raw map, which
+// only carries a $ref or a primitive type.
+func (t *tsGenerator) mapType(f *tsFile, schema map[string]any) string {
+	if ref, ok := schema["$ref"].(string); ok {
+		return t.refType(f, ref)
+	}
+	typ, _ := schema["type"].(string)
+	return t.tsBaseType(f, &parser.Schema{Type: typ})
+}
+
+// objectType returns an inline object type for schema. It spells out index
+// signatures rather than using Record, which a schema type may shadow.
+func (t *tsGenerator) objectType(f *tsFile, schema *parser.Schema) string {
+	if len(schema.Properties) == 0 {
+		switch addProps := schema.AdditionalProperties.(type) {
+		case *parser.Schema:
+			return "{ [key: string]: " + t.tsType(f, addProps) + " }"
+		case map[string]any:
+			return "{ [key: string]: " + t.mapType(f, addProps) + " }"
+		}
+		return "{ [key: string]: unknown }"
+	}
+
+	members := make([]string, 0, len(schema.Properties)+1)
+	for _, propName := range maputil.SortedKeys(schema.Properties) {
+		propSchema := schema.Properties[propName]
+		if propSchema == nil {
+			continue
+		}
+		optional := ""
+		if !isRequired(schema.Required, propName) {
+			optional = "?"
+		}
+		members = append(members, fmt.Sprintf("%s%s: %s", tsPropertyKey(propName), optional, t.tsType(f, propSchema)))
+	}
+	if allowsAdditionalProperties(schema) {
+		members = append(members, "[key: string]: unknown")
+	}
+	return "{ " + strings.Join(members, "; ") + " }"
+}
+
+// tsUnion joins types into a union, dropping duplicates.
+func tsUnion(types []string) string {
+	seen := make(map[string]bool, len(types))
+	parts := make([]string, 0, len(types))
+	for _, typ := range types {
+		if !seen[typ] {
+			seen[typ] = true
+			parts = append(parts, typ)
+		}
+	}
+	return strings.Join(parts, " | ")
+}
+
+// tsGroup parenthesizes a union or intersection so it can be an array
+// element or an intersection member.
+func tsGroup(typ string) string {
+	if strings.Contains(typ, " | ") || strings.Contains(typ, " & ") {
+		return "(" + typ + ")"
+	}
+	return typ
+}
+
+// tsLiteral returns value as a TypeScript literal type.
+func tsLiteral(value any) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(value); err != nil {
+		return "unknown"
+	}
+	return strings.TrimSpace(buf.String())
+}
+
+// tsPropertyKey returns name as a property key, quoted unless it is an
+// identifier.
+func tsPropertyKey(name string) string {
+	if tsIdentifierPattern.MatchString(name) {
+		return name
+	}
+	return tsLiteral(name)
+}
+
+// tsPropertyAccess returns the expression reading the property name of obj.
+func tsPropertyAccess(obj, name string) string {
+	if tsIdentifierPattern.MatchString(name) {
+		return obj + "." + name
+	}
+	return obj + "[" + tsLiteral(name) + "]"
+}
+
+// tsParamName returns the TypeScript variable name for a parameter.
+func tsParamName(name string) string {
+	paramName := toParamName(name)
+	if tsReservedWords[paramName] {
+		return paramName + "_"
+	}
+	return paramName
+}
+
+// tsMethodName returns the client method name for an operation: the Go
+// method name in camelCase.
+func tsMethodName(op *parser.Operation, path, method string) string {
+	name := operationToMethodName(op, path, method)
+	name = strings.ToLower(name[:1]) + name[1:]
+	if tsClientMembers[name] {
+		return name + "_"
+	}
+	return name
+}
+
+// writeTSDoc writes a JSDoc comment with the description, marking it
+// deprecated when asked. Nothing is written when there is nothing to say.
+func writeTSDoc(buf *bytes.Buffer, indent, description string, deprecated bool) {
+	description = strings.TrimSpace(strings.ReplaceAll(description, "*/", "*\\/"))
+	if description == "" && !deprecated {
+		return
+	}
+	var lines []string
+	if description != "" {
+		lines = strings.Split(description, "\n")
+	}
+	if deprecated {
+		lines = append(lines, "@deprecated")
+	}
+	if len(lines) == 1 {
+		fmt.Fprintf(buf, "%s/** %s */\n", indent, lines[0])
+		return
+	}
+	fmt.Fprintf(buf, "%s/**\n", indent)
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t")
+		if line == "" {
+			fmt.Fprintf(buf, "%s *\n", indent)
+			continue
+		}
+		fmt.Fprintf(buf, "%s * %s\n", indent, line)
+	}
+	fmt.Fprintf(buf, "%s */\n", indent)
+}
+
+// client returns client.ts: the Client class with a method per operation,
+// and the interfaces of their query and header parameters.
+func (t *tsGenerator) client() *tsFile {
+	f := newTSFile(fileNameTSClient)
+	f.globals = tsClientGlobals
+	var params, methods bytes.Buffer
+	for _, path := range maputil.SortedKeys(t.b.paths) {
+		pathItem := t.b.paths[path]
+		if pathItem == nil {
+			continue
+		}
+		operations := parser.GetOperations(pathItem, t.b.oasVersion)
+		for _, method := range t.b.httpMethods {
+			if op := operations[method]; op != nil {
+				t.writeClientMethod(f, &params, &methods, path, method, op)
+				t.b.result.GeneratedOperations++
+			}
+		}
+	}
+
+	f.buf.WriteString(tsClientPreamble)
+	if params.Len() > 0 {
+		f.buf.WriteString("\n")
+		f.buf.Write(params.Bytes())
+	}
+	f.buf.WriteString(tsClientClassStart)
+	f.buf.Write(methods.Bytes())
+	f.buf.WriteString(tsClientClassEnd)
+	return f
+}
+
+// tsRequestBody describes how a client method sends an operation's body.
+type tsRequestBody struct {
+	typ         string // the body argument's type
+	contentType string // the Content-Type header, empty to let fetch set it
+	json        bool   // the body is JSON-encoded
+}
+
+// writeClientMethod writes the client method for an operation to methods,
+// and the interface of its query and header parameters to params.
+func (t *tsGenerator) writeClientMethod(f *tsFile, params, methods *bytes.Buffer, path, method string, op *parser.Operation) {
+	methodName := tsMethodName(op, path, method)
+	paramsType := operationToMethodName(op, path, method) + "Params"
+	location := fmt.Sprintf("paths.%s.%s", path, method)
+
+	var args []string
+	urlPath := strings.NewReplacer("`", "\\`", "${", "\\${").Replace(path)
+	var queryParams, headerParams []*parser.Parameter
+	seen := make(map[string]bool)
+	paramsRequired := false
+	for _, param := range op.Parameters {
+		if param == nil {
+			continue
+		}
+		switch param.In {
+		case parser.ParamInPath:
+			name := tsParamName(param.Name)
+			args = append(args, fmt.Sprintf("%s: %s", name, t.tsType(f, tsParamSchema(param))))
+			urlPath = strings.ReplaceAll(urlPath, "{"+param.Name+"}", fmt.Sprintf("${encodeURIComponent(String(%s))}", name))
+		case parser.ParamInQuery, parser.ParamInHeader:
+			if seen[param.Name] {
+				t.b.addIssue(location, fmt.Sprintf("parameter %s is declared in both query and header - skipping the %s parameter", param.Name, param.In), SeverityWarning)
+				continue
+			}
+			seen[param.Name] = true
+			if param.In == parser.ParamInQuery {
+				queryParams = append(queryParams, param)
+			} else {
+				headerParams = append(headerParams, param)
+			}
+			paramsRequired = paramsRequired || param.Required
+		}
+	}
+
+	body, hasBody := t.requestBody(f, op)
+	if hasBody {
+		args = append(args, "body: "+body.typ)
+	}
+	if len(queryParams)+len(headerParams) > 0 {
+		if paramsRequired {
+			args = append(args, "params: "+paramsType)
+		} else {
+			args = append(args, "params: "+paramsType+" = {}")
+		}
+		t.writeParamsInterface(f, params, paramsType, append(queryParams, headerParams...))
+	}
+	args = append(args, "init?: RequestInit")
+
+	responseType, jsonResponse := t.responseType(f, op)
+
+	// Doc comment
+	doc := op.Summary
+	if doc == "" {
+		doc = op.Description
+	}
+	if doc != "" {
+		doc += "\n\n"
+	}
+	doc += strings.ToUpper(method) + " " + path
+	methods.WriteString("\n")
+	writeTSDoc(methods, "  ", doc, op.Deprecated)
+
+	fmt.Fprintf(methods, "  async %s(%s): Promise<%s> {\n", methodName, strings.Join(args, ", "), responseType)
+	call := "return this.send"
+	if jsonResponse {
+		call = "const resp = await this.send"
+	}
+	fmt.Fprintf(methods, "    %s(%s, `%s`, ", call, tsLiteral(strings.ToUpper(method)), urlPath)
+	var parts bytes.Buffer
+	writeParamsObject(&parts, "query", queryParams)
+	writeParamsObject(&parts, "headers", headerParams)
+	if hasBody {
+		if body.json {
+			parts.WriteString("      body: JSON.stringify(body),\n")
+		} else {
+			parts.WriteString("      body,\n")
+		}
+		if body.contentType != "" {
+			fmt.Fprintf(&parts, "      contentType: %s,\n", tsLiteral(body.contentType))
+		}
+	}
+	if parts.Len() > 0 {
+		methods.WriteString("{\n")
+		methods.Write(parts.Bytes())
+		methods.WriteString("    }, init);\n")
+	} else {
+		methods.WriteString("{}, init);\n")
+	}
+	if jsonResponse {
+		fmt.Fprintf(methods, "    return (await resp.json()) as %s;\n", responseType)
+	}
+	methods.WriteString("  }\n")
+}
+
+// writeParamsInterface declares the interface holding an operation's query
+// and header parameters.
+func (t *tsGenerator) writeParamsInterface(f *tsFile, buf *bytes.Buffer, typeName string, params []*parser.Parameter) {
+	if buf.Len() > 0 {
+		buf.WriteString("\n")
+	}
+	fmt.Fprintf(buf, "export interface %s {\n", typeName)
+	for _, param := range params {
+		writeTSDoc(buf, "  ", param.Description, param.Deprecated)
+		optional := "?"
+		if param.Required {
+			optional = ""
+		}
+		fmt.Fprintf(buf, "  %s%s: %s;\n", tsPropertyKey(param.Name), optional, t.tsType(f, tsParamSchema(param)))
+	}
+	buf.WriteString("}\n")
+}
+
+// writeParamsObject writes the object literal passing params to send under
+// key.
+func writeParamsObject(buf *bytes.Buffer, key string, params []*parser.Parameter) {
+	if len(params) == 0 {
+		return
+	}
+	fmt.Fprintf(buf, "      %s: {\n", key)
+	for _, param := range params {
+		fmt.Fprintf(buf, "        %s: %s,\n", tsPropertyKey(param.Name), tsPropertyAccess("params", param.Name))
+	}
+	buf.WriteString("      },\n")
+}
+
+// tsParamSchema returns the schema of a parameter: its own in OAS 3.x, or
+// one built from the type fields of an OAS 2.0 parameter.
+func tsParamSchema(param *parser.Parameter) *parser.Schema {
+	if param.Schema != nil {
+		return param.Schema
+	}
+	for _, contentType := range maputil.SortedKeys(param.Content) {
+		if mediaType := param.Content[contentType]; mediaType != nil && mediaType.Schema != nil {
+			return mediaType.Schema
+		}
+	}
+	schema := &parser.Schema{Type: param.Type, Format: param.Format, Enum: param.Enum}
+	if param.Items != nil {
+		schema.Items = tsItemsSchema(param.Items)
+	}
+	return schema
+}
+
+// tsItemsSchema converts OAS 2.0 parameter items to a schema.
+func tsItemsSchema(items *parser.Items) *parser.Schema {
+	schema := &parser.Schema{Type: items.Type, Format: items.Format, Enum: items.Enum}
+	if items.Items != nil {
+		schema.Items = tsItemsSchema(items.Items)
+	}
+	return schema
+}
+
+// requestBody returns how an operation's body is sent: JSON-encoded when its
+// content is JSON, which the OAS 2.0 body parameter always is, and passed
+// to fetch as is otherwise.
+func (t *tsGenerator) requestBody(f *tsFile, op *parser.Operation) (tsRequestBody, bool) {
+	if rb := op.RequestBody; rb != nil {
+		contentTypes := maputil.SortedKeys(rb.Content)
+		for _, contentType := range contentTypes {
+			if mediaType := rb.Content[contentType]; strings.Contains(contentType, "json") && mediaType != nil {
+				return tsRequestBody{typ: t.tsType(f, mediaType.Schema), contentType: contentType, json: true}, true
+			}
+		}
+		if len(contentTypes) == 0 {
+			return tsRequestBody{typ: "unknown", contentType: "application/json", json: true}, true
+		}
+		// fetch sets multipart and urlencoded content types itself, with
+		// the boundary multipart needs
+		contentType := contentTypes[0]
+		if strings.HasPrefix(contentType, "multipart/") || contentType == "application/x-www-form-urlencoded" {
+			contentType = ""
+		}
+		return tsRequestBody{typ: "BodyInit", contentType: contentType}, true
+	}
+	for _, param := range op.Parameters {
+		if param != nil && param.In == parser.ParamInBody {
+			return tsRequestBody{typ: t.tsType(f, param.Schema), contentType: "application/json", json: true}, true
+		}
+	}
+	return tsRequestBody{}, false
+}
+
+// responseType returns the type of an operation's JSON success response, the
+// one the Go client decodes. Operations without one return the Response.
+func (t *tsGenerator) responseType(f *tsFile, op *parser.Operation) (typ string, isJSON bool) {
+	if op.Responses == nil {
+		return "Response", false
+	}
+	for _, resp := range []*parser.Response{op.Responses.Codes["200"], op.Responses.Codes["201"], op.Responses.Codes["2XX"], op.Responses.Default} {
+		if resp == nil {
+			continue
+		}
+		if resp.Schema != nil {
+			return t.tsType(f, resp.Schema), true
+		}
+		for _, contentType := range maputil.SortedKeys(resp.Content) {
+			if mediaType := resp.Content[contentType]; strings.Contains(contentType, "json") && mediaType != nil && mediaType.Schema != nil {
+				return t.tsType(f, mediaType.Schema), true
+			}
+		}
+	}
+	return "Response", false
+}
+
+// tsClientPreamble declares the types client.ts needs ahead of the
+// parameter interfaces.
+const tsClientPreamble = `/** ApiError is thrown for responses with a status of 400 or above. */
+export class ApiError extends Error {
+  readonly status: number;
+  readonly body: string;
+
+  constructor(status: number, body: string) {
+    super(` + "`API error: status ${status}: ${body}`" + `);
+    this.name = "ApiError";
+    this.status = status;
+    this.body = body;
+  }
+}
+
+/** ClientOptions configures a Client. */
+export interface ClientOptions {
+  /** fetch replaces the global fetch, for example to add logging or retries. */
+  fetch?: typeof fetch;
+  /** headers are sent with every request. */
+  headers?: HeadersInit;
+}
+
+/** RequestParts are the parts of a request a Client method fills in. */
+interface RequestParts {
+  query?: Record<string, unknown>;
+  headers?: Record<string, unknown>;
+  body?: BodyInit;
+  contentType?: string;
+}
+`
+
+// tsClientClassStart opens the Client class, ahead of the operation methods.
+const tsClientClassStart = `
+/** Client calls the API's operations with fetch. */
+export class Client {
+  readonly baseUrl: string;
+  private readonly options: ClientOptions;
+
+  /**
+   * @param baseUrl the URL the operation paths are relative to
+   * @param options optional fetch replacement and headers
+   */
+  constructor(baseUrl: string, options: ClientOptions = {}) {
+    this.baseUrl = baseUrl.replace(/\/+$/, "");
+    this.options = options;
+  }
+`
+
+// tsClientClassEnd closes the Client class with the helper every method
+// sends its request through.
+const tsClientClassEnd = `
+  /**
+   * send performs a request, throwing an ApiError for a status of 400 or
+   * above. Query and header values that are undefined or null are left out,
+   * and array query values repeat the parameter.
+   */
+  private async send(method: string, path: string, parts: RequestParts, init?: RequestInit): Promise<Response> {
+    const query = new URLSearchParams();
+    for (const [name, value] of Object.entries(parts.query ?? {})) {
+      if (value === undefined || value === null) {
+        continue;
+      }
+      for (const item of Array.isArray(value) ? value : [value]) {
+        query.append(name, String(item));
+      }
+    }
+    const headers = new Headers(this.options.headers);
+    for (const [name, value] of Object.entries(parts.headers ?? {})) {
+      if (value !== undefined && value !== null) {
+        headers.set(name, String(value));
+      }
+    }
+    if (parts.contentType) {
+      headers.set("Content-Type", parts.contentType);
+    }
+    new Headers(init?.headers).forEach((value, name) => headers.set(name, value));
+
+    const search = query.toString();
+    const url = this.baseUrl + path + (search ? "?" + search : "");
+    const doFetch = this.options.fetch ?? fetch;
+    const resp = await doFetch(url, { ...init, method, headers, body: parts.body });
+    if (resp.status >= 400) {
+      throw new ApiError(resp.status, await resp.text());
+    }
+    return resp;
+  }
+}
+`
@@ -0,0 +1,132 @@
+package generator
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/erraggy/oastools/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the TypeScript golden files")
+
+// tsGoldenDir holds the specs of the TypeScript golden tests, and a directory
+// per spec with the files generated from it.
+const tsGoldenDir = "../testdata/generator/typescript"
+
+func TestTypeScriptGolden(t *testing.T) {
+	tests := []struct {
+		spec string
+		opts []Option
+	}{
+		{spec: "petstore-3.0.yaml"},
+		// Small limits split the types by tag
+		{spec: "store-2.0.yaml", opts: []Option{WithMaxOperationsPerFile(2), WithMaxTypesPerFile(2)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			result, err := GenerateWithOptions(append([]Option{
+				WithFilePath(filepath.Join(tsGoldenDir, tt.spec)),
+				WithLanguage("typescript"),
+				WithClient(true),
+			}, tt.opts...)...)
+			require.NoError(t, err)
+			assert.Empty(t, result.Issues)
+
+			dir := filepath.Join(tsGoldenDir, tt.spec[:len(tt.spec)-len(filepath.Ext(tt.spec))])
+			if *updateGolden {
+				require.NoError(t, os.RemoveAll(dir))
+				require.NoError(t, result.WriteFiles(dir))
+			}
+
+			entries, err := os.ReadDir(dir)
+			require.NoError(t, err, "run go test ./generator -run TestTypeScriptGolden -update to create the golden files")
+			var want []string
+			for _, entry := range entries {
+				want = append(want, entry.Name())
+			}
+			var got []string
+			for _, file := range result.Files {
+				got = append(got, file.Name)
+			}
+			assert.ElementsMatch(t, want, got)
+
+			for _, file := range result.Files {
+				golden, err := os.ReadFile(filepath.Join(dir, file.Name))
+				if err != nil {
+					continue
+				}
+				assert.Equal(t, string(golden), string(file.Content), "%s differs from its golden file; run with -update if the change is intended", file.Name)
+			}
+		})
+	}
+}
+
+func TestTypeScript_Options(t *testing.T) {
+	parsed, err := parser.New().ParseBytes([]byte(`openapi: 3.0.3
+info:
+  title: Test
+  version: 1.0.0
+paths:
+  /things:
+    get:
+      operationId: getThing
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Response'
+components:
+  schemas:
+    Response:
+      type: object
+      properties:
+        error:
+          $ref: 'common.yaml#/components/schemas/Error'
+`))
+	require.NoError(t, err)
+
+	t.Run("types only", func(t *testing.T) {
+		result, err := GenerateWithOptions(WithParsed(*parsed), WithLanguage("typescript"))
+		require.NoError(t, err)
+		require.Len(t, result.Files, 1)
+		assert.Equal(t, "types.ts", result.Files[0].Name)
+		assert.Equal(t, 1, result.GeneratedTypes)
+
+		content := string(result.Files[0].Content)
+		assert.Contains(t, content, "error?: unknown;")
+		require.Len(t, result.Issues, 1)
+		assert.Contains(t, result.Issues[0].Message, "unresolved reference common.yaml#/components/schemas/Error")
+	})
+
+	t.Run("client aliases shadowing types", func(t *testing.T) {
+		result, err := GenerateWithOptions(WithParsed(*parsed), WithLanguage("typescript"), WithClient(true))
+		require.NoError(t, err)
+		content := string(result.GetFile("client.ts").Content)
+		assert.Contains(t, content, `import type { Response as ResponseSchema } from "./types";`)
+		assert.Contains(t, content, "async getThing(init?: RequestInit): Promise<ResponseSchema> {")
+		assert.Equal(t, 1, result.GeneratedOperations)
+	})
+
+	t.Run("invalid language", func(t *testing.T) {
+		_, err := GenerateWithOptions(WithParsed(*parsed), WithLanguage("rust"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `invalid language "rust"`)
+
+		g := New()
+		g.Language = "rust"
+		_, err = g.GenerateParsed(*parsed)
+		require.Error(t, err)
+	})
+
+	t.Run("server not supported", func(t *testing.T) {
+		_, err := GenerateWithOptions(WithParsed(*parsed), WithLanguage("typescript"), WithServer(true))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "server generation is not supported for TypeScript")
+	})
+}
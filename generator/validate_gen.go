@@ -0,0 +1,670 @@
+// This file implements generation of dependency-free Validate methods that
+// check generated types against the constraints of their schemas.
+
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/erraggy/oastools/internal/pathutil"
+	"github.com/erraggy/oastools/internal/schemautil"
+	"github.com/erraggy/oastools/parser"
+)
+
+// fileNameValidate is the file holding the generated Validate methods.
+const fileNameValidate = "validate.go"
+
+// maxValidateRefDepth bounds how many $refs to alias schemas are followed
+// when inlining their constraints, guarding against reference cycles.
+const maxValidateRefDepth = 8
+
+// validateRuntimeTypes names the types the validation runtime declares.
+var validateRuntimeTypes = []string{"ConstraintError", "ConstraintErrors"}
+
+// validateGenerator generates validate.go for one document.
+type validateGenerator struct {
+	*baseCodeGenerator
+
+	// schemas holds the component schemas that generated types, keyed by $ref
+	schemas map[string]*parser.Schema
+	// validated holds the Go type names that get a validate method
+	validated map[string]bool
+	// schemaToGoType converts a schema to a Go type for the document's version
+	schemaToGoType func(*parser.Schema, bool) string
+	// oas3 selects the OAS 3.x type layout: oneOf/anyOf unions, numbered
+	// duplicate struct fields, and no recursion pointers in allOf fields
+	oas3 bool
+
+	patterns       map[string]string // pattern source to variable name, "" if unsupported
+	patternSources []string          // supported pattern sources in declaration order
+}
+
+// generateValidateMethods generates Validate methods for the types generated
+// from components.schemas.
+func (cg *oas3CodeGenerator) generateValidateMethods() error {
+	if cg.doc.Components == nil {
+		return nil
+	}
+	cg.generateValidateFile(cg.doc.Components.Schemas, pathutil.SchemaRef, cg.schemaToGoType, true)
+	return nil
+}
+
+// generateValidateMethods generates Validate methods for the types generated
+// from definitions.
+func (cg *oas2CodeGenerator) generateValidateMethods() error {
+	cg.generateValidateFile(cg.doc.Definitions, pathutil.DefinitionRef, cg.schemaToGoType, false)
+	return nil
+}
+
+// validateEntry is a component schema and the Go type generated for it.
+type validateEntry struct {
+	name     string
+	typeName string
+	schema   *parser.Schema
+}
+
+// generateValidateFile writes validate.go with a Validate method for every
+// generated type whose schema it can check. schemas maps the document's
+// component schema names to schemas and refFor builds a $ref from a name.
+func (b *baseCodeGenerator) generateValidateFile(schemas map[string]*parser.Schema, refFor func(string) string,
+	schemaToGoType func(*parser.Schema, bool) string, oas3 bool) {
+	for _, name := range validateRuntimeTypes {
+		if b.generatedTypes[name] {
+			b.addIssue(fileNameValidate, fmt.Sprintf("schema type %s conflicts with the validation runtime - skipping Validate methods", name), SeverityWarning)
+			return
+		}
+	}
+
+	vg := &validateGenerator{
+		baseCodeGenerator: b,
+		schemas:           make(map[string]*parser.Schema),
+		validated:         make(map[string]bool),
+		schemaToGoType:    schemaToGoType,
+		oas3:              oas3,
+		patterns:          make(map[string]string),
+	}
+
+	// Only schemas that generated a type take part; duplicates were skipped
+	var entries []validateEntry
+	for name, schema := range schemas {
+		ref := refFor(name)
+		typeName, ok := b.schemaNames[ref]
+		if !ok || schema == nil {
+			continue
+		}
+		vg.schemas[ref] = schema
+		entries = append(entries, validateEntry{name: name, typeName: typeName, schema: schema})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].name < entries[j].name })
+
+	// Every method is known before any is written, since fields call them
+	for _, entry := range entries {
+		if vg.hasMethod(entry.name, entry.schema) {
+			vg.validated[entry.typeName] = true
+		}
+	}
+
+	var methods bytes.Buffer
+	for _, entry := range entries {
+		if vg.validated[entry.typeName] {
+			vg.writeMethods(&methods, entry)
+		}
+	}
+	if methods.Len() == 0 {
+		return
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by oastools. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", b.result.PackageName)
+	buf.WriteString(validateRuntime)
+
+	if len(vg.patternSources) > 0 {
+		buf.WriteString("\n// Patterns from the schemas, compiled once.\nvar (\n")
+		for _, source := range vg.patternSources {
+			fmt.Fprintf(&buf, "\t%s = regexp.MustCompile(%q)\n", vg.patterns[source], source)
+		}
+		buf.WriteString(")\n")
+	}
+	buf.Write(methods.Bytes())
+
+	appendFormattedFile(b.result, fileNameValidate, &buf, b.addIssue)
+}
+
+// hasMethod reports whether the type generated for a component schema is a
+// defined type that gets a validate method, as opposed to an alias.
+func (vg *validateGenerator) hasMethod(name string, schema *parser.Schema) bool {
+	if goTypeOverride(schema) != "" || schema.Ref != "" {
+		return false
+	}
+	switch getSchemaType(schema) {
+	case "object":
+		return !vg.hasValidateField(name, schema)
+	case "array":
+		// The OAS 2.0 tuple form generates a struct with its own JSON methods
+		_, isTuple := schemautil.SchemaTuple(schema.Items)
+		return !isTuple
+	case "string":
+		return len(schema.Enum) > 0
+	case "integer", "number", "boolean":
+		return false
+	}
+	if len(schema.AllOf) > 0 {
+		for _, sub := range schema.AllOf {
+			if sub != nil && vg.hasValidateField(name, sub) {
+				return false
+			}
+		}
+		return true
+	}
+	return vg.oas3 && (len(schema.OneOf) > 0 || len(schema.AnyOf) > 0)
+}
+
+// hasValidateField reports whether a property generates a field named
+// Validate, which would collide with the method.
+func (vg *validateGenerator) hasValidateField(name string, schema *parser.Schema) bool {
+	for propName, propSchema := range schema.Properties {
+		if goFieldName(propName, propSchema) == "Validate" {
+			vg.addIssue("components.schemas."+name+".properties."+propName,
+				"field Validate conflicts with the generated Validate method - skipping it", SeverityWarning)
+			return true
+		}
+	}
+	return false
+}
+
+// writeMethods writes the exported Validate method and the unexported
+// validate method that does the work for the type generated from entry.
+func (vg *validateGenerator) writeMethods(buf *bytes.Buffer, entry validateEntry) {
+	typeName, schema := entry.typeName, entry.schema
+
+	fmt.Fprintf(buf, "\n// Validate checks v against the constraints of the %s schema. It returns\n", entry.name)
+	buf.WriteString("// ConstraintErrors listing every violation, or nil if there are none.\n")
+	fmt.Fprintf(buf, "func (v *%s) Validate() error {\n", typeName)
+	buf.WriteString("\tvar errs ConstraintErrors\n\tv.validate(\"\", &errs)\n\treturn errs.err()\n}\n\n")
+	fmt.Fprintf(buf, "func (v *%s) validate(path string, errs *ConstraintErrors) {\n", typeName)
+
+	switch getSchemaType(schema) {
+	case "object":
+		vg.writeFields(buf, schema, toTypeName(entry.name), toTypeName(entry.name), vg.oas3)
+	case "array":
+		vg.writeValue(buf, "*v", "[]"+vg.getArrayItemType(schema, vg.schemaToGoType), schema, "path", 0, 0)
+	case "string":
+		vg.writeEnumCheck(buf, "*v", schema, "path", false)
+	default:
+		if len(schema.AllOf) > 0 {
+			vg.writeAllOf(buf, entry.name, typeName, schema)
+		} else {
+			vg.writeUnion(buf, schema)
+		}
+	}
+	buf.WriteString("}\n")
+}
+
+// writeFields writes the checks for each property of an object schema,
+// naming and typing fields the way struct generation does. refTypeName
+// detects recursive fields, and is empty where they get no pointer.
+// selfRefName detects the recursive fields that are not wrapped in Optional
+// or Nullable.
+func (vg *validateGenerator) writeFields(buf *bytes.Buffer, schema *parser.Schema, refTypeName, selfRefName string, dedupe bool) {
+	propNames := make([]string, 0, len(schema.Properties))
+	for propName := range schema.Properties {
+		propNames = append(propNames, propName)
+	}
+	sort.Strings(propNames)
+
+	usedFieldNames := make(map[string]int)
+	for _, propName := range propNames {
+		propSchema := schema.Properties[propName]
+		if propSchema == nil {
+			continue
+		}
+		required := isRequired(schema.Required, propName)
+		goType := vg.fieldGoType(propSchema, required, isSelfReference(propSchema, selfRefName), vg.schemaToGoType)
+		if refTypeName != "" && isSelfReference(propSchema, refTypeName) &&
+			!strings.HasPrefix(goType, "*") && !strings.HasPrefix(goType, "[]") {
+			goType = "*" + goType
+		}
+		fieldName := goFieldName(propName, propSchema)
+		if dedupe {
+			baseName := fieldName
+			if count, exists := usedFieldNames[baseName]; exists {
+				fieldName = fmt.Sprintf("%s%d", baseName, count+1)
+			}
+			usedFieldNames[baseName]++
+		}
+
+		expr := "v." + fieldName
+		pathExpr := fmt.Sprintf("path+%q", "/"+escapePointerToken(propName))
+
+		var checks bytes.Buffer
+		vg.writeValue(&checks, expr, goType, propSchema, pathExpr, 0, 0)
+
+		// A Nullable field tells an absent value from an explicit null
+		if wrapper, _ := optionalWrapper(goType); wrapper == "Nullable" && required {
+			fmt.Fprintf(buf, "\tif !%s.Set {\n\t\terrs.add(%s, \"is required\")\n\t}\n", expr, pathExpr)
+		}
+
+		nilable := strings.HasPrefix(goType, "*") || strings.HasPrefix(goType, "[]") || strings.HasPrefix(goType, "map[")
+		if required && nilable && !isNullableSchema(propSchema) {
+			fmt.Fprintf(buf, "\tif %s == nil {\n\t\terrs.add(%s, \"is required\")\n\t}", expr, pathExpr)
+			if checks.Len() > 0 {
+				buf.WriteString(" else {\n")
+				buf.Write(checks.Bytes())
+				buf.WriteString("\t}")
+			}
+			buf.WriteString("\n")
+			continue
+		}
+		buf.Write(checks.Bytes())
+	}
+}
+
+// writeAllOf writes the checks for an allOf struct: each embedded type
+// validates itself, and inline properties are checked in place.
+func (vg *validateGenerator) writeAllOf(buf *bytes.Buffer, name, typeName string, schema *parser.Schema) {
+	refTypeName, selfRefName := toTypeName(name), toTypeName(name)
+	if vg.oas3 {
+		refTypeName, selfRefName = "", typeName
+	}
+	for _, sub := range schema.AllOf {
+		if sub == nil {
+			continue
+		}
+		if sub.Ref != "" {
+			refType := vg.resolveRef(sub.Ref)
+			if vg.validated[refType] {
+				fmt.Fprintf(buf, "\tv.%s.validate(path, errs)\n", refType)
+			}
+			continue
+		}
+		if sub.Properties != nil {
+			vg.writeFields(buf, sub, refTypeName, selfRefName, false)
+		}
+	}
+}
+
+// writeUnion writes the checks for a oneOf/anyOf union struct, validating
+// whichever variant is set.
+func (vg *validateGenerator) writeUnion(buf *bytes.Buffer, schema *parser.Schema) {
+	variants := schema.OneOf
+	if len(variants) == 0 {
+		variants = schema.AnyOf
+	}
+	for _, sub := range variants {
+		if sub == nil || sub.Ref == "" {
+			continue
+		}
+		refType := vg.resolveRef(sub.Ref)
+		if vg.validated[refType] {
+			field := unqualifiedTypeName(refType)
+			fmt.Fprintf(buf, "\tif v.%s != nil {\n\t\tv.%s.validate(path, errs)\n\t}\n", field, field)
+		}
+	}
+}
+
+// writeValue writes the checks for the value expr, of Go type goType,
+// against schema. pathExpr is a Go expression for the value's JSON pointer.
+// depth numbers the loop variables of nested collections and refDepth counts
+// the alias $refs followed.
+func (vg *validateGenerator) writeValue(buf *bytes.Buffer, expr, goType string, schema *parser.Schema, pathExpr string, depth, refDepth int) {
+	if schema == nil || goTypeOverride(schema) != "" {
+		return
+	}
+
+	if wrapper, valueType := optionalWrapper(goType); wrapper != "" {
+		var checks bytes.Buffer
+		if vg.callsMethod(schema) {
+			fmt.Fprintf(&checks, "\t%s.Value.validate(%s, errs)\n", expr, pathExpr)
+		} else {
+			vg.writeValue(&checks, expr+".Value", valueType, schema, pathExpr, depth, refDepth)
+		}
+		if checks.Len() > 0 {
+			cond := expr + ".Set"
+			if wrapper == "Nullable" {
+				cond += " && !" + expr + ".Null"
+			}
+			fmt.Fprintf(buf, "\tif %s {\n", cond)
+			buf.Write(checks.Bytes())
+			buf.WriteString("\t}\n")
+		}
+		return
+	}
+
+	if elem, isPointer := strings.CutPrefix(goType, "*"); isPointer {
+		var checks bytes.Buffer
+		if vg.callsMethod(schema) {
+			fmt.Fprintf(&checks, "\t%s.validate(%s, errs)\n", expr, pathExpr)
+		} else {
+			vg.writeValue(&checks, "*"+expr, elem, schema, pathExpr, depth, refDepth)
+		}
+		if checks.Len() > 0 {
+			fmt.Fprintf(buf, "\tif %s != nil {\n", expr)
+			buf.Write(checks.Bytes())
+			buf.WriteString("\t}\n")
+		}
+		return
+	}
+
+	if schema.Ref != "" {
+		if vg.callsMethod(schema) {
+			fmt.Fprintf(buf, "\t%s.validate(%s, errs)\n", expr, pathExpr)
+			return
+		}
+		// Aliases have no methods, so their schema's constraints apply here
+		if target := vg.schemas[schema.Ref]; target != nil && refDepth < maxValidateRefDepth {
+			vg.writeValue(buf, expr, goType, target, pathExpr, depth, refDepth+1)
+		}
+		return
+	}
+
+	switch getSchemaType(schema) {
+	case "string":
+		if stringFormatToGoType(schema.Format) == "string" {
+			vg.writeStringChecks(buf, expr, schema, pathExpr)
+		}
+	case "integer", "number":
+		vg.writeNumberChecks(buf, expr, schema, pathExpr)
+	case "array":
+		if strings.HasPrefix(goType, "[]") {
+			vg.writeArrayChecks(buf, expr, goType[2:], schema, pathExpr, depth, refDepth)
+		}
+	case "object":
+		if elemType, isMap := strings.CutPrefix(goType, "map[string]"); isMap {
+			vg.writeMapChecks(buf, expr, schema, pathExpr)
+			if additional, ok := schema.AdditionalProperties.(*parser.Schema); ok {
+				vg.writeMapValues(buf, expr, elemType, additional, pathExpr, depth, refDepth)
+			}
+		}
+	}
+}
+
+// callsMethod reports whether a value of schema's type is checked by calling
+// its validate method.
+func (vg *validateGenerator) callsMethod(schema *parser.Schema) bool {
+	return schema.Ref != "" && goTypeOverride(schema) == "" && vg.validated[vg.resolveRef(schema.Ref)]
+}
+
+// writeStringChecks writes the length, pattern, and enum checks for a string.
+func (vg *validateGenerator) writeStringChecks(buf *bytes.Buffer, expr string, schema *parser.Schema, pathExpr string) {
+	if schema.MinLength != nil && *schema.MinLength > 0 {
+		fmt.Fprintf(buf, "\tif utf8.RuneCountInString(string(%s)) < %d {\n\t\terrs.add(%s, \"length must be at least %d\")\n\t}\n",
+			expr, *schema.MinLength, pathExpr, *schema.MinLength)
+	}
+	if schema.MaxLength != nil {
+		fmt.Fprintf(buf, "\tif utf8.RuneCountInString(string(%s)) > %d {\n\t\terrs.add(%s, \"length must be at most %d\")\n\t}\n",
+			expr, *schema.MaxLength, pathExpr, *schema.MaxLength)
+	}
+	if schema.Pattern != "" {
+		if name := vg.patternVar(schema.Pattern); name != "" {
+			fmt.Fprintf(buf, "\tif !%s.MatchString(string(%s)) {\n\t\terrs.add(%s, %q)\n\t}\n",
+				name, expr, pathExpr, "must match pattern "+schema.Pattern)
+		}
+	}
+	vg.writeEnumCheck(buf, expr, schema, pathExpr, false)
+}
+
+// patternVar returns the variable holding the compiled pattern, or "" if Go's
+// regexp syntax cannot express it.
+func (vg *validateGenerator) patternVar(pattern string) string {
+	if name, ok := vg.patterns[pattern]; ok {
+		return name
+	}
+	if _, err := regexp.Compile(pattern); err != nil {
+		vg.addIssue(fileNameValidate, fmt.Sprintf("pattern %q is not supported by Go regexp - not validated: %v", pattern, err), SeverityWarning)
+		vg.patterns[pattern] = ""
+		return ""
+	}
+	name := fmt.Sprintf("validatePattern%d", len(vg.patternSources))
+	vg.patterns[pattern] = name
+	vg.patternSources = append(vg.patternSources, pattern)
+	return name
+}
+
+// writeNumberChecks writes the bound, multipleOf, and enum checks for a number.
+func (vg *validateGenerator) writeNumberChecks(buf *bytes.Buffer, expr string, schema *parser.Schema, pathExpr string) {
+	writeBound := func(bound float64, op, message string) {
+		fmt.Fprintf(buf, "\tif float64(%s) %s %s {\n\t\terrs.add(%s, %q)\n\t}\n",
+			expr, op, formatFloat(bound), pathExpr, message+" "+formatFloat(bound))
+	}
+
+	if schema.Minimum != nil {
+		if exclusive, _ := schema.ExclusiveMinimum.(bool); exclusive {
+			writeBound(*schema.Minimum, "<=", "must be greater than")
+		} else {
+			writeBound(*schema.Minimum, "<", "must be at least")
+		}
+	}
+	if bound, ok := schema.ExclusiveMinimum.(float64); ok {
+		writeBound(bound, "<=", "must be greater than")
+	}
+	if schema.Maximum != nil {
+		if exclusive, _ := schema.ExclusiveMaximum.(bool); exclusive {
+			writeBound(*schema.Maximum, ">=", "must be less than")
+		} else {
+			writeBound(*schema.Maximum, ">", "must be at most")
+		}
+	}
+	if bound, ok := schema.ExclusiveMaximum.(float64); ok {
+		writeBound(bound, ">=", "must be less than")
+	}
+	if schema.MultipleOf != nil && *schema.MultipleOf > 0 {
+		m := formatFloat(*schema.MultipleOf)
+		fmt.Fprintf(buf, "\tif !isMultipleOf(float64(%s), %s) {\n\t\terrs.add(%s, %q)\n\t}\n",
+			expr, m, pathExpr, "must be a multiple of "+m)
+	}
+	vg.writeEnumCheck(buf, expr, schema, pathExpr, true)
+}
+
+// writeEnumCheck writes a switch that reports a value outside schema's enum.
+// Numeric values are compared as float64 so any enum value is a valid case.
+func (vg *validateGenerator) writeEnumCheck(buf *bytes.Buffer, expr string, schema *parser.Schema, pathExpr string, numeric bool) {
+	if len(schema.Enum) == 0 {
+		return
+	}
+	seen := make(map[string]bool, len(schema.Enum))
+	var cases, names []string
+	for _, value := range schema.Enum {
+		if value == nil {
+			continue
+		}
+		var literal string
+		if numeric {
+			f, ok := enumFloat(value)
+			if !ok {
+				continue
+			}
+			literal = formatFloat(f)
+		} else {
+			literal = strconv.Quote(fmt.Sprintf("%v", value))
+		}
+		if seen[literal] {
+			continue
+		}
+		seen[literal] = true
+		cases = append(cases, literal)
+		names = append(names, fmt.Sprintf("%v", value))
+	}
+	if len(cases) == 0 {
+		return
+	}
+
+	subject := expr
+	if numeric {
+		subject = "float64(" + expr + ")"
+	}
+	fmt.Fprintf(buf, "\tswitch %s {\n\tcase %s:\n\tdefault:\n\t\terrs.add(%s, %q)\n\t}\n",
+		subject, strings.Join(cases, ", "), pathExpr, "must be one of "+strings.Join(names, ", "))
+}
+
+// writeArrayChecks writes the item count and uniqueness checks for a slice,
+// and the checks for each item.
+func (vg *validateGenerator) writeArrayChecks(buf *bytes.Buffer, expr, elemType string, schema *parser.Schema, pathExpr string, depth, refDepth int) {
+	if schema.MinItems != nil && *schema.MinItems > 0 {
+		fmt.Fprintf(buf, "\tif len(%s) < %d {\n\t\terrs.add(%s, \"must have at least %d items\")\n\t}\n",
+			expr, *schema.MinItems, pathExpr, *schema.MinItems)
+	}
+	if schema.MaxItems != nil {
+		fmt.Fprintf(buf, "\tif len(%s) > %d {\n\t\terrs.add(%s, \"must have at most %d items\")\n\t}\n",
+			expr, *schema.MaxItems, pathExpr, *schema.MaxItems)
+	}
+	if schema.UniqueItems && isComparableGoType(elemType) {
+		fmt.Fprintf(buf, "\tif !itemsUnique(%s) {\n\t\terrs.add(%s, \"items must be unique\")\n\t}\n", expr, pathExpr)
+	}
+
+	items, ok := schema.Items.(*parser.Schema)
+	if !ok {
+		return
+	}
+	index := fmt.Sprintf("i%d", depth)
+	indexed := expr
+	if strings.HasPrefix(expr, "*") {
+		indexed = "(" + expr + ")"
+	}
+	var checks bytes.Buffer
+	vg.writeValue(&checks, indexed+"["+index+"]", elemType, items,
+		fmt.Sprintf("%s+\"/\"+strconv.Itoa(%s)", pathExpr, index), depth+1, refDepth)
+	if checks.Len() > 0 {
+		fmt.Fprintf(buf, "\tfor %s := range %s {\n", index, expr)
+		buf.Write(checks.Bytes())
+		buf.WriteString("\t}\n")
+	}
+}
+
+// writeMapChecks writes the property count checks for a map.
+func (vg *validateGenerator) writeMapChecks(buf *bytes.Buffer, expr string, schema *parser.Schema, pathExpr string) {
+	if schema.MinProperties != nil && *schema.MinProperties > 0 {
+		fmt.Fprintf(buf, "\tif len(%s) < %d {\n\t\terrs.add(%s, \"must have at least %d properties\")\n\t}\n",
+			expr, *schema.MinProperties, pathExpr, *schema.MinProperties)
+	}
+	if schema.MaxProperties != nil {
+		fmt.Fprintf(buf, "\tif len(%s) > %d {\n\t\terrs.add(%s, \"must have at most %d properties\")\n\t}\n",
+			expr, *schema.MaxProperties, pathExpr, *schema.MaxProperties)
+	}
+}
+
+// writeMapValues writes the checks for each value of a map.
+func (vg *validateGenerator) writeMapValues(buf *bytes.Buffer, expr, elemType string, schema *parser.Schema, pathExpr string, depth, refDepth int) {
+	key, item := fmt.Sprintf("k%d", depth), fmt.Sprintf("item%d", depth)
+	var checks bytes.Buffer
+	vg.writeValue(&checks, item, elemType, schema,
+		fmt.Sprintf("%s+\"/\"+escapePointerToken(%s)", pathExpr, key), depth+1, refDepth)
+	if checks.Len() > 0 {
+		fmt.Fprintf(buf, "\tfor %s, %s := range %s {\n", key, item, expr)
+		buf.Write(checks.Bytes())
+		buf.WriteString("\t}\n")
+	}
+}
+
+// isNullableSchema reports whether schema allows null, in which case a nil
+// required field is valid.
+func isNullableSchema(schema *parser.Schema) bool {
+	return schema.Nullable || schemautil.IsNullable(schema)
+}
+
+// isComparableGoType reports whether values of the Go type can be map keys,
+// which the generated uniqueness check needs.
+func isComparableGoType(goType string) bool {
+	switch goType {
+	case "string", "int32", goTypeInt64, "float32", goTypeFloat64, goTypeBool:
+		return true
+	}
+	return false
+}
+
+// enumFloat converts a numeric enum value to float64.
+func enumFloat(value any) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float64:
+		return v, !math.IsNaN(v) && !math.IsInf(v, 0)
+	}
+	return 0, false
+}
+
+// formatFloat formats f as the shortest Go constant that represents it.
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// escapePointerToken escapes a property name for use in a JSON pointer.
+func escapePointerToken(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+// validateRuntime declares the error types and helpers the generated
+// validate methods use.
+const validateRuntime = `// ConstraintError is a value that violates a schema constraint.
+type ConstraintError struct {
+	// Path is the JSON pointer to the value, such as "/items/0/name".
+	// It is empty for the value Validate was called on.
+	Path string
+	// Message describes the violated constraint.
+	Message string
+}
+
+// Error implements error.
+func (e *ConstraintError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// ConstraintErrors lists every constraint violation Validate found.
+type ConstraintErrors []*ConstraintError
+
+// Error implements error.
+func (e ConstraintErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e *ConstraintErrors) add(path, message string) {
+	*e = append(*e, &ConstraintError{Path: path, Message: message})
+}
+
+func (e ConstraintErrors) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// isMultipleOf reports whether v is a multiple of m, within rounding error.
+func isMultipleOf(v, m float64) bool {
+	q := v / m
+	return math.Abs(q-math.Round(q)) < 1e-9
+}
+
+// itemsUnique reports whether items holds no value twice.
+func itemsUnique[T comparable](items []T) bool {
+	seen := make(map[T]struct{}, len(items))
+	for _, item := range items {
+		if _, ok := seen[item]; ok {
+			return false
+		}
+		seen[item] = struct{}{}
+	}
+	return true
+}
+
+// escapePointerToken escapes a map key for use in a JSON pointer.
+func escapePointerToken(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+`
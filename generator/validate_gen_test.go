@@ -0,0 +1,271 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/erraggy/oastools/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const validateSpec = `openapi: 3.0.3
+info:
+  title: Validate
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Status:
+      type: string
+      enum: [available, sold]
+    Code:
+      type: string
+      pattern: '^[A-Z]{3}$'
+    Tag:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+          minLength: 1
+          maxLength: 10
+    Pet:
+      type: object
+      required: [name, tags, age]
+      properties:
+        name:
+          type: string
+          minLength: 2
+        age:
+          type: integer
+          minimum: 0
+          maximum: 30
+        weight:
+          type: number
+          exclusiveMinimum: true
+          minimum: 0
+          multipleOf: 0.5
+        status:
+          $ref: '#/components/schemas/Status'
+        code:
+          $ref: '#/components/schemas/Code'
+        tags:
+          type: array
+          minItems: 1
+          uniqueItems: true
+          items:
+            $ref: '#/components/schemas/Tag'
+        labels:
+          type: object
+          maxProperties: 2
+          additionalProperties:
+            type: string
+            maxLength: 3
+        parent:
+          $ref: '#/components/schemas/Pet'
+    Tags:
+      type: array
+      maxItems: 2
+      items:
+        $ref: '#/components/schemas/Tag'
+    Named:
+      allOf:
+        - $ref: '#/components/schemas/Tag'
+        - type: object
+          properties:
+            nick:
+              type: string
+              pattern: '^[a-z]+$'
+    Result:
+      oneOf:
+        - $ref: '#/components/schemas/Pet'
+        - $ref: '#/components/schemas/Tag'
+`
+
+func TestValidateMethods_Generated(t *testing.T) {
+	result := generateFromSpec(t, validateSpec, WithValidateMethods(true))
+
+	file := result.GetFile("validate.go")
+	require.NotNil(t, file)
+	content := string(file.Content)
+
+	assert.Contains(t, content, "type ConstraintError struct")
+	for _, typeName := range []string{"Pet", "Tag", "Tags", "Status", "Named", "Result"} {
+		assert.Contains(t, content, "func (v *"+typeName+") Validate() error", typeName)
+	}
+	// Aliases have no methods; their constraints are checked where they are used
+	assert.NotContains(t, content, "func (v *Code) Validate")
+	assert.Contains(t, content, `regexp.MustCompile("^[A-Z]{3}$")`)
+
+	assert.Contains(t, content, "v.Status.validate(")
+	assert.Contains(t, content, "v.Parent.validate(")
+	assert.Contains(t, content, "v.Tag.validate(path, errs)")
+	assert.Contains(t, content, "if v.Pet != nil {")
+	assert.Contains(t, content, `errs.add(path+"/tags", "is required")`)
+	assert.Contains(t, content, `"must be greater than 0"`)
+	assert.Contains(t, content, "isMultipleOf(float64(*v.Weight), 0.5)")
+	assert.Contains(t, content, "utf8.RuneCountInString")
+}
+
+func TestValidateMethods_Disabled(t *testing.T) {
+	parsed, err := parser.New().ParseBytes([]byte(validateSpec))
+	require.NoError(t, err)
+	result, err := GenerateWithOptions(WithParsed(*parsed), WithReadme(false))
+	require.NoError(t, err)
+	assert.Nil(t, result.GetFile("validate.go"))
+}
+
+func TestValidateMethods_OAS2(t *testing.T) {
+	result := generateFromSpec(t, `swagger: "2.0"
+info:
+  title: Validate
+  version: 1.0.0
+paths: {}
+definitions:
+  Pet:
+    type: object
+    required: [name]
+    properties:
+      name:
+        type: string
+        maxLength: 5
+      count:
+        type: integer
+        maximum: 10
+        exclusiveMaximum: true
+`, WithValidateMethods(true))
+	file := result.GetFile("validate.go")
+	require.NotNil(t, file)
+	content := string(file.Content)
+	assert.Contains(t, content, "func (v *Pet) Validate() error")
+	assert.Contains(t, content, `"must be less than 10"`)
+	assert.Contains(t, content, `"length must be at most 5"`)
+}
+
+func TestValidateMethods_Conflicts(t *testing.T) {
+	result := generateFromSpec(t, `openapi: 3.0.3
+info:
+  title: Validate
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Form:
+      type: object
+      properties:
+        validate:
+          type: boolean
+        name:
+          type: string
+          pattern: '(?=lookahead)'
+    Other:
+      type: object
+      properties:
+        name:
+          type: string
+          pattern: '(?=lookahead)'
+`, WithValidateMethods(true))
+	file := result.GetFile("validate.go")
+	require.NotNil(t, file)
+	content := string(file.Content)
+	assert.NotContains(t, content, "func (v *Form) Validate")
+	assert.Contains(t, content, "func (v *Other) Validate")
+	assert.NotContains(t, content, "lookahead")
+
+	var messages []string
+	for _, issue := range result.Issues {
+		messages = append(messages, issue.Message)
+	}
+	joined := strings.Join(messages, "\n")
+	assert.Contains(t, joined, "conflicts with the generated Validate method")
+	assert.Contains(t, joined, "not supported by Go regexp")
+}
+
+func TestValidateMethods_RuntimeNameCollision(t *testing.T) {
+	result := generateFromSpec(t, `openapi: 3.0.3
+info:
+  title: Validate
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    ConstraintError:
+      type: object
+      properties:
+        message:
+          type: string
+`, WithValidateMethods(true))
+	assert.Nil(t, result.GetFile("validate.go"))
+	require.NotEmpty(t, result.Issues)
+	assert.Equal(t, SeverityWarning, result.Issues[len(result.Issues)-1].Severity)
+}
+
+func TestEscapePointerToken(t *testing.T) {
+	assert.Equal(t, "a~1b~0c", escapePointerToken("a/b~c"))
+	assert.Equal(t, "plain", escapePointerToken("plain"))
+}
+
+// TestGeneratedValidateMethodsRun builds the generated types with a program
+// that checks valid and invalid values and prints the errors.
+func TestGeneratedValidateMethodsRun(t *testing.T) {
+	result := generateFromSpec(t, validateSpec, WithValidateMethods(true))
+
+	mainSrc := `package main
+
+import (
+	"errors"
+	"fmt"
+
+	"example.com/gen/api"
+)
+
+func main() {
+	valid := api.Pet{
+		Name:   "Rex",
+		Age:    3,
+		Tags:   []api.Tag{{Name: "good"}},
+		Labels: map[string]string{"a": "x"},
+	}
+	fmt.Println("valid:", valid.Validate())
+
+	weight := 0.7
+	status := api.Status("lost")
+	code := "abc"
+	invalid := api.Pet{
+		Name:   "R",
+		Age:    40,
+		Weight: &weight,
+		Status: &status,
+		Code:   &code,
+		Tags:   []api.Tag{{Name: ""}, {Name: ""}},
+		Labels: map[string]string{"a/b": "long", "b": "", "c": ""},
+		Parent: &api.Pet{Name: "Mo"},
+	}
+	err := invalid.Validate()
+	var errs api.ConstraintErrors
+	fmt.Println("is ConstraintErrors:", errors.As(err, &errs))
+	for _, e := range errs {
+		fmt.Println(e)
+	}
+
+	fmt.Println("result:", (&api.Result{Tag: &api.Tag{}}).Validate())
+	fmt.Println("tags:", (&api.Tags{{Name: "a"}, {Name: "b"}, {Name: "c"}}).Validate())
+}
+`
+	out := runGeneratedModule(t, result, mainSrc)
+	assert.Contains(t, out, "valid: <nil>")
+	assert.Contains(t, out, "is ConstraintErrors: true")
+	assert.Contains(t, out, "/name: length must be at least 2")
+	assert.Contains(t, out, "/age: must be at most 30")
+	assert.Contains(t, out, "/weight: must be a multiple of 0.5")
+	assert.Contains(t, out, "/status: must be one of available, sold")
+	assert.Contains(t, out, "/code: must match pattern ^[A-Z]{3}$")
+	assert.Contains(t, out, "/tags/0/name: length must be at least 1")
+	assert.Contains(t, out, "/tags/1/name: length must be at least 1")
+	assert.Contains(t, out, "/labels: must have at most 2 properties")
+	assert.Contains(t, out, "/labels/a~1b: length must be at most 3")
+	assert.Contains(t, out, "/parent/tags: is required")
+	assert.Contains(t, out, "result: /name: length must be at least 1")
+	assert.Contains(t, out, "tags: must have at most 2 items")
+}
@@ -1,11 +1,19 @@
 package generator
 
 import (
+	"bufio"
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/erraggy/oastools/internal/fileutil"
+	"github.com/erraggy/oastools/internal/textdiff"
 )
 
 // WriteFiles writes all generated files to the specified output directory.
@@ -44,3 +52,165 @@ func (f *GeneratedFile) WriteFile(path string) error {
 
 	return nil
 }
+
+// generatedHeader is the first line of every generated source file. It marks
+// files in an output directory as generated when checking for drift.
+const generatedHeader = "// Code generated by oastools. DO NOT EDIT."
+
+// readmeFileName is the name of the generated README.
+const readmeFileName = "README.md"
+
+// DriftStatus describes how a file in an output directory differs from the
+// generated result.
+type DriftStatus string
+
+const (
+	// DriftStale means the file exists but its content differs from the generated file
+	DriftStale DriftStatus = "stale"
+	// DriftMissing means the file would be generated but does not exist
+	DriftMissing DriftStatus = "missing"
+	// DriftOrphaned means the file is marked as generated by oastools but
+	// would no longer be generated
+	DriftOrphaned DriftStatus = "orphaned"
+)
+
+// FileDrift is one file that is out of date in an output directory.
+type FileDrift struct {
+	// Name is the file name within the output directory
+	Name string
+	// Status is how the file differs from the generated result
+	Status DriftStatus
+	// Diff is a unified diff from the file on disk to the generated file.
+	// It is empty for orphaned files.
+	Diff string
+}
+
+// DriftReport is the result of comparing a generated result against an
+// output directory.
+type DriftReport struct {
+	// OutputDir is the directory that was compared
+	OutputDir string
+	// Files lists the out-of-date files, sorted by name
+	Files []FileDrift
+}
+
+// HasDrift reports whether the output directory is out of date.
+func (d *DriftReport) HasDrift() bool {
+	return len(d.Files) > 0
+}
+
+// CheckFiles compares the generated files byte for byte against the files in
+// outputDir without writing anything. The Generated timestamp row of
+// README.md changes on every run and is ignored. Files that differ or do not
+// exist are reported with a unified diff. Files in outputDir that oastools
+// generated but that are not part of the result are reported as orphaned:
+// source files that start with the generated-code header, and a README.md
+// that carries the note of a generated README. Other files are taken to be
+// hand-written and left out of the comparison.
+func (r *GenerateResult) CheckFiles(outputDir string) (*DriftReport, error) {
+	report := &DriftReport{OutputDir: outputDir}
+	generated := make(map[string]bool, len(r.Files))
+
+	for _, file := range r.Files {
+		safeName := filepath.Base(file.Name)
+		if safeName != file.Name {
+			return nil, fmt.Errorf("invalid file name %q: must not contain path separators", file.Name)
+		}
+		generated[file.Name] = true
+
+		filePath := filepath.Join(outputDir, safeName)
+		existing, err := os.ReadFile(filePath) //nolint:gosec // G304: output directory is user-provided by design
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			report.Files = append(report.Files, FileDrift{
+				Name:   file.Name,
+				Status: DriftMissing,
+				Diff:   textdiff.Unified("/dev/null", filePath, nil, file.Content),
+			})
+		case err != nil:
+			return nil, fmt.Errorf("failed to read file %s: %w", file.Name, err)
+		case !sameContent(file.Name, existing, file.Content):
+			report.Files = append(report.Files, FileDrift{
+				Name:   file.Name,
+				Status: DriftStale,
+				Diff:   textdiff.Unified(filePath, filePath+" (generated)", existing, file.Content),
+			})
+		}
+	}
+
+	entries, err := os.ReadDir(outputDir)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read output directory: %w", err)
+	}
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || generated[entry.Name()] {
+			continue
+		}
+		isGenerated, err := isGeneratedOutput(filepath.Join(outputDir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if isGenerated {
+			report.Files = append(report.Files, FileDrift{Name: entry.Name(), Status: DriftOrphaned})
+		}
+	}
+
+	sort.Slice(report.Files, func(i, j int) bool {
+		return report.Files[i].Name < report.Files[j].Name
+	})
+	return report, nil
+}
+
+// readmeTimestampRow starts the README.md row recording when the code was
+// generated.
+const readmeTimestampRow = "| Generated | "
+
+// sameContent reports whether the existing file matches the generated
+// content. For README.md, the Generated timestamp row is left out of the
+// comparison so that regenerating an unchanged spec is not drift.
+func sameContent(name string, existing, generated []byte) bool {
+	if bytes.Equal(existing, generated) {
+		return true
+	}
+	if name != readmeFileName {
+		return false
+	}
+	return bytes.Equal(withoutTimestampRow(existing), withoutTimestampRow(generated))
+}
+
+// withoutTimestampRow returns content without its README timestamp row.
+func withoutTimestampRow(content []byte) []byte {
+	var buf bytes.Buffer
+	for line := range bytes.Lines(content) {
+		if bytes.HasPrefix(line, []byte(readmeTimestampRow)) {
+			continue
+		}
+		buf.Write(line)
+	}
+	return buf.Bytes()
+}
+
+// isGeneratedOutput reports whether the file at path was written by oastools:
+// README.md when it carries the note of a generated README, and any other
+// file when it starts with the generated-code header.
+func isGeneratedOutput(path string) (bool, error) {
+	if filepath.Base(path) == readmeFileName {
+		content, err := os.ReadFile(path) //nolint:gosec // G304: path is within the user-provided output directory
+		if err != nil {
+			return false, fmt.Errorf("failed to read file %s: %w", readmeFileName, err)
+		}
+		return bytes.Contains(content, []byte(readmeGeneratedNote)), nil
+	}
+
+	f, err := os.Open(path) //nolint:gosec // G304: path is within the user-provided output directory
+	if err != nil {
+		return false, fmt.Errorf("failed to read file %s: %w", filepath.Base(path), err)
+	}
+	defer func() { _ = f.Close() }()
+
+	line, err := bufio.NewReader(f).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, fmt.Errorf("failed to read file %s: %w", filepath.Base(path), err)
+	}
+	return strings.TrimRight(line, "\r\n") == generatedHeader, nil
+}
@@ -173,8 +173,11 @@ func (v *Validator) getOperation(pathTemplate, method string) *parser.Operation
 		return pathItem.Options
 	case http.MethodTrace:
 		return pathItem.Trace
+	case "QUERY":
+		return pathItem.Query
 	default:
-		return nil
+		// additionalOperations keys are the method exactly as sent (OAS 3.2+)
+		return pathItem.AdditionalOperations[method]
 	}
 }
 
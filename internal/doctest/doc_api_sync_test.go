@@ -31,9 +31,9 @@ func TestDocCodeExampleAPISync(t *testing.T) {
 
 	// Public oastools packages to verify symbol references against.
 	publicPkgNames := []string{
-		"builder", "converter", "differ", "fixer", "generator",
-		"httpvalidator", "joiner", "oaserrors", "overlay",
-		"parser", "validator", "walker",
+		"builder", "converter", "differ", "exporter", "fixer",
+		"generator", "httpvalidator", "joiner", "jsonpath", "oaserrors",
+		"overlay", "parser", "validator", "walker",
 	}
 
 	// Build symbol table: package name → set of exported symbol names.
@@ -51,7 +51,6 @@ func TestDocCodeExampleAPISync(t *testing.T) {
 	internalPkgs := map[string]string{
 		"severity":   "differ",
 		"httputil":   "",
-		"jsonpath":   "",
 		"maputil":    "",
 		"naming":     "",
 		"pathutil":   "",
@@ -36,6 +36,7 @@ func TestDeepDiveOptionTables(t *testing.T) {
 		{"overlay", "overlay"},
 		{"httpvalidator", "httpvalidator"},
 		{"generator", "generator"},
+		{"exporter", "exporter"},
 		{"builder", "builder"},
 		{"walker", "walker"},
 	}
@@ -128,7 +129,8 @@ func TestDeepDiveOptionTables(t *testing.T) {
 
 		// WithErrorHandler is referenced in generator docs but is actually
 		// a builder.ServerBuilderOption, not a generator.Option.
-		"generator": {"WithErrorHandler": true},
+		// WithRetryPolicy is a ClientOption of generated clients.
+		"generator": {"WithErrorHandler": true, "WithRetryPolicy": true},
 	}
 
 	for _, pkg := range packages {
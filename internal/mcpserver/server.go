@@ -97,12 +97,12 @@ func registerAllTools(server *mcp.Server) {
 
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "overlay_apply",
-		Description: "Apply an Overlay document to an OpenAPI Specification. Overlays use JSONPath expressions to update or remove parts of the spec. Supports dry-run preview and file output.",
+		Description: "Apply an Overlay document to an OpenAPI Specification. Overlays (1.0.0 or 1.1.0) use JSONPath expressions to update, copy onto or remove parts of the spec. Supports dry-run preview and file output.",
 	}, handleOverlayApply)
 
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "overlay_validate",
-		Description: "Validate an Overlay document structure. Checks required fields, supported version, valid JSONPath syntax in action targets, and that actions have update or remove operations.",
+		Description: "Validate an Overlay document structure. Checks required fields, supported version, valid JSONPath syntax in action targets and copy sources, that actions have update, copy or remove operations, and that a 1.0.0 overlay uses no 1.1 features.",
 	}, handleOverlayValidate)
 
 	mcp.AddTool(server, &mcp.Tool{
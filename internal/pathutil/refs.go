@@ -120,6 +120,10 @@ func CutRefPrefix(ref, prefix string) (rest string, ok bool) {
 	return ref[i:], true
 }
 
+// RefPrefixPaths is the prefix of a reference into the paths object, which
+// every OAS version shares.
+const RefPrefixPaths = "#/paths/"
+
 // OAS 2.0 reference prefixes
 const (
 	RefPrefixDefinitions         = "#/definitions/"
@@ -0,0 +1,199 @@
+// Package textdiff renders line-based unified diffs of text files.
+package textdiff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// contextLines is the number of unchanged lines shown around each change,
+// matching diff -u
+const contextLines = 3
+
+// maxAlignCells bounds the size of the table used to align the changed middle
+// of two texts. Texts whose changed regions are larger than this are shown as
+// one replacement rather than aligned line by line.
+const maxAlignCells = 4_000_000
+
+// noNewline marks a final line that has no line terminator
+const noNewline = "\\ No newline at end of file\n"
+
+// op is one line of an edit script: kept (' '), deleted ('-') or inserted ('+')
+type op struct {
+	kind byte
+	line string
+}
+
+// Unified returns the unified diff that turns old into new, with oldName and
+// newName as the file labels. It returns "" when the texts are equal.
+func Unified(oldName, newName string, old, new []byte) string {
+	if string(old) == string(new) {
+		return ""
+	}
+	ops := editScript(splitLines(string(old)), splitLines(string(new)))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", oldName, newName)
+	for _, h := range hunks(ops) {
+		writeHunk(&b, ops, h)
+	}
+	return b.String()
+}
+
+// splitLines splits text into lines, each keeping its terminator
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// editScript returns the lines of old and new as a sequence of kept, deleted
+// and inserted lines. The common prefix and suffix are kept as they are, and
+// the middle is aligned on a longest common subsequence of lines.
+func editScript(old, new []string) []op {
+	prefix := 0
+	for prefix < len(old) && prefix < len(new) && old[prefix] == new[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(old)-prefix && suffix < len(new)-prefix &&
+		old[len(old)-1-suffix] == new[len(new)-1-suffix] {
+		suffix++
+	}
+
+	ops := make([]op, 0, len(old)+len(new))
+	for _, line := range old[:prefix] {
+		ops = append(ops, op{' ', line})
+	}
+	ops = append(ops, alignMiddle(old[prefix:len(old)-suffix], new[prefix:len(new)-suffix])...)
+	for _, line := range old[len(old)-suffix:] {
+		ops = append(ops, op{' ', line})
+	}
+	return ops
+}
+
+// alignMiddle returns the edit script of two texts that differ at both ends
+func alignMiddle(old, new []string) []op {
+	n, m := len(old), len(new)
+	ops := make([]op, 0, n+m)
+	if n*m > maxAlignCells {
+		for _, line := range old {
+			ops = append(ops, op{'-', line})
+		}
+		for _, line := range new {
+			ops = append(ops, op{'+', line})
+		}
+		return ops
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of old[i:] and new[j:]
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if old[i] == new[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case old[i] == new[j]:
+			ops = append(ops, op{' ', old[i]})
+			i, j = i+1, j+1
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, op{'-', old[i]})
+			i++
+		default:
+			ops = append(ops, op{'+', new[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		ops = append(ops, op{'-', old[i]})
+	}
+	for ; j < m; j++ {
+		ops = append(ops, op{'+', new[j]})
+	}
+	return ops
+}
+
+// hunk is a half-open range of an edit script shown under one @@ header
+type hunk struct {
+	start, end int
+}
+
+// hunks groups the changes of an edit script with their surrounding context.
+// Changes whose context would touch or overlap share a hunk.
+func hunks(ops []op) []hunk {
+	var result []hunk
+	for i, o := range ops {
+		if o.kind == ' ' {
+			continue
+		}
+		start := max(i-contextLines, 0)
+		end := min(i+1+contextLines, len(ops))
+		if last := len(result) - 1; last >= 0 && start <= result[last].end {
+			result[last].end = end
+			continue
+		}
+		result = append(result, hunk{start, end})
+	}
+	return result
+}
+
+// writeHunk writes the header and lines of one hunk
+func writeHunk(b *strings.Builder, ops []op, h hunk) {
+	// Line numbers are 1-based positions of the hunk's first line in each text
+	oldStart, newStart := 1, 1
+	for _, o := range ops[:h.start] {
+		if o.kind != '+' {
+			oldStart++
+		}
+		if o.kind != '-' {
+			newStart++
+		}
+	}
+	oldCount, newCount := 0, 0
+	for _, o := range ops[h.start:h.end] {
+		if o.kind != '+' {
+			oldCount++
+		}
+		if o.kind != '-' {
+			newCount++
+		}
+	}
+
+	fmt.Fprintf(b, "@@ -%s +%s @@\n", hunkRange(oldStart, oldCount), hunkRange(newStart, newCount))
+	for _, o := range ops[h.start:h.end] {
+		b.WriteByte(o.kind)
+		b.WriteString(o.line)
+		if !strings.HasSuffix(o.line, "\n") {
+			b.WriteString("\n" + noNewline)
+		}
+	}
+}
+
+// hunkRange formats one side of a hunk header as diff -u does: the count is
+// omitted when it is 1, and an empty range names the line before it.
+func hunkRange(start, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", start-1)
+	case 1:
+		return fmt.Sprintf("%d", start)
+	default:
+		return fmt.Sprintf("%d,%d", start, count)
+	}
+}
@@ -0,0 +1,94 @@
+package textdiff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnified(t *testing.T) {
+	tests := []struct {
+		name     string
+		old      string
+		new      string
+		expected string
+	}{
+		{
+			name:     "equal",
+			old:      "a\nb\n",
+			new:      "a\nb\n",
+			expected: "",
+		},
+		{
+			name: "changed line",
+			old:  "a\nb\nc\n",
+			new:  "a\nB\nc\n",
+			expected: "--- old\n+++ new\n" +
+				"@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n",
+		},
+		{
+			name: "inserted line",
+			old:  "a\nc\n",
+			new:  "a\nb\nc\n",
+			expected: "--- old\n+++ new\n" +
+				"@@ -1,2 +1,3 @@\n a\n+b\n c\n",
+		},
+		{
+			name: "from empty",
+			old:  "",
+			new:  "a\n",
+			expected: "--- old\n+++ new\n" +
+				"@@ -0,0 +1 @@\n+a\n",
+		},
+		{
+			name: "to empty",
+			old:  "a\nb\n",
+			new:  "",
+			expected: "--- old\n+++ new\n" +
+				"@@ -1,2 +0,0 @@\n-a\n-b\n",
+		},
+		{
+			name: "missing final newline",
+			old:  "a\nb",
+			new:  "a\nb\n",
+			expected: "--- old\n+++ new\n" +
+				"@@ -1,2 +1,2 @@\n a\n-b\n\\ No newline at end of file\n+b\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Unified("old", "new", []byte(tt.old), []byte(tt.new)))
+		})
+	}
+}
+
+func TestUnified_Hunks(t *testing.T) {
+	lines := make([]string, 20)
+	for i := range lines {
+		lines[i] = string(rune('a'+i)) + "\n"
+	}
+	old := strings.Join(lines, "")
+
+	t.Run("distant changes get separate hunks", func(t *testing.T) {
+		changed := append([]string(nil), lines...)
+		changed[1] = "B\n"
+		changed[18] = "S\n"
+		diff := Unified("old", "new", []byte(old), []byte(strings.Join(changed, "")))
+
+		assert.Equal(t, 2, strings.Count(diff, "@@ -"))
+		assert.Contains(t, diff, "@@ -1,5 +1,5 @@\n")
+		assert.Contains(t, diff, "@@ -16,5 +16,5 @@\n")
+	})
+
+	t.Run("nearby changes share a hunk", func(t *testing.T) {
+		changed := append([]string(nil), lines...)
+		changed[5] = "F\n"
+		changed[10] = "K\n"
+		diff := Unified("old", "new", []byte(old), []byte(strings.Join(changed, "")))
+
+		assert.Equal(t, 1, strings.Count(diff, "@@ -"))
+		assert.Contains(t, diff, "@@ -3,12 +3,12 @@\n")
+	})
+}
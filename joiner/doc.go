@@ -4,7 +4,11 @@
 // document. It supports OAS 2.0 documents with other 2.0 documents, and all OAS 3.x
 // versions together (3.0.x, 3.1.x, 3.2.x). It uses the version and format (JSON or YAML)
 // from the first document as the result version and format, ensuring format consistency
-// when writing output with WriteResult.
+// when writing output with WriteResult. To join documents of different major versions,
+// use [WithTargetVersion] to convert every input to a common version first. To compose
+// a gateway spec from service specs, [WithPathPrefix] mounts each service's paths
+// under a prefix and folds its server path into them, and [WithSourceExtension]
+// stamps each path item, operation and component with the source it came from.
 //
 // # Configuration
 //
@@ -433,6 +437,10 @@
 //   - WarnNamespacePrefixed: Namespace prefix was applied
 //   - WarnMetadataOverride: Metadata was overridden (host, basePath)
 //   - WarnGenericSourceName: Document has a generic source name (e.g., "ParseBytes.yaml")
+//   - WarnConversionIssue: Issue reported while converting an input (see WithTargetVersion)
+//   - WarnPathMounted: A document's paths were mounted under a path prefix (see WithPathPrefix)
+//   - WarnServerPathConflict: A server's path could not be folded into the mounted paths
+//   - WarnMountedPathCollision: A path was dropped because another path of its document mounted at the same key
 //
 // For backward compatibility, warnings are also available as []string via result.Warnings.
 //
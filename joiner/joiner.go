@@ -96,6 +96,20 @@ type JoinerConfig struct {
 	// Example: {"users-api.yaml": "Users", "billing-api.yaml": "Billing"}
 	// When a prefix is configured, schemas from that source get prefixed: User -> Users_User
 	NamespacePrefix map[string]string
+	// PathPrefix maps source file paths to path prefixes their paths are mounted under
+	// Example: {"billing-api.yaml": "/billing"}
+	// The path portion of each source's server URLs (or basePath) is folded in as well,
+	// so "/invoices" served from "https://billing.internal/v1" becomes "/billing/v1/invoices".
+	// See WithPathPrefix.
+	PathPrefix map[string]string
+	// SourceExtension is the extension key stamped on each path item, operation and
+	// component of the joined document, naming the source document it came from.
+	// Empty (default) disables stamping. See WithSourceExtension.
+	SourceExtension string
+	// SourceLabels maps source file paths to the values stamped by SourceExtension.
+	// Sources without a label are stamped with their file path.
+	// Example: {"billing-api.yaml": "team-billing"}
+	SourceLabels map[string]string
 	// AlwaysApplyPrefix when true applies namespace prefix to all schemas from a source,
 	// not just those that collide. When false (default), prefix is only applied on collision.
 	AlwaysApplyPrefix bool
@@ -140,6 +154,8 @@ func DefaultConfig() JoinerConfig {
 		MergeArrays:       true,
 		RenameTemplate:    "{{.Name}}_{{.Source}}",
 		NamespacePrefix:   make(map[string]string),
+		PathPrefix:        make(map[string]string),
+		SourceLabels:      make(map[string]string),
 		AlwaysApplyPrefix: false,
 		EquivalenceMode:   "none",
 		EquivalenceDocs:   string(EquivalenceDocsInclude),
@@ -374,6 +390,9 @@ func (j *Joiner) JoinParsed(parsedDocs []parser.ParseResult) (*JoinResult, error
 		}
 	}
 
+	// Mount paths under their configured prefixes (on copies)
+	parsedDocs, mountWarnings := j.mountPaths(parsedDocs)
+
 	// Verify all documents are the same major version
 	baseVersion := parsedDocs[0].OASVersion
 	var versionWarnings JoinWarnings
@@ -404,11 +423,17 @@ func (j *Joiner) JoinParsed(parsedDocs []parser.ParseResult) (*JoinResult, error
 		return nil, fmt.Errorf("joiner: unsupported OpenAPI version: %s", parsedDocs[0].Version)
 	}
 
+	// Stamp provenance extensions on the merged document
+	if err == nil {
+		j.stampSources(result)
+	}
+
 	// Add early warnings to result (prepend so they appear first)
 	if result != nil {
 		var prependWarnings JoinWarnings
 		prependWarnings = append(prependWarnings, genericNameWarnings...)
 		prependWarnings = append(prependWarnings, versionWarnings...)
+		prependWarnings = append(prependWarnings, mountWarnings...)
 		if len(prependWarnings) > 0 {
 			result.StructuredWarnings = append(prependWarnings, result.StructuredWarnings...)
 			// Rebuild legacy Warnings slice from StructuredWarnings for consistency
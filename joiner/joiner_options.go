@@ -28,6 +28,9 @@ type joinConfig struct {
 	// Advanced collision strategies configuration
 	renameTemplate         *string
 	namespacePrefix        map[string]string
+	pathPrefix             map[string]string
+	sourceExtension        *string
+	sourceLabels           map[string]string
 	alwaysApplyPrefix      *bool
 	equivalenceMode        *string
 	equivalenceDocs        *string
@@ -50,18 +53,23 @@ type joinConfig struct {
 	postJoinOverlayFile *string                     // File path for post-join overlay
 	specOverlays        map[string]*overlay.Overlay // Per-spec overlays
 	specOverlayFiles    map[string]string           // Per-spec overlay file paths
+
+	// Version conversion applied to every input before joining
+	targetVersion *string
 }
 
 // JoinWithOptions joins multiple OpenAPI specifications using functional options.
 // This provides a flexible, extensible API that combines input source selection
 // and configuration in a single function call.
 //
-// When overlay options are provided, the join process follows these steps:
+// When overlay or target version options are provided, the join process
+// follows these steps:
 //  1. Parse all input specifications
 //  2. Apply pre-join overlays to all specs (in order specified)
 //  3. Apply per-spec overlays to their respective specs
-//  4. Perform the join operation
-//  5. Apply post-join overlay to the merged result
+//  4. Convert specs to the target version (see WithTargetVersion)
+//  5. Perform the join operation
+//  6. Apply post-join overlay to the merged result
 //
 // Example:
 //
@@ -88,6 +96,9 @@ func JoinWithOptions(opts ...Option) (*JoinResult, error) {
 		MergeArrays:           boolValueOrDefault(cfg.mergeArrays, defaults.MergeArrays),
 		RenameTemplate:        stringValueOrDefault(cfg.renameTemplate, defaults.RenameTemplate),
 		NamespacePrefix:       mapValueOrDefault(cfg.namespacePrefix, defaults.NamespacePrefix),
+		PathPrefix:            mapValueOrDefault(cfg.pathPrefix, defaults.PathPrefix),
+		SourceExtension:       stringValueOrDefault(cfg.sourceExtension, defaults.SourceExtension),
+		SourceLabels:          mapValueOrDefault(cfg.sourceLabels, defaults.SourceLabels),
 		AlwaysApplyPrefix:     boolValueOrDefault(cfg.alwaysApplyPrefix, defaults.AlwaysApplyPrefix),
 		EquivalenceMode:       stringValueOrDefault(cfg.equivalenceMode, defaults.EquivalenceMode),
 		EquivalenceDocs:       stringValueOrDefault(cfg.equivalenceDocs, defaults.EquivalenceDocs),
@@ -122,12 +133,12 @@ func JoinWithOptions(opts ...Option) (*JoinResult, error) {
 		len(cfg.specOverlays) > 0 ||
 		len(cfg.specOverlayFiles) > 0
 
-	// Fast path: no overlays configured, use original logic
-	if !hasOverlays {
+	// Fast path: no overlays or conversion configured, use original logic
+	if !hasOverlays && cfg.targetVersion == nil {
 		return joinWithoutOverlays(j, cfg)
 	}
 
-	// Slow path: overlays require us to parse, transform, then join
+	// Slow path: overlays and conversion require us to parse, transform, then join
 	return joinWithOverlays(j, cfg)
 }
 
@@ -158,7 +169,7 @@ func joinWithoutOverlays(j *Joiner, cfg *joinConfig) (*JoinResult, error) {
 	return j.JoinParsed(allDocs)
 }
 
-// joinWithOverlays handles join with overlay processing
+// joinWithOverlays handles join with overlay processing and target version conversion
 func joinWithOverlays(j *Joiner, cfg *joinConfig) (*JoinResult, error) {
 	// Step 1: Parse all overlay files
 	preOverlays, err := parseOverlayList(cfg.preJoinOverlays, cfg.preJoinOverlayFiles)
@@ -234,13 +245,26 @@ func joinWithOverlays(j *Joiner, cfg *joinConfig) (*JoinResult, error) {
 		}
 	}
 
-	// Step 4: Perform the join
+	// Step 4: Convert all documents to the target version
+	var conversionWarnings JoinWarnings
+	if cfg.targetVersion != nil {
+		conversionWarnings, err = convertDocuments(allDocs, *cfg.targetVersion, cfg.sourceMaps)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Step 5: Perform the join
 	joinResult, err := j.JoinParsed(allDocs)
 	if err != nil {
 		return nil, err
 	}
+	if len(conversionWarnings) > 0 {
+		joinResult.StructuredWarnings = append(conversionWarnings, joinResult.StructuredWarnings...)
+		joinResult.Warnings = joinResult.StructuredWarnings.Strings()
+	}
 
-	// Step 5: Apply post-join overlay
+	// Step 6: Apply post-join overlay
 	if postOverlay != nil {
 		postResult, err := applier.ApplyParsed(&parser.ParseResult{
 			Document:     joinResult.Document,
@@ -377,6 +401,9 @@ func WithConfig(config JoinerConfig) Option {
 		cfg.mergeArrays = &config.MergeArrays
 		cfg.renameTemplate = &config.RenameTemplate
 		cfg.namespacePrefix = config.NamespacePrefix
+		cfg.pathPrefix = config.PathPrefix
+		cfg.sourceExtension = &config.SourceExtension
+		cfg.sourceLabels = config.SourceLabels
 		cfg.alwaysApplyPrefix = &config.AlwaysApplyPrefix
 		cfg.equivalenceMode = &config.EquivalenceMode
 		cfg.equivalenceDocs = &config.EquivalenceDocs
@@ -0,0 +1,73 @@
+package joiner
+
+import (
+	"fmt"
+
+	"github.com/erraggy/oastools/converter"
+	"github.com/erraggy/oastools/parser"
+)
+
+// WithTargetVersion converts every input document to the given OpenAPI version
+// before joining, so documents of different major versions can be merged.
+//
+// Each input whose version differs from the target is run through
+// converter.ConvertWithOptions. Conversion issues are reported in
+// JoinResult.StructuredWarnings with category WarnConversionIssue, tagged with
+// the source file they came from. A conversion that fails outright fails the join.
+//
+// Example:
+//
+//	result, err := joiner.JoinWithOptions(
+//	    joiner.WithFilePaths("gateway.yaml", "legacy-swagger.yaml"),
+//	    joiner.WithTargetVersion("3.1.0"),
+//	)
+func WithTargetVersion(version string) Option {
+	return func(cfg *joinConfig) error {
+		if _, ok := parser.ParseVersion(version); !ok {
+			return fmt.Errorf("invalid target version: %s", version)
+		}
+		cfg.targetVersion = &version
+		return nil
+	}
+}
+
+// convertDocuments converts each document to the target version in place and
+// returns the conversion issues as warnings. Documents already at the target
+// version are left untouched.
+func convertDocuments(docs []parser.ParseResult, targetVersion string, sourceMaps map[string]*parser.SourceMap) (JoinWarnings, error) {
+	target, _ := parser.ParseVersion(targetVersion)
+
+	var warnings JoinWarnings
+	for i := range docs {
+		if docs[i].OASVersion == target {
+			continue
+		}
+
+		opts := []converter.Option{
+			converter.WithParsed(docs[i]),
+			converter.WithTargetVersion(targetVersion),
+		}
+		if sm := sourceMaps[docs[i].SourcePath]; sm != nil {
+			opts = append(opts, converter.WithSourceMap(sm))
+		}
+
+		convResult, err := converter.ConvertWithOptions(opts...)
+		if err != nil {
+			return nil, fmt.Errorf("joiner: failed to convert %s from %s to %s: %w",
+				docs[i].SourcePath, docs[i].Version, targetVersion, err)
+		}
+
+		for _, issue := range convResult.Issues {
+			warnings = append(warnings, NewConversionIssueWarning(issue, docs[i].SourcePath, docs[i].Version, targetVersion))
+		}
+
+		// Keep the source identity so collisions, namespace prefixes and
+		// per-spec options still refer to the original file.
+		converted := convResult.ToParseResult()
+		converted.SourcePath = docs[i].SourcePath
+		converted.Warnings = docs[i].Warnings
+		docs[i] = *converted
+	}
+
+	return warnings, nil
+}
@@ -0,0 +1,172 @@
+package joiner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/erraggy/oastools/converter"
+	"github.com/erraggy/oastools/internal/severity"
+	"github.com/erraggy/oastools/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const gatewaySpec31 = `openapi: "3.1.0"
+info:
+  title: Gateway
+  version: "1.0.0"
+paths:
+  /orders:
+    get:
+      operationId: listOrders
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Order'
+components:
+  schemas:
+    Order:
+      type: object
+      properties:
+        id:
+          type: string
+`
+
+// legacySpec20 declares no host, which the converter reports when it
+// synthesizes the default server.
+const legacySpec20 = `swagger: "2.0"
+info:
+  title: Legacy
+  version: "1.0.0"
+paths:
+  /users:
+    get:
+      operationId: listUsers
+      produces:
+        - application/json
+      responses:
+        "200":
+          description: OK
+          schema:
+            $ref: '#/definitions/UserList'
+definitions:
+  User:
+    type: object
+    properties:
+      id:
+        type: string
+  UserList:
+    type: object
+    properties:
+      users:
+        type: array
+        items:
+          $ref: '#/definitions/User'
+`
+
+func TestWithTargetVersion_CrossMajorVersion(t *testing.T) {
+	dir := t.TempDir()
+	gatewayPath := filepath.Join(dir, "gateway.yaml")
+	legacyPath := filepath.Join(dir, "legacy.yaml")
+	require.NoError(t, os.WriteFile(gatewayPath, []byte(gatewaySpec31), 0600))
+	require.NoError(t, os.WriteFile(legacyPath, []byte(legacySpec20), 0600))
+
+	result, err := JoinWithOptions(
+		WithFilePaths(gatewayPath, legacyPath),
+		WithTargetVersion("3.1.0"),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, parser.OASVersion310, result.OASVersion)
+	doc, ok := result.Document.(*parser.OAS3Document)
+	require.True(t, ok, "expected *parser.OAS3Document, got %T", result.Document)
+
+	assert.Contains(t, doc.Paths, "/orders")
+	require.Contains(t, doc.Paths, "/users")
+	require.NotNil(t, doc.Components)
+	assert.Contains(t, doc.Components.Schemas, "Order")
+	require.Contains(t, doc.Components.Schemas, "UserList")
+
+	// The converted document's refs point at components, not definitions.
+	items := doc.Components.Schemas["UserList"].Properties["users"].Items
+	itemSchema, ok := items.(*parser.Schema)
+	require.True(t, ok)
+	assert.Equal(t, "#/components/schemas/User", itemSchema.Ref)
+
+	conversion := result.StructuredWarnings.ByCategory(WarnConversionIssue)
+	require.NotEmpty(t, conversion, "expected conversion issues to be reported")
+	for _, w := range conversion {
+		assert.Equal(t, legacyPath, w.SourceFile)
+		assert.Equal(t, "2.0", w.Context["source_version"])
+		assert.Equal(t, "3.1.0", w.Context["target_version"])
+	}
+	assert.Len(t, result.Warnings, len(result.StructuredWarnings))
+}
+
+func TestWithTargetVersion_Parsed(t *testing.T) {
+	gateway, err := parser.ParseWithOptions(parser.WithBytes([]byte(gatewaySpec31)))
+	require.NoError(t, err)
+	gateway.SourcePath = "gateway.yaml"
+
+	legacy, err := parser.ParseWithOptions(parser.WithBytes([]byte(legacySpec20)))
+	require.NoError(t, err)
+	legacy.SourcePath = "legacy.yaml"
+
+	// Without a target version the major versions are incompatible.
+	_, err = JoinWithOptions(WithParsed(*gateway, *legacy))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "incompatible versions")
+
+	result, err := JoinWithOptions(
+		WithParsed(*gateway, *legacy),
+		WithTargetVersion("3.1.0"),
+	)
+	require.NoError(t, err)
+
+	doc, ok := result.Document.(*parser.OAS3Document)
+	require.True(t, ok)
+	assert.Contains(t, doc.Paths, "/users")
+
+	conversion := result.StructuredWarnings.ByCategory(WarnConversionIssue)
+	require.NotEmpty(t, conversion)
+	for _, w := range conversion {
+		assert.Equal(t, "legacy.yaml", w.SourceFile, "only the converted document reports issues")
+	}
+
+	// The caller's documents are not modified.
+	_, ok = legacy.Document.(*parser.OAS2Document)
+	assert.True(t, ok)
+}
+
+func TestWithTargetVersion_Invalid(t *testing.T) {
+	_, err := JoinWithOptions(
+		WithFilePaths("a.yaml", "b.yaml"),
+		WithTargetVersion("9.9.9"),
+	)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid target version")
+}
+
+func TestNewConversionIssueWarning(t *testing.T) {
+	issue := converter.ConversionIssue{
+		Path:     "document.paths./users.get",
+		Message:  "collectionFormat has no exact OAS 3.x equivalent",
+		Severity: severity.SeverityWarning,
+		Context:  "collectionFormat",
+		Line:     12,
+		Column:   5,
+	}
+
+	w := NewConversionIssueWarning(issue, "legacy.yaml", "2.0", "3.1.0")
+	assert.Equal(t, WarnConversionIssue, w.Category)
+	assert.Equal(t, "document.paths./users.get", w.Path)
+	assert.Equal(t, "legacy.yaml", w.SourceFile)
+	assert.Equal(t, 12, w.Line)
+	assert.Equal(t, severity.SeverityWarning, w.Severity)
+	assert.Equal(t, "collectionFormat", w.Context["context"])
+	assert.Contains(t, w.String(), "legacy.yaml: converting 2.0 to 3.1.0")
+}
@@ -0,0 +1,455 @@
+package joiner
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/erraggy/oastools/internal/maputil"
+	"github.com/erraggy/oastools/internal/pathutil"
+	"github.com/erraggy/oastools/internal/schemautil"
+	"github.com/erraggy/oastools/parser"
+)
+
+// WithPathPrefix mounts every path of a source document under prefix.
+//
+// Besides prepending prefix, the path portion of the document's base URL is
+// folded into each path, so the joined document can be served from a single
+// gateway server. For OAS 3.x the base is the path of the first entry in
+// servers (variables take their default values), and path-level servers
+// override it for their path item; the folded portion is stripped from the
+// server URLs that carried it. For OAS 2.0 the base is basePath, which is
+// cleared. References into the paths object ($ref and link operationRef) are
+// rewritten to the mounted keys. Webhooks and callbacks are not mounted.
+//
+// An empty prefix folds the base URL without adding a prefix.
+// Can be called multiple times to add multiple mappings.
+//
+// Example:
+//
+//	result, err := joiner.JoinWithOptions(
+//	    joiner.WithFilePaths("billing.yaml", "users.yaml"),
+//	    joiner.WithPathPrefix("billing.yaml", "/billing"),
+//	    joiner.WithPathPrefix("users.yaml", "/users"),
+//	)
+func WithPathPrefix(sourcePath, prefix string) Option {
+	return func(cfg *joinConfig) error {
+		if prefix != "" && !strings.HasPrefix(prefix, "/") {
+			return fmt.Errorf("path prefix for %s must start with '/': %s", sourcePath, prefix)
+		}
+		if cfg.pathPrefix == nil {
+			cfg.pathPrefix = make(map[string]string)
+		}
+		cfg.pathPrefix[sourcePath] = prefix
+		return nil
+	}
+}
+
+// mountPaths returns docs with every document that has a configured path
+// prefix replaced by a mounted copy. The input slice and its documents are
+// not modified.
+func (j *Joiner) mountPaths(docs []parser.ParseResult) ([]parser.ParseResult, JoinWarnings) {
+	if len(j.config.PathPrefix) == 0 {
+		return docs, nil
+	}
+
+	var warnings JoinWarnings
+	mounted := make([]parser.ParseResult, len(docs))
+	copy(mounted, docs)
+	for i, doc := range mounted {
+		prefix, ok := j.config.PathPrefix[doc.SourcePath]
+		if !ok {
+			continue
+		}
+		prefix = strings.TrimSuffix(prefix, "/")
+
+		var (
+			renames   map[string]string
+			conflicts JoinWarnings
+		)
+		switch d := doc.Document.(type) {
+		case *parser.OAS3Document:
+			d = d.DeepCopy()
+			renames, conflicts = mountOAS3Paths(d, prefix, doc.SourcePath, doc.OASVersion)
+			rewritePathRefsOAS3(d, renames)
+			mounted[i].Document = d
+		case *parser.OAS2Document:
+			d = d.DeepCopy()
+			renames, conflicts = mountOAS2Paths(d, prefix, doc.SourcePath)
+			rewritePathRefsOAS2(d, renames)
+			mounted[i].Document = d
+		default:
+			continue
+		}
+		warnings = append(warnings, conflicts...)
+		warnings = append(warnings, NewPathMountedWarning(doc.SourcePath, prefix, len(renames)))
+	}
+	return mounted, warnings
+}
+
+// mountOAS2Paths folds basePath and prefix into the path keys and returns the
+// old-to-new key mapping along with warnings for paths dropped because their
+// mounted key was already taken.
+func mountOAS2Paths(doc *parser.OAS2Document, prefix, sourceFile string) (map[string]string, JoinWarnings) {
+	var warnings JoinWarnings
+	base := strings.TrimSuffix(doc.BasePath, "/")
+	doc.BasePath = ""
+
+	renames := make(map[string]string, len(doc.Paths))
+	paths := make(parser.Paths, len(doc.Paths))
+	for _, key := range maputil.SortedKeys(doc.Paths) {
+		warnings = append(warnings, mountPath(paths, renames, key, prefix+base+key, doc.Paths[key], sourceFile)...)
+	}
+	doc.Paths = paths
+	return renames, warnings
+}
+
+// mountOAS3Paths folds the server path and prefix into the path keys, strips
+// the folded portion from the servers that carried it, and returns the
+// old-to-new key mapping along with warnings for servers that could not be
+// folded and for paths dropped because their mounted key was already taken.
+func mountOAS3Paths(doc *parser.OAS3Document, prefix, sourceFile string, version parser.OASVersion) (map[string]string, JoinWarnings) {
+	var warnings JoinWarnings
+	base := ""
+	if len(doc.Servers) > 0 {
+		base = serverPath(doc.Servers[0])
+		warnings = append(warnings, stripServerPaths(doc.Servers, base, "servers", sourceFile)...)
+	}
+
+	renames := make(map[string]string, len(doc.Paths))
+	paths := make(parser.Paths, len(doc.Paths))
+	for _, key := range maputil.SortedKeys(doc.Paths) {
+		item := doc.Paths[key]
+		itemBase := base
+		if item != nil {
+			itemPath := fmt.Sprintf("paths.%s", key)
+			if len(item.Servers) > 0 {
+				itemBase = serverPath(item.Servers[0])
+				warnings = append(warnings, stripServerPaths(item.Servers, itemBase, itemPath+".servers", sourceFile)...)
+			}
+			ops := parser.GetOperations(item, version)
+			for _, method := range maputil.SortedKeys(ops) {
+				if op := ops[method]; op != nil && len(op.Servers) > 0 {
+					warnings = append(warnings, stripServerPaths(op.Servers, itemBase, fmt.Sprintf("%s.%s.servers", itemPath, method), sourceFile)...)
+				}
+			}
+		}
+		warnings = append(warnings, mountPath(paths, renames, key, prefix+itemBase+key, item, sourceFile)...)
+	}
+	doc.Paths = paths
+	return renames, warnings
+}
+
+// mountPath adds item to paths under newKey and records the rename. When an
+// earlier path already mounted at newKey, it keeps that one, drops item, and
+// returns a collision warning.
+func mountPath(paths parser.Paths, renames map[string]string, key, newKey string, item *parser.PathItem, sourceFile string) JoinWarnings {
+	if _, taken := paths[newKey]; taken {
+		kept := ""
+		for oldKey, mountedKey := range renames {
+			if mountedKey == newKey {
+				kept = oldKey
+				break
+			}
+		}
+		return JoinWarnings{NewMountedPathCollisionWarning(key, newKey, kept, sourceFile)}
+	}
+	renames[key] = newKey
+	paths[newKey] = item
+	return nil
+}
+
+// splitServerURL splits a server URL into its origin (scheme and authority,
+// empty for a relative URL) and its path.
+func splitServerURL(raw string) (origin, path string) {
+	if i := strings.Index(raw, "//"); i >= 0 && !strings.Contains(raw[:i], "/") {
+		if j := strings.IndexByte(raw[i+2:], '/'); j >= 0 {
+			return raw[:i+2+j], raw[i+2+j:]
+		}
+		return raw, ""
+	}
+	return "", raw
+}
+
+// serverPath returns the path portion of a server URL with its variables
+// replaced by their defaults and without a trailing slash.
+func serverPath(server *parser.Server) string {
+	if server == nil {
+		return ""
+	}
+	_, path := splitServerURL(server.URL)
+	for name, variable := range server.Variables {
+		path = strings.ReplaceAll(path, "{"+name+"}", variable.Default)
+	}
+	path = strings.TrimSuffix(path, "/")
+	if path != "" && !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return path
+}
+
+// stripServerPaths removes base from the servers whose path equals it and
+// reports the others, whose paths cannot be folded into the mounted path.
+func stripServerPaths(servers []*parser.Server, base, jsonPath, sourceFile string) JoinWarnings {
+	var warnings JoinWarnings
+	for i, server := range servers {
+		if server == nil {
+			continue
+		}
+		if serverPath(server) != base {
+			warnings = append(warnings, NewServerPathConflictWarning(
+				fmt.Sprintf("%s[%d]", jsonPath, i), server.URL, base, sourceFile))
+			continue
+		}
+		origin, _ := splitServerURL(server.URL)
+		if origin == "" {
+			origin = "/"
+		}
+		server.URL = origin
+		// Drop the variables that only appeared in the folded path.
+		for name := range server.Variables {
+			if !strings.Contains(origin, "{"+name+"}") {
+				delete(server.Variables, name)
+			}
+		}
+		if len(server.Variables) == 0 {
+			server.Variables = nil
+		}
+	}
+	return warnings
+}
+
+// pathRefRewriter rewrites references into the paths object after its keys
+// have been mounted. Every reference site is visited because a pointer into a
+// path item can appear wherever a $ref is allowed, not only on path items.
+type pathRefRewriter struct {
+	renames map[string]string
+	visited map[*parser.Schema]bool
+}
+
+// rewrite maps a reference into a renamed path to the mounted key and
+// returns any other reference unchanged.
+func (r *pathRefRewriter) rewrite(ref string) string {
+	rest, ok := pathutil.CutRefPrefix(ref, pathutil.RefPrefixPaths)
+	if !ok {
+		return ref
+	}
+	token, suffix, hasSuffix := strings.Cut(rest, "/")
+	newKey, ok := r.renames[pathutil.UnescapeRefToken(token)]
+	if !ok {
+		newKey, ok = r.renames[pathutil.DecodeRefToken(token)]
+	}
+	if !ok {
+		return ref
+	}
+	newRef := pathutil.RefPrefixPaths + pathutil.EscapeRefToken(newKey)
+	if hasSuffix {
+		newRef += "/" + suffix
+	}
+	return newRef
+}
+
+// rewritePathRefsOAS3 rewrites every reference into the renamed paths.
+func rewritePathRefsOAS3(doc *parser.OAS3Document, renames map[string]string) {
+	r := &pathRefRewriter{renames: renames, visited: make(map[*parser.Schema]bool)}
+	for _, item := range doc.Paths {
+		r.pathItem(item)
+	}
+	for _, item := range doc.Webhooks {
+		r.pathItem(item)
+	}
+	if c := doc.Components; c != nil {
+		for _, schema := range c.Schemas {
+			r.schema(schema)
+		}
+		for _, resp := range c.Responses {
+			r.response(resp)
+		}
+		for _, param := range c.Parameters {
+			r.parameter(param)
+		}
+		for _, body := range c.RequestBodies {
+			r.requestBody(body)
+		}
+		for _, header := range c.Headers {
+			r.header(header)
+		}
+		for _, link := range c.Links {
+			r.link(link)
+		}
+		for _, callback := range c.Callbacks {
+			r.callback(callback)
+		}
+		for _, item := range c.PathItems {
+			r.pathItem(item)
+		}
+		for _, mediaType := range c.MediaTypes {
+			r.mediaType(mediaType)
+		}
+	}
+}
+
+// rewritePathRefsOAS2 rewrites every reference into the renamed paths.
+func rewritePathRefsOAS2(doc *parser.OAS2Document, renames map[string]string) {
+	r := &pathRefRewriter{renames: renames, visited: make(map[*parser.Schema]bool)}
+	for _, item := range doc.Paths {
+		r.pathItem(item)
+	}
+	for _, schema := range doc.Definitions {
+		r.schema(schema)
+	}
+	for _, param := range doc.Parameters {
+		r.parameter(param)
+	}
+	for _, resp := range doc.Responses {
+		r.response(resp)
+	}
+}
+
+func (r *pathRefRewriter) pathItem(item *parser.PathItem) {
+	if item == nil {
+		return
+	}
+	item.Ref = r.rewrite(item.Ref)
+	for _, param := range item.Parameters {
+		r.parameter(param)
+	}
+	// Every method is visited regardless of the document version, since a
+	// field the version does not define is simply nil.
+	for _, op := range parser.GetOperations(item, parser.OASVersion320) {
+		r.operation(op)
+	}
+}
+
+func (r *pathRefRewriter) operation(op *parser.Operation) {
+	if op == nil {
+		return
+	}
+	for _, param := range op.Parameters {
+		r.parameter(param)
+	}
+	r.requestBody(op.RequestBody)
+	if op.Responses != nil {
+		r.response(op.Responses.Default)
+		for _, resp := range op.Responses.Codes {
+			r.response(resp)
+		}
+	}
+	for _, callback := range op.Callbacks {
+		r.callback(callback)
+	}
+	for _, ref := range op.CallbackRefs {
+		if ref != nil {
+			ref.Ref = r.rewrite(ref.Ref)
+		}
+	}
+}
+
+func (r *pathRefRewriter) callback(callback *parser.Callback) {
+	if callback == nil {
+		return
+	}
+	for _, item := range *callback {
+		r.pathItem(item)
+	}
+}
+
+func (r *pathRefRewriter) parameter(param *parser.Parameter) {
+	if param == nil {
+		return
+	}
+	param.Ref = r.rewrite(param.Ref)
+	r.schema(param.Schema)
+	for _, mediaType := range param.Content {
+		r.mediaType(mediaType)
+	}
+}
+
+func (r *pathRefRewriter) requestBody(body *parser.RequestBody) {
+	if body == nil {
+		return
+	}
+	body.Ref = r.rewrite(body.Ref)
+	for _, mediaType := range body.Content {
+		r.mediaType(mediaType)
+	}
+}
+
+func (r *pathRefRewriter) response(resp *parser.Response) {
+	if resp == nil {
+		return
+	}
+	resp.Ref = r.rewrite(resp.Ref)
+	r.schema(resp.Schema)
+	for _, header := range resp.Headers {
+		r.header(header)
+	}
+	for _, mediaType := range resp.Content {
+		r.mediaType(mediaType)
+	}
+	for _, link := range resp.Links {
+		r.link(link)
+	}
+}
+
+func (r *pathRefRewriter) header(header *parser.Header) {
+	if header == nil {
+		return
+	}
+	header.Ref = r.rewrite(header.Ref)
+	r.schema(header.Schema)
+	for _, mediaType := range header.Content {
+		r.mediaType(mediaType)
+	}
+}
+
+func (r *pathRefRewriter) mediaType(mediaType *parser.MediaType) {
+	if mediaType == nil {
+		return
+	}
+	r.schema(mediaType.Schema)
+	r.schema(mediaType.ItemSchema)
+}
+
+func (r *pathRefRewriter) link(link *parser.Link) {
+	if link == nil {
+		return
+	}
+	link.Ref = r.rewrite(link.Ref)
+	link.OperationRef = r.rewrite(link.OperationRef)
+}
+
+func (r *pathRefRewriter) schema(schema *parser.Schema) {
+	if schema == nil || r.visited[schema] {
+		return
+	}
+	r.visited[schema] = true
+	schema.Ref = r.rewrite(schema.Ref)
+
+	for _, s := range schema.Properties {
+		r.schema(s)
+	}
+	for _, s := range schema.PatternProperties {
+		r.schema(s)
+	}
+	for _, s := range schema.DependentSchemas {
+		r.schema(s)
+	}
+	for _, s := range schema.Defs {
+		r.schema(s)
+	}
+	for _, field := range []any{schema.AdditionalProperties, schema.Items, schema.AdditionalItems,
+		schema.UnevaluatedProperties, schema.UnevaluatedItems} {
+		for _, s := range schemautil.SchemaOrBoolSchemas(field) {
+			r.schema(s)
+		}
+	}
+	for _, list := range [][]*parser.Schema{schema.PrefixItems, schema.AllOf, schema.AnyOf, schema.OneOf} {
+		for _, s := range list {
+			r.schema(s)
+		}
+	}
+	for _, s := range []*parser.Schema{schema.Not, schema.Contains, schema.PropertyNames,
+		schema.If, schema.Then, schema.Else, schema.ContentSchema} {
+		r.schema(s)
+	}
+}
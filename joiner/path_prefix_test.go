@@ -0,0 +1,273 @@
+package joiner
+
+import (
+	"testing"
+
+	"github.com/erraggy/oastools/internal/maputil"
+	"github.com/erraggy/oastools/internal/severity"
+	"github.com/erraggy/oastools/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const billingSpec30 = `openapi: "3.0.3"
+info:
+  title: Billing
+  version: "1.0.0"
+servers:
+  - url: https://billing.internal/{version}
+    variables:
+      version:
+        default: v1
+  - url: https://billing-staging.internal/v1/
+paths:
+  /invoices:
+    get:
+      operationId: listInvoices
+      responses:
+        "200":
+          description: OK
+          links:
+            GetInvoice:
+              operationRef: '#/paths/~1invoices~1{id}/get'
+  /invoices/{id}:
+    get:
+      operationId: getInvoice
+      parameters:
+        - $ref: '#/paths/~1invoices~1{id}/parameters/0'
+      responses:
+        "200":
+          description: OK
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: string
+  /reports:
+    servers:
+      - url: https://reports.internal/r
+    get:
+      operationId: listReports
+      servers:
+        - url: https://reports-eu.internal/other
+      responses:
+        "200":
+          description: OK
+  /legacy-invoices:
+    $ref: '#/paths/~1invoices'
+`
+
+const usersSpec30 = `openapi: "3.0.3"
+info:
+  title: Users
+  version: "1.0.0"
+servers:
+  - url: /api
+paths:
+  /users:
+    get:
+      operationId: listUsers
+      responses:
+        "200":
+          description: OK
+`
+
+const ordersSpec20 = `swagger: "2.0"
+info:
+  title: Orders
+  version: "1.0.0"
+host: orders.internal
+basePath: /v2/
+paths:
+  /orders:
+    get:
+      operationId: listOrders
+      responses:
+        "200":
+          description: OK
+`
+
+func parseSpec(t *testing.T, spec, sourcePath string) parser.ParseResult {
+	t.Helper()
+	result, err := parser.ParseWithOptions(parser.WithBytes([]byte(spec)))
+	require.NoError(t, err)
+	result.SourcePath = sourcePath
+	return *result
+}
+
+func TestWithPathPrefix_OAS3(t *testing.T) {
+	billing := parseSpec(t, billingSpec30, "billing.yaml")
+	users := parseSpec(t, usersSpec30, "users.yaml")
+
+	result, err := JoinWithOptions(
+		WithParsed(billing, users),
+		WithPathPrefix("billing.yaml", "/billing/"),
+		WithPathPrefix("users.yaml", "/people"),
+	)
+	require.NoError(t, err)
+
+	doc, ok := result.Document.(*parser.OAS3Document)
+	require.True(t, ok)
+
+	assert.ElementsMatch(t, []string{
+		"/billing/v1/invoices",
+		"/billing/v1/invoices/{id}",
+		"/billing/v1/legacy-invoices",
+		"/billing/r/reports",
+		"/people/api/users",
+	}, maputil.SortedKeys(doc.Paths))
+
+	// The folded server paths are stripped, and variables only used there dropped.
+	require.Len(t, doc.Servers, 3)
+	assert.Equal(t, "https://billing.internal", doc.Servers[0].URL)
+	assert.Nil(t, doc.Servers[0].Variables)
+	assert.Equal(t, "https://billing-staging.internal", doc.Servers[1].URL)
+	assert.Equal(t, "/", doc.Servers[2].URL)
+
+	reports := doc.Paths["/billing/r/reports"]
+	require.Len(t, reports.Servers, 1)
+	assert.Equal(t, "https://reports.internal", reports.Servers[0].URL)
+	// An operation server with a different path cannot be folded.
+	assert.Equal(t, "https://reports-eu.internal/other", reports.Get.Servers[0].URL)
+
+	// References into the paths object follow the mounted keys.
+	assert.Equal(t, "#/paths/~1billing~1v1~1invoices", doc.Paths["/billing/v1/legacy-invoices"].Ref)
+	link := doc.Paths["/billing/v1/invoices"].Get.Responses.Codes["200"].Links["GetInvoice"]
+	assert.Equal(t, "#/paths/~1billing~1v1~1invoices~1{id}/get", link.OperationRef)
+	param := doc.Paths["/billing/v1/invoices/{id}"].Get.Parameters[0]
+	assert.Equal(t, "#/paths/~1billing~1v1~1invoices~1{id}/parameters/0", param.Ref)
+
+	mounted := result.StructuredWarnings.ByCategory(WarnPathMounted)
+	require.Len(t, mounted, 2)
+	assert.Equal(t, "billing.yaml", mounted[0].SourceFile)
+	assert.Equal(t, 4, mounted[0].Context["count"])
+	assert.Equal(t, severity.SeverityInfo, mounted[0].Severity)
+
+	conflicts := result.StructuredWarnings.ByCategory(WarnServerPathConflict)
+	require.Len(t, conflicts, 1)
+	assert.Equal(t, "paths./reports.get.servers[0]", conflicts[0].Path)
+	assert.Equal(t, severity.SeverityWarning, conflicts[0].Severity)
+
+	// The caller's documents are not modified.
+	original := billing.Document.(*parser.OAS3Document)
+	assert.Contains(t, original.Paths, "/invoices")
+	assert.Equal(t, "https://billing.internal/{version}", original.Servers[0].URL)
+	assert.Equal(t, "#/paths/~1invoices", original.Paths["/legacy-invoices"].Ref)
+}
+
+func TestWithPathPrefix_OAS2(t *testing.T) {
+	orders := parseSpec(t, ordersSpec20, "orders.yaml")
+	other := parseSpec(t, `swagger: "2.0"
+info:
+  title: Other
+  version: "1.0.0"
+paths:
+  /status:
+    get:
+      responses:
+        "200":
+          description: OK
+`, "other.yaml")
+
+	result, err := JoinWithOptions(
+		WithParsed(other, orders),
+		WithPathPrefix("orders.yaml", "/orders-svc"),
+	)
+	require.NoError(t, err)
+
+	doc, ok := result.Document.(*parser.OAS2Document)
+	require.True(t, ok)
+	assert.ElementsMatch(t, []string{"/status", "/orders-svc/v2/orders"}, maputil.SortedKeys(doc.Paths))
+	assert.Empty(t, doc.BasePath)
+	assert.Equal(t, "/v2/", orders.Document.(*parser.OAS2Document).BasePath)
+}
+
+func TestWithPathPrefix_EmptyPrefixFoldsServerPath(t *testing.T) {
+	users := parseSpec(t, usersSpec30, "users.yaml")
+	billing := parseSpec(t, billingSpec30, "billing.yaml")
+
+	result, err := JoinWithOptions(
+		WithParsed(users, billing),
+		WithPathPrefix("users.yaml", ""),
+	)
+	require.NoError(t, err)
+
+	doc := result.Document.(*parser.OAS3Document)
+	assert.Contains(t, doc.Paths, "/api/users")
+	assert.Contains(t, doc.Paths, "/invoices")
+}
+
+func TestWithPathPrefix_Invalid(t *testing.T) {
+	_, err := JoinWithOptions(
+		WithFilePaths("a.yaml", "b.yaml"),
+		WithPathPrefix("a.yaml", "billing"),
+	)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must start with '/'")
+}
+
+func TestSplitServerURL(t *testing.T) {
+	tests := []struct {
+		url, origin, path string
+	}{
+		{"https://api.example.com/v1", "https://api.example.com", "/v1"},
+		{"https://api.example.com", "https://api.example.com", ""},
+		{"//api.example.com/v1", "//api.example.com", "/v1"},
+		{"/v1", "", "/v1"},
+		{"{scheme}://{host}/api/{version}", "{scheme}://{host}", "/api/{version}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			origin, path := splitServerURL(tt.url)
+			assert.Equal(t, tt.origin, origin)
+			assert.Equal(t, tt.path, path)
+		})
+	}
+}
+
+func TestWithPathPrefix_MountedPathCollision(t *testing.T) {
+	colliding := parseSpec(t, `openapi: "3.0.3"
+info:
+  title: Colliding
+  version: "1.0.0"
+servers:
+  - url: https://api.internal
+paths:
+  /a:
+    servers:
+      - url: https://api.internal/x
+    get:
+      operationId: getA
+      responses:
+        "200":
+          description: OK
+  /x/a:
+    get:
+      operationId: getXA
+      responses:
+        "200":
+          description: OK
+`, "colliding.yaml")
+	users := parseSpec(t, usersSpec30, "users.yaml")
+
+	result, err := JoinWithOptions(
+		WithParsed(users, colliding),
+		WithPathPrefix("colliding.yaml", "/svc"),
+	)
+	require.NoError(t, err)
+
+	doc := result.Document.(*parser.OAS3Document)
+	require.Contains(t, doc.Paths, "/svc/x/a")
+	assert.Equal(t, "getA", doc.Paths["/svc/x/a"].Get.OperationID)
+
+	collisions := result.StructuredWarnings.ByCategory(WarnMountedPathCollision)
+	require.Len(t, collisions, 1)
+	assert.Equal(t, "paths./x/a", collisions[0].Path)
+	assert.Equal(t, "/a", collisions[0].Context["kept_path"])
+	assert.Equal(t, "/svc/x/a", collisions[0].Context["mounted_path"])
+
+	mounted := result.StructuredWarnings.ByCategory(WarnPathMounted)
+	require.Len(t, mounted, 1)
+	assert.Equal(t, 1, mounted[0].Context["count"])
+}
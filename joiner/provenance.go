@@ -0,0 +1,186 @@
+package joiner
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/erraggy/oastools/parser"
+)
+
+// DefaultSourceExtension is the extension key WithSourceExtension uses when
+// given an empty key.
+const DefaultSourceExtension = "x-oastools-source"
+
+// WithSourceExtension stamps each path item, operation and component of the
+// joined document with an extension naming the source document it came from.
+//
+// The value is the source's file path, or its label when one is configured
+// with WithSourceLabel. An empty key uses DefaultSourceExtension. An element
+// that already carries the extension keeps its value, so joining an already
+// joined document preserves the original provenance. Callbacks are not
+// stamped: their path items belong to the operation that declares them.
+//
+// Example:
+//
+//	result, err := joiner.JoinWithOptions(
+//	    joiner.WithFilePaths("billing.yaml", "users.yaml"),
+//	    joiner.WithSourceExtension("x-owner"),
+//	    joiner.WithSourceLabel("billing.yaml", "team-billing"),
+//	    joiner.WithSourceLabel("users.yaml", "team-identity"),
+//	)
+func WithSourceExtension(key string) Option {
+	return func(cfg *joinConfig) error {
+		if key == "" {
+			key = DefaultSourceExtension
+		}
+		if !strings.HasPrefix(key, "x-") {
+			return fmt.Errorf("source extension must start with 'x-': %s", key)
+		}
+		cfg.sourceExtension = &key
+		return nil
+	}
+}
+
+// WithSourceLabel sets the value stamped by WithSourceExtension for a source
+// file, in place of its file path.
+// Can be called multiple times to add multiple mappings.
+func WithSourceLabel(sourcePath, label string) Option {
+	return func(cfg *joinConfig) error {
+		if cfg.sourceLabels == nil {
+			cfg.sourceLabels = make(map[string]string)
+		}
+		cfg.sourceLabels[sourcePath] = label
+		return nil
+	}
+}
+
+// sourceStamper writes the provenance extension into a joined document.
+//
+// The joined document shares its values with the input documents, so every
+// stamped value is a shallow copy with its own extension map, and the inputs
+// are left untouched.
+type sourceStamper struct {
+	key     string
+	labels  map[string]string
+	result  *JoinResult
+	version parser.OASVersion
+}
+
+// stampSources stamps the joined document when SourceExtension is configured.
+func (j *Joiner) stampSources(result *JoinResult) {
+	if j.config.SourceExtension == "" || result == nil {
+		return
+	}
+	s := &sourceStamper{
+		key:     j.config.SourceExtension,
+		labels:  j.config.SourceLabels,
+		result:  result,
+		version: result.OASVersion,
+	}
+
+	switch doc := result.Document.(type) {
+	case *parser.OAS3Document:
+		s.pathItems(doc.Paths, sectionPaths)
+		s.pathItems(doc.Webhooks, sectionWebhooks)
+		if c := doc.Components; c != nil {
+			stampEntries(s, c.Schemas, sectionSchemas, func(v *parser.Schema) *map[string]any { return &v.Extra })
+			stampEntries(s, c.Responses, "components.responses", func(v *parser.Response) *map[string]any { return &v.Extra })
+			stampEntries(s, c.Parameters, "components.parameters", func(v *parser.Parameter) *map[string]any { return &v.Extra })
+			stampEntries(s, c.Examples, "components.examples", func(v *parser.Example) *map[string]any { return &v.Extra })
+			stampEntries(s, c.RequestBodies, "components.requestBodies", func(v *parser.RequestBody) *map[string]any { return &v.Extra })
+			stampEntries(s, c.Headers, "components.headers", func(v *parser.Header) *map[string]any { return &v.Extra })
+			stampEntries(s, c.SecuritySchemes, "components.securitySchemes", func(v *parser.SecurityScheme) *map[string]any { return &v.Extra })
+			stampEntries(s, c.Links, "components.links", func(v *parser.Link) *map[string]any { return &v.Extra })
+			stampEntries(s, c.MediaTypes, "components.mediaTypes", func(v *parser.MediaType) *map[string]any { return &v.Extra })
+			s.pathItems(c.PathItems, "components.pathItems")
+		}
+	case *parser.OAS2Document:
+		s.pathItems(doc.Paths, sectionPaths)
+		stampEntries(s, doc.Definitions, sectionDefinitions, func(v *parser.Schema) *map[string]any { return &v.Extra })
+		stampEntries(s, doc.Parameters, "parameters", func(v *parser.Parameter) *map[string]any { return &v.Extra })
+		stampEntries(s, doc.Responses, "responses", func(v *parser.Response) *map[string]any { return &v.Extra })
+		stampEntries(s, doc.SecurityDefinitions, "securityDefinitions", func(v *parser.SecurityScheme) *map[string]any { return &v.Extra })
+	}
+}
+
+// label returns the value stamped on the element under name in section.
+func (s *sourceStamper) label(section, name string) string {
+	source := s.result.originOf(section, name).filePath
+	if label, ok := s.labels[source]; ok {
+		return label
+	}
+	return source
+}
+
+// stamped returns extra with the provenance extension added, copying it
+// rather than writing to a map the input documents may share. It reports
+// false if extra already carries the extension.
+func (s *sourceStamper) stamped(extra map[string]any, value string) (map[string]any, bool) {
+	if _, ok := extra[s.key]; ok {
+		return extra, false
+	}
+	out := make(map[string]any, len(extra)+1)
+	maps.Copy(out, extra)
+	out[s.key] = value
+	return out, true
+}
+
+// stampEntries replaces each entry of a top-level container with a copy
+// carrying the provenance extension.
+func stampEntries[T any](s *sourceStamper, entries map[string]*T, section string, extra func(*T) *map[string]any) {
+	for name, entry := range entries {
+		if entry == nil {
+			continue
+		}
+		stamped, ok := s.stamped(*extra(entry), s.label(section, name))
+		if !ok {
+			continue
+		}
+		copied := *entry
+		*extra(&copied) = stamped
+		entries[name] = &copied
+	}
+}
+
+// pathItems stamps each path item and the operations it defines. Operations
+// take the origin of their path item.
+func (s *sourceStamper) pathItems(items map[string]*parser.PathItem, section string) {
+	for name, item := range items {
+		if item == nil {
+			continue
+		}
+		value := s.label(section, name)
+		copied := *item
+		if extra, ok := s.stamped(item.Extra, value); ok {
+			copied.Extra = extra
+		}
+		for _, op := range []**parser.Operation{
+			&copied.Get, &copied.Put, &copied.Post, &copied.Delete, &copied.Options,
+			&copied.Head, &copied.Patch, &copied.Trace, &copied.Query,
+		} {
+			*op = s.operation(*op, value)
+		}
+		if len(item.AdditionalOperations) > 0 {
+			copied.AdditionalOperations = make(map[string]*parser.Operation, len(item.AdditionalOperations))
+			for method, op := range item.AdditionalOperations {
+				copied.AdditionalOperations[method] = s.operation(op, value)
+			}
+		}
+		items[name] = &copied
+	}
+}
+
+// operation returns a copy of op carrying the provenance extension.
+func (s *sourceStamper) operation(op *parser.Operation, value string) *parser.Operation {
+	if op == nil {
+		return nil
+	}
+	extra, ok := s.stamped(op.Extra, value)
+	if !ok {
+		return op
+	}
+	copied := *op
+	copied.Extra = extra
+	return &copied
+}
@@ -0,0 +1,164 @@
+package joiner
+
+import (
+	"testing"
+
+	"github.com/erraggy/oastools/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+const petsSpec30 = `openapi: "3.0.3"
+info:
+  title: Pets
+  version: "1.0.0"
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      x-owner: legacy-team
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+  parameters:
+    Limit:
+      name: limit
+      in: query
+      schema:
+        type: integer
+`
+
+const storeSpec30 = `openapi: "3.0.3"
+info:
+  title: Store
+  version: "1.0.0"
+paths:
+  /orders:
+    post:
+      operationId: createOrder
+      responses:
+        "201":
+          description: Created
+components:
+  schemas:
+    Order:
+      type: object
+`
+
+func TestWithSourceExtension_FilePath(t *testing.T) {
+	pets := parseSpec(t, petsSpec30, "pets.yaml")
+	store := parseSpec(t, storeSpec30, "store.yaml")
+
+	result, err := JoinWithOptions(
+		WithParsed(pets, store),
+		WithSourceExtension(""),
+	)
+	require.NoError(t, err)
+
+	doc, ok := result.Document.(*parser.OAS3Document)
+	require.True(t, ok)
+
+	assert.Equal(t, "pets.yaml", doc.Paths["/pets"].Extra[DefaultSourceExtension])
+	assert.Equal(t, "pets.yaml", doc.Paths["/pets"].Get.Extra[DefaultSourceExtension])
+	assert.Equal(t, "store.yaml", doc.Paths["/orders"].Extra[DefaultSourceExtension])
+	assert.Equal(t, "store.yaml", doc.Paths["/orders"].Post.Extra[DefaultSourceExtension])
+	assert.Equal(t, "pets.yaml", doc.Components.Schemas["Pet"].Extra[DefaultSourceExtension])
+	assert.Equal(t, "pets.yaml", doc.Components.Parameters["Limit"].Extra[DefaultSourceExtension])
+	assert.Equal(t, "store.yaml", doc.Components.Schemas["Order"].Extra[DefaultSourceExtension])
+
+	// The caller's documents are not modified.
+	original := pets.Document.(*parser.OAS3Document)
+	assert.NotContains(t, original.Paths["/pets"].Extra, DefaultSourceExtension)
+	assert.NotContains(t, original.Paths["/pets"].Get.Extra, DefaultSourceExtension)
+	assert.NotContains(t, original.Components.Schemas["Pet"].Extra, DefaultSourceExtension)
+
+	// The extension is written out with the document.
+	data, err := yaml.Marshal(doc)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "x-oastools-source: store.yaml")
+}
+
+func TestWithSourceExtension_Labels(t *testing.T) {
+	pets := parseSpec(t, petsSpec30, "pets.yaml")
+	store := parseSpec(t, storeSpec30, "store.yaml")
+
+	result, err := JoinWithOptions(
+		WithParsed(pets, store),
+		WithSourceExtension("x-owner"),
+		WithSourceLabel("pets.yaml", "team-pets"),
+		WithSourceLabel("store.yaml", "team-store"),
+	)
+	require.NoError(t, err)
+
+	doc := result.Document.(*parser.OAS3Document)
+	assert.Equal(t, "team-pets", doc.Paths["/pets"].Extra["x-owner"])
+	assert.Equal(t, "team-store", doc.Paths["/orders"].Post.Extra["x-owner"])
+	assert.Equal(t, "team-store", doc.Components.Schemas["Order"].Extra["x-owner"])
+
+	// An existing value is kept.
+	assert.Equal(t, "legacy-team", doc.Paths["/pets"].Get.Extra["x-owner"])
+}
+
+func TestWithSourceExtension_FollowsCollisionWinner(t *testing.T) {
+	left := parseSpec(t, storeSpec30, "left.yaml")
+	right := parseSpec(t, storeSpec30, "right.yaml")
+
+	result, err := JoinWithOptions(
+		WithParsed(left, right),
+		WithPathStrategy(StrategyAcceptRight),
+		WithSchemaStrategy(StrategyAcceptLeft),
+		WithSourceExtension(""),
+	)
+	require.NoError(t, err)
+
+	doc := result.Document.(*parser.OAS3Document)
+	assert.Equal(t, "right.yaml", doc.Paths["/orders"].Extra[DefaultSourceExtension])
+	assert.Equal(t, "left.yaml", doc.Components.Schemas["Order"].Extra[DefaultSourceExtension])
+}
+
+func TestWithSourceExtension_OAS2(t *testing.T) {
+	orders := parseSpec(t, ordersSpec20, "orders.yaml")
+	other := parseSpec(t, `swagger: "2.0"
+info:
+  title: Other
+  version: "1.0.0"
+paths:
+  /status:
+    get:
+      responses:
+        "200":
+          description: OK
+definitions:
+  Status:
+    type: object
+`, "other.yaml")
+
+	result, err := JoinWithOptions(
+		WithParsed(orders, other),
+		WithSourceExtension("x-team"),
+	)
+	require.NoError(t, err)
+
+	doc := result.Document.(*parser.OAS2Document)
+	assert.Equal(t, "orders.yaml", doc.Paths["/orders"].Get.Extra["x-team"])
+	assert.Equal(t, "other.yaml", doc.Paths["/status"].Extra["x-team"])
+	assert.Equal(t, "other.yaml", doc.Definitions["Status"].Extra["x-team"])
+}
+
+func TestWithSourceExtension_Invalid(t *testing.T) {
+	_, err := JoinWithOptions(
+		WithFilePaths("a.yaml", "b.yaml"),
+		WithSourceExtension("owner"),
+	)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must start with 'x-'")
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/erraggy/oastools/converter"
 	"github.com/erraggy/oastools/internal/severity"
 )
 
@@ -36,6 +37,18 @@ const (
 	WarnHandlerError WarningCategory = "handler_error"
 	// WarnHandlerResolution indicates a collision handler resolved with a message.
 	WarnHandlerResolution WarningCategory = "handler_resolution"
+	// WarnConversionIssue indicates an issue reported while converting an input
+	// document to the target version (see WithTargetVersion).
+	WarnConversionIssue WarningCategory = "conversion_issue"
+	// WarnPathMounted indicates a document's paths were mounted under a path
+	// prefix (see WithPathPrefix).
+	WarnPathMounted WarningCategory = "path_mounted"
+	// WarnServerPathConflict indicates a server whose path differs from the one
+	// folded into the mounted paths, so it was left unchanged.
+	WarnServerPathConflict WarningCategory = "server_path_conflict"
+	// WarnMountedPathCollision indicates a path that was dropped because
+	// another path of the same document mounted at the same key.
+	WarnMountedPathCollision WarningCategory = "mounted_path_collision"
 )
 
 // JoinWarning represents a structured warning from the joiner package.
@@ -322,6 +335,29 @@ func NewHandlerResolutionWarning(jsonPath, message, source string, line, col int
 	}
 }
 
+// NewConversionIssueWarning creates a warning from an issue reported while
+// converting sourceFile from sourceVersion to targetVersion before joining.
+// The warning keeps the issue's severity and location.
+func NewConversionIssueWarning(issue converter.ConversionIssue, sourceFile, sourceVersion, targetVersion string) *JoinWarning {
+	ctx := map[string]any{
+		"source_version": sourceVersion,
+		"target_version": targetVersion,
+	}
+	if issue.Context != "" {
+		ctx["context"] = issue.Context
+	}
+	return &JoinWarning{
+		Category:   WarnConversionIssue,
+		Path:       issue.Path,
+		Message:    fmt.Sprintf("%s: converting %s to %s: %s: %s", sourceFile, sourceVersion, targetVersion, issue.Path, issue.Message),
+		SourceFile: sourceFile,
+		Line:       issue.Line,
+		Column:     issue.Column,
+		Severity:   issue.Severity,
+		Context:    ctx,
+	}
+}
+
 // JoinWarnings is a collection of JoinWarning.
 type JoinWarnings []*JoinWarning
 
@@ -374,3 +410,50 @@ func (ws JoinWarnings) Summary() string {
 	}
 	return strings.TrimSuffix(sb.String(), "\n")
 }
+
+// NewPathMountedWarning creates an informational warning summarizing the
+// paths of sourceFile mounted under prefix.
+func NewPathMountedWarning(sourceFile, prefix string, count int) *JoinWarning {
+	return &JoinWarning{
+		Category:   WarnPathMounted,
+		Message:    fmt.Sprintf("mounted %d path(s) from %s under '%s'", count, sourceFile, prefix),
+		SourceFile: sourceFile,
+		Severity:   severity.SeverityInfo,
+		Context: map[string]any{
+			"prefix": prefix,
+			"count":  count,
+		},
+	}
+}
+
+// NewServerPathConflictWarning creates a warning for a server whose path
+// differs from the base path folded into the mounted paths.
+func NewServerPathConflictWarning(jsonPath, serverURL, basePath, sourceFile string) *JoinWarning {
+	return &JoinWarning{
+		Category:   WarnServerPathConflict,
+		Path:       jsonPath,
+		Message:    fmt.Sprintf("server '%s' at %s does not match mounted base path '%s' and was left unchanged: source %s", serverURL, jsonPath, basePath, sourceFile),
+		SourceFile: sourceFile,
+		Severity:   severity.SeverityWarning,
+		Context: map[string]any{
+			"server_url": serverURL,
+			"base_path":  basePath,
+		},
+	}
+}
+
+// NewMountedPathCollisionWarning creates a warning for a path that was dropped
+// because keptPath from the same document already mounted at mountedPath.
+func NewMountedPathCollisionWarning(path, mountedPath, keptPath, sourceFile string) *JoinWarning {
+	return &JoinWarning{
+		Category:   WarnMountedPathCollision,
+		Path:       fmt.Sprintf("paths.%s", path),
+		Message:    fmt.Sprintf("path '%s' mounts at '%s', already taken by path '%s', and was dropped: source %s", path, mountedPath, keptPath, sourceFile),
+		SourceFile: sourceFile,
+		Severity:   severity.SeverityWarning,
+		Context: map[string]any{
+			"mounted_path": mountedPath,
+			"kept_path":    keptPath,
+		},
+	}
+}
@@ -0,0 +1,70 @@
+// Package jsonpath implements RFC 9535 JSONPath queries over decoded JSON and
+// YAML documents.
+//
+// Documents are the generic structures produced by unmarshaling into any:
+// map[string]any for objects, []any for arrays, and strings, numbers, bools
+// and nil for primitives. The overlay package uses this package to select
+// action targets, and it can be used directly to query or edit OpenAPI
+// documents in that form.
+//
+// # Quick Start
+//
+//	path, err := jsonpath.Parse("$.paths.*[?@.deprecated==true]")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, op := range path.Get(doc) {
+//	    fmt.Println(op.(map[string]any)["operationId"])
+//	}
+//
+// [Path.Query] also reports each node's location as a [Location], whose
+// String method returns the RFC 9535 normalized path, such as
+// $['paths']['/pets']['get'].
+//
+// # Supported Syntax
+//
+// The RFC 9535 grammar is supported:
+//   - Name selectors: $.info, $['info'], $["paths"]['/users/{id}']
+//   - Wildcards: $.paths.*, $[*]
+//   - Index selectors: $.servers[0], $.servers[-1]
+//   - Array slices: $.servers[1:3], $.servers[::2], $.servers[::-1]
+//   - Unions: $.info['title','version'], $.servers[0,-1]
+//   - Descendant segments: $..description, $..[0]
+//   - Filters with comparisons (==, !=, <, <=, >, >=), logical operators
+//     (&&, ||, !) and parentheses: $.paths.*[?!(@.deprecated==true) && @.tags]
+//   - Existence tests, which tell a missing member from a null one: $..[?@.default]
+//   - Absolute queries in filters: $.values[?@ == $.expected]
+//
+// Member names in shorthand notation may also contain hyphens after the
+// first character, as in $.info.x-api-id or @.x-internal. RFC 9535 reads
+// such names only in bracket notation; the extension lets OpenAPI vendor
+// extensions be written as they appear.
+//
+// # Functions
+//
+// Filters can call the function extensions defined by RFC 9535:
+//   - length(value) - characters of a string, elements of an array or members of an object
+//   - count(query) - number of nodes a query selects
+//   - match(value, regexp) - whether a string matches an I-Regexp (RFC 9485) entirely
+//   - search(value, regexp) - whether a string contains a match of an I-Regexp
+//   - value(query) - value of a query's only node
+//
+// Function calls are type checked when the expression is parsed, so
+// $[?length(@.*)>1], which passes a query that may select several nodes to
+// a function taking a value, is a parse error.
+//
+// # Mutation
+//
+// [Path.Set], [Path.Remove] and [Path.Modify] edit a document in place.
+// Remove splices array elements out rather than setting them to nil, and a
+// node selected more than once, such as by the union $[0,0], is changed once.
+//
+// # Ordering
+//
+// Object members are selected in the order of their names, so results are
+// deterministic even though Go maps are unordered.
+//
+// # Related Packages
+//
+//   - [github.com/erraggy/oastools/overlay] - Apply overlays whose actions target JSONPath expressions
+package jsonpath
@@ -0,0 +1,519 @@
+package jsonpath
+
+import (
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// jsonpathLogger is used for warning-level log output (e.g., depth limit
+// truncation). Tests swap it with a discard logger to suppress expected noise.
+var jsonpathLogger = slog.Default()
+
+// Node is a value selected by a query together with its location.
+type Node struct {
+	// Location is the normalized path of the node.
+	Location Location
+	// Value is the node's value.
+	Value any
+}
+
+// Location identifies a node by the member names (string) and array
+// indexes (int) leading to it from the root.
+type Location []any
+
+// String returns the location as an RFC 9535 normalized path, such as
+// $['paths']['/pets']['get']['parameters'][0].
+func (l Location) String() string {
+	var b strings.Builder
+	b.WriteByte('$')
+	for _, elem := range l {
+		b.WriteByte('[')
+		switch elem := elem.(type) {
+		case int:
+			b.WriteString(strconv.Itoa(elem))
+		case string:
+			writeNormalizedName(&b, elem)
+		}
+		b.WriteByte(']')
+	}
+	return b.String()
+}
+
+// writeNormalizedName writes a member name as a normalized path's
+// single-quoted string.
+func writeNormalizedName(b *strings.Builder, name string) {
+	b.WriteByte('\'')
+	for _, r := range name {
+		switch r {
+		case '\b':
+			b.WriteString(`\b`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\'':
+			b.WriteString(`\'`)
+		case '\\':
+			b.WriteString(`\\`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(b, `\u%04x`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('\'')
+}
+
+// Get evaluates the path against the document and returns all matching values.
+//
+// The document should be a map[string]any or []any structure (typically from
+// JSON/YAML unmarshaling). Object members are visited in the order of their
+// names, so results are deterministic. Returns an empty slice if no matches
+// are found.
+func (p *Path) Get(doc any) []any {
+	e := &evaluator{root: doc}
+	return values(e.query(doc, p.segments))
+}
+
+// Query evaluates the path against the document and returns the matching
+// nodes with their locations.
+func (p *Path) Query(doc any) []Node {
+	e := &evaluator{root: doc, track: true}
+	nodes := e.query(doc, p.segments)
+	if len(nodes) == 0 {
+		return nil
+	}
+	result := make([]Node, len(nodes))
+	for i, n := range nodes {
+		result[i] = Node{Location: n.loc.location(), Value: n.value}
+	}
+	return result
+}
+
+// Set sets the value at all matching locations in the document.
+//
+// When the last segment selects a single member name or index, the member is
+// created in each object the rest of the path matches, or the element is
+// replaced in each array. Returns an error if the rest of the path matches no
+// nodes or cannot be traversed. The document is modified in place.
+func (p *Path) Set(doc any, value any) error {
+	if len(p.segments) == 0 {
+		return fmt.Errorf("jsonpath: cannot set on root path")
+	}
+
+	e := &evaluator{root: doc, track: true}
+	last := p.segments[len(p.segments)-1]
+	parents := e.query(doc, p.segments[:len(p.segments)-1])
+	if len(parents) == 0 {
+		return fmt.Errorf("jsonpath: no matches for parent path")
+	}
+
+	if !last.descendant && len(last.selectors) == 1 {
+		switch sel := last.selectors[0].(type) {
+		case nameSelector:
+			for _, parent := range parents {
+				m, ok := parent.value.(map[string]any)
+				if !ok {
+					return fmt.Errorf("jsonpath: cannot set child on non-object")
+				}
+				m[string(sel)] = value
+			}
+			return nil
+		case indexSelector:
+			for _, parent := range parents {
+				arr, ok := parent.value.([]any)
+				if !ok {
+					return fmt.Errorf("jsonpath: cannot set index on non-array")
+				}
+				idx, ok := normalizeIndex(int(sel), len(arr))
+				if !ok {
+					return fmt.Errorf("jsonpath: index %d out of bounds", int(sel))
+				}
+				arr[idx] = value
+			}
+			return nil
+		}
+	}
+
+	for _, n := range e.apply(parents, last) {
+		modifyAt(doc, n.loc.location(), func(any) any { return value })
+	}
+	return nil
+}
+
+// Remove removes all matching nodes from the document.
+//
+// Returns the modified document. For maps, matching keys are deleted.
+// For arrays, matching elements are spliced out (not set to nil).
+// Returns the original document unmodified (not an error) if the path matches
+// no nodes — callers that need to distinguish no-match from success should
+// pre-check with Get.
+func (p *Path) Remove(doc any) (any, error) {
+	if len(p.segments) == 0 {
+		return nil, fmt.Errorf("jsonpath: cannot remove root")
+	}
+
+	e := &evaluator{root: doc, track: true}
+	locations := uniqueLocations(e.query(doc, p.segments))
+
+	// Remove the deepest nodes first, and later elements of an array before
+	// earlier ones, so the locations still to be removed stay valid.
+	slices.SortStableFunc(locations, func(a, b Location) int {
+		if len(a) != len(b) {
+			return len(b) - len(a)
+		}
+		ai, aIsIndex := a[len(a)-1].(int)
+		bi, bIsIndex := b[len(b)-1].(int)
+		if aIsIndex && bIsIndex {
+			return bi - ai
+		}
+		return 0
+	})
+
+	for _, loc := range locations {
+		parentLoc, last := loc[:len(loc)-1], loc[len(loc)-1]
+		parent, ok := resolve(doc, parentLoc)
+		if !ok {
+			continue
+		}
+		switch container := parent.(type) {
+		case map[string]any:
+			if name, ok := last.(string); ok {
+				delete(container, name)
+			}
+		case []any:
+			idx, ok := last.(int)
+			if !ok || idx >= len(container) {
+				continue
+			}
+			spliced := append(container[:idx:idx], container[idx+1:]...)
+			if len(parentLoc) == 0 {
+				doc = spliced
+			} else {
+				modifyAt(doc, parentLoc, func(any) any { return spliced })
+			}
+		}
+	}
+
+	return doc, nil
+}
+
+// Modify applies a transformation function to all matching nodes.
+//
+// The function receives each matched value and should return the new value.
+// The document is modified in place. Nodes are transformed in the order they
+// are selected, so a node is transformed before its descendants. For the root
+// path ("$"), the document must be a map[string]any; fn is expected to mutate
+// it in place (e.g. mergeDeep). Root replacement via fn's return value is not
+// supported since the caller's variable cannot be reassigned.
+func (p *Path) Modify(doc any, fn func(any) any) error {
+	if len(p.segments) == 0 {
+		m, ok := doc.(map[string]any)
+		if !ok || m == nil {
+			return fmt.Errorf("jsonpath: root path Modify requires a non-nil map document; got %T", doc)
+		}
+		// fn is expected to mutate the map in place; return value is ignored.
+		fn(m)
+		return nil
+	}
+
+	e := &evaluator{root: doc, track: true}
+	for _, loc := range uniqueLocations(e.query(doc, p.segments)) {
+		modifyAt(doc, loc, fn)
+	}
+	return nil
+}
+
+// node is a selected value and, when tracked, its location.
+type node struct {
+	value any
+	loc   *location
+}
+
+// location is a node's location as a linked list from the node to the root,
+// so selecting a child shares its parent's location rather than copying it.
+type location struct {
+	parent *location
+	name   string
+	index  int // -1 for object members
+}
+
+func (l *location) location() Location {
+	depth := 0
+	for n := l; n != nil; n = n.parent {
+		depth++
+	}
+	result := make(Location, depth)
+	for n := l; n != nil; n = n.parent {
+		depth--
+		if n.index >= 0 {
+			result[depth] = n.index
+		} else {
+			result[depth] = n.name
+		}
+	}
+	return result
+}
+
+func values(nodes []node) []any {
+	if len(nodes) == 0 {
+		return nil
+	}
+	result := make([]any, len(nodes))
+	for i, n := range nodes {
+		result[i] = n.value
+	}
+	return result
+}
+
+// uniqueLocations returns the locations of nodes, dropping repeats, which a
+// union or descendant segment can select.
+func uniqueLocations(nodes []node) []Location {
+	seen := make(map[string]bool, len(nodes))
+	result := make([]Location, 0, len(nodes))
+	for _, n := range nodes {
+		loc := n.loc.location()
+		key := loc.String()
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, loc)
+	}
+	return result
+}
+
+// evaluator evaluates segments against a document.
+type evaluator struct {
+	root any
+	// track records the location of each selected node.
+	track bool
+}
+
+// query applies segments in turn, starting from a single node.
+func (e *evaluator) query(start any, segments []*segment) []node {
+	current := []node{{value: start}}
+	for _, seg := range segments {
+		current = e.apply(current, seg)
+		if len(current) == 0 {
+			return nil
+		}
+	}
+	return current
+}
+
+// apply applies a segment to a list of nodes and returns the results.
+func (e *evaluator) apply(input []node, seg *segment) []node {
+	var results []node
+	for _, n := range input {
+		if !seg.descendant {
+			results = e.selectChildren(n, seg.selectors, results)
+			continue
+		}
+		// A descendant segment applies its selectors to the node and to
+		// each of its descendants, visiting a node before its children.
+		e.descend(n, 0, func(d node) {
+			results = e.selectChildren(d, seg.selectors, results)
+		})
+	}
+	return results
+}
+
+// maxRecursionDepth caps how deep recursive descent will traverse to prevent
+// stack overflow on pathologically nested structures.
+const maxRecursionDepth = 500
+
+// descend calls visit for a node and each of its descendants.
+func (e *evaluator) descend(n node, depth int, visit func(node)) {
+	if depth > maxRecursionDepth {
+		jsonpathLogger.Warn("jsonpath recursive descent truncated at depth limit",
+			"depth", depth,
+			"maxDepth", maxRecursionDepth)
+		return
+	}
+	visit(n)
+	e.eachChild(n, func(child node) {
+		e.descend(child, depth+1, visit)
+	})
+}
+
+// eachChild calls fn for each member of an object, in name order, or each
+// element of an array.
+func (e *evaluator) eachChild(n node, fn func(node)) {
+	switch v := n.value.(type) {
+	case map[string]any:
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			fn(e.member(n, key, v[key]))
+		}
+	case []any:
+		for i, elem := range v {
+			fn(e.element(n, i, elem))
+		}
+	}
+}
+
+func (e *evaluator) member(parent node, name string, value any) node {
+	child := node{value: value}
+	if e.track {
+		child.loc = &location{parent: parent.loc, name: name, index: -1}
+	}
+	return child
+}
+
+func (e *evaluator) element(parent node, index int, value any) node {
+	child := node{value: value}
+	if e.track {
+		child.loc = &location{parent: parent.loc, index: index}
+	}
+	return child
+}
+
+// selectChildren appends the children of n that the selectors select.
+func (e *evaluator) selectChildren(n node, selectors []selector, results []node) []node {
+	for _, sel := range selectors {
+		switch s := sel.(type) {
+		case nameSelector:
+			if m, ok := n.value.(map[string]any); ok {
+				if val, exists := m[string(s)]; exists {
+					results = append(results, e.member(n, string(s), val))
+				}
+			}
+
+		case wildcardSelector:
+			e.eachChild(n, func(child node) {
+				results = append(results, child)
+			})
+
+		case indexSelector:
+			if arr, ok := n.value.([]any); ok {
+				if idx, ok := normalizeIndex(int(s), len(arr)); ok {
+					results = append(results, e.element(n, idx, arr[idx]))
+				}
+			}
+
+		case sliceSelector:
+			if arr, ok := n.value.([]any); ok {
+				for _, idx := range s.indexes(len(arr)) {
+					results = append(results, e.element(n, idx, arr[idx]))
+				}
+			}
+
+		case filterSelector:
+			e.eachChild(n, func(child node) {
+				ctx := &filterContext{current: child.value, root: e.root}
+				if s.expr.test(ctx) {
+					results = append(results, child)
+				}
+			})
+		}
+	}
+	return results
+}
+
+// normalizeIndex resolves a possibly negative index against an array length.
+func normalizeIndex(idx, length int) (int, bool) {
+	if idx < 0 {
+		idx += length
+	}
+	return idx, idx >= 0 && idx < length
+}
+
+// indexes returns the indexes a slice selects from an array of the given
+// length, in selection order (RFC 9535, section 2.3.4.2).
+func (s sliceSelector) indexes(length int) []int {
+	if s.step == 0 {
+		return nil
+	}
+
+	normalize := func(i int) int {
+		if i < 0 {
+			return length + i
+		}
+		return i
+	}
+	var lower, upper int
+	if s.step > 0 {
+		start, end := 0, length
+		if s.start != nil {
+			start = normalize(*s.start)
+		}
+		if s.end != nil {
+			end = normalize(*s.end)
+		}
+		lower, upper = min(max(start, 0), length), min(max(end, 0), length)
+		var result []int
+		for i := lower; i < upper; i += s.step {
+			result = append(result, i)
+		}
+		return result
+	}
+
+	start, end := length-1, -length-1
+	if s.start != nil {
+		start = normalize(*s.start)
+	}
+	if s.end != nil {
+		end = normalize(*s.end)
+	}
+	upper, lower = min(max(start, -1), length-1), min(max(end, -1), length-1)
+	var result []int
+	for i := upper; lower < i; i += s.step {
+		result = append(result, i)
+	}
+	return result
+}
+
+// resolve returns the value at a location of the document.
+func resolve(doc any, loc Location) (any, bool) {
+	current := doc
+	for _, elem := range loc {
+		switch elem := elem.(type) {
+		case string:
+			m, ok := current.(map[string]any)
+			if !ok {
+				return nil, false
+			}
+			if current, ok = m[elem]; !ok {
+				return nil, false
+			}
+		case int:
+			arr, ok := current.([]any)
+			if !ok || elem >= len(arr) {
+				return nil, false
+			}
+			current = arr[elem]
+		}
+	}
+	return current, true
+}
+
+// modifyAt replaces the value at a non-root location of the document with
+// fn's result, if the location still exists.
+func modifyAt(doc any, loc Location, fn func(any) any) {
+	parent, ok := resolve(doc, loc[:len(loc)-1])
+	if !ok {
+		return
+	}
+	switch container := parent.(type) {
+	case map[string]any:
+		if name, ok := loc[len(loc)-1].(string); ok {
+			if val, exists := container[name]; exists {
+				container[name] = fn(val)
+			}
+		}
+	case []any:
+		if idx, ok := loc[len(loc)-1].(int); ok && idx < len(container) {
+			container[idx] = fn(container[idx])
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package jsonpath_test
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/erraggy/oastools/jsonpath"
+)
+
+func exampleDocument() map[string]any {
+	return map[string]any{
+		"paths": map[string]any{
+			"/pets": map[string]any{
+				"get": map[string]any{
+					"operationId": "listPets",
+					"parameters": []any{
+						map[string]any{"name": "limit", "in": "query"},
+						map[string]any{"name": "cursor", "in": "query"},
+					},
+				},
+			},
+			"/pets/{id}": map[string]any{
+				"get": map[string]any{
+					"operationId": "getPet",
+					"deprecated":  true,
+				},
+			},
+		},
+	}
+}
+
+// Example demonstrates selecting values with a filter.
+func Example() {
+	path, err := jsonpath.Parse("$.paths.*[?@.deprecated==true].operationId")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(path.Get(exampleDocument()))
+	// Output: [getPet]
+}
+
+// ExamplePath_Query demonstrates reporting the location of each match.
+func ExamplePath_Query() {
+	path := jsonpath.MustParse("$..parameters[?match(@.name, 'c.*')]")
+
+	for _, node := range path.Query(exampleDocument()) {
+		fmt.Println(node.Location)
+	}
+	// Output: $['paths']['/pets']['get']['parameters'][1]
+}
+
+// ExamplePath_Remove demonstrates removing array elements with a slice.
+func ExamplePath_Remove() {
+	doc := exampleDocument()
+
+	if _, err := jsonpath.MustParse("$.paths['/pets'].get.parameters[1:]").Remove(doc); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(jsonpath.MustParse("$..parameters[*].name").Get(doc))
+	// Output: [limit]
+}
@@ -0,0 +1,545 @@
+package jsonpath
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// nothing is the absence of a value, such as the result of a singular query
+// that selects no node. It is distinct from JSON null (nil).
+type nothingType struct{}
+
+var nothing any = nothingType{}
+
+// filterContext is the state a filter expression is evaluated in: the node
+// being tested (@) and the document root ($).
+type filterContext struct {
+	current any
+	root    any
+}
+
+// logicalExpr is a filter expression that evaluates to true or false.
+type logicalExpr interface {
+	test(ctx *filterContext) bool
+}
+
+// orExpr holds when any operand holds.
+type orExpr []logicalExpr
+
+// andExpr holds when every operand holds.
+type andExpr []logicalExpr
+
+// notExpr negates its operand.
+type notExpr struct {
+	expr logicalExpr
+}
+
+// existenceExpr holds when its query selects at least one node.
+type existenceExpr struct {
+	query *filterQuery
+}
+
+// functionTestExpr holds when a function returns true (LogicalType) or a
+// non-empty node list (NodesType).
+type functionTestExpr struct {
+	fn *functionExpr
+}
+
+// comparisonExpr compares two values.
+type comparisonExpr struct {
+	left, right comparable
+	op          string
+}
+
+func (e orExpr) test(ctx *filterContext) bool {
+	for _, operand := range e {
+		if operand.test(ctx) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e andExpr) test(ctx *filterContext) bool {
+	for _, operand := range e {
+		if !operand.test(ctx) {
+			return false
+		}
+	}
+	return true
+}
+
+func (e notExpr) test(ctx *filterContext) bool {
+	return !e.expr.test(ctx)
+}
+
+func (e existenceExpr) test(ctx *filterContext) bool {
+	return len(e.query.nodes(ctx)) > 0
+}
+
+func (e functionTestExpr) test(ctx *filterContext) bool {
+	switch result := e.fn.call(ctx).(type) {
+	case bool:
+		return result
+	case []any:
+		return len(result) > 0
+	default:
+		return false
+	}
+}
+
+func (e comparisonExpr) test(ctx *filterContext) bool {
+	return compare(e.left.value(ctx), e.op, e.right.value(ctx))
+}
+
+// comparable is an operand of a comparison: a literal, a singular query or
+// a function returning a value.
+type comparable interface {
+	// value returns the operand's value, or nothing.
+	value(ctx *filterContext) any
+}
+
+// literal is a string, number, boolean or null literal.
+type literal struct {
+	v any
+}
+
+func (l literal) value(*filterContext) any {
+	return l.v
+}
+
+// filterQuery is a query inside a filter, relative to the current node (@)
+// or absolute from the root ($).
+type filterQuery struct {
+	relative bool
+	segments []*segment
+}
+
+// nodes returns the values the query selects.
+func (q *filterQuery) nodes(ctx *filterContext) []any {
+	start := ctx.root
+	if q.relative {
+		start = ctx.current
+	}
+	e := &evaluator{root: ctx.root}
+	return values(e.query(start, q.segments))
+}
+
+// value returns the value of a singular query, or nothing if it selects no
+// node.
+func (q *filterQuery) value(ctx *filterContext) any {
+	nodes := q.nodes(ctx)
+	if len(nodes) != 1 {
+		return nothing
+	}
+	return nodes[0]
+}
+
+// singular reports whether the query selects at most one node: it uses
+// only name and index selectors, one per child segment.
+func (q *filterQuery) singular() bool {
+	for _, seg := range q.segments {
+		if seg.descendant || len(seg.selectors) != 1 {
+			return false
+		}
+		switch seg.selectors[0].(type) {
+		case nameSelector, indexSelector:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// compare compares two values with a comparison operator, following the
+// rules of RFC 9535: nothing equals only nothing, numbers compare
+// numerically, strings by code point, and other values only for equality.
+func compare(left any, op string, right any) bool {
+	switch op {
+	case "==":
+		return valuesEqual(left, right)
+	case "!=":
+		return !valuesEqual(left, right)
+	case "<":
+		return compareLess(left, right)
+	case "<=":
+		return compareLess(left, right) || valuesEqual(left, right)
+	case ">":
+		return compareLess(right, left)
+	case ">=":
+		return compareLess(right, left) || valuesEqual(left, right)
+	default:
+		return false
+	}
+}
+
+// valuesEqual reports whether two values are equal. Numbers are compared
+// by value regardless of their Go type, and arrays and objects deeply.
+func valuesEqual(left, right any) bool {
+	if l, ok := toNumber(left); ok {
+		r, ok := toNumber(right)
+		return ok && l == r
+	}
+
+	switch l := left.(type) {
+	case []any:
+		r, ok := right.([]any)
+		if !ok || len(l) != len(r) {
+			return false
+		}
+		for i := range l {
+			if !valuesEqual(l[i], r[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]any:
+		r, ok := right.(map[string]any)
+		if !ok || len(l) != len(r) {
+			return false
+		}
+		for key, lv := range l {
+			rv, ok := r[key]
+			if !ok || !valuesEqual(lv, rv) {
+				return false
+			}
+		}
+		return true
+	case string, bool, nil, nothingType:
+		return left == right
+	default:
+		return reflect.DeepEqual(left, right)
+	}
+}
+
+// compareLess reports whether left < right for numbers and strings.
+func compareLess(left, right any) bool {
+	if l, ok := toNumber(left); ok {
+		r, ok := toNumber(right)
+		return ok && l < r
+	}
+	if l, ok := left.(string); ok {
+		r, ok := right.(string)
+		return ok && l < r
+	}
+	return false
+}
+
+// toNumber converts a numeric value to float64.
+//
+// This handles the common case where YAML unmarshaling produces different
+// numeric types (int vs int64 vs float64).
+func toNumber(v any) (float64, bool) {
+	switch val := v.(type) {
+	case float64:
+		return val, true
+	case int:
+		return float64(val), true
+	case int8:
+		return float64(val), true
+	case int16:
+		return float64(val), true
+	case int32:
+		return float64(val), true
+	case int64:
+		return float64(val), true
+	case uint:
+		return float64(val), true
+	case uint8:
+		return float64(val), true
+	case uint16:
+		return float64(val), true
+	case uint32:
+		return float64(val), true
+	case uint64:
+		return float64(val), true
+	case float32:
+		return float64(val), true
+	default:
+		return 0, false
+	}
+}
+
+// parseFilter parses the logical expression of a filter selector.
+func (p *parser) parseFilter() (logicalExpr, error) {
+	expr, err := p.parseLogicalOr()
+	if err != nil {
+		return nil, err
+	}
+	return p.testExpr(expr)
+}
+
+// bareOperand is a literal, query or function not (yet) used in a
+// comparison. Whether it is valid depends on where it appears: as a
+// function argument, or as a test expression.
+type bareOperand struct {
+	operand any
+	pos     int
+}
+
+func (bareOperand) test(*filterContext) bool {
+	return false
+}
+
+// testExpr converts a bare operand to a test expression, which must be a
+// query or a function that does not return a value.
+func (p *parser) testExpr(expr logicalExpr) (logicalExpr, error) {
+	bare, ok := expr.(bareOperand)
+	if !ok {
+		return expr, nil
+	}
+	switch operand := bare.operand.(type) {
+	case *filterQuery:
+		return existenceExpr{query: operand}, nil
+	case *functionExpr:
+		if operand.result == valueType {
+			return nil, p.errorAt(bare.pos, "result of %s() must be compared", operand.name)
+		}
+		return functionTestExpr{fn: operand}, nil
+	default:
+		return nil, p.errorAt(bare.pos, "literal must be compared")
+	}
+}
+
+func (p *parser) parseLogicalOr() (logicalExpr, error) {
+	var operands orExpr
+	for {
+		expr, err := p.parseLogicalAnd()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, expr)
+		p.skipBlank()
+		if !p.consumeString("||") {
+			break
+		}
+		p.skipBlank()
+	}
+	if len(operands) == 1 {
+		return operands[0], nil
+	}
+	for i := range operands {
+		var err error
+		if operands[i], err = p.testExpr(operands[i]); err != nil {
+			return nil, err
+		}
+	}
+	return operands, nil
+}
+
+func (p *parser) parseLogicalAnd() (logicalExpr, error) {
+	var operands andExpr
+	for {
+		expr, err := p.parseBasic()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, expr)
+		p.skipBlank()
+		if !p.consumeString("&&") {
+			break
+		}
+		p.skipBlank()
+	}
+	if len(operands) == 1 {
+		return operands[0], nil
+	}
+	for i := range operands {
+		var err error
+		if operands[i], err = p.testExpr(operands[i]); err != nil {
+			return nil, err
+		}
+	}
+	return operands, nil
+}
+
+// parseBasic parses a parenthesized expression, a negation, a comparison,
+// or a bare operand.
+func (p *parser) parseBasic() (logicalExpr, error) {
+	if p.consume('!') {
+		p.skipBlank()
+		if p.peek() != '(' && p.peek() != '@' && p.peek() != '$' && !isLower(p.peek()) {
+			return nil, p.errorf("expected query, function or '(' after '!'")
+		}
+		parenthesized := p.peek() == '('
+		operand, err := p.parseBasic()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := operand.(comparisonExpr); ok && !parenthesized {
+			return nil, p.errorf("a comparison must be parenthesized to be negated")
+		}
+		expr, err := p.testExpr(operand)
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{expr: expr}, nil
+	}
+
+	if p.consume('(') {
+		p.skipBlank()
+		expr, err := p.parseLogicalOr()
+		if err != nil {
+			return nil, err
+		}
+		if expr, err = p.testExpr(expr); err != nil {
+			return nil, err
+		}
+		p.skipBlank()
+		if !p.consume(')') {
+			return nil, p.errorf("expected ')'")
+		}
+		return expr, nil
+	}
+
+	start := p.pos
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	// A comparison operator may follow the operand
+	beforeOp := p.pos
+	p.skipBlank()
+	op := p.parseComparisonOp()
+	if op == "" {
+		p.pos = beforeOp
+		return bareOperand{operand: left, pos: start}, nil
+	}
+	p.skipBlank()
+	rightStart := p.pos
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := p.comparable(left, start)
+	if err != nil {
+		return nil, err
+	}
+	r, err := p.comparable(right, rightStart)
+	if err != nil {
+		return nil, err
+	}
+	return comparisonExpr{left: l, right: r, op: op}, nil
+}
+
+// comparable checks that an operand can be compared: a literal, a singular
+// query, or a function returning a value.
+func (p *parser) comparable(operand any, pos int) (comparable, error) {
+	switch operand := operand.(type) {
+	case literal:
+		return operand, nil
+	case *filterQuery:
+		if !operand.singular() {
+			return nil, p.errorAt(pos, "query in a comparison must be singular")
+		}
+		return operand, nil
+	case *functionExpr:
+		if operand.result != valueType {
+			return nil, p.errorAt(pos, "result of %s() cannot be compared", operand.name)
+		}
+		return operand, nil
+	}
+	return nil, p.errorAt(pos, "invalid comparison operand")
+}
+
+func (p *parser) parseComparisonOp() string {
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if p.consumeString(op) {
+			return op
+		}
+	}
+	return ""
+}
+
+// parseOperand parses a literal, a query, or a function call.
+func (p *parser) parseOperand() (any, error) {
+	switch ch := p.peek(); {
+	case ch == '@' || ch == '$':
+		p.advance()
+		segments, err := p.parseSegments()
+		if err != nil {
+			return nil, err
+		}
+		return &filterQuery{relative: ch == '@', segments: segments}, nil
+	case ch == '\'' || ch == '"':
+		s, err := p.parseStringLiteral()
+		if err != nil {
+			return nil, err
+		}
+		return literal{v: s}, nil
+	case ch == '-' || isDigit(rune(ch)):
+		n, err := p.parseNumber()
+		if err != nil {
+			return nil, err
+		}
+		return literal{v: n}, nil
+	case isLower(ch):
+		start := p.pos
+		for p.pos < len(p.input) && (isLower(p.input[p.pos]) || p.input[p.pos] == '_' || isDigit(rune(p.input[p.pos]))) {
+			p.pos++
+		}
+		name := p.input[start:p.pos]
+		if p.peek() == '(' {
+			return p.parseFunction(name, start)
+		}
+		switch name {
+		case "true":
+			return literal{v: true}, nil
+		case "false":
+			return literal{v: false}, nil
+		case "null":
+			return literal{v: nil}, nil
+		}
+		return nil, p.errorAt(start, "unexpected %q in filter", name)
+	case ch == 0:
+		return nil, p.errorf("unexpected end of filter expression")
+	default:
+		return nil, p.errorf("unexpected character %q in filter", ch)
+	}
+}
+
+// parseNumber parses a JSON number literal, which may also be "-0".
+func (p *parser) parseNumber() (float64, error) {
+	start := p.pos
+	p.consume('-')
+	digits := p.pos
+	for p.pos < len(p.input) && isDigit(rune(p.input[p.pos])) {
+		p.pos++
+	}
+	if p.pos == digits || (p.input[digits] == '0' && p.pos-digits > 1) {
+		return 0, p.errorAt(start, "invalid number")
+	}
+	if p.consume('.') {
+		fraction := p.pos
+		for p.pos < len(p.input) && isDigit(rune(p.input[p.pos])) {
+			p.pos++
+		}
+		if p.pos == fraction {
+			return 0, p.errorAt(start, "invalid number")
+		}
+	}
+	if p.consume('e') || p.consume('E') {
+		if !p.consume('+') {
+			p.consume('-')
+		}
+		exponent := p.pos
+		for p.pos < len(p.input) && isDigit(rune(p.input[p.pos])) {
+			p.pos++
+		}
+		if p.pos == exponent {
+			return 0, p.errorAt(start, "invalid number")
+		}
+	}
+	n, err := strconv.ParseFloat(p.input[start:p.pos], 64)
+	if err != nil || math.IsInf(n, 0) {
+		return 0, p.errorAt(start, "invalid number %q", p.input[start:p.pos])
+	}
+	return n, nil
+}
+
+func isLower(ch byte) bool {
+	return ch >= 'a' && ch <= 'z'
+}
@@ -0,0 +1,260 @@
+package jsonpath
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// functionType is the declared type of a function parameter or result.
+type functionType int
+
+const (
+	// valueType is a JSON value or nothing.
+	valueType functionType = iota
+	// logicalType is true or false.
+	logicalType
+	// nodesType is a list of nodes.
+	nodesType
+)
+
+// function is a function extension of RFC 9535.
+type function struct {
+	params []functionType
+	result functionType
+	// call receives a value (or nothing) for each valueType parameter, a
+	// []any for each nodesType parameter and a bool for each logicalType
+	// parameter, and returns a value of the result type.
+	call func(args []any) any
+}
+
+// functions are the function extensions defined by RFC 9535.
+var functions = map[string]function{
+	"length": {params: []functionType{valueType}, result: valueType, call: lengthFunc},
+	"count":  {params: []functionType{nodesType}, result: valueType, call: countFunc},
+	"match":  {params: []functionType{valueType, valueType}, result: logicalType, call: matchFunc},
+	"search": {params: []functionType{valueType, valueType}, result: logicalType, call: searchFunc},
+	"value":  {params: []functionType{nodesType}, result: valueType, call: valueFunc},
+}
+
+// functionExpr is a call of a function extension.
+type functionExpr struct {
+	name   string
+	fn     function
+	args   []functionArg
+	result functionType
+}
+
+// functionArg evaluates a function argument to the type of its parameter.
+type functionArg func(ctx *filterContext) any
+
+func (f *functionExpr) call(ctx *filterContext) any {
+	args := make([]any, len(f.args))
+	for i, arg := range f.args {
+		args[i] = arg(ctx)
+	}
+	return f.fn.call(args)
+}
+
+// value returns the result of a function returning a value.
+func (f *functionExpr) value(ctx *filterContext) any {
+	return f.call(ctx)
+}
+
+// parseFunction parses the arguments of a function call and checks that
+// each is well-typed for its parameter.
+func (p *parser) parseFunction(name string, start int) (*functionExpr, error) {
+	fn, ok := functions[name]
+	if !ok {
+		return nil, p.errorAt(start, "unknown function %s()", name)
+	}
+	p.advance() // '('
+
+	expr := &functionExpr{name: name, fn: fn, result: fn.result}
+	p.skipBlank()
+	for !p.consume(')') {
+		if len(expr.args) > 0 {
+			if !p.consume(',') {
+				return nil, p.errorf("expected ',' or ')' in arguments of %s()", name)
+			}
+			p.skipBlank()
+		}
+		if len(expr.args) == len(fn.params) {
+			return nil, p.errorAt(start, "too many arguments for %s()", name)
+		}
+		argStart := p.pos
+		parsed, err := p.parseLogicalOr()
+		if err != nil {
+			return nil, err
+		}
+		arg, err := p.functionArg(parsed, fn.params[len(expr.args)], name, argStart)
+		if err != nil {
+			return nil, err
+		}
+		expr.args = append(expr.args, arg)
+		p.skipBlank()
+	}
+	if len(expr.args) != len(fn.params) {
+		return nil, p.errorAt(start, "%s() takes %d argument(s)", name, len(fn.params))
+	}
+	return expr, nil
+}
+
+// functionArg checks that a parsed argument is well-typed for a parameter
+// and returns its evaluator.
+func (p *parser) functionArg(parsed logicalExpr, param functionType, name string, pos int) (functionArg, error) {
+	bare, isBare := parsed.(bareOperand)
+
+	switch param {
+	case valueType:
+		if !isBare {
+			return nil, p.errorAt(pos, "%s() requires a value argument", name)
+		}
+		c, err := p.comparable(bare.operand, pos)
+		if err != nil {
+			return nil, p.errorAt(pos, "%s() requires a value argument", name)
+		}
+		return c.value, nil
+
+	case nodesType:
+		if isBare {
+			switch operand := bare.operand.(type) {
+			case *filterQuery:
+				return func(ctx *filterContext) any { return operand.nodes(ctx) }, nil
+			case *functionExpr:
+				if operand.result == nodesType {
+					return operand.call, nil
+				}
+			}
+		}
+		return nil, p.errorAt(pos, "%s() requires a query argument", name)
+
+	default:
+		expr, err := p.testExpr(parsed)
+		if err != nil {
+			return nil, err
+		}
+		return func(ctx *filterContext) any { return expr.test(ctx) }, nil
+	}
+}
+
+// lengthFunc returns the number of characters of a string, elements of an
+// array or members of an object, and nothing for any other value.
+func lengthFunc(args []any) any {
+	switch v := args[0].(type) {
+	case string:
+		return float64(utf8.RuneCountInString(v))
+	case []any:
+		return float64(len(v))
+	case map[string]any:
+		return float64(len(v))
+	default:
+		return nothing
+	}
+}
+
+// countFunc returns the number of nodes in a node list.
+func countFunc(args []any) any {
+	return float64(len(args[0].([]any)))
+}
+
+// valueFunc returns the value of a node list's only node, or nothing.
+func valueFunc(args []any) any {
+	nodes := args[0].([]any)
+	if len(nodes) != 1 {
+		return nothing
+	}
+	return nodes[0]
+}
+
+// matchFunc reports whether a string matches an I-Regexp entirely.
+func matchFunc(args []any) any {
+	return regexpTest(args, true)
+}
+
+// searchFunc reports whether a string contains a match of an I-Regexp.
+func searchFunc(args []any) any {
+	return regexpTest(args, false)
+}
+
+func regexpTest(args []any, anchored bool) bool {
+	s, ok := args[0].(string)
+	if !ok {
+		return false
+	}
+	pattern, ok := args[1].(string)
+	if !ok {
+		return false
+	}
+	re := compileIRegexp(pattern, anchored)
+	return re != nil && re.MatchString(s)
+}
+
+// iregexpCache holds compiled I-Regexps by pattern and anchoring, so a
+// filter compiles its pattern once rather than once per node.
+var iregexpCache sync.Map
+
+type iregexpKey struct {
+	pattern  string
+	anchored bool
+}
+
+// compileIRegexp compiles an I-Regexp (RFC 9485) to a Go regular
+// expression. It returns nil if the pattern is not a valid I-Regexp.
+func compileIRegexp(pattern string, anchored bool) *regexp.Regexp {
+	key := iregexpKey{pattern, anchored}
+	if cached, ok := iregexpCache.Load(key); ok {
+		return cached.(*regexp.Regexp)
+	}
+
+	translated, ok := translateIRegexp(pattern)
+	var re *regexp.Regexp
+	if ok {
+		if anchored {
+			translated = `\A(?:` + translated + `)\z`
+		}
+		re, _ = regexp.Compile(translated)
+	}
+	iregexpCache.Store(key, re)
+	return re
+}
+
+// translateIRegexp rewrites the constructs whose meaning differs between
+// I-Regexp and Go: '.' matches any character but a line break, and '^' and
+// '$' are ordinary characters outside character classes. It rejects escapes
+// and group syntax that I-Regexp does not have.
+func translateIRegexp(pattern string) (string, bool) {
+	var b strings.Builder
+	inClass := false
+	for i := 0; i < len(pattern); i++ {
+		ch := pattern[i]
+		switch {
+		case ch == '\\':
+			if i+1 >= len(pattern) || !strings.ContainsRune(`()*+-.?[\]^{|}nrtpP`, rune(pattern[i+1])) {
+				return "", false
+			}
+			b.WriteByte(ch)
+			b.WriteByte(pattern[i+1])
+			i++
+		case inClass:
+			if ch == ']' {
+				inClass = false
+			}
+			b.WriteByte(ch)
+		case ch == '[':
+			inClass = true
+			b.WriteByte(ch)
+		case ch == '(' && i+1 < len(pattern) && pattern[i+1] == '?':
+			return "", false
+		case ch == '.':
+			b.WriteString(`[^\n\r]`)
+		case ch == '^' || ch == '$':
+			b.WriteByte('\\')
+			b.WriteByte(ch)
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	return b.String(), !inClass
+}
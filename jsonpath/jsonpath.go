@@ -0,0 +1,472 @@
+package jsonpath
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Path represents a parsed JSONPath expression.
+type Path struct {
+	raw      string
+	segments []*segment
+}
+
+// String returns the original JSONPath expression.
+func (p *Path) String() string {
+	return p.raw
+}
+
+// segment is a child segment (.name, [selectors]) or, when descendant is
+// set, a descendant segment (..name, ..[selectors]).
+type segment struct {
+	descendant bool
+	selectors  []selector
+}
+
+// selector selects children of a node. It is one of nameSelector,
+// wildcardSelector, indexSelector, sliceSelector or filterSelector.
+type selector interface {
+	// selector is a marker method that restricts the interface to the types
+	// defined in this package.
+	selector()
+}
+
+// nameSelector selects the member of an object with the given name.
+type nameSelector string
+
+// wildcardSelector selects every member of an object or element of an array.
+type wildcardSelector struct{}
+
+// indexSelector selects an array element; negative indexes count from the end.
+type indexSelector int
+
+// sliceSelector selects the array elements of [start:end:step].
+type sliceSelector struct {
+	start, end *int
+	step       int
+}
+
+// filterSelector selects the children for which a logical expression holds.
+type filterSelector struct {
+	expr logicalExpr
+}
+
+func (nameSelector) selector()     {}
+func (wildcardSelector) selector() {}
+func (indexSelector) selector()    {}
+func (sliceSelector) selector()    {}
+func (filterSelector) selector()   {}
+
+// maxInt and minInt bound the integers of index and slice selectors to the
+// I-JSON range required by RFC 9535.
+const (
+	maxInt = 1<<53 - 1
+	minInt = -maxInt
+)
+
+// Parse parses a JSONPath expression string into a Path.
+//
+// The expression must be a well-formed and valid RFC 9535 query. As an
+// extension, member names in dot notation may contain hyphens after their
+// first character, so extension keys can be written as $.info.x-logo.
+//
+// Examples:
+//
+//	Parse("$.info")                            // Navigate to info object
+//	Parse("$.paths['/users'].get")             // Navigate to specific operation
+//	Parse("$.paths.*.get")                     // All GET operations
+//	Parse("$.paths[?@.x-internal==true]")      // Filter by extension
+//	Parse("$.tags[0:2]")                       // The first two tags
+//	Parse("$..parameters[?length(@.name) > 20]") // Filter with a function
+func Parse(expr string) (*Path, error) {
+	if expr == "" {
+		return nil, fmt.Errorf("jsonpath: empty expression")
+	}
+
+	p := &parser{input: expr}
+	if !p.consume('$') {
+		return nil, fmt.Errorf("jsonpath: expression must start with '$'")
+	}
+	segments, err := p.parseSegments()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.input) {
+		if isBlank(p.peek()) {
+			return nil, p.errorf("unexpected whitespace")
+		}
+		return nil, p.errorf("unexpected character %q", p.peek())
+	}
+
+	return &Path{
+		raw:      expr,
+		segments: segments,
+	}, nil
+}
+
+// MustParse is like Parse but panics if the expression cannot be parsed.
+// It simplifies the initialization of package-level paths.
+func MustParse(expr string) *Path {
+	p, err := Parse(expr)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// parser is the internal JSONPath parser.
+type parser struct {
+	input string
+	pos   int
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return fmt.Errorf("jsonpath: %s at position %d", fmt.Sprintf(format, args...), p.pos)
+}
+
+// errorAt returns a parse error at an earlier position, such as the start
+// of the construct that is invalid.
+func (p *parser) errorAt(pos int, format string, args ...any) error {
+	return fmt.Errorf("jsonpath: %s at position %d", fmt.Sprintf(format, args...), pos)
+}
+
+// parseSegments parses the segments following a root or current node
+// identifier. Blank space is allowed between segments, but not after the
+// last one, which is left for the caller to reject or consume.
+func (p *parser) parseSegments() ([]*segment, error) {
+	var segments []*segment
+	for {
+		start := p.pos
+		p.skipBlank()
+		if ch := p.peek(); ch != '.' && ch != '[' {
+			p.pos = start
+			return segments, nil
+		}
+		seg, err := p.parseSegment()
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, seg)
+	}
+}
+
+func (p *parser) parseSegment() (*segment, error) {
+	if p.consume('[') {
+		selectors, err := p.parseBracketedSelection()
+		if err != nil {
+			return nil, err
+		}
+		return &segment{selectors: selectors}, nil
+	}
+
+	p.advance() // '.'
+	seg := &segment{}
+	if p.consume('.') {
+		seg.descendant = true
+		if p.consume('[') {
+			selectors, err := p.parseBracketedSelection()
+			if err != nil {
+				return nil, err
+			}
+			seg.selectors = selectors
+			return seg, nil
+		}
+	}
+
+	if p.consume('*') {
+		seg.selectors = []selector{wildcardSelector{}}
+		return seg, nil
+	}
+	name := p.parseMemberName()
+	if name == "" {
+		if p.pos >= len(p.input) {
+			return nil, p.errorf("unexpected end after '.'")
+		}
+		return nil, p.errorf("expected member name after '.'")
+	}
+	seg.selectors = []selector{nameSelector(name)}
+	return seg, nil
+}
+
+// parseMemberName parses the member name of dot notation: a letter, '_' or
+// non-ASCII character, followed by those, digits and (as an extension to
+// RFC 9535) hyphens.
+func (p *parser) parseMemberName() string {
+	start := p.pos
+	for p.pos < len(p.input) {
+		r, size := utf8.DecodeRuneInString(p.input[p.pos:])
+		first := p.pos == start
+		if !isNameFirst(r) && (first || !(isDigit(r) || r == '-')) {
+			break
+		}
+		p.pos += size
+	}
+	return p.input[start:p.pos]
+}
+
+func isNameFirst(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || r == '_' ||
+		(r >= 0x80 && r != utf8.RuneError && (r < 0xD800 || r > 0xDFFF))
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+// parseBracketedSelection parses the comma-separated selectors after '['.
+func (p *parser) parseBracketedSelection() ([]selector, error) {
+	var selectors []selector
+	for {
+		p.skipBlank()
+		sel, err := p.parseSelector()
+		if err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, sel)
+		p.skipBlank()
+		if p.consume(']') {
+			return selectors, nil
+		}
+		if !p.consume(',') {
+			if p.pos >= len(p.input) {
+				return nil, p.errorf("expected ']'")
+			}
+			return nil, p.errorf("unexpected character %q in bracket", p.peek())
+		}
+	}
+}
+
+func (p *parser) parseSelector() (selector, error) {
+	if p.pos >= len(p.input) {
+		return nil, p.errorf("unexpected end after '['")
+	}
+
+	switch ch := p.peek(); {
+	case ch == '\'' || ch == '"':
+		name, err := p.parseStringLiteral()
+		if err != nil {
+			return nil, err
+		}
+		return nameSelector(name), nil
+	case ch == '*':
+		p.advance()
+		return wildcardSelector{}, nil
+	case ch == '?':
+		p.advance()
+		p.skipBlank()
+		expr, err := p.parseFilter()
+		if err != nil {
+			return nil, err
+		}
+		return filterSelector{expr: expr}, nil
+	case ch == ':' || ch == '-' || isDigit(rune(ch)):
+		return p.parseIndexOrSlice()
+	default:
+		return nil, p.errorf("unexpected character %q in bracket", ch)
+	}
+}
+
+// parseIndexOrSlice parses an index selector or a slice selector.
+func (p *parser) parseIndexOrSlice() (selector, error) {
+	sel := sliceSelector{step: 1}
+	if p.peek() != ':' {
+		n, err := p.parseInt()
+		if err != nil {
+			return nil, err
+		}
+		p.skipBlank()
+		if p.peek() != ':' {
+			return indexSelector(n), nil
+		}
+		sel.start = &n
+	}
+	p.advance() // ':'
+	p.skipBlank()
+
+	if ch := p.peek(); ch == '-' || isDigit(rune(ch)) {
+		n, err := p.parseInt()
+		if err != nil {
+			return nil, err
+		}
+		sel.end = &n
+		p.skipBlank()
+	}
+	if p.consume(':') {
+		p.skipBlank()
+		if ch := p.peek(); ch == '-' || isDigit(rune(ch)) {
+			n, err := p.parseInt()
+			if err != nil {
+				return nil, err
+			}
+			sel.step = n
+		}
+	}
+	return sel, nil
+}
+
+// parseInt parses an RFC 9535 integer: no leading zeros, no "-0", and
+// within the I-JSON range.
+func (p *parser) parseInt() (int, error) {
+	start := p.pos
+	p.consume('-')
+	digits := p.pos
+	for p.pos < len(p.input) && isDigit(rune(p.input[p.pos])) {
+		p.pos++
+	}
+	text := p.input[start:p.pos]
+	switch {
+	case p.pos == digits:
+		return 0, p.errorf("expected digits")
+	case p.input[digits] == '0' && (p.pos-digits > 1 || digits > start):
+		return 0, p.errorAt(start, "invalid integer %q", text)
+	}
+	n, err := strconv.ParseInt(text, 10, 64)
+	if err != nil || n > maxInt || n < minInt {
+		return 0, p.errorAt(start, "integer %s out of range", text)
+	}
+	return int(n), nil
+}
+
+// parseStringLiteral parses a single- or double-quoted string literal,
+// decoding its escape sequences.
+func (p *parser) parseStringLiteral() (string, error) {
+	quote := p.input[p.pos]
+	p.advance()
+
+	var result strings.Builder
+	for p.pos < len(p.input) {
+		ch := p.input[p.pos]
+		switch {
+		case ch == quote:
+			p.pos++
+			return result.String(), nil
+		case ch < 0x20:
+			return "", p.errorf("unescaped control character in string")
+		case ch == '\\':
+			p.pos++
+			if err := p.parseEscape(quote, &result); err != nil {
+				return "", err
+			}
+		default:
+			result.WriteByte(ch)
+			p.pos++
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+// parseEscape decodes the escape sequence after a backslash.
+func (p *parser) parseEscape(quote byte, result *strings.Builder) error {
+	if p.pos >= len(p.input) {
+		return p.errorf("unterminated string")
+	}
+	escaped := p.input[p.pos]
+	p.pos++
+	switch escaped {
+	case 'b':
+		result.WriteByte('\b')
+	case 'f':
+		result.WriteByte('\f')
+	case 'n':
+		result.WriteByte('\n')
+	case 'r':
+		result.WriteByte('\r')
+	case 't':
+		result.WriteByte('\t')
+	case '/', '\\':
+		result.WriteByte(escaped)
+	case 'u':
+		r, err := p.parseUnicodeEscape()
+		if err != nil {
+			return err
+		}
+		result.WriteRune(r)
+	default:
+		if escaped != quote {
+			return p.errorf("invalid escape sequence '\\%c'", escaped)
+		}
+		result.WriteByte(escaped)
+	}
+	return nil
+}
+
+// parseUnicodeEscape decodes the hex digits of a \u escape, combining a
+// surrogate pair into one character.
+func (p *parser) parseUnicodeEscape() (rune, error) {
+	r, err := p.parseHex4()
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case r >= 0xDC00 && r <= 0xDFFF:
+		return 0, p.errorf("unpaired low surrogate")
+	case r >= 0xD800 && r <= 0xDBFF:
+		if !strings.HasPrefix(p.input[p.pos:], `\u`) {
+			return 0, p.errorf("unpaired high surrogate")
+		}
+		p.pos += 2
+		low, err := p.parseHex4()
+		if err != nil {
+			return 0, err
+		}
+		if low < 0xDC00 || low > 0xDFFF {
+			return 0, p.errorf("invalid low surrogate")
+		}
+		return 0x10000 + (r-0xD800)<<10 + (low - 0xDC00), nil
+	}
+	return r, nil
+}
+
+func (p *parser) parseHex4() (rune, error) {
+	if p.pos+4 > len(p.input) {
+		return 0, p.errorf("incomplete unicode escape")
+	}
+	n, err := strconv.ParseUint(p.input[p.pos:p.pos+4], 16, 32)
+	if err != nil {
+		return 0, p.errorf("invalid unicode escape")
+	}
+	p.pos += 4
+	return rune(n), nil
+}
+
+func (p *parser) peek() byte {
+	if p.pos >= len(p.input) {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+func (p *parser) advance() {
+	if p.pos < len(p.input) {
+		p.pos++
+	}
+}
+
+func (p *parser) consume(ch byte) bool {
+	if p.peek() == ch {
+		p.advance()
+		return true
+	}
+	return false
+}
+
+func (p *parser) consumeString(s string) bool {
+	if strings.HasPrefix(p.input[p.pos:], s) {
+		p.pos += len(s)
+		return true
+	}
+	return false
+}
+
+// skipBlank skips the blank space RFC 9535 allows between tokens.
+func (p *parser) skipBlank() {
+	for p.pos < len(p.input) && isBlank(p.input[p.pos]) {
+		p.pos++
+	}
+}
+
+func isBlank(ch byte) bool {
+	return ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r'
+}
@@ -24,7 +24,7 @@ func TestParse(t *testing.T) {
 		name    string
 		input   string
 		wantErr bool
-		segLen  int // Expected number of segments
+		segLen  int // Expected number of segments, counting the root identifier
 	}{
 		// Valid expressions
 		{name: "root only", input: "$", wantErr: false, segLen: 1},
@@ -83,7 +83,7 @@ func TestParse(t *testing.T) {
 
 			require.NoError(t, err, "Parse(%q) unexpected error", tt.input)
 			require.NotNil(t, path, "Parse(%q) returned nil path without error", tt.input)
-			assert.Len(t, path.segments, tt.segLen, "Parse(%q) segment count", tt.input)
+			assert.Len(t, path.segments, tt.segLen-1, "Parse(%q) segment count", tt.input)
 			assert.Equal(t, tt.input, path.String(), "Path.String()")
 		})
 	}
@@ -644,15 +644,12 @@ func TestEdgeCases(t *testing.T) {
 	})
 }
 
-// TestFilterExpr_String tests the String method of FilterExpr.
-func TestFilterExpr_String(t *testing.T) {
-	expr := &FilterExpr{
-		Field:    "status",
-		Operator: "==",
-		Value:    "active",
-	}
-
-	assert.Equal(t, "@.status == active", expr.String())
+// TestLocation_String tests the normalized path form of Location.
+func TestLocation_String(t *testing.T) {
+	assert.Equal(t, "$", Location{}.String())
+	assert.Equal(t, "$['paths']['/pets']['get']['parameters'][0]",
+		Location{"paths", "/pets", "get", "parameters", 0}.String())
+	assert.Equal(t, `$['it\'s']['a\\b']['\n']['\u0001']`, Location{"it's", `a\b`, "\n", "\u0001"}.String())
 }
 
 // TestSetInParent tests setting values at nested paths.
@@ -941,7 +938,7 @@ func TestPathString(t *testing.T) {
 	}
 }
 
-// TestRecursiveDescentDepthLimit verifies that recursiveDescend stops at
+// TestRecursiveDescentDepthLimit verifies that a descendant segment stops at
 // maxRecursionDepth and does not stack overflow on deeply nested structures.
 func TestRecursiveDescentDepthLimit(t *testing.T) {
 	suppressJSONPathLogger(t)
@@ -952,9 +949,8 @@ func TestRecursiveDescentDepthLimit(t *testing.T) {
 		node = map[string]any{"nested": node}
 	}
 
-	// Use a child segment that matches the "nested" key at every level.
-	child := ChildSegment{Key: "nested"}
-	results := recursiveDescend(node, child, 0)
+	// Use a descendant segment that matches the "nested" key at every level.
+	results := MustParse("$..nested").Get(node)
 
 	// Should not panic or stack overflow; results are capped by depth limit.
 	assert.LessOrEqual(t, len(results), 501)
@@ -974,8 +970,7 @@ func TestRecursiveDescentDepthLimit_WarningEmitted(t *testing.T) {
 		node = map[string]any{"nested": node}
 	}
 
-	child := ChildSegment{Key: "nested"}
-	recursiveDescend(node, child, 0)
+	MustParse("$..nested").Get(node)
 
 	assert.Contains(t, buf.String(), "truncated at depth limit")
 }
@@ -1124,9 +1119,9 @@ func TestModifyRecursiveDescent(t *testing.T) {
 	assert.Equal(t, "updated", doc["paths"].(map[string]any)["/users"].(map[string]any)["description"], "path description should be updated")
 }
 
-// TestRecursiveDescentDepthLimit_nilChild verifies the depth cap when
-// recursiveDescend delegates to collectAllDescendants (nil child segment).
-func TestRecursiveDescentDepthLimit_nilChild(t *testing.T) {
+// TestRecursiveDescentDepthLimit_wildcard verifies the depth cap when a
+// descendant segment selects every child.
+func TestRecursiveDescentDepthLimit_wildcard(t *testing.T) {
 	suppressJSONPathLogger(t)
 
 	var node any = "leaf"
@@ -1134,26 +1129,188 @@ func TestRecursiveDescentDepthLimit_nilChild(t *testing.T) {
 		node = map[string]any{"nested": node}
 	}
 
-	results := recursiveDescend(node, nil, 0)
+	results := MustParse("$..*").Get(node)
 
 	assert.LessOrEqual(t, len(results), 501)
 	assert.Greater(t, len(results), 0, "expected some results before hitting depth cap")
 }
 
-// TestCollectAllDescendantsDepthLimit verifies that collectAllDescendants
-// stops at maxRecursionDepth on deeply nested structures.
-func TestCollectAllDescendantsDepthLimit(t *testing.T) {
+// TestDescendDepthLimit verifies that descend stops at maxRecursionDepth on
+// deeply nested structures.
+func TestDescendDepthLimit(t *testing.T) {
 	suppressJSONPathLogger(t)
 
-	var node any = "leaf"
+	var doc any = "leaf"
 	for range 600 {
-		node = map[string]any{"nested": node}
+		doc = map[string]any{"nested": doc}
 	}
 
 	var results []any
-	collectAllDescendants(node, &results, 0)
+	e := &evaluator{root: doc}
+	e.descend(node{value: doc}, 0, func(n node) { results = append(results, n.value) })
 
 	// Each level adds one value; depth cap at 500 means at most ~501 entries.
 	assert.LessOrEqual(t, len(results), 501)
 	assert.Greater(t, len(results), 0, "expected some results before hitting depth cap")
 }
+
+// TestSlicesAndUnions tests slice selectors and selector unions.
+func TestSlicesAndUnions(t *testing.T) {
+	doc := map[string]any{
+		"servers": []any{"a", "b", "c", "d", "e"},
+		"info":    map[string]any{"title": "API", "version": "1.0", "summary": "s"},
+	}
+
+	tests := []struct {
+		path string
+		want []any
+	}{
+		{"$.servers[1:3]", []any{"b", "c"}},
+		{"$.servers[-2:]", []any{"d", "e"}},
+		{"$.servers[::2]", []any{"a", "c", "e"}},
+		{"$.servers[::-1]", []any{"e", "d", "c", "b", "a"}},
+		{"$.servers[0,-1]", []any{"a", "e"}},
+		{"$.servers[4,0:2]", []any{"e", "a", "b"}},
+		{"$.info['title','version']", []any{"API", "1.0"}},
+		{"$.info['version','title']", []any{"1.0", "API"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, MustParse(tt.path).Get(doc))
+		})
+	}
+}
+
+// TestFunctions tests the RFC 9535 function extensions against an
+// OpenAPI-shaped document.
+func TestFunctions(t *testing.T) {
+	doc := map[string]any{
+		"paths": map[string]any{
+			"/pets":          map[string]any{"get": map[string]any{"operationId": "listPets", "tags": []any{"pets"}}},
+			"/pets/{id}":     map[string]any{"get": map[string]any{"operationId": "getPet", "tags": []any{"pets", "public"}}},
+			"/admin/reports": map[string]any{"get": map[string]any{"operationId": "adminReports"}},
+		},
+	}
+
+	tests := []struct {
+		path string
+		want []any
+	}{
+		{"$.paths[?length(@.get.tags)==2].get.operationId", []any{"getPet"}},
+		{"$.paths.*.get[?count(@.tags[*])>=1].operationId", nil},
+		{"$.paths.*[?count(@.tags[*])>=1].operationId", []any{"listPets", "getPet"}},
+		{"$.paths.*[?match(@.operationId, 'get.*')].operationId", []any{"getPet"}},
+		{"$.paths.*[?search(@.operationId, 'Pet')].operationId", []any{"listPets", "getPet"}},
+		{"$.paths.*[?value(@.tags[1])=='public'].operationId", []any{"getPet"}},
+		{"$.paths.*[?!@.tags].operationId", []any{"adminReports"}},
+		{"$.paths.*[?!(@.operationId=='getPet') && @.tags].operationId", []any{"listPets"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, MustParse(tt.path).Get(doc))
+		})
+	}
+}
+
+// TestMissingVersusNull tests that a missing member and a null member are
+// distinguished in filters.
+func TestMissingVersusNull(t *testing.T) {
+	doc := []any{
+		map[string]any{"name": "a", "default": nil},
+		map[string]any{"name": "b"},
+	}
+
+	assert.Len(t, MustParse("$[?@.default==null]").Get(doc), 1)
+	assert.Len(t, MustParse("$[?@.default]").Get(doc), 1)
+	assert.Len(t, MustParse("$[?!@.default]").Get(doc), 1)
+	assert.Len(t, MustParse("$[?@.default!=null]").Get(doc), 1)
+}
+
+// TestParseErrors tests that invalid selectors report their position.
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		path    string
+		wantErr string
+	}{
+		{"$[?length(@.*)==1]", "length() requires a value argument"},
+		{"$[?count(@.a)]", "position"},
+		{"$[?unknown(@)]", "unknown function unknown()"},
+		{"$[01]", "position 2"},
+		{"$.a ", "position"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			_, err := Parse(tt.path)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+// TestQuery tests that Query reports the normalized path of each node.
+func TestQuery(t *testing.T) {
+	doc := map[string]any{
+		"paths": map[string]any{
+			"/pets": map[string]any{
+				"get": map[string]any{
+					"parameters": []any{
+						map[string]any{"name": "limit"},
+						map[string]any{"name": "cursor"},
+					},
+				},
+			},
+		},
+	}
+
+	nodes := MustParse("$..parameters[?@.name=='cursor']").Query(doc)
+	require.Len(t, nodes, 1)
+	assert.Equal(t, Location{"paths", "/pets", "get", "parameters", 1}, nodes[0].Location)
+	assert.Equal(t, "$['paths']['/pets']['get']['parameters'][1]", nodes[0].Location.String())
+	assert.Equal(t, map[string]any{"name": "cursor"}, nodes[0].Value)
+
+	root := MustParse("$").Query(doc)
+	require.Len(t, root, 1)
+	assert.Empty(t, root[0].Location)
+
+	assert.Nil(t, MustParse("$.missing").Query(doc))
+}
+
+// TestMutateWithSlicesAndUnions tests Set, Remove and Modify with selectors
+// that match several or repeated nodes.
+func TestMutateWithSlicesAndUnions(t *testing.T) {
+	t.Run("remove slice", func(t *testing.T) {
+		doc := map[string]any{"servers": []any{"a", "b", "c", "d"}}
+		_, err := MustParse("$.servers[1:3]").Remove(doc)
+		require.NoError(t, err)
+		assert.Equal(t, []any{"a", "d"}, doc["servers"])
+	})
+
+	t.Run("remove union with repeated index", func(t *testing.T) {
+		doc := map[string]any{"servers": []any{"a", "b", "c"}}
+		_, err := MustParse("$.servers[0,2,0]").Remove(doc)
+		require.NoError(t, err)
+		assert.Equal(t, []any{"b"}, doc["servers"])
+	})
+
+	t.Run("remove from root array", func(t *testing.T) {
+		got, err := MustParse("$[-1]").Remove([]any{"a", "b"})
+		require.NoError(t, err)
+		assert.Equal(t, []any{"a"}, got)
+	})
+
+	t.Run("set slice", func(t *testing.T) {
+		doc := map[string]any{"servers": []any{"a", "b", "c"}}
+		require.NoError(t, MustParse("$.servers[:2]").Set(doc, "x"))
+		assert.Equal(t, []any{"x", "x", "c"}, doc["servers"])
+	})
+
+	t.Run("modify union with repeated name once", func(t *testing.T) {
+		doc := map[string]any{"count": 1.0}
+		err := MustParse("$['count','count']").Modify(doc, func(v any) any { return v.(float64) + 1 })
+		require.NoError(t, err)
+		assert.Equal(t, 2.0, doc["count"])
+	})
+}
@@ -0,0 +1,147 @@
+package jsonpath
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// rfcTest is a test case in the file format of the JSONPath Compliance Test
+// Suite.
+type rfcTest struct {
+	Name            string  `json:"name"`
+	Selector        string  `json:"selector"`
+	Document        any     `json:"document"`
+	Result          []any   `json:"result"`
+	Results         [][]any `json:"results"`
+	InvalidSelector bool    `json:"invalid_selector"`
+}
+
+// knownFailures lists the compliance suite cases expected to fail, by name,
+// with the reason each is accepted. A listed case that passes, or that the
+// suite no longer contains, fails TestCompliance.
+var knownFailures = map[string]string{}
+
+// TestRFC9535Examples runs the hand-written cases in testdata/rfc9535.json,
+// taken from RFC 9535 and its examples. They are not the upstream compliance
+// test suite.
+func TestRFC9535Examples(t *testing.T) {
+	runSuite(t, readSuite(t, "testdata/rfc9535.json"), nil)
+}
+
+// TestCompliance runs the JSONPath Compliance Test Suite vendored in
+// testdata/cts at the commit pinned in testdata/cts/sources.txt. It skips
+// while no commit is pinned.
+func TestCompliance(t *testing.T) {
+	commit := pinnedCTSCommit(t)
+	if commit == "-" {
+		t.Skip("testdata/cts/sources.txt pins no commit; run 'make jsonpath-cts-update'")
+	}
+	if _, err := os.Stat("testdata/cts/cts.json"); errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("testdata/cts/cts.json is missing at pinned commit %s; run 'make jsonpath-cts-vendor'", commit)
+	}
+	runSuite(t, readSuite(t, "testdata/cts/cts.json"), knownFailures)
+}
+
+// pinnedCTSCommit returns the commit recorded in testdata/cts/sources.txt.
+func pinnedCTSCommit(t *testing.T) string {
+	t.Helper()
+	f, err := os.Open("testdata/cts/sources.txt")
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		require.Len(t, fields, 4, "sources.txt record %q", line)
+		return fields[1]
+	}
+	require.NoError(t, scanner.Err())
+	t.Fatal("testdata/cts/sources.txt has no record")
+	return ""
+}
+
+// readSuite reads the test cases of a file in the compliance suite format.
+func readSuite(t *testing.T, name string) []rfcTest {
+	t.Helper()
+	data, err := os.ReadFile(name)
+	require.NoError(t, err)
+
+	var suite struct {
+		Tests []rfcTest `json:"tests"`
+	}
+	require.NoError(t, json.Unmarshal(data, &suite))
+	require.NotEmpty(t, suite.Tests)
+	return suite.Tests
+}
+
+// runSuite runs each case as a subtest. A case named in knownFailures must
+// fail, and is then skipped with its reason; every name in knownFailures must
+// be a case of the suite.
+func runSuite(t *testing.T, tests []rfcTest, knownFailures map[string]string) {
+	seen := make(map[string]bool, len(tests))
+	for _, tc := range tests {
+		seen[tc.Name] = true
+		t.Run(tc.Name, func(t *testing.T) {
+			err := checkCase(tc)
+			reason, known := knownFailures[tc.Name]
+			switch {
+			case known && err == nil:
+				t.Errorf("passes but is listed in knownFailures (%s); remove it", reason)
+			case known:
+				t.Skipf("known failure: %s: %v", reason, err)
+			case err != nil:
+				t.Error(err)
+			}
+		})
+	}
+	for name := range knownFailures {
+		if !seen[name] {
+			t.Errorf("knownFailures lists %q, which is not a case of the suite", name)
+		}
+	}
+}
+
+// checkCase runs one case: an invalid selector must fail to parse, and a
+// valid one must produce the expected result, or one of the listed results
+// where object member order makes several valid.
+func checkCase(tc rfcTest) error {
+	path, err := Parse(tc.Selector)
+	if tc.InvalidSelector {
+		if err == nil {
+			return fmt.Errorf("Parse(%q) accepted an invalid selector", tc.Selector)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Parse(%q): %w", tc.Selector, err)
+	}
+
+	got := path.Get(tc.Document)
+	if got == nil {
+		got = []any{}
+	}
+	if tc.Results != nil {
+		for _, want := range tc.Results {
+			if reflect.DeepEqual(want, got) {
+				return nil
+			}
+		}
+		return fmt.Errorf("Get(%q) = %v, want one of %v", tc.Selector, got, tc.Results)
+	}
+	if !reflect.DeepEqual(tc.Result, got) {
+		return fmt.Errorf("Get(%q) = %v, want %v", tc.Selector, got, tc.Result)
+	}
+	return nil
+}
@@ -4,7 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 
-	"github.com/erraggy/oastools/internal/jsonpath"
+	"github.com/erraggy/oastools/jsonpath"
 	"github.com/erraggy/oastools/parser"
 	"go.yaml.in/yaml/v4"
 )
@@ -63,7 +63,7 @@ func (a *Applier) ApplyParsed(spec *parser.ParseResult, o *Overlay) (*ApplyResul
 
 	// Apply each action sequentially
 	for i, action := range o.Actions {
-		change, err := a.applyAction(doc, action, i)
+		change, err := a.applyAction(doc, action, i, o.Version)
 		if err != nil {
 			if a.StrictTargets {
 				return nil, err
@@ -170,12 +170,22 @@ func (a *Applier) previewAction(doc any, action Action, index int) (*ProposedCha
 	change.MatchCount = len(matches)
 
 	// Determine operation type
-	if action.Remove {
+	switch {
+	case action.Remove:
 		change.Operation = "remove"
-	} else if action.Update != nil {
+	case action.Copy != "":
+		if _, err := copySource(doc, action.Copy); err != nil {
+			return nil, &ApplyError{
+				ActionIndex: index,
+				Target:      action.Target,
+				Cause:       err,
+			}
+		}
+		change.Operation = "copy"
+	case action.Update != nil:
 		// Peek at first match to determine operation type
 		if len(matches) > 0 {
-			switch target := matches[0].(type) {
+			switch matches[0].(type) {
 			case map[string]any:
 				if _, ok := action.Update.(map[string]any); ok {
 					change.Operation = "update"
@@ -184,7 +194,6 @@ func (a *Applier) previewAction(doc any, action Action, index int) (*ProposedCha
 				}
 			case []any:
 				change.Operation = "append"
-				_ = target // use variable
 			default:
 				change.Operation = "replace"
 			}
@@ -222,8 +231,9 @@ func DryRunWithOptions(opts ...Option) (*DryRunResult, error) {
 	return a.DryRun(spec, o)
 }
 
-// applyAction applies a single action to the document.
-func (a *Applier) applyAction(doc any, action Action, index int) (*ChangeRecord, error) {
+// applyAction applies a single action to the document, following the merge
+// rules of the overlay's version.
+func (a *Applier) applyAction(doc any, action Action, index int, version string) (*ChangeRecord, error) {
 	path, err := jsonpath.Parse(action.Target)
 	if err != nil {
 		return nil, &ApplyError{
@@ -238,7 +248,7 @@ func (a *Applier) applyAction(doc any, action Action, index int) (*ChangeRecord,
 		Target:      action.Target,
 	}
 
-	// Remove takes precedence over Update (per spec)
+	// Remove takes precedence over Update and Copy (per spec)
 	if action.Remove {
 		record.Operation = "remove"
 		matches := path.Get(doc)
@@ -256,30 +266,34 @@ func (a *Applier) applyAction(doc any, action Action, index int) (*ChangeRecord,
 		return record, nil
 	}
 
+	// A copy merges the node it selects as if it were the update value
+	update := action.Update
+	if action.Copy != "" {
+		update, err = copySource(doc, action.Copy)
+		if err != nil {
+			return nil, &ApplyError{
+				ActionIndex: index,
+				Target:      action.Target,
+				Cause:       err,
+			}
+		}
+	}
+
 	// Update operation
-	if action.Update != nil {
+	if update != nil {
 		matches := path.Get(doc)
 		record.MatchCount = len(matches)
 
 		if len(matches) > 0 {
 			err := path.Modify(doc, func(elem any) any {
-				switch target := elem.(type) {
-				case map[string]any:
-					if update, ok := action.Update.(map[string]any); ok {
-						record.Operation = "update"
-						return mergeDeep(target, update)
-					}
-					// If update is not a map, replace the value
-					record.Operation = "replace"
-					return action.Update
-				case []any:
-					record.Operation = "append"
-					return append(target, action.Update)
-				default:
-					// For scalar values, replace
-					record.Operation = "replace"
-					return action.Update
+				// Each target gets its own copy, so later actions editing one
+				// target (or the copy source) leave the others alone
+				merged, operation := mergeUpdate(elem, deepCopyValue(update), version)
+				record.Operation = operation
+				if action.Copy != "" {
+					record.Operation = "copy"
 				}
+				return merged
 			})
 
 			if err != nil {
@@ -296,20 +310,68 @@ func (a *Applier) applyAction(doc any, action Action, index int) (*ChangeRecord,
 	return record, nil
 }
 
+// copySource returns the single node a copy action's source selects.
+func copySource(doc any, expr string) (any, error) {
+	source, err := jsonpath.Parse(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid copy JSONPath: %w", err)
+	}
+	matches := source.Get(doc)
+	if len(matches) != 1 {
+		return nil, fmt.Errorf("copy source %q matched %d nodes; exactly one is required", expr, len(matches))
+	}
+	return matches[0], nil
+}
+
+// mergeUpdate merges update into a target node and returns the result with
+// the operation performed.
+//
+// An object update is merged into an object target, and any other update
+// replaces an object or primitive target. An array target has the update
+// appended; under Overlay 1.1 an array update appends its items instead of
+// itself.
+func mergeUpdate(target, update any, version string) (any, string) {
+	concat := version != Version10
+	switch target := target.(type) {
+	case map[string]any:
+		if update, ok := update.(map[string]any); ok {
+			return mergeDeep(target, update, concat), "update"
+		}
+		// If update is not a map, replace the value
+		return update, "replace"
+	case []any:
+		if items, ok := update.([]any); ok && concat {
+			return append(target, items...), "append"
+		}
+		return append(target, update), "append"
+	default:
+		// For scalar values, replace
+		return update, "replace"
+	}
+}
+
 // mergeDeep performs a deep merge of source into target.
 //
 // Properties from source are recursively merged into target:
 //   - Same-name properties are replaced
 //   - New properties are added
 //   - Nested objects are merged recursively
-func mergeDeep(target, source map[string]any) map[string]any {
+//   - Nested arrays are concatenated when concat is set (Overlay 1.1), and
+//     replaced otherwise (Overlay 1.0)
+func mergeDeep(target, source map[string]any, concat bool) map[string]any {
 	for key, srcVal := range source {
 		if targetVal, exists := target[key]; exists {
-			targetMap, targetIsMap := targetVal.(map[string]any)
-			srcMap, srcIsMap := srcVal.(map[string]any)
-			if targetIsMap && srcIsMap {
-				mergeDeep(targetMap, srcMap)
-				continue
+			switch targetVal := targetVal.(type) {
+			case map[string]any:
+				if srcMap, ok := srcVal.(map[string]any); ok {
+					mergeDeep(targetVal, srcMap, concat)
+					continue
+				}
+			case []any:
+				if srcItems, ok := srcVal.([]any); ok && concat {
+					target[key] = append(targetVal, srcItems...)
+					continue
+				}
 			}
 		}
 		target[key] = srcVal
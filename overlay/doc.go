@@ -1,8 +1,8 @@
-// Package overlay provides support for OpenAPI Overlay Specification v1.0.0.
+// Package overlay provides support for OpenAPI Overlay Specification v1.0.0 and v1.1.0.
 //
 // The OpenAPI Overlay Specification provides a standardized mechanism for augmenting
 // OpenAPI documents through targeted transformations. Overlays use JSONPath expressions
-// to select specific locations in an OpenAPI document and apply updates or removals.
+// to select specific locations in an OpenAPI document and apply updates, copies or removals.
 //
 // # Quick Start
 //
@@ -26,8 +26,8 @@
 // # Overlay Document Structure
 //
 // An overlay document contains:
-//   - overlay: The specification version (must be "1.0.0")
-//   - info: Metadata with title and version
+//   - overlay: The specification version ("1.0.0" or "1.1.0")
+//   - info: Metadata with title and version, and from 1.1 a description
 //   - extends: Optional URI of the target document
 //   - actions: Ordered list of transformation actions
 //
@@ -51,18 +51,32 @@
 //   - For objects: Properties are recursively merged
 //   - For arrays: The update value is appended
 //   - Same-name properties are replaced, new properties are added
+//   - For primitives: The value is replaced
+//
+// Copy actions (Overlay 1.1) select a single node with a second JSONPath
+// expression and merge it into the matched nodes as if it were the update.
 //
 // Remove actions delete matched nodes from their parent container.
-// When both update and remove are specified, remove takes precedence.
+// When remove is combined with update or copy, remove takes precedence.
+//
+// # Versions
+//
+// The overlay version selects the rules an overlay is validated and applied
+// under. Under 1.1 an array update appends its items rather than itself, and
+// arrays inside a merged object are concatenated rather than replaced; 1.0
+// overlays keep the 1.0 behavior. [Validate] reports a 1.1 feature (copy,
+// info.description) used in a document declaring 1.0.0.
 //
 // # JSONPath Support
 //
-// This package includes a built-in JSONPath implementation supporting:
+// Targets are RFC 9535 JSONPath expressions, evaluated by the
+// [github.com/erraggy/oastools/jsonpath] package:
 //   - Basic navigation: $.info, $.paths['/users']
 //   - Wildcards: $.paths.*, $.paths.*.*
-//   - Array indices: $.servers[0], $.servers[-1]
-//   - Simple filters: $.paths[?@.x-internal==true]
-//   - Compound filters: $.paths[?@.deprecated==true && @.x-internal==false]
+//   - Array indices and slices: $.servers[0], $.servers[-1], $.servers[1:]
+//   - Unions: $.info['title','description']
+//   - Filters: $.paths[?@.x-internal==true], $.paths.*[?!(@.deprecated==true) && @.tags]
+//   - Functions: $.paths.*[?count(@.parameters[*])>5], $..[?match(@.operationId, 'admin.*')]
 //   - Recursive descent: $..description (find all descriptions at any depth)
 //
 // # Dry-Run Preview
@@ -78,6 +92,19 @@
 //	        change.Operation, change.MatchCount, change.Target)
 //	}
 //
+// # Generating Overlays
+//
+// [Generator] computes the overlay that transforms one document into another,
+// so applying it to the first document reproduces the second:
+//
+//	result, _ := overlay.NewGenerator().Generate("upstream.yaml", "patched.yaml")
+//	data, _ := overlay.MarshalOverlay(result.Overlay)
+//
+// Removed properties become remove actions, added and changed properties are
+// merged by one update per object, and items added to or removed from the end
+// of an array are appended or removed. Other array changes replace the array.
+// Actions are described with the changes [differ] reports at their location.
+//
 // # Validation
 //
 // Overlays can be validated before application:
@@ -0,0 +1,317 @@
+package overlay
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/erraggy/oastools/differ"
+	"github.com/erraggy/oastools/parser"
+)
+
+// Generator computes the overlay that transforms one document into another.
+type Generator struct {
+	// Info is written to the generated overlay. Empty fields default to the
+	// title "Generated overlay" and the version "1.0.0".
+	Info Info
+}
+
+// NewGenerator creates a new Generator with default settings.
+func NewGenerator() *Generator {
+	return &Generator{}
+}
+
+// GenerateResult contains the overlay computed from two documents.
+type GenerateResult struct {
+	// Overlay transforms the source document into the target document.
+	// It has no actions when the documents are identical.
+	Overlay *Overlay
+
+	// Changes are the semantic changes differ reports between the documents.
+	// Each action whose location a change can be traced to carries the
+	// change's message in its description.
+	Changes []differ.Change
+}
+
+// HasActions returns true if the documents differ.
+func (r *GenerateResult) HasActions() bool {
+	return len(r.Overlay.Actions) > 0
+}
+
+// Generate parses two specification files and computes the overlay that
+// transforms the first into the second.
+func (g *Generator) Generate(fromPath, toPath string) (*GenerateResult, error) {
+	p := parser.New()
+	from, err := p.Parse(fromPath)
+	if err != nil {
+		return nil, fmt.Errorf("overlay: failed to parse source specification: %w", err)
+	}
+	to, err := p.Parse(toPath)
+	if err != nil {
+		return nil, fmt.Errorf("overlay: failed to parse target specification: %w", err)
+	}
+
+	result, err := g.GenerateParsed(from, to)
+	if err != nil {
+		return nil, err
+	}
+	result.Overlay.Extends = fromPath
+	return result, nil
+}
+
+// GenerateParsed computes the overlay that transforms an already-parsed
+// document into another.
+//
+// The documents are compared structurally, so every difference becomes an
+// action: a removed property becomes a remove action, added and changed
+// properties of an object are collected into one update action targeting
+// that object, and items appended to or removed from the end of an array
+// become append and remove actions. Any other change to an array replaces
+// it through its parent's update. The overlay uses version 1.0.0, whose
+// merge replaces nested arrays.
+//
+// The generated overlay is applied to the source document before it is
+// returned, and an error is returned if the result differs from the target.
+func (g *Generator) GenerateParsed(from, to *parser.ParseResult) (*GenerateResult, error) {
+	if from == nil || to == nil {
+		return nil, fmt.Errorf("overlay: source and target documents are required")
+	}
+	fromDoc, err := genericDocument(from.Document)
+	if err != nil {
+		return nil, fmt.Errorf("overlay: source document: %w", err)
+	}
+	toDoc, err := genericDocument(to.Document)
+	if err != nil {
+		return nil, fmt.Errorf("overlay: target document: %w", err)
+	}
+
+	diff, err := differ.DiffWithOptions(
+		differ.WithSourceParsed(*from),
+		differ.WithTargetParsed(*to),
+		differ.WithIncludeInfo(true),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("overlay: failed to diff documents: %w", err)
+	}
+
+	gen := &actionGenerator{}
+	gen.object("$", "document", fromDoc, toDoc)
+	gen.describe(diff.Changes)
+
+	o := &Overlay{
+		Version: Version10,
+		Info:    g.Info,
+		Actions: make([]Action, 0, len(gen.actions)),
+	}
+	if o.Info.Title == "" {
+		o.Info.Title = "Generated overlay"
+	}
+	if o.Info.Version == "" {
+		o.Info.Version = "1.0.0"
+	}
+	for _, ga := range gen.actions {
+		o.Actions = append(o.Actions, ga.Action)
+	}
+
+	if len(o.Actions) > 0 {
+		applied, err := NewApplier().ApplyParsed(&parser.ParseResult{Document: fromDoc}, o)
+		if err != nil {
+			return nil, fmt.Errorf("overlay: generated overlay failed to apply: %w", err)
+		}
+		if !reflect.DeepEqual(applied.Document, toDoc) {
+			return nil, fmt.Errorf("overlay: generated overlay does not reproduce the target document")
+		}
+	}
+
+	return &GenerateResult{Overlay: o, Changes: diff.Changes}, nil
+}
+
+// genericDocument converts a document to the JSON data model the overlay is
+// computed on, so both documents use the same representation for numbers
+// regardless of how they were parsed.
+func genericDocument(doc any) (map[string]any, error) {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	var result map[string]any
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// generatedAction is an action with the location differ reports changes
+// to it under.
+type generatedAction struct {
+	Action
+	location string
+	messages []string
+}
+
+// actionGenerator collects the actions of a structural diff.
+type actionGenerator struct {
+	actions []*generatedAction
+}
+
+func (g *actionGenerator) add(action Action, location string) {
+	g.actions = append(g.actions, &generatedAction{Action: action, location: location})
+}
+
+// object diffs two objects at target. Removed properties are removed,
+// added and replaced properties are merged by a single update, and
+// properties that differ within are diffed recursively.
+func (g *actionGenerator) object(target, location string, from, to map[string]any) {
+	update := make(map[string]any)
+	var nested []string
+
+	for _, key := range slices.Sorted(maps.Keys(from)) {
+		if _, ok := to[key]; !ok {
+			g.add(Action{Target: target + selector(key), Remove: true}, location+"."+key)
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(to)) {
+		toVal := to[key]
+		fromVal, ok := from[key]
+		switch {
+		case !ok:
+			update[key] = toVal
+		case reflect.DeepEqual(fromVal, toVal):
+		case isObject(fromVal) && isObject(toVal):
+			nested = append(nested, key)
+		case isArray(fromVal) && isArray(toVal) && editableArray(fromVal.([]any), toVal.([]any)):
+			nested = append(nested, key)
+		default:
+			update[key] = toVal
+		}
+	}
+	if len(update) > 0 {
+		g.add(Action{Target: target, Update: update}, location)
+	}
+
+	for _, key := range nested {
+		childTarget, childLocation := target+selector(key), location+"."+key
+		switch fromVal := from[key].(type) {
+		case map[string]any:
+			g.object(childTarget, childLocation, fromVal, to[key].(map[string]any))
+		case []any:
+			g.array(childTarget, childLocation, fromVal, to[key].([]any))
+		}
+	}
+}
+
+// editableArray reports whether an array can turn from into to with actions
+// targeting the array or its items, rather than by replacing it.
+//
+// That is the case when items are only appended to or removed from the end
+// of a non-empty array, or when the arrays have the same length and every
+// item that differs is an object.
+func editableArray(from, to []any) bool {
+	switch {
+	case len(from) == 0 || len(to) == 0:
+		return false
+	case len(from) < len(to):
+		// A null item can't be appended: an update of null is no update
+		return reflect.DeepEqual(from, to[:len(from)]) && !slices.Contains(to[len(from):], nil)
+	case len(from) > len(to):
+		return reflect.DeepEqual(from[:len(to)], to)
+	}
+	for i := range from {
+		if !reflect.DeepEqual(from[i], to[i]) && !(isObject(from[i]) && isObject(to[i])) {
+			return false
+		}
+	}
+	return true
+}
+
+// array diffs two arrays accepted by editableArray.
+func (g *actionGenerator) array(target, location string, from, to []any) {
+	switch {
+	case len(from) < len(to):
+		// Overlay 1.0 appends an update to an array as a single item
+		for _, item := range to[len(from):] {
+			g.add(Action{Target: target, Update: item}, location)
+		}
+	case len(from) > len(to):
+		// Remove from the end, so the indexes of the remaining items hold
+		for i := len(from) - 1; i >= len(to); i-- {
+			g.add(Action{Target: target + "[" + strconv.Itoa(i) + "]", Remove: true}, location)
+		}
+	default:
+		for i := range from {
+			if !reflect.DeepEqual(from[i], to[i]) {
+				g.object(target+"["+strconv.Itoa(i)+"]", location, from[i].(map[string]any), to[i].(map[string]any))
+			}
+		}
+	}
+}
+
+// arrayIdentity matches the identity differ appends to the path of an array
+// item, such as [limit:query] for a parameter.
+var arrayIdentity = regexp.MustCompile(`\[[^\]]*\]`)
+
+// describe attaches each change to the action with the most specific
+// location that contains it. Changes that match no action, or that match
+// several actions equally well, such as items of one array, are left out.
+func (g *actionGenerator) describe(changes []differ.Change) {
+	for _, change := range changes {
+		path := arrayIdentity.ReplaceAllString(change.Path, "")
+		var best []*generatedAction
+		for _, ga := range g.actions {
+			if path != ga.location && !strings.HasPrefix(path, ga.location+".") {
+				continue
+			}
+			switch {
+			case len(best) == 0 || len(ga.location) > len(best[0].location):
+				best = []*generatedAction{ga}
+			case len(ga.location) == len(best[0].location):
+				best = append(best, ga)
+			}
+		}
+		if len(best) == 1 && change.Message != "" && !slices.Contains(best[0].messages, change.Message) {
+			best[0].messages = append(best[0].messages, change.Message)
+		}
+	}
+	for _, ga := range g.actions {
+		ga.Description = strings.Join(ga.messages, "; ")
+	}
+}
+
+// selector returns the JSONPath child selector for key, using dot notation
+// when key is a plain name and a quoted bracket otherwise.
+func selector(key string) string {
+	if isPlainName(key) {
+		return "." + key
+	}
+	r := strings.NewReplacer(`\`, `\\`, `'`, `\'`, "\n", `\n`, "\t", `\t`)
+	return "['" + r.Replace(key) + "']"
+}
+
+// isPlainName reports whether key can be written in dot notation.
+func isPlainName(key string) bool {
+	if key == "" {
+		return false
+	}
+	for i, ch := range key {
+		letter := (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') || ch == '_'
+		if !letter && (i == 0 || ch < '0' || ch > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+func isObject(v any) bool {
+	_, ok := v.(map[string]any)
+	return ok
+}
+
+func isArray(v any) bool {
+	_, ok := v.([]any)
+	return ok
+}
@@ -0,0 +1,195 @@
+package overlay
+
+import (
+	"testing"
+
+	"github.com/erraggy/oastools/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const upstreamSpec = `openapi: "3.0.3"
+info:
+  title: Pets
+  version: "1.0.0"
+servers:
+  - url: https://api.example.com
+  - url: https://staging.example.com
+tags:
+  - name: pets
+  - name: admin
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      summary: List pets
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+      responses:
+        "200":
+          description: OK
+  /pets/{id}:
+    get:
+      operationId: getPet
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: OK
+  /internal/health:
+    get:
+      responses:
+        "200":
+          description: OK
+`
+
+const patchedSpec = `openapi: "3.0.3"
+info:
+  title: Pets API
+  version: "1.0.0"
+  x-team: pets
+servers:
+  - url: https://api.example.com
+tags:
+  - name: admin
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      summary: List all pets
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+        - name: cursor
+          in: query
+          schema:
+            type: string
+      responses:
+        "200":
+          description: OK
+  /pets/{id}:
+    get:
+      operationId: getPet
+      deprecated: true
+      parameters:
+        - name: id
+          in: path
+          required: true
+          description: Pet ID
+          schema:
+            type: string
+      responses:
+        "200":
+          description: OK
+        "404":
+          description: Not found
+`
+
+func parseGenerateSpec(t *testing.T, data string) *parser.ParseResult {
+	t.Helper()
+	result, err := parser.ParseWithOptions(parser.WithBytes([]byte(data)))
+	require.NoError(t, err)
+	return result
+}
+
+func TestGenerate_ReproducesTarget(t *testing.T) {
+	from := parseGenerateSpec(t, upstreamSpec)
+	to := parseGenerateSpec(t, patchedSpec)
+
+	result, err := NewGenerator().GenerateParsed(from, to)
+	require.NoError(t, err)
+	require.True(t, result.HasActions())
+	assert.NotEmpty(t, result.Changes)
+	assert.Empty(t, Validate(result.Overlay))
+
+	// The overlay survives being written out and read back
+	data, err := MarshalOverlay(result.Overlay)
+	require.NoError(t, err)
+	o, err := ParseOverlay(data)
+	require.NoError(t, err)
+
+	applied, err := ApplyWithOptions(
+		WithSpecParsed(*from),
+		WithOverlayParsed(o),
+		WithStrictTargets(true),
+	)
+	require.NoError(t, err)
+	assert.Zero(t, applied.ActionsSkipped)
+
+	reparsed, err := ReparseDocument(from, applied.Document)
+	require.NoError(t, err)
+	assert.True(t, to.Document.(*parser.OAS3Document).Equals(reparsed.Document.(*parser.OAS3Document)),
+		"applying the generated overlay does not reproduce the target")
+}
+
+func TestGenerate_Actions(t *testing.T) {
+	result, err := NewGenerator().GenerateParsed(parseGenerateSpec(t, upstreamSpec), parseGenerateSpec(t, patchedSpec))
+	require.NoError(t, err)
+
+	actions := make(map[string]Action)
+	for _, action := range result.Overlay.Actions {
+		key := action.Target
+		if action.Remove {
+			key = "remove " + key
+		}
+		actions[key] = action
+	}
+
+	// Added and changed properties are merged into their object
+	info := actions["$.info"]
+	assert.Equal(t, map[string]any{"title": "Pets API", "x-team": "pets"}, info.Update)
+	assert.NotEmpty(t, info.Description, "differ's changes describe the action")
+	assert.Equal(t, map[string]any{"summary": "List all pets"}, actions["$.paths['/pets'].get"].Update)
+
+	// Removed properties and trailing items are removed
+	assert.Contains(t, actions, "remove $.paths['/internal/health']")
+	assert.Contains(t, actions, "remove $.servers[1]")
+
+	// Trailing items are appended and items in place are edited
+	params := actions["$.paths['/pets'].get.parameters"]
+	assert.Equal(t, "cursor", params.Update.(map[string]any)["name"])
+	assert.Equal(t, map[string]any{"description": "Pet ID"}, actions["$.paths['/pets/{id}'].get.parameters[0]"].Update)
+
+	// Other array changes replace the array through its parent
+	assert.Equal(t, map[string]any{"tags": []any{map[string]any{"name": "admin"}}}, actions["$"].Update)
+
+	assert.Equal(t, map[string]any{"404": map[string]any{"description": "Not found"}},
+		actions["$.paths['/pets/{id}'].get.responses"].Update)
+}
+
+func TestGenerate_IdenticalDocuments(t *testing.T) {
+	from := parseGenerateSpec(t, upstreamSpec)
+	to := parseGenerateSpec(t, upstreamSpec)
+
+	result, err := NewGenerator().GenerateParsed(from, to)
+	require.NoError(t, err)
+	assert.False(t, result.HasActions())
+	assert.Equal(t, Version10, result.Overlay.Version)
+	assert.Equal(t, "Generated overlay", result.Overlay.Info.Title)
+}
+
+func TestSelector(t *testing.T) {
+	tests := map[string]string{
+		"info":     ".info",
+		"_private": "._private",
+		"v2":       ".v2",
+		"x-team":   "['x-team']",
+		"200":      "['200']",
+		"/pets":    "['/pets']",
+		"it's":     `['it\'s']`,
+		`a\b`:      `['a\\b']`,
+		"":         "['']",
+	}
+	for key, want := range tests {
+		assert.Equal(t, want, selector(key), key)
+	}
+}
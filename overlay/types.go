@@ -6,12 +6,13 @@ import (
 	"github.com/erraggy/oastools/parser"
 )
 
-// Overlay represents an OpenAPI Overlay document (v1.0.0).
+// Overlay represents an OpenAPI Overlay document (v1.0.0 or v1.1.0).
 //
 // The Overlay specification provides a standardized mechanism for augmenting
 // OpenAPI documents through targeted transformations using JSONPath expressions.
 type Overlay struct {
-	// Version is the overlay specification version (e.g., "1.0.0").
+	// Version is the overlay specification version ("1.0.0" or "1.1.0").
+	// It selects the rules the overlay is validated and applied under.
 	// This field is required.
 	Version string `yaml:"overlay" json:"overlay"`
 
@@ -37,12 +38,17 @@ type Info struct {
 	// Version is the version of the overlay document.
 	// This field is required.
 	Version string `yaml:"version" json:"version"`
+
+	// Description is an optional explanation of the overlay's purpose.
+	// CommonMark syntax may be used. Added in Overlay 1.1.
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
 }
 
 // Action represents a single transformation action in an overlay.
 //
 // Each action targets specific locations in the OpenAPI document using
-// JSONPath expressions and either updates or removes the matched nodes.
+// JSONPath expressions and either updates, copies onto or removes the matched
+// nodes.
 type Action struct {
 	// Target is a JSONPath expression selecting nodes to operate on.
 	// This field is required.
@@ -54,11 +60,19 @@ type Action struct {
 
 	// Update specifies content to merge with selected nodes.
 	// For objects, properties are recursively merged.
-	// For arrays, the update value is appended.
+	// For arrays, the update value is appended; under Overlay 1.1 an array
+	// update appends its items, and arrays inside merged objects are
+	// concatenated rather than replaced.
+	// Primitive targets are replaced.
 	Update any `yaml:"update,omitempty" json:"update,omitempty"`
 
+	// Copy is a JSONPath expression selecting a single node of the document,
+	// which is merged into the selected nodes as if it were the update value.
+	// Copy and Update are mutually exclusive. Added in Overlay 1.1.
+	Copy string `yaml:"copy,omitempty" json:"copy,omitempty"`
+
 	// Remove, when true, removes the target from its parent.
-	// Remove takes precedence over Update when both are specified.
+	// Remove takes precedence over Update and Copy when both are specified.
 	Remove bool `yaml:"remove,omitempty" json:"remove,omitempty"`
 }
 
@@ -100,7 +114,7 @@ type ChangeRecord struct {
 	// Target is the JSONPath expression that was evaluated.
 	Target string
 
-	// Operation describes what was done: "update", "remove", or "append".
+	// Operation describes what was done: "update", "copy", "remove", "replace", or "append".
 	Operation string
 
 	// MatchCount is the number of nodes matched by the target.
@@ -201,7 +215,7 @@ type ProposedChange struct {
 	// Description is the action's description, if provided.
 	Description string
 
-	// Operation describes what would be done: "update", "remove", "replace", or "append".
+	// Operation describes what would be done: "update", "copy", "remove", "replace", or "append".
 	Operation string
 
 	// MatchCount is the number of nodes that would be affected.
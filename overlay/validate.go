@@ -2,21 +2,35 @@ package overlay
 
 import (
 	"fmt"
+	"slices"
 
-	"github.com/erraggy/oastools/internal/jsonpath"
+	"github.com/erraggy/oastools/jsonpath"
 )
 
-// SupportedVersion is the overlay specification version supported by this implementation.
-const SupportedVersion = "1.0.0"
+// Overlay specification versions.
+const (
+	// Version10 is Overlay Specification 1.0.0.
+	Version10 = "1.0.0"
+	// Version11 is Overlay Specification 1.1.0, which adds the copy action and
+	// info.description, and settles how updates merge arrays and primitives.
+	Version11 = "1.1.0"
+)
+
+// SupportedVersion is the latest overlay specification version supported by this implementation.
+const SupportedVersion = Version11
+
+// SupportedVersions lists every overlay specification version this implementation accepts.
+var SupportedVersions = []string{Version10, Version11}
 
 // Validate checks an overlay document for structural errors.
 //
 // Returns a slice of validation errors. An empty slice indicates the overlay
 // is valid. Validation checks include:
 //   - Required fields (overlay version, info.title, info.version, actions)
-//   - Supported overlay version (currently only 1.0.0)
-//   - Valid JSONPath syntax in action targets
-//   - Actions have either update or remove (or both)
+//   - Supported overlay version (1.0.0 or 1.1.0)
+//   - Valid JSONPath syntax in action targets and copy sources
+//   - Actions have update, copy or remove, and not both update and copy
+//   - No 1.1 feature (copy, info.description) in a 1.0.0 document
 func Validate(o *Overlay) []ValidationError {
 	var errs []ValidationError
 
@@ -26,10 +40,10 @@ func Validate(o *Overlay) []ValidationError {
 			Field:   "overlay",
 			Message: "version is required",
 		})
-	} else if o.Version != SupportedVersion {
+	} else if !slices.Contains(SupportedVersions, o.Version) {
 		errs = append(errs, ValidationError{
 			Field:   "overlay",
-			Message: fmt.Sprintf("unsupported version %q; only %q is supported", o.Version, SupportedVersion),
+			Message: fmt.Sprintf("unsupported version %q; supported versions are %q and %q", o.Version, Version10, Version11),
 		})
 	}
 
@@ -57,17 +71,25 @@ func Validate(o *Overlay) []ValidationError {
 		})
 	}
 
+	// info.description was added in 1.1
+	if o.Info.Description != "" && o.Version == Version10 {
+		errs = append(errs, ValidationError{
+			Field:   "info.description",
+			Message: requires11("info.description"),
+		})
+	}
+
 	// Validate each action
 	for i, action := range o.Actions {
-		actionErrs := validateAction(action, i)
+		actionErrs := validateAction(action, i, o.Version)
 		errs = append(errs, actionErrs...)
 	}
 
 	return errs
 }
 
-// validateAction validates a single action.
-func validateAction(action Action, index int) []ValidationError {
+// validateAction validates a single action against the overlay's version.
+func validateAction(action Action, index int, version string) []ValidationError {
 	var errs []ValidationError
 	pathPrefix := fmt.Sprintf("actions[%d]", index)
 
@@ -87,17 +109,47 @@ func validateAction(action Action, index int) []ValidationError {
 		}
 	}
 
-	// Must have update or remove (or both - remove takes precedence)
-	if action.Update == nil && !action.Remove {
+	// copy was added in 1.1
+	if action.Copy != "" {
+		if version == Version10 {
+			errs = append(errs, ValidationError{
+				Path:    pathPrefix + ".copy",
+				Message: requires11("copy"),
+			})
+		} else if _, err := jsonpath.Parse(action.Copy); err != nil {
+			errs = append(errs, ValidationError{
+				Path:    pathPrefix + ".copy",
+				Message: fmt.Sprintf("invalid JSONPath: %v", err),
+			})
+		}
+		if action.Update != nil {
+			errs = append(errs, ValidationError{
+				Path:    pathPrefix,
+				Message: "update and copy are mutually exclusive",
+			})
+		}
+	}
+
+	// Must have update, copy or remove (remove takes precedence over both)
+	if action.Update == nil && action.Copy == "" && !action.Remove {
+		message := "action must have update, copy or remove"
+		if version == Version10 {
+			message = "action must have update or remove"
+		}
 		errs = append(errs, ValidationError{
 			Path:    pathPrefix,
-			Message: "action must have update or remove",
+			Message: message,
 		})
 	}
 
 	return errs
 }
 
+// requires11 describes a 1.1 feature used in a 1.0.0 document.
+func requires11(feature string) string {
+	return fmt.Sprintf("%s requires overlay version %q; this document declares %q", feature, Version11, Version10)
+}
+
 // IsValid is a convenience function that returns true if the overlay has no validation errors.
 func IsValid(o *Overlay) bool {
 	return len(Validate(o)) == 0
@@ -0,0 +1,223 @@
+package overlay
+
+import (
+	"testing"
+
+	"github.com/erraggy/oastools/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// schemasDoc returns a document with two component schemas for copy tests.
+func schemasDoc() *parser.ParseResult {
+	return &parser.ParseResult{
+		Document: map[string]any{
+			"openapi": "3.1.0",
+			"tags":    []any{map[string]any{"name": "pets"}},
+			"components": map[string]any{
+				"schemas": map[string]any{
+					"Error": map[string]any{
+						"type":     "object",
+						"required": []any{"code"},
+						"properties": map[string]any{
+							"code": map[string]any{"type": "integer"},
+						},
+					},
+					"Problem": map[string]any{
+						"type":     "object",
+						"required": []any{"title"},
+					},
+				},
+			},
+		},
+		SourceFormat: parser.SourceFormatYAML,
+	}
+}
+
+func TestParseOverlay_Version11(t *testing.T) {
+	o, err := ParseOverlay([]byte(`overlay: 1.1.0
+info:
+  title: Shared errors
+  version: 1.0.0
+  description: Reuses the Error schema.
+actions:
+  - target: $.components.schemas.Problem
+    copy: $.components.schemas.Error
+`))
+	require.NoError(t, err)
+
+	assert.Equal(t, Version11, o.Version)
+	assert.Equal(t, "Reuses the Error schema.", o.Info.Description)
+	require.Len(t, o.Actions, 1)
+	assert.Equal(t, "$.components.schemas.Error", o.Actions[0].Copy)
+	assert.Empty(t, Validate(o))
+}
+
+func TestValidate_Version11Features(t *testing.T) {
+	info := Info{Title: "Test", Version: "1.0.0"}
+
+	t.Run("both versions accepted", func(t *testing.T) {
+		for _, version := range SupportedVersions {
+			o := &Overlay{Version: version, Info: info, Actions: []Action{{Target: "$.info", Remove: true}}}
+			assert.Empty(t, Validate(o), version)
+		}
+	})
+
+	t.Run("copy in a 1.0 document", func(t *testing.T) {
+		o := &Overlay{
+			Version: Version10,
+			Info:    info,
+			Actions: []Action{{Target: "$.info", Copy: "$.components"}},
+		}
+		errs := Validate(o)
+		require.Len(t, errs, 1)
+		assert.Equal(t, "actions[0].copy", errs[0].Path)
+		assert.Contains(t, errs[0].Message, `requires overlay version "1.1.0"`)
+	})
+
+	t.Run("info description in a 1.0 document", func(t *testing.T) {
+		o := &Overlay{
+			Version: Version10,
+			Info:    Info{Title: "Test", Version: "1.0.0", Description: "d"},
+			Actions: []Action{{Target: "$.info", Remove: true}},
+		}
+		errs := Validate(o)
+		require.Len(t, errs, 1)
+		assert.Equal(t, "info.description", errs[0].Field)
+	})
+
+	t.Run("update and copy", func(t *testing.T) {
+		o := &Overlay{
+			Version: Version11,
+			Info:    info,
+			Actions: []Action{{Target: "$.info", Copy: "$.components", Update: map[string]any{}}},
+		}
+		errs := Validate(o)
+		require.Len(t, errs, 1)
+		assert.Equal(t, "update and copy are mutually exclusive", errs[0].Message)
+	})
+
+	t.Run("invalid copy JSONPath", func(t *testing.T) {
+		o := &Overlay{
+			Version: Version11,
+			Info:    info,
+			Actions: []Action{{Target: "$.info", Copy: "$.components["}},
+		}
+		errs := Validate(o)
+		require.Len(t, errs, 1)
+		assert.Equal(t, "actions[0].copy", errs[0].Path)
+	})
+}
+
+func TestApply_Copy(t *testing.T) {
+	o := &Overlay{
+		Version: Version11,
+		Info:    Info{Title: "Test", Version: "1.0.0"},
+		Actions: []Action{
+			{Target: "$.components.schemas.Problem", Copy: "$.components.schemas.Error"},
+			{Target: "$.components.schemas.Error.properties", Update: map[string]any{"message": map[string]any{"type": "string"}}},
+		},
+	}
+
+	result, err := NewApplier().ApplyParsed(schemasDoc(), o)
+	require.NoError(t, err)
+	require.Len(t, result.Changes, 2)
+	assert.Equal(t, "copy", result.Changes[0].Operation)
+
+	schemas := result.Document.(map[string]any)["components"].(map[string]any)["schemas"].(map[string]any)
+	problem := schemas["Problem"].(map[string]any)
+	// Arrays are concatenated under 1.1
+	assert.Equal(t, []any{"title", "code"}, problem["required"])
+	assert.Contains(t, problem["properties"], "code")
+	// The copy is independent of its source
+	assert.NotContains(t, problem["properties"], "message")
+	assert.Contains(t, schemas["Error"].(map[string]any)["properties"], "message")
+}
+
+func TestApply_CopySourceMustBeSingle(t *testing.T) {
+	o := &Overlay{
+		Version: Version11,
+		Info:    Info{Title: "Test", Version: "1.0.0"},
+		Actions: []Action{{Target: "$.info", Copy: "$.components.schemas.*"}},
+	}
+
+	result, err := NewApplier().ApplyParsed(schemasDoc(), o)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.ActionsSkipped)
+	warnings := result.StructuredWarnings.ByCategory(WarnActionError)
+	require.Len(t, warnings, 1)
+	assert.ErrorContains(t, warnings[0].Cause, "matched 2 nodes; exactly one is required")
+
+	strict := &Applier{StrictTargets: true}
+	_, err = strict.ApplyParsed(schemasDoc(), o)
+	require.Error(t, err)
+}
+
+func TestApply_ArrayMergeByVersion(t *testing.T) {
+	actions := []Action{
+		{Target: "$.tags", Update: []any{map[string]any{"name": "stores"}}},
+		{Target: "$.components.schemas.Problem", Update: map[string]any{"required": []any{"status"}}},
+	}
+
+	tests := []struct {
+		version  string
+		tags     int
+		required []any
+	}{
+		// 1.0 appends the update array as one element and replaces nested arrays
+		{Version10, 2, []any{"status"}},
+		// 1.1 appends the update array's items and concatenates nested arrays
+		{Version11, 2, []any{"title", "status"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			o := &Overlay{Version: tt.version, Info: Info{Title: "Test", Version: "1.0.0"}, Actions: actions}
+			result, err := NewApplier().ApplyParsed(schemasDoc(), o)
+			require.NoError(t, err)
+
+			doc := result.Document.(map[string]any)
+			tags := doc["tags"].([]any)
+			require.Len(t, tags, tt.tags)
+			if tt.version == Version10 {
+				assert.IsType(t, []any{}, tags[1])
+			} else {
+				assert.Equal(t, map[string]any{"name": "stores"}, tags[1])
+			}
+			problem := doc["components"].(map[string]any)["schemas"].(map[string]any)["Problem"].(map[string]any)
+			assert.Equal(t, tt.required, problem["required"])
+		})
+	}
+}
+
+func TestDryRun_Copy(t *testing.T) {
+	o := &Overlay{
+		Version: Version11,
+		Info:    Info{Title: "Test", Version: "1.0.0"},
+		Actions: []Action{
+			{Target: "$.components.schemas.Problem", Copy: "$.components.schemas.Error"},
+			{Target: "$.info", Copy: "$.components.schemas.Missing"},
+		},
+	}
+
+	result, err := NewApplier().DryRun(schemasDoc(), o)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.WouldApply)
+	assert.Equal(t, 1, result.WouldSkip)
+	require.Len(t, result.Changes, 1)
+	assert.Equal(t, "copy", result.Changes[0].Operation)
+}
+
+func TestApply_Version10RejectsCopy(t *testing.T) {
+	o := &Overlay{
+		Version: Version10,
+		Info:    Info{Title: "Test", Version: "1.0.0"},
+		Actions: []Action{{Target: "$.components.schemas.Problem", Copy: "$.components.schemas.Error"}},
+	}
+
+	_, err := NewApplier().ApplyParsed(schemasDoc(), o)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "copy requires overlay version")
+
+	_, err = NewApplier().DryRun(schemasDoc(), o)
+	require.Error(t, err)
+}
@@ -185,6 +185,22 @@
 //		// Order information is available
 //	}
 //
+// # Format-Preserving YAML
+//
+// Re-marshaling a document loses the comments, anchors, quoting and blank
+// lines of its YAML source. To write an edited document as a minimal diff,
+// parse with WithPreserveFormatting and marshal with MarshalPreservedYAML,
+// which patches the source text only where the document changed:
+//
+//	result, _ := parser.ParseWithOptions(
+//		parser.WithFilePath("openapi.yaml"),
+//		parser.WithPreserveFormatting(true),
+//	)
+//	// ... edit result.Document, or fix, convert or overlay it ...
+//	yamlBytes, _ := result.MarshalPreservedYAML(result.Document)
+//
+// PatchYAML does the same for generic documents given the source text.
+//
 // # Source Naming for Pre-Parsed Documents
 //
 // When parsing from bytes or io.Reader (common when fetching specs from HTTP
@@ -68,6 +68,11 @@ type Parser struct {
 	// This is useful for hash-based caching where roundtrip identity matters.
 	// Default: false
 	PreserveOrder bool
+	// PreserveFormatting keeps the source text of YAML documents, allowing
+	// MarshalPreservedYAML to write an edited document as minimal changes to
+	// that text, keeping its comments, anchors, quoting and blank lines.
+	// Default: false
+	PreserveFormatting bool
 }
 
 // New creates a new Parser instance with default settings
@@ -153,6 +158,13 @@ type ParseResult struct {
 	// Only populated when Parser.PreserveOrder is true.
 	// Use MarshalOrderedJSON/MarshalOrderedYAML to marshal with preserved order.
 	sourceNode *yaml.Node
+	// sourceText holds the YAML source for format-preserving marshaling.
+	// Only populated when Parser.PreserveFormatting is true and the source is YAML.
+	// Use MarshalPreservedYAML to marshal by patching it.
+	sourceText []byte
+	// sourceBase holds the JSON form of the document decoded from sourceText,
+	// so MarshalPreservedYAML can tell edits from decoding normalizations.
+	sourceBase []byte
 }
 
 // OAS2Document returns the parsed document as an OAS2Document if the specification
@@ -231,7 +243,9 @@ func (pr *ParseResult) Copy() *ParseResult {
 		OASVersion:   pr.OASVersion,
 		LoadTime:     pr.LoadTime,
 		SourceSize:   pr.SourceSize,
-		Stats:        pr.Stats, // DocumentStats is a value type, copied by value
+		Stats:        pr.Stats,      // DocumentStats is a value type, copied by value
+		sourceText:   pr.sourceText, // never modified, so it can be shared
+		sourceBase:   pr.sourceBase,
 	}
 
 	// Deep copy the Document using generated DeepCopy methods
@@ -503,6 +517,13 @@ func (p *Parser) parseBytesWithBaseDirAndURL(data []byte, baseDir, baseURL strin
 	result.Document = doc
 	result.OASVersion = oasVersion
 
+	// Keep the YAML text, and what it decoded to, for format-preserving marshaling
+	if p.PreserveFormatting && format == SourceFormatYAML {
+		if base, err := json.Marshal(doc); err == nil {
+			result.sourceText, result.sourceBase = data, base
+		}
+	}
+
 	// Validate structure if enabled
 	if p.ValidateStructure {
 		validationErrors := p.validateStructure(result)
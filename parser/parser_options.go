@@ -41,6 +41,9 @@ type parseConfig struct {
 	// Order preservation
 	preserveOrder bool
 
+	// Formatting preservation
+	preserveFormatting bool
+
 	// Source identification
 	sourceName *string // Override SourcePath in the result
 }
@@ -75,6 +78,7 @@ func ParseWithOptions(opts ...Option) (*ParseResult, error) {
 		MaxInputSize:       cfg.maxInputSize,
 		BuildSourceMap:     cfg.buildSourceMap,
 		PreserveOrder:      cfg.preserveOrder,
+		PreserveFormatting: cfg.preserveFormatting,
 	}
 
 	// Route to appropriate parsing method based on input source
@@ -355,6 +359,29 @@ func WithPreserveOrder(enabled bool) Option {
 	}
 }
 
+// WithPreserveFormatting enables format-preserving marshaling.
+// When enabled, ParseResult keeps the source text of YAML documents,
+// allowing MarshalPreservedYAML to write an edited document as minimal
+// changes to that text. Comments, anchors, quoting styles and blank lines
+// survive wherever their content is unchanged.
+//
+// Default: false
+//
+// Example:
+//
+//	result, err := parser.ParseWithOptions(
+//	    parser.WithFilePath("api.yaml"),
+//	    parser.WithPreserveFormatting(true),
+//	)
+//	fixed, _ := fixer.FixWithOptions(fixer.WithParsed(*result))
+//	data, _ := result.MarshalPreservedYAML(fixed.Document)
+func WithPreserveFormatting(enabled bool) Option {
+	return func(cfg *parseConfig) error {
+		cfg.preserveFormatting = enabled
+		return nil
+	}
+}
+
 // WithSourceName specifies a meaningful name for the source document.
 // This is particularly useful when parsing from bytes or reader, where
 // the default names ("ParseBytes.yaml", "ParseReader.yaml") are not descriptive.
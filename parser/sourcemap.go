@@ -77,6 +77,18 @@ func NewSourceMap() *SourceMap {
 	}
 }
 
+// NewSourceMapFromBytes builds the SourceMap of a YAML or JSON document
+// without parsing it as an OpenAPI document, so it also works for files that
+// hold only the fragments other documents reference. file is recorded as the
+// File of every location. References are recorded but not resolved.
+func NewSourceMapFromBytes(data []byte, file string) (*SourceMap, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("parser: building source map: %w", err)
+	}
+	return buildSourceMap(&root, file), nil
+}
+
 // Get returns the source location for a JSON path.
 // Returns a zero SourceLocation if the path is not found.
 func (sm *SourceMap) Get(path string) SourceLocation {
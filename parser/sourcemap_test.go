@@ -228,6 +228,24 @@ func TestSourceMap_RefTracking(t *testing.T) {
 	assert.Equal(t, 10, got.Origin.Line)
 }
 
+func TestNewSourceMapFromBytes(t *testing.T) {
+	// A fragment file, which is not an OpenAPI document
+	data := []byte(`Pet:
+  type: object
+  properties:
+    owner:
+      $ref: '#/Owner'
+`)
+	sm, err := NewSourceMapFromBytes(data, "schemas.yaml")
+	require.NoError(t, err)
+
+	assert.Equal(t, SourceLocation{Line: 2, Column: 9, File: "schemas.yaml"}, sm.Get("$.Pet.type"))
+	assert.Equal(t, "#/Owner", sm.GetRef("$.Pet.properties.owner").TargetRef)
+
+	_, err = NewSourceMapFromBytes([]byte("a: [b"), "bad.yaml")
+	assert.Error(t, err)
+}
+
 func TestBuildChildPath(t *testing.T) {
 	tests := []struct {
 		parent string
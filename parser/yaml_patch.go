@@ -0,0 +1,1089 @@
+// yaml_patch.go edits YAML source text in place so that it holds a new
+// document. Instead of re-marshaling, the node tree of the source is compared
+// with the new document and only the differences are written back as text
+// edits: a changed scalar is replaced where it stands, a removed key takes its
+// lines with it, and a new key is inserted next to its neighbours. Everything
+// the edits do not touch (comments, anchors, quoting, blank lines and
+// indentation) is left byte for byte as it was.
+
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// maxAlignCells bounds the size of the table used to align the elements of two
+// sequences. Longer sequences are aligned by position.
+const maxAlignCells = 1 << 20
+
+// errUnpatchable reports that a node cannot be edited in place, so the edit
+// must be made by replacing the node that holds it.
+var errUnpatchable = errors.New("node cannot be patched in place")
+
+// PatchYAML returns the YAML document source edited so that it holds doc,
+// changing only the text that differs. Comments, anchors, quoting styles,
+// blank lines and key order survive wherever the content they belong to is
+// unchanged, so a one-value change produces a one-line diff.
+//
+// doc is anything that marshals to JSON, such as the generic form of a
+// document. New content is written in the indentation and quoting style
+// detected from source. When the source cannot be patched, such as a
+// flow-style document whose edits would need restructuring, the whole
+// document is re-rendered instead, so the result always decodes to doc.
+//
+// To write a typed document, parse with WithPreserveFormatting and use
+// ParseResult.MarshalPreservedYAML, which also ignores the differences the
+// typed model introduces on its own, such as dropped default values.
+func PatchYAML(source []byte, doc any) ([]byte, error) {
+	out, err := patchYAML(source, nil, doc, nil)
+	if err != nil {
+		return nil, fmt.Errorf("parser: patching YAML: %w", err)
+	}
+	return out, nil
+}
+
+// MarshalPreservedYAML marshals doc to YAML by patching the source text of
+// this result, so the output differs from the source only where doc differs
+// from the document parsed from it. doc is typically this result's Document
+// after editing, or the document a fixer, converter, joiner or overlay
+// produced from this result.
+//
+// Only real edits are written: a value the typed model normalizes, such as a
+// default it omits, keeps its source text. The output is parsed again to
+// check that it holds doc; if it does not, the document is re-rendered in
+// full.
+//
+// This method requires PreserveFormatting to be enabled during parsing and
+// the source to be YAML. Otherwise it falls back to standard YAML marshaling.
+//
+// Example:
+//
+//	result, _ := parser.ParseWithOptions(
+//		parser.WithFilePath("api.yaml"),
+//		parser.WithPreserveFormatting(true),
+//	)
+//	if doc, ok := result.OAS3Document(); ok {
+//		doc.Info.Version = "2.0.0"
+//	}
+//	data, _ := result.MarshalPreservedYAML(result.Document) // a one-line diff
+func (pr *ParseResult) MarshalPreservedYAML(doc any) ([]byte, error) {
+	if pr.sourceText == nil {
+		return yaml.Marshal(doc)
+	}
+	out, err := patchYAML(pr.sourceText, pr.sourceBase, doc, func(out []byte) (*yaml.Node, error) {
+		p := New()
+		p.ValidateStructure = false
+		result, err := p.ParseBytes(out)
+		if err != nil {
+			return nil, err
+		}
+		return documentToNode(result.Document)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("parser: patching YAML: %w", err)
+	}
+	return out, nil
+}
+
+// HasPreservedFormatting returns true if this ParseResult kept the source
+// text needed by MarshalPreservedYAML. This is true when PreserveFormatting
+// was enabled during parsing and the source was YAML.
+func (pr *ParseResult) HasPreservedFormatting() bool {
+	return pr.sourceText != nil
+}
+
+// patchYAML patches source to hold doc. When base, the JSON form of the
+// document source was decoded to, is given, only the differences between
+// base and doc are edits; other differences between source and doc are
+// left alone. decode reads the patched output back for the check that it
+// holds doc, and defaults to decoding it as generic YAML. A custom decode
+// also reads doc itself, so a generic doc is compared in the form the typed
+// model gives it.
+func patchYAML(source, base []byte, doc any, decode func([]byte) (*yaml.Node, error)) ([]byte, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(source, &root); err != nil {
+		return nil, err
+	}
+	target, err := documentToNode(doc)
+	if err != nil {
+		return nil, err
+	}
+	var baseNode *yaml.Node
+	if base != nil {
+		if baseNode, err = documentToNode(json.RawMessage(base)); err != nil {
+			return nil, err
+		}
+	}
+	want := target
+	if decode == nil {
+		decode = func(out []byte) (*yaml.Node, error) {
+			var check yaml.Node
+			if err := yaml.Unmarshal(out, &check); err != nil || len(check.Content) == 0 {
+				return nil, errUnpatchable
+			}
+			return check.Content[0], nil
+		}
+	} else if data, err := json.Marshal(doc); err == nil {
+		if node, err := decode(data); err == nil {
+			want = node
+		}
+	}
+
+	p := newYAMLPatcher(source, &root)
+	if p.root != nil {
+		// Aliases are kept at first; if an edit to an anchored node changed what
+		// an unchanged alias stands for, retry with every alias expanded.
+		for _, expandAliases := range []bool{false, true} {
+			out, ok := p.patch(baseNode, target, expandAliases)
+			if !ok {
+				continue
+			}
+			if check, err := decode(out); err == nil && p.canonical(check) == p.canonical(want) {
+				return out, nil
+			}
+		}
+	}
+
+	out, err := p.dump(target)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(out + "\n"), nil
+}
+
+// documentToNode converts doc to a node tree through its JSON form, which
+// orders the members of typed documents as the OpenAPI specification lists
+// them. The styles JSON imposes are cleared so the tree renders as block YAML.
+func documentToNode(doc any) (*yaml.Node, error) {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+	if len(root.Content) == 0 {
+		return scalarNode("!!null", "null"), nil
+	}
+	node := root.Content[0]
+	clearNodeStyle(node)
+	return node, nil
+}
+
+// clearNodeStyle resets the style of node and its descendants.
+func clearNodeStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		clearNodeStyle(child)
+	}
+}
+
+// textEdit replaces src[start:end] with text. An insertion has start == end.
+type textEdit struct {
+	start, end int
+	text       string
+}
+
+// nodeContext describes where a node sits in its parent.
+type nodeContext struct {
+	// indent is the indentation of the mapping key or sequence dash that holds
+	// the node, or -1 for the root.
+	indent int
+	// key is the key node when the node is a mapping value.
+	key *yaml.Node
+}
+
+// yamlPatcher computes the text edits that turn a source document into a
+// target node tree.
+//
+// Each source node is patched against the matching node of the base the
+// source was decoded to, when one is known, and of the target: only where
+// base and target differ is there an edit to make. Without a base, the
+// source node itself is compared with the target.
+type yamlPatcher struct {
+	src        []byte
+	root       *yaml.Node // content of the source document; nil when empty
+	lineStarts []int
+
+	// Rendering style detected from the source
+	indent     int
+	compactSeq bool
+	quote      yaml.QuoteStyle
+	crlf       bool
+
+	expandAliases bool
+	canon         map[*yaml.Node]string
+	edits         []textEdit
+}
+
+func newYAMLPatcher(src []byte, doc *yaml.Node) *yamlPatcher {
+	p := &yamlPatcher{
+		src:        src,
+		lineStarts: []int{0},
+		indent:     2,
+		quote:      yaml.QuoteSingle,
+		crlf:       bytes.Contains(src, []byte("\r\n")),
+		canon:      make(map[*yaml.Node]string),
+	}
+	for i, b := range src {
+		if b == '\n' {
+			p.lineStarts = append(p.lineStarts, i+1)
+		}
+	}
+	if doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 {
+		p.root = doc.Content[0]
+		p.detectStyle()
+	}
+	return p
+}
+
+// detectStyle sets the indentation width, sequence indentation and preferred
+// quotes from the first examples of each in the source.
+func (p *yamlPatcher) detectStyle() {
+	var foundIndent, foundSeq bool
+	var single, double int
+	var walk func(node *yaml.Node)
+	walk = func(node *yaml.Node) {
+		switch node.Style {
+		case yaml.SingleQuotedStyle:
+			single++
+		case yaml.DoubleQuotedStyle:
+			double++
+		}
+		if node.Kind == yaml.MappingNode && node.Style&yaml.FlowStyle == 0 {
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				key, val := node.Content[i], node.Content[i+1]
+				if val.Line <= key.Line || val.Style&yaml.FlowStyle != 0 {
+					continue
+				}
+				switch {
+				case val.Kind == yaml.MappingNode && !foundIndent && val.Column > key.Column:
+					p.indent, foundIndent = val.Column-key.Column, true
+				case val.Kind == yaml.SequenceNode && !foundSeq:
+					p.compactSeq, foundSeq = val.Column == key.Column, true
+				}
+			}
+		}
+		for _, child := range node.Content {
+			walk(child)
+		}
+	}
+	walk(p.root)
+	if double > single {
+		p.quote = yaml.QuoteDouble
+	}
+}
+
+// patch computes the edits that turn the source into target and applies
+// them. It reports false when the root cannot be patched.
+func (p *yamlPatcher) patch(base, target *yaml.Node, expandAliases bool) ([]byte, bool) {
+	p.expandAliases = expandAliases
+	p.edits = p.edits[:0]
+	if err := p.patchNode(p.root, base, target, nodeContext{indent: -1}); err != nil {
+		return nil, false
+	}
+	out, err := p.apply()
+	if err != nil {
+		return nil, false
+	}
+	return out, true
+}
+
+// apply returns the source with the edits applied.
+func (p *yamlPatcher) apply() ([]byte, error) {
+	edits := slices.Clone(p.edits)
+	// Stable, so insertions at the same offset keep the order they were made in
+	slices.SortStableFunc(edits, func(a, b textEdit) int {
+		if a.start != b.start {
+			return a.start - b.start
+		}
+		return a.end - b.end
+	})
+
+	var out bytes.Buffer
+	out.Grow(len(p.src))
+	pos := 0
+	for _, e := range edits {
+		if e.start < pos {
+			return nil, fmt.Errorf("overlapping edits at offset %d", e.start)
+		}
+		out.Write(p.src[pos:e.start])
+		text := e.text
+		if p.crlf {
+			text = strings.ReplaceAll(text, "\n", "\r\n")
+		}
+		out.WriteString(text)
+		pos = e.end
+	}
+	out.Write(p.src[pos:])
+	return out.Bytes(), nil
+}
+
+func (p *yamlPatcher) edit(start, end int, text string) {
+	p.edits = append(p.edits, textEdit{start: start, end: end, text: text})
+}
+
+// patchNode records the edits that turn old into new, where base, if not
+// nil, is what old was decoded to. Collections are patched member by member;
+// when that is not possible the whole node is replaced.
+func (p *yamlPatcher) patchNode(old, base, new *yaml.Node, ctx nodeContext) error {
+	unchanged := base
+	if unchanged == nil {
+		unchanged = old
+	}
+	if p.canonical(unchanged) == p.canonical(new) && !(p.expandAliases && hasAlias(old)) {
+		return nil
+	}
+
+	mark := len(p.edits)
+	var err error
+	switch {
+	case old.Kind == yaml.MappingNode && new.Kind == yaml.MappingNode && isBlockCollection(old) && len(new.Content) > 0:
+		err = p.patchMapping(old, base, new)
+	case old.Kind == yaml.SequenceNode && new.Kind == yaml.SequenceNode && isBlockCollection(old) && len(new.Content) > 0:
+		err = p.patchSequence(old, base, new)
+	case old.Kind == yaml.ScalarNode && new.Kind == yaml.ScalarNode && !isImplicitNull(old):
+		err = p.replaceScalar(old, new, ctx)
+	default:
+		err = errUnpatchable
+	}
+	if err == nil {
+		return nil
+	}
+	p.edits = p.edits[:mark]
+	return p.replace(old, new, ctx)
+}
+
+// patchMapping patches the values of the keys both mappings share, removes
+// the entries new lacks and inserts new's other entries after the entry that
+// precedes them in new. With a base, an entry base lacks was dropped in
+// decoding rather than removed, and an entry base already had in new's form
+// was added in decoding rather than inserted, so neither is an edit.
+func (p *yamlPatcher) patchMapping(old, base, new *yaml.Node) error {
+	oldIndex := make(map[string]int, len(old.Content)/2)
+	for i := 0; i+1 < len(old.Content); i += 2 {
+		if old.Content[i].Kind != yaml.ScalarNode {
+			return errUnpatchable
+		}
+		oldIndex[old.Content[i].Value] = i
+	}
+	newIndex := mappingIndex(new)
+	var baseIndex map[string]int
+	if base != nil {
+		if base.Kind != yaml.MappingNode {
+			base = nil
+		} else {
+			baseIndex = mappingIndex(base)
+		}
+	}
+	keyIndent := old.Content[0].Column - 1
+
+	for i := 0; i+1 < len(old.Content); i += 2 {
+		key, val := old.Content[i], old.Content[i+1]
+		var baseVal *yaml.Node
+		if k, ok := baseIndex[key.Value]; ok {
+			baseVal = base.Content[k+1]
+		}
+		j, ok := newIndex[key.Value]
+		if !ok {
+			if base != nil && baseVal == nil {
+				continue
+			}
+			if err := p.deleteEntry(key, val); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := p.patchNode(val, baseVal, new.Content[j+1], nodeContext{indent: keyIndent, key: key}); err != nil {
+			return err
+		}
+	}
+
+	// Group new entries by the shared entry they follow; -1 is the start
+	pending := make(map[int][]*yaml.Node)
+	var order []int
+	after := -1
+	for j := 0; j+1 < len(new.Content); j += 2 {
+		key, val := new.Content[j], new.Content[j+1]
+		if i, ok := oldIndex[key.Value]; ok {
+			after = i
+			continue
+		}
+		if k, ok := baseIndex[key.Value]; ok && p.canonical(base.Content[k+1]) == p.canonical(val) {
+			continue
+		}
+		if _, ok := pending[after]; !ok {
+			order = append(order, after)
+		}
+		pending[after] = append(pending[after], key, val)
+	}
+	for _, after := range order {
+		var offset int
+		if after < 0 {
+			start, err := p.offsetOf(old.Content[0])
+			if err != nil {
+				return err
+			}
+			if offset, err = p.lineStartBefore(start); err != nil {
+				return err
+			}
+			offset = p.withCommentsAbove(offset, keyIndent)
+		} else {
+			end, err := p.entryEnd(old.Content[after], old.Content[after+1])
+			if err != nil {
+				return err
+			}
+			offset = p.nextLineStart(end)
+		}
+		text, err := p.dump(&yaml.Node{Kind: yaml.MappingNode, Content: pending[after]})
+		if err != nil {
+			return err
+		}
+		p.insertLines(offset, text, keyIndent)
+	}
+	return nil
+}
+
+// patchSequence aligns the elements of both sequences, keeping equal ones in
+// place, patching the ones that changed and removing or inserting the rest.
+// A base is only used when it has an element for every element of old.
+func (p *yamlPatcher) patchSequence(old, base, new *yaml.Node) error {
+	dashIndent := -1
+	for _, item := range old.Content {
+		dash, err := p.dashOffset(item)
+		if err != nil {
+			return err
+		}
+		if dashIndent < 0 {
+			dashIndent = dash - p.lineStarts[p.lineIndex(dash)]
+		}
+	}
+
+	ref := old.Content
+	if base != nil && base.Kind == yaml.SequenceNode && len(base.Content) == len(old.Content) {
+		ref = base.Content
+	} else {
+		base = nil
+	}
+	baseItem := func(i int) *yaml.Node {
+		if base == nil {
+			return nil
+		}
+		return base.Content[i]
+	}
+
+	prev := -1 // last old element kept in place
+	i, j := 0, 0
+	for _, m := range append(p.alignSequences(ref, new.Content), [2]int{len(old.Content), len(new.Content)}) {
+		for ; i < m[0] && j < m[1]; i, j = i+1, j+1 {
+			if err := p.patchNode(old.Content[i], baseItem(i), new.Content[j], nodeContext{indent: dashIndent}); err != nil {
+				return err
+			}
+			prev = i
+		}
+		for ; i < m[0]; i++ {
+			if err := p.deleteItem(old.Content[i]); err != nil {
+				return err
+			}
+		}
+		if j < m[1] {
+			if err := p.insertItems(old, prev, new.Content[j:m[1]], dashIndent); err != nil {
+				return err
+			}
+			j = m[1]
+		}
+		if m[0] < len(old.Content) {
+			if err := p.patchNode(old.Content[m[0]], baseItem(m[0]), new.Content[m[1]], nodeContext{indent: dashIndent}); err != nil {
+				return err
+			}
+			prev = m[0]
+		}
+		i, j = m[0]+1, m[1]+1
+	}
+	return nil
+}
+
+// alignSequences returns the index pairs of a longest run of equal elements
+// common to both sequences, in order. Sequences too long to align are
+// compared by position.
+func (p *yamlPatcher) alignSequences(old, new []*yaml.Node) [][2]int {
+	n, m := len(old), len(new)
+	if n*m > maxAlignCells {
+		var pairs [][2]int
+		for i := range min(n, m) {
+			if p.canonical(old[i]) == p.canonical(new[i]) {
+				pairs = append(pairs, [2]int{i, i})
+			}
+		}
+		return pairs
+	}
+
+	// lcs[i][j] is the length of the longest common run of old[i:] and new[j:]
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if p.canonical(old[i]) == p.canonical(new[j]) {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	var pairs [][2]int
+	for i, j := 0, 0; i < n && j < m; {
+		switch {
+		case p.canonical(old[i]) == p.canonical(new[j]):
+			pairs = append(pairs, [2]int{i, j})
+			i, j = i+1, j+1
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return pairs
+}
+
+// mappingIndex returns the index of each key of a mapping node.
+func mappingIndex(node *yaml.Node) map[string]int {
+	index := make(map[string]int, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		index[node.Content[i].Value] = i
+	}
+	return index
+}
+
+// replaceScalar replaces the text of a scalar, keeping its anchor, tag and,
+// when both are strings, its quoting style.
+func (p *yamlPatcher) replaceScalar(old, new *yaml.Node, ctx nodeContext) error {
+	start, err := p.valueStart(old)
+	if err != nil {
+		return err
+	}
+	end, err := p.scalarEnd(old, start)
+	if err != nil {
+		return err
+	}
+	if old.Tag == "!!str" && new.Tag == "!!str" {
+		new.Style = old.Style & (yaml.DoubleQuotedStyle | yaml.SingleQuotedStyle | yaml.LiteralStyle | yaml.FoldedStyle)
+	}
+	text, err := p.dump(new)
+	if err != nil {
+		return err
+	}
+	p.edit(start, end, indentFollowingLines(text, max(ctx.indent, 0)))
+	return nil
+}
+
+// replace replaces the whole text of old with a rendering of new. A mapping
+// value is rewritten from the colon after its key, so it can change between
+// an inline scalar and a block collection.
+func (p *yamlPatcher) replace(old, new *yaml.Node, ctx nodeContext) error {
+	if isFlowCollection(old) && (new.Kind == yaml.MappingNode || new.Kind == yaml.SequenceNode) {
+		new.Style = yaml.FlowStyle
+	}
+	text, err := p.dump(new)
+	if err != nil {
+		return err
+	}
+	end, err := p.nodeEnd(old)
+	if err != nil {
+		return err
+	}
+
+	if ctx.key == nil {
+		if isImplicitNull(old) {
+			return errUnpatchable
+		}
+		start, err := p.offsetOf(old)
+		if err != nil {
+			return err
+		}
+		column := start - p.lineStarts[p.lineIndex(start)]
+		p.edit(start, end, indentFollowingLines(text, column))
+		return nil
+	}
+
+	start, err := p.afterKey(ctx.key)
+	if err != nil {
+		return err
+	}
+	end = max(end, start)
+	if !isBlockCollection(new) {
+		p.edit(start, end, " "+indentFollowingLines(text, ctx.indent+p.indent))
+		return nil
+	}
+
+	indent := ctx.indent + p.indent
+	switch {
+	case isBlockCollection(old):
+		indent = old.Column - 1
+	case new.Kind == yaml.SequenceNode && p.compactSeq:
+		indent = ctx.indent
+	}
+	p.edit(start, end, "\n"+indentLines(text, indent))
+	return nil
+}
+
+// deleteEntry removes the lines of a mapping entry, with the comment lines
+// directly above its key.
+func (p *yamlPatcher) deleteEntry(key, val *yaml.Node) error {
+	keyStart, err := p.offsetOf(key)
+	if err != nil {
+		return err
+	}
+	start, err := p.lineStartBefore(keyStart)
+	if err != nil {
+		return err
+	}
+	end, err := p.entryEnd(key, val)
+	if err != nil {
+		return err
+	}
+	p.edit(p.withCommentsAbove(start, keyStart-start), p.nextLineStart(end), "")
+	return nil
+}
+
+// deleteItem removes the lines of a sequence element, with the comment lines
+// directly above its dash.
+func (p *yamlPatcher) deleteItem(item *yaml.Node) error {
+	dash, err := p.dashOffset(item)
+	if err != nil {
+		return err
+	}
+	start, err := p.lineStartBefore(dash)
+	if err != nil {
+		return err
+	}
+	end, err := p.nodeEnd(item)
+	if err != nil {
+		return err
+	}
+	p.edit(p.withCommentsAbove(start, dash-start), p.nextLineStart(max(end, dash+1)), "")
+	return nil
+}
+
+// insertItems inserts items into seq after its element at index prev, or
+// before its first element when prev is -1.
+func (p *yamlPatcher) insertItems(seq *yaml.Node, prev int, items []*yaml.Node, dashIndent int) error {
+	var offset int
+	if prev < 0 {
+		dash, err := p.dashOffset(seq.Content[0])
+		if err != nil {
+			return err
+		}
+		if offset, err = p.lineStartBefore(dash); err != nil {
+			return err
+		}
+		offset = p.withCommentsAbove(offset, dashIndent)
+	} else {
+		end, err := p.nodeEnd(seq.Content[prev])
+		if err != nil {
+			return err
+		}
+		offset = p.nextLineStart(end)
+	}
+	text, err := p.dump(&yaml.Node{Kind: yaml.SequenceNode, Content: items})
+	if err != nil {
+		return err
+	}
+	p.insertLines(offset, text, dashIndent)
+	return nil
+}
+
+// insertLines inserts text as whole lines at offset, indented by indent.
+func (p *yamlPatcher) insertLines(offset int, text string, indent int) {
+	text = indentLines(text, indent) + "\n"
+	if offset == len(p.src) && offset > 0 && p.src[offset-1] != '\n' {
+		text = "\n" + text
+	}
+	p.edit(offset, offset, text)
+}
+
+// dump renders node in the style detected from the source, without a
+// trailing newline.
+func (p *yamlPatcher) dump(node *yaml.Node) (string, error) {
+	out, err := yaml.Dump(node,
+		yaml.WithIndent(p.indent),
+		yaml.WithCompactSeqIndent(p.compactSeq),
+		yaml.WithLineWidth(-1),
+		yaml.WithQuotePreference(p.quote),
+	)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(string(out), "\n"), nil
+}
+
+// canonical returns a string that is equal for nodes that decode to equal
+// values, whatever their style.
+func (p *yamlPatcher) canonical(node *yaml.Node) string {
+	if s, ok := p.canon[node]; ok {
+		return s
+	}
+	var s string
+	var v any
+	if err := node.Decode(&v); err == nil {
+		if data, err := json.Marshal(v); err == nil {
+			s = string(data)
+		}
+	}
+	if s == "" {
+		// Undecodable nodes are equal only to themselves
+		s = fmt.Sprintf("\x00%p", node)
+	}
+	p.canon[node] = s
+	return s
+}
+
+// offsetOf returns the byte offset of node's position.
+func (p *yamlPatcher) offsetOf(node *yaml.Node) (int, error) {
+	if node.Line < 1 || node.Line > len(p.lineStarts) {
+		return 0, errUnpatchable
+	}
+	offset := p.lineStarts[node.Line-1]
+	for range node.Column - 1 {
+		if offset >= len(p.src) || p.src[offset] == '\n' {
+			return 0, errUnpatchable
+		}
+		_, size := utf8.DecodeRune(p.src[offset:])
+		offset += size
+	}
+	return offset, nil
+}
+
+// lineIndex returns the zero-based line holding offset.
+func (p *yamlPatcher) lineIndex(offset int) int {
+	i, found := slices.BinarySearch(p.lineStarts, offset)
+	if !found {
+		i--
+	}
+	return i
+}
+
+// lineStartBefore returns the start of the line holding offset, which must
+// be preceded on its line by nothing but indentation.
+func (p *yamlPatcher) lineStartBefore(offset int) (int, error) {
+	start := p.lineStarts[p.lineIndex(offset)]
+	for _, b := range p.src[start:offset] {
+		if b != ' ' {
+			return 0, errUnpatchable
+		}
+	}
+	return start, nil
+}
+
+// nextLineStart returns the start of the line after the one holding offset,
+// or the end of the source.
+func (p *yamlPatcher) nextLineStart(offset int) int {
+	if i := bytes.IndexByte(p.src[offset:], '\n'); i >= 0 {
+		return offset + i + 1
+	}
+	return len(p.src)
+}
+
+// withCommentsAbove extends a line start upward over the comment lines
+// directly above it that share its indentation.
+func (p *yamlPatcher) withCommentsAbove(start, indent int) int {
+	for line := p.lineIndex(start) - 1; line >= 0; line-- {
+		text := p.src[p.lineStarts[line]:p.lineStarts[line+1]]
+		trimmed := bytes.TrimLeft(text, " ")
+		if len(text)-len(trimmed) != indent || !bytes.HasPrefix(trimmed, []byte("#")) {
+			break
+		}
+		start = p.lineStarts[line]
+	}
+	return start
+}
+
+// dashOffset returns the offset of the dash that introduces a block sequence
+// element.
+func (p *yamlPatcher) dashOffset(item *yaml.Node) (int, error) {
+	start, err := p.offsetOf(item)
+	if err != nil {
+		return 0, err
+	}
+	if isImplicitNull(item) {
+		// An empty element is positioned after its dash
+		start--
+	}
+	i := start - 1
+	for i >= 0 && p.src[i] == ' ' {
+		i--
+	}
+	if i < 0 || p.src[i] != '-' {
+		return 0, errUnpatchable
+	}
+	return i, nil
+}
+
+// valueStart returns the offset of a node's value, after its anchor and tag.
+func (p *yamlPatcher) valueStart(node *yaml.Node) (int, error) {
+	offset, err := p.offsetOf(node)
+	if err != nil {
+		return 0, err
+	}
+	for offset < len(p.src) && (p.src[offset] == '&' || p.src[offset] == '!') {
+		for offset < len(p.src) && !isYAMLSpace(p.src[offset]) {
+			offset++
+		}
+		for offset < len(p.src) && p.src[offset] == ' ' {
+			offset++
+		}
+	}
+	if offset >= len(p.src) || p.src[offset] == '\n' || p.src[offset] == '\r' {
+		return 0, errUnpatchable
+	}
+	return offset, nil
+}
+
+// afterKey returns the offset just past the colon that follows a key.
+func (p *yamlPatcher) afterKey(key *yaml.Node) (int, error) {
+	start, err := p.valueStart(key)
+	if err != nil {
+		return 0, err
+	}
+	var end int
+	if key.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0 {
+		if end, err = p.scalarEnd(key, start); err != nil {
+			return 0, err
+		}
+	} else {
+		end = start + len(key.Value)
+		if end > len(p.src) || string(p.src[start:end]) != key.Value {
+			return 0, errUnpatchable
+		}
+	}
+	for end < len(p.src) && p.src[end] == ' ' {
+		end++
+	}
+	if end >= len(p.src) || p.src[end] != ':' {
+		return 0, errUnpatchable
+	}
+	return end + 1, nil
+}
+
+// entryEnd returns the offset where a mapping entry's text ends.
+func (p *yamlPatcher) entryEnd(key, val *yaml.Node) (int, error) {
+	colon, err := p.afterKey(key)
+	if err != nil {
+		return 0, err
+	}
+	end, err := p.nodeEnd(val)
+	if err != nil {
+		return 0, err
+	}
+	return max(colon, end), nil
+}
+
+// nodeEnd returns the offset where a node's text ends, or -1 for an empty
+// value, which has no text.
+func (p *yamlPatcher) nodeEnd(node *yaml.Node) (int, error) {
+	switch {
+	case isImplicitNull(node):
+		return -1, nil
+	case node.Kind == yaml.AliasNode:
+		start, err := p.offsetOf(node)
+		if err != nil {
+			return 0, err
+		}
+		return start + 1 + len(node.Value), nil
+	case node.Kind == yaml.ScalarNode:
+		start, err := p.valueStart(node)
+		if err != nil {
+			return 0, err
+		}
+		return p.scalarEnd(node, start)
+	case isFlowCollection(node):
+		start, err := p.valueStart(node)
+		if err != nil {
+			return 0, err
+		}
+		return p.flowEnd(start)
+	case len(node.Content) > 0:
+		if node.Kind == yaml.MappingNode {
+			return p.entryEnd(node.Content[len(node.Content)-2], node.Content[len(node.Content)-1])
+		}
+		return p.nodeEnd(node.Content[len(node.Content)-1])
+	}
+	return 0, errUnpatchable
+}
+
+// scalarEnd returns the offset where the text of a scalar starting at start
+// ends, excluding any comment after it.
+func (p *yamlPatcher) scalarEnd(node *yaml.Node, start int) (int, error) {
+	src := p.src
+	switch node.Style {
+	case yaml.DoubleQuotedStyle:
+		for i := start + 1; i < len(src); i++ {
+			switch src[i] {
+			case '\\':
+				i++
+			case '"':
+				return i + 1, nil
+			}
+		}
+	case yaml.SingleQuotedStyle:
+		for i := start + 1; i < len(src); i++ {
+			if src[i] == '\'' {
+				if i+1 < len(src) && src[i+1] == '\'' {
+					i++
+					continue
+				}
+				return i + 1, nil
+			}
+		}
+	case yaml.LiteralStyle, yaml.FoldedStyle:
+		return p.blockScalarEnd(node, start)
+	default:
+		end := start
+		for end < len(src) && src[end] != '\n' && src[end] != '\r' {
+			if src[end] == '#' && end > start && isYAMLSpace(src[end-1]) {
+				break
+			}
+			end++
+		}
+		for end > start && isYAMLSpace(src[end-1]) {
+			end--
+		}
+		// A plain scalar folded over several lines reads differently
+		if string(src[start:end]) == node.Value {
+			return end, nil
+		}
+	}
+	return 0, errUnpatchable
+}
+
+// blockScalarEnd returns the offset where a literal or folded scalar ends:
+// the end of its last non-empty content line.
+func (p *yamlPatcher) blockScalarEnd(node *yaml.Node, start int) (int, error) {
+	header := p.lineIndex(start)
+	headerEnd := p.nextLineStart(start)
+	if bytes.ContainsAny(p.src[start:headerEnd], "123456789") {
+		// An explicit indentation indicator
+		return 0, errUnpatchable
+	}
+	end := bytes.TrimRight(p.src[:headerEnd], " \t\r\n")
+	if node.Value == "" {
+		return len(end), nil
+	}
+
+	last := len(end)
+	indent := -1
+	for line := header + 1; line < len(p.lineStarts); line++ {
+		text := p.src[p.lineStarts[line]:p.nextLineStart(p.lineStarts[line])]
+		content := bytes.TrimLeft(text, " ")
+		if len(bytes.TrimSpace(content)) == 0 {
+			continue
+		}
+		lineIndent := len(text) - len(content)
+		if indent < 0 {
+			indent = lineIndent
+		}
+		if lineIndent < indent {
+			break
+		}
+		last = p.lineStarts[line] + len(bytes.TrimRight(text, "\r\n"))
+	}
+	return last, nil
+}
+
+// flowEnd returns the offset just past the bracket that closes the flow
+// collection opened at start.
+func (p *yamlPatcher) flowEnd(start int) (int, error) {
+	src := p.src
+	depth := 0
+	for i := start; i < len(src); i++ {
+		switch src[i] {
+		case '[', '{':
+			depth++
+		case ']', '}':
+			depth--
+			if depth == 0 {
+				return i + 1, nil
+			}
+		case '"':
+			for i++; i < len(src) && src[i] != '"'; i++ {
+				if src[i] == '\\' {
+					i++
+				}
+			}
+		case '\'':
+			for i++; i < len(src); i++ {
+				if src[i] == '\'' {
+					if i+1 < len(src) && src[i+1] == '\'' {
+						i++
+						continue
+					}
+					break
+				}
+			}
+		}
+	}
+	return 0, errUnpatchable
+}
+
+// hasAlias reports whether node or any of its descendants is an alias.
+func hasAlias(node *yaml.Node) bool {
+	if node.Kind == yaml.AliasNode {
+		return true
+	}
+	return slices.ContainsFunc(node.Content, hasAlias)
+}
+
+// isBlockCollection reports whether node is a non-empty block mapping or
+// sequence.
+func isBlockCollection(node *yaml.Node) bool {
+	return (node.Kind == yaml.MappingNode || node.Kind == yaml.SequenceNode) &&
+		len(node.Content) > 0 && node.Style&yaml.FlowStyle == 0
+}
+
+// isFlowCollection reports whether node is a mapping or sequence written in
+// flow style, such as {} or [a, b].
+func isFlowCollection(node *yaml.Node) bool {
+	return (node.Kind == yaml.MappingNode || node.Kind == yaml.SequenceNode) &&
+		node.Style&yaml.FlowStyle != 0
+}
+
+// isImplicitNull reports whether node is a value left empty, as in "key:".
+func isImplicitNull(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.Tag == "!!null" && node.Value == "" && node.Style == 0
+}
+
+func isYAMLSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r'
+}
+
+// indentLines prefixes every non-empty line of text with indent spaces.
+func indentLines(text string, indent int) string {
+	prefix := strings.Repeat(" ", indent)
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// indentFollowingLines indents every line of text but the first, which
+// continues the line it is written on.
+func indentFollowingLines(text string, indent int) string {
+	first, rest, found := strings.Cut(text, "\n")
+	if !found {
+		return text
+	}
+	return first + "\n" + indentLines(rest, indent)
+}
@@ -0,0 +1,245 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+const patchTestYAML = `# Pet Store API
+openapi: "3.0.3"
+info:
+  title: Pets   # shown in the portal
+  version: '1.0'
+
+  description: |
+    Manages pets.
+    Internal only.
+tags:
+  - name: a
+  # b is deprecated
+  - name: b
+  - name: c
+servers: [{url: "https://api.example.com"}]
+x-shared: &shared
+  type: string
+x-copy: *shared
+x-empty:
+paths: {}
+`
+
+// patchGeneric decodes patchTestYAML, applies edit and patches the source
+// with the result.
+func patchGeneric(t *testing.T, edit func(doc map[string]any)) string {
+	t.Helper()
+	var doc map[string]any
+	require.NoError(t, yaml.Unmarshal([]byte(patchTestYAML), &doc))
+	edit(doc)
+	out, err := PatchYAML([]byte(patchTestYAML), doc)
+	require.NoError(t, err)
+
+	// Whatever was patched, the output must hold the edited document
+	var got map[string]any
+	require.NoError(t, yaml.Unmarshal(out, &got))
+	assert.Equal(t, doc, got)
+	return string(out)
+}
+
+func TestPatchYAML(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(doc map[string]any)
+		want string
+	}{
+		{
+			name: "unchanged document is returned as is",
+			edit: func(map[string]any) {},
+			want: patchTestYAML,
+		},
+		{
+			name: "scalars keep their comments and quotes",
+			edit: func(doc map[string]any) {
+				info := doc["info"].(map[string]any)
+				info["title"] = "Dogs"
+				info["version"] = "2.0"
+			},
+			want: strings.NewReplacer(
+				"title: Pets ", "title: Dogs ",
+				"version: '1.0'", "version: '2.0'",
+			).Replace(patchTestYAML),
+		},
+		{
+			name: "removed entries take their lines and new ones follow their neighbours",
+			edit: func(doc map[string]any) {
+				info := doc["info"].(map[string]any)
+				delete(info, "description")
+				info["x-team"] = "pets"
+			},
+			want: strings.Replace(patchTestYAML,
+				"  version: '1.0'\n\n  description: |\n    Manages pets.\n    Internal only.\n",
+				"  version: '1.0'\n  x-team: pets\n\n", 1),
+		},
+		{
+			name: "sequence elements are removed with their comments and appended",
+			edit: func(doc map[string]any) {
+				tags := doc["tags"].([]any)
+				doc["tags"] = []any{tags[0], tags[2], map[string]any{"name": "d"}}
+			},
+			want: strings.Replace(patchTestYAML,
+				"  - name: a\n  # b is deprecated\n  - name: b\n  - name: c\n",
+				"  - name: a\n  - name: c\n  - name: d\n", 1),
+		},
+		{
+			name: "flow collections stay in flow style",
+			edit: func(doc map[string]any) {
+				doc["servers"] = []any{map[string]any{"url": "https://staging.example.com"}}
+				doc["paths"] = map[string]any{"/pets": map[string]any{}}
+			},
+			want: strings.NewReplacer(
+				`servers: [{url: "https://api.example.com"}]`, `servers: [{url: "https://staging.example.com"}]`,
+				"paths: {}", "paths: {/pets: {}}",
+			).Replace(patchTestYAML),
+		},
+		{
+			name: "empty value becomes a block collection",
+			edit: func(doc map[string]any) {
+				doc["x-empty"] = map[string]any{"ids": []any{1, 2}}
+			},
+			want: strings.Replace(patchTestYAML, "x-empty:\n",
+				"x-empty:\n  ids:\n    - 1\n    - 2\n", 1),
+		},
+		{
+			name: "aliases are expanded when their anchor changes",
+			edit: func(doc map[string]any) {
+				doc["x-shared"] = map[string]any{"type": "integer"}
+			},
+			want: strings.Replace(patchTestYAML,
+				"  type: string\nx-copy: *shared\n",
+				"  type: integer\nx-copy:\n  type: string\n", 1),
+		},
+		{
+			name: "literal blocks stay literal",
+			edit: func(doc map[string]any) {
+				doc["info"].(map[string]any)["description"] = "Manages pets.\nPublic.\n"
+			},
+			want: strings.Replace(patchTestYAML, "    Internal only.\n", "    Public.\n", 1),
+		},
+		{
+			name: "collections become scalars",
+			edit: func(doc map[string]any) {
+				doc["tags"] = "none"
+			},
+			want: strings.Replace(patchTestYAML,
+				"tags:\n  - name: a\n  # b is deprecated\n  - name: b\n  - name: c\n",
+				"tags: none\n", 1),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, patchGeneric(t, tt.edit))
+		})
+	}
+}
+
+func TestPatchYAML_DetectsStyle(t *testing.T) {
+	src := "a:\n    b: 1\nlist:\n- x\n"
+	out, err := PatchYAML([]byte(src), map[string]any{
+		"a":    map[string]any{"b": 1, "c": map[string]any{"d": "true"}},
+		"list": []any{"x", []any{"y"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "a:\n    b: 1\n    c:\n        d: 'true'\nlist:\n- x\n- - y\n", string(out))
+
+	// Double quotes are preferred when the source mostly uses them
+	src = "a: \"x\"\nb: \"y\"\n"
+	out, err = PatchYAML([]byte(src), map[string]any{"a": "x", "b": "y", "c": "1"})
+	require.NoError(t, err)
+	assert.Equal(t, "a: \"x\"\nb: \"y\"\nc: \"1\"\n", string(out))
+}
+
+func TestPatchYAML_CRLF(t *testing.T) {
+	src := "a: 1\r\nb: 2\r\n"
+	out, err := PatchYAML([]byte(src), map[string]any{"a": 1, "b": 3, "c": 4})
+	require.NoError(t, err)
+	assert.Equal(t, "a: 1\r\nb: 3\r\nc: 4\r\n", string(out))
+}
+
+func TestPatchYAML_EmptySource(t *testing.T) {
+	out, err := PatchYAML(nil, map[string]any{"openapi": "3.0.3"})
+	require.NoError(t, err)
+	assert.Equal(t, "openapi: 3.0.3\n", string(out))
+}
+
+func TestPatchYAML_InvalidSource(t *testing.T) {
+	_, err := PatchYAML([]byte("a: [\n"), map[string]any{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "parser: patching YAML")
+}
+
+func TestParseResult_MarshalPreservedYAML(t *testing.T) {
+	src := `openapi: 3.0.3
+info:
+  title: Pets
+  version: 1.0.0 # bumped on release
+
+# Operations
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      parameters:
+        - name: limit
+          in: query
+          required: false # the default, which the typed model drops
+          schema:
+            type: integer
+      responses:
+        '200':
+          description: OK
+`
+	result, err := ParseWithOptions(WithBytes([]byte(src)), WithPreserveFormatting(true))
+	require.NoError(t, err)
+	require.True(t, result.HasPreservedFormatting())
+
+	// Unedited, the document is written exactly as it was read
+	out, err := result.MarshalPreservedYAML(result.Document)
+	require.NoError(t, err)
+	assert.Equal(t, src, string(out))
+
+	doc, ok := result.OAS3Document()
+	require.True(t, ok)
+	doc.Info.Version = "1.1.0"
+	doc.Paths["/pets"].Get.Summary = "List pets"
+
+	out, err = result.MarshalPreservedYAML(result.Document)
+	require.NoError(t, err)
+	want := strings.NewReplacer(
+		"version: 1.0.0 #", "version: 1.1.0 #",
+		"    get:\n", "    get:\n      summary: List pets\n",
+	).Replace(src)
+	assert.Equal(t, want, string(out))
+
+	// Copies share the source text
+	assert.True(t, result.Copy().HasPreservedFormatting())
+}
+
+func TestParseResult_MarshalPreservedYAML_Fallback(t *testing.T) {
+	src := `{"openapi": "3.0.3", "info": {"title": "Pets", "version": "1.0"}, "paths": {}}`
+
+	// JSON sources are not kept
+	result, err := ParseWithOptions(WithBytes([]byte(src)), WithPreserveFormatting(true))
+	require.NoError(t, err)
+	assert.False(t, result.HasPreservedFormatting())
+
+	// Without the option, the document is marshaled as usual
+	result, err = ParseWithOptions(WithBytes([]byte("openapi: 3.0.3\ninfo:\n  title: Pets\n  version: '1.0'\npaths: {}\n")))
+	require.NoError(t, err)
+	assert.False(t, result.HasPreservedFormatting())
+	out, err := result.MarshalPreservedYAML(result.Document)
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "title: Pets")
+}
@@ -0,0 +1,132 @@
+#!/usr/bin/env bash
+#
+# jsonpath-cts-vendor.sh materializes the vendored JSONPath Compliance Test
+# Suite under jsonpath/testdata/cts, at the commit pinned in
+# jsonpath/testdata/cts/sources.txt.
+#
+# With --update it resolves the pinned ref to its current upstream head,
+# vendors from there, and rewrites sources.txt with what it found. That is how
+# the pin moves, and it is always a reviewed diff rather than a live fetch.
+#
+# Every failure is fatal. Without --update, the digests recorded in sources.txt
+# are compared against what landed, and a record without a commit or digests is
+# refused rather than trusted.
+
+set -euo pipefail
+
+REPO_URL="https://github.com/jsonpath-standard/jsonpath-compliance-test-suite.git"
+ROOT="$(cd "$(dirname "${BASH_SOURCE[0]}")/.." && pwd)"
+DEST="$ROOT/jsonpath/testdata/cts"
+SOURCES="$DEST/sources.txt"
+FILES="cts.json LICENSE"
+
+UPDATE=0
+if [ "${1:-}" = "--update" ]; then
+	UPDATE=1
+elif [ $# -gt 0 ]; then
+	echo "usage: $(basename "$0") [--update]" >&2
+	exit 2
+fi
+
+if [ ! -f "$SOURCES" ]; then
+	echo "error: $SOURCES not found" >&2
+	exit 1
+fi
+
+WORK="$(mktemp -d)"
+trap 'rm -rf "$WORK"' EXIT INT TERM
+
+# sha256 of stdin, as bare hex. macOS ships shasum, most Linux images ship
+# sha256sum, and CI may be either.
+sha256() {
+	if command -v shasum >/dev/null 2>&1; then
+		shasum -a 256 | cut -d' ' -f1
+	else
+		sha256sum | cut -d' ' -f1
+	fi
+}
+
+records() {
+	grep -vE '^[[:space:]]*(#|$)' "$1" || true
+}
+
+if [ "$(records "$SOURCES" | wc -l | tr -d ' ')" -ne 1 ]; then
+	echo "error: $SOURCES must hold exactly one record" >&2
+	exit 1
+fi
+read -r ref commit want_cts want_license < <(records "$SOURCES")
+
+git init -q "$WORK/cts"
+git -C "$WORK/cts" remote add origin "$REPO_URL"
+
+if [ "$UPDATE" -eq 1 ]; then
+	head="$(git -C "$WORK/cts" ls-remote origin "refs/heads/$ref" </dev/null | cut -f1)"
+	if [ -z "$head" ]; then
+		echo "error: $ref does not exist in $REPO_URL" >&2
+		exit 1
+	fi
+	if [ "$head" != "$commit" ]; then
+		echo "  $ref moved ${commit:0:7} -> ${head:0:7}"
+	fi
+	commit="$head"
+fi
+
+# A placeholder pin is what sources.txt holds before the suite was first
+# vendored. Vendoring from it would mean vendoring whatever the branch holds
+# today, which is what --update is for.
+case "$commit" in
+*[!0-9a-f]* | "")
+	echo "error: $ref is not pinned to a commit; run 'make jsonpath-cts-update'" >&2
+	exit 1
+	;;
+esac
+
+echo "Vendoring the JSONPath Compliance Test Suite into jsonpath/testdata/cts..."
+echo "  $ref @ ${commit:0:7}"
+
+# Fetching the commit rather than the branch is what makes this a pinned
+# vendor. Ordered before any copy so a network failure cannot damage the
+# existing tree.
+if ! git -C "$WORK/cts" fetch -q --depth 1 origin "$commit" </dev/null; then
+	echo "error: cannot fetch $commit from $REPO_URL" >&2
+	exit 1
+fi
+for f in $FILES; do
+	if ! git -C "$WORK/cts" checkout -q FETCH_HEAD -- "$f" </dev/null 2>/dev/null; then
+		echo "error: cannot check out $f at $commit" >&2
+		exit 1
+	fi
+done
+for f in $FILES; do
+	cp "$WORK/cts/$f" "$DEST/$f"
+done
+
+got_cts="$(sha256 <"$DEST/cts.json")"
+got_license="$(sha256 <"$DEST/LICENSE")"
+
+if [ "$UPDATE" -eq 1 ]; then
+	# Staged inside the destination so the move is same-filesystem, and so an
+	# interrupt cannot leave sources.txt truncated.
+	rewritten="$(mktemp "$DEST/.sources.XXXXXX")"
+	grep -E '^[[:space:]]*(#|$)' "$SOURCES" >"$rewritten" || true
+	printf '%s %s %s %s\n' "$ref" "$commit" "$got_cts" "$got_license" >>"$rewritten"
+	mv "$rewritten" "$SOURCES"
+	echo "Updated sources.txt. Review 'git diff jsonpath/testdata/cts' before committing."
+	exit 0
+fi
+
+status=0
+if [ "$got_cts" != "$want_cts" ]; then
+	echo "error: cts.json digest $want_cts recorded, $got_cts vendored" >&2
+	status=1
+fi
+if [ "$got_license" != "$want_license" ]; then
+	echo "error: LICENSE digest $want_license recorded, $got_license vendored" >&2
+	status=1
+fi
+if [ "$status" -ne 0 ]; then
+	echo "Vendoring failed: the tree does not match sources.txt." >&2
+	exit "$status"
+fi
+
+echo "Done. Pin unchanged, so the tree matches what sources.txt records."
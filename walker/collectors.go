@@ -1,9 +1,44 @@
 package walker
 
 import (
+	"slices"
+	"sync"
+
 	"github.com/erraggy/oastools/parser"
 )
 
+// orderedItems gathers a collector's items from handlers that may run
+// concurrently, and returns them in sequential walk order.
+type orderedItems[T any] struct {
+	mu    sync.Mutex
+	items []orderedItem[T]
+}
+
+type orderedItem[T any] struct {
+	order visitOrder
+	item  T
+}
+
+// add records item as visited at wc.
+func (o *orderedItems[T]) add(wc *WalkContext, item T) {
+	o.mu.Lock()
+	o.items = append(o.items, orderedItem[T]{order: wc.order, item: item})
+	o.mu.Unlock()
+}
+
+// sorted returns the items in the order a sequential walk visits them.
+func (o *orderedItems[T]) sorted() []T {
+	byOrder := func(a, b orderedItem[T]) int { return compareVisitOrder(a.order, b.order) }
+	if !slices.IsSortedFunc(o.items, byOrder) {
+		slices.SortFunc(o.items, byOrder)
+	}
+	out := make([]T, len(o.items))
+	for i, it := range o.items {
+		out[i] = it.item
+	}
+	return out
+}
+
 // SchemaInfo contains information about a collected schema.
 type SchemaInfo struct {
 	// Schema is the collected schema.
@@ -43,7 +78,9 @@ type SchemaCollector struct {
 
 // CollectSchemas walks the document and collects all schemas.
 // It returns a SchemaCollector containing all schemas organized by various criteria.
-func CollectSchemas(result *parser.ParseResult) (*SchemaCollector, error) {
+// Options such as WithConcurrency configure the walk; the results are in the
+// same order whatever the options.
+func CollectSchemas(result *parser.ParseResult, opts ...Option) (*SchemaCollector, error) {
 	collector := &SchemaCollector{
 		All:        make([]*SchemaInfo, 0),
 		Components: make([]*SchemaInfo, 0),
@@ -52,7 +89,8 @@ func CollectSchemas(result *parser.ParseResult) (*SchemaCollector, error) {
 		ByName:     make(map[string]*SchemaInfo),
 	}
 
-	err := Walk(result,
+	var infos orderedItems[*SchemaInfo]
+	err := Walk(result, append(slices.Clip(opts),
 		WithSchemaHandler(func(wc *WalkContext, schema *parser.Schema) Action {
 			info := &SchemaInfo{
 				Schema:      schema,
@@ -61,26 +99,29 @@ func CollectSchemas(result *parser.ParseResult) (*SchemaCollector, error) {
 				IsComponent: wc.IsComponent,
 			}
 
-			collector.All = append(collector.All, info)
-			collector.ByPath[wc.JSONPath] = info
-
-			if wc.IsComponent {
-				collector.Components = append(collector.Components, info)
-				if wc.Name != "" {
-					collector.ByName[wc.Name] = info
-				}
-			} else {
-				collector.Inline = append(collector.Inline, info)
-			}
-
+			infos.add(wc, info)
 			return Continue
 		}),
-	)
+	)...)
 
 	if err != nil {
 		return nil, err
 	}
 
+	for _, info := range infos.sorted() {
+		collector.All = append(collector.All, info)
+		collector.ByPath[info.JSONPath] = info
+
+		if info.IsComponent {
+			collector.Components = append(collector.Components, info)
+			if info.Name != "" {
+				collector.ByName[info.Name] = info
+			}
+		} else {
+			collector.Inline = append(collector.Inline, info)
+		}
+	}
+
 	return collector, nil
 }
 
@@ -118,7 +159,9 @@ type OperationCollector struct {
 
 // CollectOperations walks the document and collects all operations.
 // It returns an OperationCollector containing all operations organized by various criteria.
-func CollectOperations(result *parser.ParseResult) (*OperationCollector, error) {
+// Options such as WithConcurrency configure the walk; the results are in the
+// same order whatever the options.
+func CollectOperations(result *parser.ParseResult, opts ...Option) (*OperationCollector, error) {
 	collector := &OperationCollector{
 		All:      make([]*OperationInfo, 0),
 		ByPath:   make(map[string][]*OperationInfo),
@@ -126,7 +169,8 @@ func CollectOperations(result *parser.ParseResult) (*OperationCollector, error)
 		ByTag:    make(map[string][]*OperationInfo),
 	}
 
-	err := Walk(result,
+	var infos orderedItems[*OperationInfo]
+	err := Walk(result, append(slices.Clip(opts),
 		WithOperationHandler(func(wc *WalkContext, op *parser.Operation) Action {
 			info := &OperationInfo{
 				Operation:    op,
@@ -135,22 +179,25 @@ func CollectOperations(result *parser.ParseResult) (*OperationCollector, error)
 				JSONPath:     wc.JSONPath,
 			}
 
-			collector.All = append(collector.All, info)
-			collector.ByPath[wc.PathTemplate] = append(collector.ByPath[wc.PathTemplate], info)
-			collector.ByMethod[wc.Method] = append(collector.ByMethod[wc.Method], info)
-
-			for _, tag := range op.Tags {
-				collector.ByTag[tag] = append(collector.ByTag[tag], info)
-			}
-
+			infos.add(wc, info)
 			return Continue
 		}),
-	)
+	)...)
 
 	if err != nil {
 		return nil, err
 	}
 
+	for _, info := range infos.sorted() {
+		collector.All = append(collector.All, info)
+		collector.ByPath[info.PathTemplate] = append(collector.ByPath[info.PathTemplate], info)
+		collector.ByMethod[info.Method] = append(collector.ByMethod[info.Method], info)
+
+		for _, tag := range info.Operation.Tags {
+			collector.ByTag[tag] = append(collector.ByTag[tag], info)
+		}
+	}
+
 	return collector, nil
 }
 
@@ -195,7 +242,9 @@ type ParameterCollector struct {
 
 // CollectParameters walks the document and collects all parameters.
 // It returns a ParameterCollector containing all parameters organized by various criteria.
-func CollectParameters(result *parser.ParseResult) (*ParameterCollector, error) {
+// Options such as WithConcurrency configure the walk; the results are in the
+// same order whatever the options.
+func CollectParameters(result *parser.ParseResult, opts ...Option) (*ParameterCollector, error) {
 	collector := &ParameterCollector{
 		All:        make([]*ParameterInfo, 0),
 		ByName:     make(map[string][]*ParameterInfo),
@@ -203,7 +252,8 @@ func CollectParameters(result *parser.ParseResult) (*ParameterCollector, error)
 		ByPath:     make(map[string][]*ParameterInfo),
 	}
 
-	err := Walk(result,
+	var infos orderedItems[*ParameterInfo]
+	err := Walk(result, append(slices.Clip(opts),
 		WithParameterHandler(func(wc *WalkContext, param *parser.Parameter) Action {
 			info := &ParameterInfo{
 				Parameter:    param,
@@ -215,21 +265,24 @@ func CollectParameters(result *parser.ParseResult) (*ParameterCollector, error)
 				IsComponent:  wc.IsComponent,
 			}
 
-			collector.All = append(collector.All, info)
-			collector.ByName[param.Name] = append(collector.ByName[param.Name], info)
-			collector.ByLocation[param.In] = append(collector.ByLocation[param.In], info)
-			if wc.PathTemplate != "" {
-				collector.ByPath[wc.PathTemplate] = append(collector.ByPath[wc.PathTemplate], info)
-			}
-
+			infos.add(wc, info)
 			return Continue
 		}),
-	)
+	)...)
 
 	if err != nil {
 		return nil, err
 	}
 
+	for _, info := range infos.sorted() {
+		collector.All = append(collector.All, info)
+		collector.ByName[info.Name] = append(collector.ByName[info.Name], info)
+		collector.ByLocation[info.In] = append(collector.ByLocation[info.In], info)
+		if info.PathTemplate != "" {
+			collector.ByPath[info.PathTemplate] = append(collector.ByPath[info.PathTemplate], info)
+		}
+	}
+
 	return collector, nil
 }
 
@@ -268,14 +321,17 @@ type ResponseCollector struct {
 
 // CollectResponses walks the document and collects all responses.
 // It returns a ResponseCollector containing all responses organized by various criteria.
-func CollectResponses(result *parser.ParseResult) (*ResponseCollector, error) {
+// Options such as WithConcurrency configure the walk; the results are in the
+// same order whatever the options.
+func CollectResponses(result *parser.ParseResult, opts ...Option) (*ResponseCollector, error) {
 	collector := &ResponseCollector{
 		All:          make([]*ResponseInfo, 0),
 		ByStatusCode: make(map[string][]*ResponseInfo),
 		ByPath:       make(map[string][]*ResponseInfo),
 	}
 
-	err := Walk(result,
+	var infos orderedItems[*ResponseInfo]
+	err := Walk(result, append(slices.Clip(opts),
 		WithResponseHandler(func(wc *WalkContext, resp *parser.Response) Action {
 			info := &ResponseInfo{
 				Response:     resp,
@@ -286,22 +342,25 @@ func CollectResponses(result *parser.ParseResult) (*ResponseCollector, error) {
 				IsComponent:  wc.IsComponent,
 			}
 
-			collector.All = append(collector.All, info)
-			if wc.StatusCode != "" {
-				collector.ByStatusCode[wc.StatusCode] = append(collector.ByStatusCode[wc.StatusCode], info)
-			}
-			if wc.PathTemplate != "" {
-				collector.ByPath[wc.PathTemplate] = append(collector.ByPath[wc.PathTemplate], info)
-			}
-
+			infos.add(wc, info)
 			return Continue
 		}),
-	)
+	)...)
 
 	if err != nil {
 		return nil, err
 	}
 
+	for _, info := range infos.sorted() {
+		collector.All = append(collector.All, info)
+		if info.StatusCode != "" {
+			collector.ByStatusCode[info.StatusCode] = append(collector.ByStatusCode[info.StatusCode], info)
+		}
+		if info.PathTemplate != "" {
+			collector.ByPath[info.PathTemplate] = append(collector.ByPath[info.PathTemplate], info)
+		}
+	}
+
 	return collector, nil
 }
 
@@ -328,13 +387,16 @@ type SecuritySchemeCollector struct {
 
 // CollectSecuritySchemes walks the document and collects all security schemes.
 // It returns a SecuritySchemeCollector containing all security schemes organized by various criteria.
-func CollectSecuritySchemes(result *parser.ParseResult) (*SecuritySchemeCollector, error) {
+// Options such as WithConcurrency configure the walk; the results are in the
+// same order whatever the options.
+func CollectSecuritySchemes(result *parser.ParseResult, opts ...Option) (*SecuritySchemeCollector, error) {
 	collector := &SecuritySchemeCollector{
 		All:    make([]*SecuritySchemeInfo, 0),
 		ByName: make(map[string]*SecuritySchemeInfo),
 	}
 
-	err := Walk(result,
+	var infos orderedItems[*SecuritySchemeInfo]
+	err := Walk(result, append(slices.Clip(opts),
 		WithSecuritySchemeHandler(func(wc *WalkContext, scheme *parser.SecurityScheme) Action {
 			info := &SecuritySchemeInfo{
 				SecurityScheme: scheme,
@@ -342,18 +404,21 @@ func CollectSecuritySchemes(result *parser.ParseResult) (*SecuritySchemeCollecto
 				JSONPath:       wc.JSONPath,
 			}
 
-			collector.All = append(collector.All, info)
-			if wc.Name != "" {
-				collector.ByName[wc.Name] = info
-			}
-
+			infos.add(wc, info)
 			return Continue
 		}),
-	)
+	)...)
 
 	if err != nil {
 		return nil, err
 	}
 
+	for _, info := range infos.sorted() {
+		collector.All = append(collector.All, info)
+		if info.Name != "" {
+			collector.ByName[info.Name] = info
+		}
+	}
+
 	return collector, nil
 }
@@ -0,0 +1,165 @@
+package walker
+
+import (
+	"cmp"
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"github.com/erraggy/oastools/parser"
+)
+
+// visitOrder places a handler call in the order a sequential walk would make
+// it. Calls on the walking goroutine share task 0 of their batch, calls in a
+// concurrent subtree carry the subtree's index, and n counts calls within
+// either, so sorting by (batch, task, n) recovers the sequential order.
+type visitOrder struct {
+	batch int
+	task  int
+	n     int
+}
+
+// compareVisitOrder orders a before b as a sequential walk would.
+func compareVisitOrder(a, b visitOrder) int {
+	return cmp.Or(cmp.Compare(a.batch, b.batch), cmp.Compare(a.task, b.task), cmp.Compare(a.n, b.n))
+}
+
+// nextOrder returns the order of the next handler call and advances the count.
+func (w *Walker) nextOrder() visitOrder {
+	o := w.order
+	w.order.n++
+	return o
+}
+
+// stop ends the walk, including subtrees running on other goroutines.
+func (w *Walker) stop() {
+	w.stopped = true
+	if w.halt != nil {
+		w.halt.Store(true)
+	}
+}
+
+// halted reports whether another subtree of a concurrent batch has stopped
+// the walk, and stops this one too if so.
+func (w *Walker) halted() bool {
+	if w.halt != nil && w.halt.Load() {
+		w.stopped = true
+	}
+	return w.stopped
+}
+
+// queuedEdit is an edit to the map of a concurrent batch, applied once every
+// subtree of the batch has finished reading the map.
+type queuedEdit struct {
+	slot        nodeSlot
+	edit        editKind
+	replacement any
+	path        string
+}
+
+// walkEach calls fn for each key of m in order, stopping at the first error or
+// when a handler stops the walk. With WithConcurrency the keys are independent
+// subtrees walked by up to w.concurrency goroutines, each with its own forked
+// Walker, and fn must use the Walker and state it is given.
+//
+// The subtrees read m while they run, so Replace and Delete of an entry of m
+// are queued and applied in key order after the batch, leaving m unchanged
+// until every subtree has finished.
+func (w *Walker) walkEach(keys []string, m any, state *walkState, fn func(w *Walker, key string, state *walkState) error) error {
+	if w.concurrency <= 1 || len(keys) < 2 {
+		for _, key := range keys {
+			if w.stopped {
+				return nil
+			}
+			if err := fn(w, key, state); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	batch := w.order.batch + 1
+	batchMap := reflect.ValueOf(m).Pointer()
+	forks := make([]*Walker, len(keys))
+	errs := make([]error, len(keys))
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for range min(w.concurrency, len(keys)) {
+		wg.Go(func() {
+			for {
+				i := int(next.Add(1)) - 1
+				if i >= len(keys) || w.halt.Load() {
+					return
+				}
+				fw := w.fork(batch, i)
+				fw.batchMap = batchMap
+				forks[i] = fw
+				if errs[i] = fn(fw, keys[i], state.fork(fw)); errs[i] != nil {
+					w.halt.Store(true)
+				}
+			}
+		})
+	}
+	wg.Wait()
+
+	// Merge the forks in key order so edits and errors match a sequential walk.
+	w.order = visitOrder{batch: batch + 1}
+	var firstErr error
+	for i, fw := range forks {
+		if fw == nil {
+			continue
+		}
+		w.deleted = append(w.deleted, fw.deleted...)
+		if w.editErr == nil {
+			w.editErr = fw.editErr
+		}
+		for _, q := range fw.queued {
+			w.recordEditErr(q.edit, q.path, w.applyEdit(q.slot, q.edit, q.replacement))
+		}
+		if firstErr == nil {
+			firstErr = errs[i]
+		}
+	}
+	if w.halt.Load() {
+		w.stopped = true
+	}
+	return firstErr
+}
+
+// fork returns a copy of w for walking one subtree of a concurrent batch. The
+// copy shares handlers, configuration, and the stop and edit coordination,
+// but has its own cycle detection and edit bookkeeping.
+func (w *Walker) fork(batch, task int) *Walker {
+	fw := *w
+	fw.visitedSchemas = make(map[*parser.Schema]bool)
+	fw.stopped = false
+	fw.editErr = nil
+	fw.deleted = nil
+	fw.queued = nil
+	fw.order = visitOrder{batch: batch, task: task}
+	fw.concurrency = 0 // a subtree's own children are walked sequentially
+	return &fw
+}
+
+// isBatchContainer reports whether container is the map the subtrees of the
+// current concurrent batch are walking.
+func (w *Walker) isBatchContainer(container any) bool {
+	if w.batchMap == 0 {
+		return false
+	}
+	v := reflect.ValueOf(container)
+	return v.Kind() == reflect.Map && v.Pointer() == w.batchMap
+}
+
+// fork returns a copy of s for fw, with a private copy of the parent stack
+// so subtrees can push and pop independently.
+func (s *walkState) fork(fw *Walker) *walkState {
+	fs := s.clone()
+	fs.walker = fw
+	if s.parentStack != nil {
+		stack := make([]*ParentInfo, len(*s.parentStack), len(*s.parentStack)+16)
+		copy(stack, *s.parentStack)
+		fs.parentStack = &stack
+	}
+	return fs
+}
@@ -52,6 +52,16 @@ type WalkContext struct {
 	Parent *ParentInfo
 
 	ctx context.Context
+
+	// slot locates the current node in its parent for Replace and Delete,
+	// and edit and replacement hold what a handler requested.
+	slot        nodeSlot
+	edit        editKind
+	replacement any
+
+	// order places this call in the sequential walk order, for collectors
+	// that must be deterministic under WithConcurrency.
+	order visitOrder
 }
 
 // Context returns the context.Context for cancellation and deadline propagation.
@@ -114,6 +124,9 @@ func (s *walkState) buildContext(jsonPath string) *WalkContext {
 	wc.IsComponent = s.isComponent
 	wc.Parent = s.currentParent()
 	wc.ctx = s.ctx
+	if s.walker != nil {
+		wc.order = s.walker.nextOrder()
+	}
 	return wc
 }
 
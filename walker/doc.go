@@ -125,6 +125,28 @@
 //	    }),
 //	)
 //
+// To replace a node or remove it from its parent, return [WalkContext.Replace]
+// or [WalkContext.Delete]. The walker updates the map, slice or field holding
+// the node, so handlers need no bookkeeping of their own:
+//
+//	walker.Walk(result,
+//	    walker.WithParameterHandler(func(wc *walker.WalkContext, param *parser.Parameter) walker.Action {
+//	        if param.In == "header" && param.Name == "X-Debug" {
+//	            return wc.Delete() // removed from its parameters slice
+//	        }
+//	        return walker.Continue
+//	    }),
+//	    walker.WithSchemaHandler(func(wc *walker.WalkContext, schema *parser.Schema) walker.Action {
+//	        if !wc.IsComponent && schema.Title == "Pet" {
+//	            return wc.Replace(&parser.Schema{Ref: "#/components/schemas/Pet"})
+//	        }
+//	        return walker.Continue
+//	    }),
+//	)
+//
+// Deleted slice elements are removed when the walk finishes, so the paths of
+// their siblings stay valid during the walk.
+//
 // # WalkContext
 //
 // Every handler receives a [WalkContext] as its first parameter, providing
@@ -174,6 +196,21 @@
 //	walker.Walk(result, handlers1...)
 //	walker.Walk(result, handlers2...)
 //
+// For large documents, [WithConcurrency] walks path items, webhooks, and
+// component schemas on several goroutines. Handlers must then be safe for
+// concurrent use; within one path item or schema they still run in order:
+//
+//	var mu sync.Mutex
+//	walker.Walk(result,
+//	    walker.WithConcurrency(runtime.GOMAXPROCS(0)),
+//	    walker.WithSchemaHandler(func(wc *walker.WalkContext, s *parser.Schema) walker.Action {
+//	        mu.Lock()
+//	        defer mu.Unlock()
+//	        // ... update shared state
+//	        return walker.Continue
+//	    }),
+//	)
+//
 // # Built-in Collectors
 //
 // For common collection patterns, the walker provides pre-built helpers that
@@ -198,6 +235,8 @@
 //	}
 //
 // Each collector provides an All slice for ordered traversal and various maps for indexed lookup.
+// Collectors accept walk options such as [WithConcurrency] and return the same
+// order either way.
 //
 // # Schema Cycle Detection
 //
@@ -122,6 +122,60 @@ func ExampleWalk_mutation() {
 	// x-visited: true
 }
 
+func ExampleWalkContext_Replace() {
+	doc := &parser.OAS3Document{
+		OpenAPI: "3.0.3",
+		Info:    &parser.Info{Title: "Test", Version: "1.0.0"},
+		Paths: parser.Paths{
+			"/pets": &parser.PathItem{
+				Get: &parser.Operation{
+					Parameters: []*parser.Parameter{
+						{Name: "limit", In: "query"},
+						{Name: "X-Debug", In: "header"},
+					},
+					Responses: &parser.Responses{
+						Codes: map[string]*parser.Response{
+							"200": {
+								Description: "OK",
+								Content: map[string]*parser.MediaType{
+									"application/json": {Schema: &parser.Schema{Title: "Pet", Type: "object"}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	result := &parser.ParseResult{
+		Document:   doc,
+		OASVersion: parser.OASVersion303,
+	}
+
+	_ = walker.Walk(result,
+		walker.WithParameterHandler(func(wc *walker.WalkContext, param *parser.Parameter) walker.Action {
+			if param.In == "header" {
+				return wc.Delete()
+			}
+			return walker.Continue
+		}),
+		walker.WithSchemaHandler(func(wc *walker.WalkContext, schema *parser.Schema) walker.Action {
+			if schema.Title == "Pet" {
+				return wc.Replace(&parser.Schema{Ref: "#/components/schemas/Pet"})
+			}
+			return walker.Continue
+		}),
+	)
+
+	op := doc.Paths["/pets"].Get
+	fmt.Printf("parameters: %d\n", len(op.Parameters))
+	fmt.Printf("schema: %s\n", op.Responses.Codes["200"].Content["application/json"].Schema.Ref)
+	// Output:
+	// parameters: 1
+	// schema: #/components/schemas/Pet
+}
+
 func ExampleWalk_skipChildren() {
 	doc := &parser.OAS3Document{
 		OpenAPI: "3.0.3",
@@ -89,6 +89,35 @@ func WithMapRefTracking() Option {
 	}
 }
 
+// WithConcurrency walks independent subtrees on up to n goroutines. The
+// subtrees are the entries of paths, webhooks, and component schemas
+// (definitions in OAS 2.0); every other part of the document is walked on the
+// calling goroutine. Values below 2 keep the default sequential walk.
+//
+// Handlers must be safe for concurrent use: handlers for different subtrees
+// can run at the same time, so shared state needs a mutex or similar. Within
+// one subtree, handlers are called one at a time in the usual order with the
+// usual WalkContext, and post-visit handlers still follow their children.
+// Each section finishes before the next begins, so document handlers and
+// handlers outside those sections never overlap with another handler.
+//
+// Stop ends the walk once subtrees already in progress reach their next
+// handler call. Replace and Delete of a subtree's own entry in paths,
+// webhooks, or component schemas are held until every subtree of that section
+// has finished, then applied in key order, so the entry stays in place while
+// the section is walked. Edits deeper in a subtree are applied at once. A node
+// reachable from several subtrees, such as a schema shared through resolved
+// $refs, may be visited by more than one goroutine at once and should not be
+// edited.
+//
+// The Collect functions accept this option and return results in the same
+// order as a sequential walk.
+func WithConcurrency(n int) Option {
+	return func(w *Walker) {
+		w.concurrency = n
+	}
+}
+
 // WalkWithOptions walks a document using functional options for input, handlers, and configuration.
 // All options use the unified Option type - no adapter is needed.
 //
@@ -1,6 +1,15 @@
 package walker
 
-import "github.com/erraggy/oastools/parser"
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/erraggy/oastools/internal/schemautil"
+	"github.com/erraggy/oastools/parser"
+)
 
 // ParentInfo provides information about a parent node in the traversal.
 // This enables handlers to access ancestor nodes for context-aware processing.
@@ -92,3 +101,237 @@ func (wc *WalkContext) Depth() int {
 	}
 	return depth
 }
+
+// nodeSlot records where the current node is held in its parent, so that
+// Replace and Delete can update the parent container in place: an entry of a
+// map, an element of the slice a pointer leads to, or the field a pointer
+// leads to. The zero nodeSlot holds nothing; the document root has no parent
+// to be edited in.
+type nodeSlot struct {
+	// container is a map, or a pointer to a slice or to a field.
+	container any
+	// key is the map key when container is a map.
+	key string
+	// index is the slice index when container points to a slice, else -1.
+	index int
+}
+
+// mapSlot returns the slot of the entry key of m.
+func mapSlot[V any](m map[string]V, key string) nodeSlot {
+	return nodeSlot{container: m, key: key, index: -1}
+}
+
+// indexSlot returns the slot of element i of the slice s points to.
+func indexSlot[E any](s *[]E, i int) nodeSlot {
+	return nodeSlot{container: s, index: i}
+}
+
+// fieldSlot returns the slot of the field p points to.
+func fieldSlot[T any](p *T) nodeSlot {
+	return nodeSlot{container: p, index: -1}
+}
+
+// tupleSlot returns the slot of element i of the tuple held by a
+// schema-or-bool field, or of the field itself for the single-schema form.
+func tupleSlot(field *any, i int) nodeSlot {
+	if i == schemautil.SingleForm {
+		return fieldSlot(field)
+	}
+	return nodeSlot{container: field, index: i}
+}
+
+// editKind is the edit a handler requested for the node it visited.
+type editKind int
+
+const (
+	editNone editKind = iota
+	editReplace
+	editDelete
+)
+
+// Replace replaces the current node in its parent container with node and
+// returns SkipChildren, so a handler can end with:
+//
+//	return wc.Replace(&parser.Schema{Ref: "#/components/schemas/Pet"})
+//
+// node has the type of the visited node: a *parser.Schema for a schema, a
+// *parser.Parameter for a parameter, and so on. The children of neither the
+// old nor the new node are walked, and the post-visit handler of a replaced
+// node is not called.
+//
+// Replace works in pre-visit and post-visit handlers for every node held by
+// a map, slice or field of its parent: schemas, parameters, responses,
+// operations, path items, media types and the rest. The walk stops and Walk
+// returns an error if the node has no parent to be replaced in, such as the
+// document itself, or node has the wrong type.
+func (wc *WalkContext) Replace(node any) Action {
+	wc.edit = editReplace
+	wc.replacement = node
+	return SkipChildren
+}
+
+// Delete removes the current node from its parent container and returns
+// SkipChildren, so a handler can end with:
+//
+//	return wc.Delete()
+//
+// A map entry is deleted and a field is set to nil. A slice element is set
+// to nil at once and the slice is compacted when the walk finishes, so the
+// indexes in the JSON paths of its remaining elements stay valid while the
+// walk continues. As with Replace, the node's children are not walked.
+func (wc *WalkContext) Delete() Action {
+	wc.edit = editDelete
+	wc.replacement = nil
+	return SkipChildren
+}
+
+// handleEdit applies the edit a handler requested through wc, if any, and
+// reports whether there was one. An edit that cannot be applied stops the
+// walk, and Walk returns its error.
+func (w *Walker) handleEdit(wc *WalkContext) bool {
+	if wc.edit == editNone {
+		return false
+	}
+	if w.isBatchContainer(wc.slot.container) {
+		// Other subtrees of the batch are reading this map, so the edit
+		// waits until they have finished
+		w.queued = append(w.queued, queuedEdit{slot: wc.slot, edit: wc.edit, replacement: wc.replacement, path: wc.JSONPath})
+	} else {
+		if w.editMu != nil {
+			// Subtrees of a concurrent walk can share a container, such as
+			// a schema reached through resolved $refs, so edits are serialized.
+			w.editMu.Lock()
+		}
+		err := w.applyEdit(wc.slot, wc.edit, wc.replacement)
+		if w.editMu != nil {
+			w.editMu.Unlock()
+		}
+		w.recordEditErr(wc.edit, wc.JSONPath, err)
+	}
+	wc.edit = editNone
+	wc.replacement = nil
+	return true
+}
+
+// recordEditErr keeps the first edit error of the walk and stops it.
+func (w *Walker) recordEditErr(edit editKind, path string, err error) {
+	if err != nil && w.editErr == nil {
+		w.editErr = fmt.Errorf("walker: %s of %s: %w", edit, path, err)
+		w.stop()
+	}
+}
+
+// String returns the name of the edit for error messages.
+func (e editKind) String() string {
+	if e == editDelete {
+		return "delete"
+	}
+	return "replace"
+}
+
+// applyEdit updates the container of the node in slot as edit requests.
+func (w *Walker) applyEdit(slot nodeSlot, edit editKind, replacement any) error {
+	if slot.container == nil {
+		return errors.New("node has no parent container")
+	}
+
+	container := reflect.ValueOf(slot.container)
+	var elemType reflect.Type
+	switch {
+	case container.Kind() == reflect.Map:
+		elemType = container.Type().Elem()
+	case slot.index >= 0:
+		container = sliceOf(container)
+		if !container.IsValid() || slot.index >= container.Len() {
+			return errors.New("node is no longer in its parent")
+		}
+		elemType = container.Type().Elem()
+	default:
+		elemType = container.Type().Elem()
+	}
+
+	var value reflect.Value
+	if edit == editDelete {
+		value = reflect.Zero(elemType)
+	} else {
+		if replacement == nil {
+			return errors.New("replacement is nil; use Delete to remove a node")
+		}
+		value = reflect.ValueOf(replacement)
+		if !value.Type().AssignableTo(elemType) {
+			// Callbacks are visited by value but held by pointer
+			if elemType.Kind() != reflect.Pointer || !value.Type().AssignableTo(elemType.Elem()) {
+				return fmt.Errorf("replacement is %s, want %s", value.Type(), elemType)
+			}
+			ptr := reflect.New(elemType.Elem())
+			ptr.Elem().Set(value)
+			value = ptr
+		}
+	}
+
+	switch {
+	case container.Kind() == reflect.Map:
+		key := reflect.ValueOf(slot.key).Convert(container.Type().Key())
+		if edit == editDelete {
+			container.SetMapIndex(key, reflect.Value{})
+		} else {
+			container.SetMapIndex(key, value)
+		}
+	case slot.index >= 0:
+		container.Index(slot.index).Set(value)
+		if edit == editDelete {
+			w.deleted = append(w.deleted, deletedElement{slice: slot.container, index: slot.index})
+		}
+	default:
+		reflect.ValueOf(slot.container).Elem().Set(value)
+	}
+	return nil
+}
+
+// deletedElement is a slice element set to nil by Delete, to be removed when
+// the walk finishes.
+type deletedElement struct {
+	// slice is a pointer to the slice, or to the field holding it.
+	slice any
+	index int
+}
+
+// compactDeleted removes the slice elements Delete set to nil.
+func (w *Walker) compactDeleted() {
+	// Remove from the highest index down, so earlier removals do not shift
+	// the indexes still to be removed.
+	slices.SortStableFunc(w.deleted, func(a, b deletedElement) int {
+		return cmp.Compare(b.index, a.index)
+	})
+	for _, d := range w.deleted {
+		holder := reflect.ValueOf(d.slice).Elem()
+		s := sliceOf(reflect.ValueOf(d.slice))
+		if !s.IsValid() || d.index >= s.Len() {
+			continue
+		}
+		kept := reflect.AppendSlice(
+			reflect.AppendSlice(reflect.MakeSlice(s.Type(), 0, s.Len()-1), s.Slice(0, d.index)),
+			s.Slice(d.index+1, s.Len()),
+		)
+		// An emptied field is left nil so that it is omitted when marshaled;
+		// a tuple stays a tuple
+		if kept.Len() == 0 && holder.Kind() != reflect.Interface {
+			kept = reflect.Zero(s.Type())
+		}
+		holder.Set(kept)
+	}
+	w.deleted = nil
+}
+
+// sliceOf returns the slice a pointer leads to, looking through a field of
+// interface type such as a schema-or-bool field holding a tuple.
+func sliceOf(ptr reflect.Value) reflect.Value {
+	v := ptr.Elem()
+	if v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Slice {
+		return reflect.Value{}
+	}
+	return v
+}
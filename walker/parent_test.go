@@ -676,3 +676,243 @@ func BenchmarkWalk_WithParentTracking(b *testing.B) {
 		}
 	})
 }
+
+func editTestDoc() *parser.OAS3Document {
+	return &parser.OAS3Document{
+		OpenAPI: "3.1.0",
+		Info:    &parser.Info{Title: "Test", Version: "1.0.0"},
+		Paths: parser.Paths{
+			"/pets": &parser.PathItem{
+				Get: &parser.Operation{
+					OperationID: "listPets",
+					Parameters: []*parser.Parameter{
+						{Name: "limit", In: "query"},
+						{Name: "X-Debug", In: "header"},
+						{Name: "offset", In: "query"},
+						{Name: "X-Trace", In: "header"},
+					},
+					Responses: &parser.Responses{
+						Codes: map[string]*parser.Response{
+							"200": {
+								Description: "OK",
+								Content: map[string]*parser.MediaType{
+									"application/json": {
+										Schema: &parser.Schema{
+											Type: "array",
+											Items: &parser.Schema{
+												Type:       "object",
+												Properties: map[string]*parser.Schema{"name": {Type: "string"}},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				Delete: &parser.Operation{OperationID: "deletePets"},
+			},
+		},
+		Components: &parser.Components{
+			Schemas: map[string]*parser.Schema{
+				"Pet":    {Type: "object", Properties: map[string]*parser.Schema{"name": {Type: "string"}}},
+				"Unused": {Type: "string"},
+			},
+		},
+	}
+}
+
+func TestReplace_SchemaWithRef(t *testing.T) {
+	doc := editTestDoc()
+	result := &parser.ParseResult{Document: doc, OASVersion: parser.OASVersion310}
+
+	var visited []string
+	err := Walk(result, WithSchemaHandler(func(wc *WalkContext, schema *parser.Schema) Action {
+		visited = append(visited, wc.JSONPath)
+		if !wc.IsComponent && schema.Type == "object" {
+			return wc.Replace(&parser.Schema{Ref: "#/components/schemas/Pet"})
+		}
+		return Continue
+	}))
+	require.NoError(t, err)
+
+	items := doc.Paths["/pets"].Get.Responses.Codes["200"].Content["application/json"].Schema.Items
+	assert.Equal(t, &parser.Schema{Ref: "#/components/schemas/Pet"}, items)
+	// The replaced schema's properties are not walked
+	assert.NotContains(t, visited, "$.paths['/pets'].get.responses['200'].content['application/json'].schema.items.properties['name']")
+	assert.Contains(t, visited, "$.components.schemas['Pet'].properties['name']")
+}
+
+func TestDelete_SliceElements(t *testing.T) {
+	doc := editTestDoc()
+	result := &parser.ParseResult{Document: doc, OASVersion: parser.OASVersion310}
+
+	var visited []string
+	err := Walk(result, WithParameterHandler(func(wc *WalkContext, param *parser.Parameter) Action {
+		visited = append(visited, wc.JSONPath)
+		if param.In == "header" {
+			return wc.Delete()
+		}
+		return Continue
+	}))
+	require.NoError(t, err)
+
+	// Every element is visited at its original index
+	assert.Equal(t, []string{
+		"$.paths['/pets'].get.parameters[0]",
+		"$.paths['/pets'].get.parameters[1]",
+		"$.paths['/pets'].get.parameters[2]",
+		"$.paths['/pets'].get.parameters[3]",
+	}, visited)
+
+	params := doc.Paths["/pets"].Get.Parameters
+	require.Len(t, params, 2)
+	assert.Equal(t, "limit", params[0].Name)
+	assert.Equal(t, "offset", params[1].Name)
+}
+
+func TestDelete_MapEntriesAndFields(t *testing.T) {
+	doc := editTestDoc()
+	result := &parser.ParseResult{Document: doc, OASVersion: parser.OASVersion310}
+
+	err := Walk(result,
+		WithSchemaHandler(func(wc *WalkContext, schema *parser.Schema) Action {
+			if wc.IsComponent && wc.Name == "Unused" {
+				return wc.Delete()
+			}
+			return Continue
+		}),
+		WithOperationHandler(func(wc *WalkContext, op *parser.Operation) Action {
+			if wc.Method == "delete" {
+				return wc.Delete()
+			}
+			return Continue
+		}),
+	)
+	require.NoError(t, err)
+
+	assert.NotContains(t, doc.Components.Schemas, "Unused")
+	assert.Contains(t, doc.Components.Schemas, "Pet")
+	assert.Nil(t, doc.Paths["/pets"].Delete)
+	assert.NotNil(t, doc.Paths["/pets"].Get)
+}
+
+func TestReplace_PostHandler(t *testing.T) {
+	doc := editTestDoc()
+	result := &parser.ParseResult{Document: doc, OASVersion: parser.OASVersion310}
+
+	// Bottom-up: the array schema is replaced once its items have been seen
+	var sawItems bool
+	err := Walk(result,
+		WithSchemaHandler(func(wc *WalkContext, schema *parser.Schema) Action {
+			if schema.Type == "object" && !wc.IsComponent {
+				sawItems = true
+			}
+			return Continue
+		}),
+		WithSchemaPostHandler(func(wc *WalkContext, schema *parser.Schema) {
+			if schema.Type == "array" {
+				wc.Replace(&parser.Schema{Ref: "#/components/schemas/PetList"})
+			}
+		}),
+	)
+	require.NoError(t, err)
+	assert.True(t, sawItems)
+
+	mt := doc.Paths["/pets"].Get.Responses.Codes["200"].Content["application/json"]
+	assert.Equal(t, "#/components/schemas/PetList", mt.Schema.Ref)
+}
+
+func TestReplace_Errors(t *testing.T) {
+	t.Run("document root has no parent", func(t *testing.T) {
+		result := &parser.ParseResult{Document: editTestDoc(), OASVersion: parser.OASVersion310}
+		err := Walk(result, WithDocumentHandler(func(wc *WalkContext, doc any) Action {
+			return wc.Delete()
+		}))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "walker: delete of $: node has no parent container")
+	})
+
+	t.Run("wrong type stops the walk", func(t *testing.T) {
+		result := &parser.ParseResult{Document: editTestDoc(), OASVersion: parser.OASVersion310}
+		var visited int
+		err := Walk(result, WithParameterHandler(func(wc *WalkContext, param *parser.Parameter) Action {
+			visited++
+			return wc.Replace(&parser.Schema{})
+		}))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "replacement is *parser.Schema, want *parser.Parameter")
+		assert.Equal(t, 1, visited)
+	})
+
+	t.Run("nil replacement", func(t *testing.T) {
+		result := &parser.ParseResult{Document: editTestDoc(), OASVersion: parser.OASVersion310}
+		err := Walk(result, WithInfoHandler(func(wc *WalkContext, info *parser.Info) Action {
+			return wc.Replace(nil)
+		}))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "use Delete")
+	})
+}
+
+func TestDelete_TupleItems(t *testing.T) {
+	// OAS 2.0 allows items to be a tuple of schemas
+	doc := &parser.OAS2Document{
+		Swagger: "2.0",
+		Info:    &parser.Info{Title: "Test", Version: "1.0.0"},
+		Definitions: map[string]*parser.Schema{
+			"Pair": {
+				Type:  "array",
+				Items: []*parser.Schema{{Type: "string"}, {Type: "null"}, {Type: "integer"}},
+			},
+		},
+		Responses: map[string]*parser.Response{
+			"Gone": {Description: "Gone"},
+			"OK":   {Description: "OK"},
+		},
+	}
+	result := &parser.ParseResult{Document: doc, OASVersion: parser.OASVersion20}
+
+	err := Walk(result,
+		WithSchemaHandler(func(wc *WalkContext, schema *parser.Schema) Action {
+			if schema.Type == "null" {
+				return wc.Delete()
+			}
+			return Continue
+		}),
+		WithResponseHandler(func(wc *WalkContext, resp *parser.Response) Action {
+			if wc.Name == "Gone" {
+				return wc.Delete()
+			}
+			return Continue
+		}),
+	)
+	require.NoError(t, err)
+
+	items, ok := doc.Definitions["Pair"].Items.([]*parser.Schema)
+	require.True(t, ok)
+	require.Len(t, items, 2)
+	assert.Equal(t, "string", items[0].Type)
+	assert.Equal(t, "integer", items[1].Type)
+	assert.NotContains(t, doc.Responses, "Gone")
+}
+
+func TestReplace_Callback(t *testing.T) {
+	cb := parser.Callback{"{$request.body#/url}": &parser.PathItem{}}
+	doc := &parser.OAS3Document{
+		OpenAPI: "3.0.3",
+		Info:    &parser.Info{Title: "Test", Version: "1.0.0"},
+		Components: &parser.Components{
+			Callbacks: map[string]*parser.Callback{"onEvent": &cb},
+		},
+	}
+	result := &parser.ParseResult{Document: doc, OASVersion: parser.OASVersion303}
+
+	// Callbacks are visited by value; the replacement is stored by pointer
+	replacement := parser.Callback{"{$request.body#/hook}": &parser.PathItem{}}
+	err := Walk(result, WithCallbackHandler(func(wc *WalkContext, callback parser.Callback) Action {
+		return wc.Replace(replacement)
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, replacement, *doc.Components.Callbacks["onEvent"])
+}
@@ -14,7 +14,7 @@ func (w *Walker) walkOAS2(doc *parser.OAS2Document, state *walkState) error {
 	continueToChildren := true
 	if w.onOAS2Document != nil {
 		wc := state.buildContext("$")
-		result := w.handleAction(w.onOAS2Document(wc, doc))
+		result := w.handleAction(wc, w.onOAS2Document(wc, doc))
 		releaseContext(wc)
 		if !result {
 			if w.stopped {
@@ -27,7 +27,7 @@ func (w *Walker) walkOAS2(doc *parser.OAS2Document, state *walkState) error {
 	// but not if it returned Stop
 	if w.onDocument != nil {
 		wc := state.buildContext("$")
-		result := w.handleAction(w.onDocument(wc, doc))
+		result := w.handleAction(wc, w.onDocument(wc, doc))
 		releaseContext(wc)
 		if !result {
 			if w.stopped {
@@ -44,7 +44,8 @@ func (w *Walker) walkOAS2(doc *parser.OAS2Document, state *walkState) error {
 	// Info
 	if doc.Info != nil && w.onInfo != nil {
 		wc := state.buildContext("$.info")
-		result := w.handleAction(w.onInfo(wc, doc.Info))
+		wc.slot = fieldSlot(&doc.Info)
+		result := w.handleAction(wc, w.onInfo(wc, doc.Info))
 		releaseContext(wc)
 		if !result {
 			if w.stopped {
@@ -56,7 +57,8 @@ func (w *Walker) walkOAS2(doc *parser.OAS2Document, state *walkState) error {
 	// ExternalDocs (root level)
 	if doc.ExternalDocs != nil && w.onExternalDocs != nil {
 		wc := state.buildContext("$.externalDocs")
-		result := w.handleAction(w.onExternalDocs(wc, doc.ExternalDocs))
+		wc.slot = fieldSlot(&doc.ExternalDocs)
+		result := w.handleAction(wc, w.onExternalDocs(wc, doc.ExternalDocs))
 		releaseContext(wc)
 		if !result {
 			if w.stopped {
@@ -77,18 +79,17 @@ func (w *Walker) walkOAS2(doc *parser.OAS2Document, state *walkState) error {
 		defState := state.clone()
 		defState.isComponent = true
 
-		for _, name := range maputil.SortedKeys(doc.Definitions) {
-			if w.stopped {
-				return nil
-			}
+		err := w.walkEach(maputil.SortedKeys(doc.Definitions), doc.Definitions, defState, func(w *Walker, name string, state *walkState) error {
 			schema := doc.Definitions[name]
-			if schema != nil {
-				schemaState := defState.clone()
-				schemaState.name = name
-				if err := w.walkSchema(schema, "$.definitions['"+name+"']", 0, schemaState); err != nil {
-					return err
-				}
+			if schema == nil {
+				return nil
 			}
+			schemaState := state.clone()
+			schemaState.name = name
+			return w.walkSchema(schema, mapSlot(doc.Definitions, name), "$.definitions['"+name+"']", 0, schemaState)
+		})
+		if err != nil {
+			return err
 		}
 	}
 
@@ -105,7 +106,7 @@ func (w *Walker) walkOAS2(doc *parser.OAS2Document, state *walkState) error {
 			if param != nil {
 				pState := paramState.clone()
 				pState.name = name
-				if err := w.walkParameter(param, "$.parameters['"+name+"']", pState); err != nil {
+				if err := w.walkParameter(param, mapSlot(doc.Parameters, name), "$.parameters['"+name+"']", pState); err != nil {
 					return err
 				}
 			}
@@ -125,7 +126,7 @@ func (w *Walker) walkOAS2(doc *parser.OAS2Document, state *walkState) error {
 			if resp != nil {
 				rState := respState.clone()
 				rState.name = name
-				if err := w.walkOAS2Response(resp, "$.responses['"+name+"']", rState); err != nil {
+				if err := w.walkOAS2Response(resp, mapSlot(doc.Responses, name), "$.responses['"+name+"']", rState); err != nil {
 					return err
 				}
 			}
@@ -157,7 +158,8 @@ func (w *Walker) walkOAS2(doc *parser.OAS2Document, state *walkState) error {
 
 			if w.onSecurityScheme != nil {
 				wc := sState.buildContext(ssPath)
-				w.handleAction(w.onSecurityScheme(wc, ss))
+				wc.slot = mapSlot(doc.SecurityDefinitions, name)
+				w.handleAction(wc, w.onSecurityScheme(wc, ss))
 				releaseContext(wc)
 			}
 		}
@@ -170,7 +172,8 @@ func (w *Walker) walkOAS2(doc *parser.OAS2Document, state *walkState) error {
 		}
 		if tag != nil && w.onTag != nil {
 			wc := state.buildContext(fmt.Sprintf("$.tags[%d]", i))
-			w.handleAction(w.onTag(wc, tag))
+			wc.slot = indexSlot(&doc.Tags, i)
+			w.handleAction(wc, w.onTag(wc, tag))
 			releaseContext(wc)
 		}
 	}
@@ -187,14 +190,10 @@ func (w *Walker) walkOAS2(doc *parser.OAS2Document, state *walkState) error {
 
 // walkOAS2Paths walks all paths in sorted order.
 func (w *Walker) walkOAS2Paths(paths parser.Paths, basePath string, state *walkState) error {
-	pathKeys := maputil.SortedKeys(paths)
-	for _, pathTemplate := range pathKeys {
-		if w.stopped {
-			return nil
-		}
+	return w.walkEach(maputil.SortedKeys(paths), paths, state, func(w *Walker, pathTemplate string, state *walkState) error {
 		pathItem := paths[pathTemplate]
 		if pathItem == nil {
-			continue
+			return nil
 		}
 
 		itemPath := basePath + "['" + pathTemplate + "']"
@@ -207,7 +206,8 @@ func (w *Walker) walkOAS2Paths(paths parser.Paths, basePath string, state *walkS
 		continueToChildren := true
 		if w.onPath != nil {
 			wc := pathState.buildContext(itemPath)
-			continueToChildren = w.handleAction(w.onPath(wc, pathItem))
+			wc.slot = mapSlot(paths, pathTemplate)
+			continueToChildren = w.handleAction(wc, w.onPath(wc, pathItem))
 			releaseContext(wc)
 			if w.stopped {
 				return nil
@@ -215,16 +215,14 @@ func (w *Walker) walkOAS2Paths(paths parser.Paths, basePath string, state *walkS
 		}
 
 		if continueToChildren {
-			if err := w.walkOAS2PathItem(pathItem, itemPath, pathState); err != nil {
-				return err
-			}
+			return w.walkOAS2PathItem(pathItem, mapSlot(paths, pathTemplate), itemPath, pathState)
 		}
-	}
-	return nil
+		return nil
+	})
 }
 
-// walkOAS2PathItem walks a single PathItem.
-func (w *Walker) walkOAS2PathItem(pathItem *parser.PathItem, basePath string, state *walkState) error {
+// walkOAS2PathItem walks a single PathItem held in slot.
+func (w *Walker) walkOAS2PathItem(pathItem *parser.PathItem, slot nodeSlot, basePath string, state *walkState) error {
 	// Check for $ref
 	if w.handleRef(pathItem.Ref, basePath, RefNodePathItem, state) == Stop {
 		return nil
@@ -234,7 +232,8 @@ func (w *Walker) walkOAS2PathItem(pathItem *parser.PathItem, basePath string, st
 	continueToChildren := true
 	if w.onPathItem != nil {
 		wc := state.buildContext(basePath)
-		continueToChildren = w.handleAction(w.onPathItem(wc, pathItem))
+		wc.slot = slot
+		continueToChildren = w.handleAction(wc, w.onPathItem(wc, pathItem))
 		releaseContext(wc)
 		if w.stopped {
 			return nil
@@ -254,7 +253,7 @@ func (w *Walker) walkOAS2PathItem(pathItem *parser.PathItem, basePath string, st
 		if w.stopped {
 			return nil
 		}
-		if err := w.walkParameter(param, fmt.Sprintf("%s.parameters[%d]", basePath, i), state); err != nil {
+		if err := w.walkParameter(param, indexSlot(&pathItem.Parameters, i), fmt.Sprintf("%s.parameters[%d]", basePath, i), state); err != nil {
 			return err
 		}
 	}
@@ -262,25 +261,25 @@ func (w *Walker) walkOAS2PathItem(pathItem *parser.PathItem, basePath string, st
 	// Operations (OAS 2.0 doesn't have trace or query)
 	ops := []struct {
 		method string
-		op     *parser.Operation
+		op     **parser.Operation
 	}{
-		{"get", pathItem.Get},
-		{"put", pathItem.Put},
-		{"post", pathItem.Post},
-		{"delete", pathItem.Delete},
-		{"options", pathItem.Options},
-		{"head", pathItem.Head},
-		{"patch", pathItem.Patch},
+		{"get", &pathItem.Get},
+		{"put", &pathItem.Put},
+		{"post", &pathItem.Post},
+		{"delete", &pathItem.Delete},
+		{"options", &pathItem.Options},
+		{"head", &pathItem.Head},
+		{"patch", &pathItem.Patch},
 	}
 
 	for _, item := range ops {
 		if w.stopped {
 			return nil
 		}
-		if item.op != nil {
+		if op := *item.op; op != nil {
 			opState := state.clone()
 			opState.method = item.method
-			if err := w.walkOAS2Operation(item.op, basePath+"."+item.method, opState); err != nil {
+			if err := w.walkOAS2Operation(op, fieldSlot(item.op), basePath+"."+item.method, opState); err != nil {
 				return err
 			}
 		}
@@ -289,20 +288,23 @@ func (w *Walker) walkOAS2PathItem(pathItem *parser.PathItem, basePath string, st
 	// Call post-visit handler after children (but before popParent)
 	if w.onPathItemPost != nil && !w.stopped {
 		wc := state.buildContext(basePath)
+		wc.slot = slot
 		w.onPathItemPost(wc, pathItem)
+		w.handleEdit(wc)
 		releaseContext(wc)
 	}
 
 	return nil
 }
 
-// walkOAS2Operation walks a single Operation.
-func (w *Walker) walkOAS2Operation(op *parser.Operation, basePath string, state *walkState) error {
+// walkOAS2Operation walks a single Operation held in slot.
+func (w *Walker) walkOAS2Operation(op *parser.Operation, slot nodeSlot, basePath string, state *walkState) error {
 	// Operation pre-visit handler
 	continueToChildren := true
 	if w.onOperation != nil {
 		wc := state.buildContext(basePath)
-		continueToChildren = w.handleAction(w.onOperation(wc, op))
+		wc.slot = slot
+		continueToChildren = w.handleAction(wc, w.onOperation(wc, op))
 		releaseContext(wc)
 		if w.stopped {
 			return nil
@@ -320,7 +322,8 @@ func (w *Walker) walkOAS2Operation(op *parser.Operation, basePath string, state
 	// ExternalDocs
 	if op.ExternalDocs != nil && w.onExternalDocs != nil {
 		wc := state.buildContext(basePath + ".externalDocs")
-		w.handleAction(w.onExternalDocs(wc, op.ExternalDocs))
+		wc.slot = fieldSlot(&op.ExternalDocs)
+		w.handleAction(wc, w.onExternalDocs(wc, op.ExternalDocs))
 		releaseContext(wc)
 		if w.stopped {
 			return nil
@@ -332,7 +335,7 @@ func (w *Walker) walkOAS2Operation(op *parser.Operation, basePath string, state
 		if w.stopped {
 			return nil
 		}
-		if err := w.walkParameter(param, fmt.Sprintf("%s.parameters[%d]", basePath, i), state); err != nil {
+		if err := w.walkParameter(param, indexSlot(&op.Parameters, i), fmt.Sprintf("%s.parameters[%d]", basePath, i), state); err != nil {
 			return err
 		}
 	}
@@ -347,7 +350,9 @@ func (w *Walker) walkOAS2Operation(op *parser.Operation, basePath string, state
 	// Call post-visit handler after children (but before popParent)
 	if w.onOperationPost != nil && !w.stopped {
 		wc := state.buildContext(basePath)
+		wc.slot = slot
 		w.onOperationPost(wc, op)
+		w.handleEdit(wc)
 		releaseContext(wc)
 	}
 
@@ -360,7 +365,7 @@ func (w *Walker) walkOAS2Responses(responses *parser.Responses, basePath string,
 	if responses.Default != nil {
 		respState := state.clone()
 		respState.statusCode = "default"
-		if err := w.walkOAS2Response(responses.Default, basePath+".default", respState); err != nil {
+		if err := w.walkOAS2Response(responses.Default, fieldSlot(&responses.Default), basePath+".default", respState); err != nil {
 			return err
 		}
 	}
@@ -375,7 +380,7 @@ func (w *Walker) walkOAS2Responses(responses *parser.Responses, basePath string,
 			if resp != nil {
 				respState := state.clone()
 				respState.statusCode = code
-				if err := w.walkOAS2Response(resp, basePath+"['"+code+"']", respState); err != nil {
+				if err := w.walkOAS2Response(resp, mapSlot(responses.Codes, code), basePath+"['"+code+"']", respState); err != nil {
 					return err
 				}
 			}
@@ -385,8 +390,8 @@ func (w *Walker) walkOAS2Responses(responses *parser.Responses, basePath string,
 	return nil
 }
 
-// walkOAS2Response walks a single Response (OAS 2.0 style).
-func (w *Walker) walkOAS2Response(resp *parser.Response, basePath string, state *walkState) error {
+// walkOAS2Response walks a single Response (OAS 2.0 style) held in slot.
+func (w *Walker) walkOAS2Response(resp *parser.Response, slot nodeSlot, basePath string, state *walkState) error {
 	// Check for $ref
 	if w.handleRef(resp.Ref, basePath, RefNodeResponse, state) == Stop {
 		return nil
@@ -396,7 +401,8 @@ func (w *Walker) walkOAS2Response(resp *parser.Response, basePath string, state
 	continueToChildren := true
 	if w.onResponse != nil {
 		wc := state.buildContext(basePath)
-		continueToChildren = w.handleAction(w.onResponse(wc, resp))
+		wc.slot = slot
+		continueToChildren = w.handleAction(wc, w.onResponse(wc, resp))
 		releaseContext(wc)
 		if w.stopped {
 			return nil
@@ -422,7 +428,7 @@ func (w *Walker) walkOAS2Response(resp *parser.Response, basePath string, state
 	if resp.Schema != nil {
 		schemaState := state.clone()
 		schemaState.name = "" // Clear name for nested schemas
-		if err := w.walkSchema(resp.Schema, basePath+".schema", 0, schemaState); err != nil {
+		if err := w.walkSchema(resp.Schema, fieldSlot(&resp.Schema), basePath+".schema", 0, schemaState); err != nil {
 			return err
 		}
 	}
@@ -430,7 +436,9 @@ func (w *Walker) walkOAS2Response(resp *parser.Response, basePath string, state
 	// Call post-visit handler after children (but before popParent)
 	if w.onResponsePost != nil && !w.stopped {
 		wc := state.buildContext(basePath)
+		wc.slot = slot
 		w.onResponsePost(wc, resp)
+		w.handleEdit(wc)
 		releaseContext(wc)
 	}
 
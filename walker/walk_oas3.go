@@ -14,7 +14,7 @@ func (w *Walker) walkOAS3(doc *parser.OAS3Document, state *walkState) error {
 	continueToChildren := true
 	if w.onOAS3Document != nil {
 		wc := state.buildContext("$")
-		result := w.handleAction(w.onOAS3Document(wc, doc))
+		result := w.handleAction(wc, w.onOAS3Document(wc, doc))
 		releaseContext(wc)
 		if !result {
 			if w.stopped {
@@ -27,7 +27,7 @@ func (w *Walker) walkOAS3(doc *parser.OAS3Document, state *walkState) error {
 	// but not if it returned Stop
 	if w.onDocument != nil {
 		wc := state.buildContext("$")
-		result := w.handleAction(w.onDocument(wc, doc))
+		result := w.handleAction(wc, w.onDocument(wc, doc))
 		releaseContext(wc)
 		if !result {
 			if w.stopped {
@@ -44,7 +44,8 @@ func (w *Walker) walkOAS3(doc *parser.OAS3Document, state *walkState) error {
 	// Info
 	if doc.Info != nil && w.onInfo != nil {
 		wc := state.buildContext("$.info")
-		result := w.handleAction(w.onInfo(wc, doc.Info))
+		wc.slot = fieldSlot(&doc.Info)
+		result := w.handleAction(wc, w.onInfo(wc, doc.Info))
 		releaseContext(wc)
 		if !result {
 			if w.stopped {
@@ -56,7 +57,8 @@ func (w *Walker) walkOAS3(doc *parser.OAS3Document, state *walkState) error {
 	// ExternalDocs (root level)
 	if doc.ExternalDocs != nil && w.onExternalDocs != nil {
 		wc := state.buildContext("$.externalDocs")
-		result := w.handleAction(w.onExternalDocs(wc, doc.ExternalDocs))
+		wc.slot = fieldSlot(&doc.ExternalDocs)
+		result := w.handleAction(wc, w.onExternalDocs(wc, doc.ExternalDocs))
 		releaseContext(wc)
 		if !result {
 			if w.stopped {
@@ -72,7 +74,8 @@ func (w *Walker) walkOAS3(doc *parser.OAS3Document, state *walkState) error {
 		}
 		if server != nil && w.onServer != nil {
 			wc := state.buildContext(fmt.Sprintf("$.servers[%d]", i))
-			w.handleAction(w.onServer(wc, server))
+			wc.slot = indexSlot(&doc.Servers, i)
+			w.handleAction(wc, w.onServer(wc, server))
 			releaseContext(wc)
 		}
 	}
@@ -107,7 +110,8 @@ func (w *Walker) walkOAS3(doc *parser.OAS3Document, state *walkState) error {
 		}
 		if tag != nil && w.onTag != nil {
 			wc := state.buildContext(fmt.Sprintf("$.tags[%d]", i))
-			w.handleAction(w.onTag(wc, tag))
+			wc.slot = indexSlot(&doc.Tags, i)
+			w.handleAction(wc, w.onTag(wc, tag))
 			releaseContext(wc)
 		}
 	}
@@ -124,14 +128,10 @@ func (w *Walker) walkOAS3(doc *parser.OAS3Document, state *walkState) error {
 
 // walkOAS3Paths walks all paths in sorted order.
 func (w *Walker) walkOAS3Paths(paths parser.Paths, basePath string, state *walkState) error {
-	pathKeys := maputil.SortedKeys(paths)
-	for _, pathTemplate := range pathKeys {
-		if w.stopped {
-			return nil
-		}
+	return w.walkEach(maputil.SortedKeys(paths), paths, state, func(w *Walker, pathTemplate string, state *walkState) error {
 		pathItem := paths[pathTemplate]
 		if pathItem == nil {
-			continue
+			return nil
 		}
 
 		itemPath := basePath + "['" + pathTemplate + "']"
@@ -144,7 +144,8 @@ func (w *Walker) walkOAS3Paths(paths parser.Paths, basePath string, state *walkS
 		continueToChildren := true
 		if w.onPath != nil {
 			wc := pathState.buildContext(itemPath)
-			continueToChildren = w.handleAction(w.onPath(wc, pathItem))
+			wc.slot = mapSlot(paths, pathTemplate)
+			continueToChildren = w.handleAction(wc, w.onPath(wc, pathItem))
 			releaseContext(wc)
 			if w.stopped {
 				return nil
@@ -152,23 +153,18 @@ func (w *Walker) walkOAS3Paths(paths parser.Paths, basePath string, state *walkS
 		}
 
 		if continueToChildren {
-			if err := w.walkOAS3PathItem(pathItem, itemPath, pathState); err != nil {
-				return err
-			}
+			return w.walkOAS3PathItem(pathItem, mapSlot(paths, pathTemplate), itemPath, pathState)
 		}
-	}
-	return nil
+		return nil
+	})
 }
 
 // walkOAS3Webhooks walks webhooks (OAS 3.1+).
 func (w *Walker) walkOAS3Webhooks(webhooks map[string]*parser.PathItem, basePath string, state *walkState) error {
-	for _, name := range maputil.SortedKeys(webhooks) {
-		if w.stopped {
-			return nil
-		}
+	return w.walkEach(maputil.SortedKeys(webhooks), webhooks, state, func(w *Walker, name string, state *walkState) error {
 		pathItem := webhooks[name]
 		if pathItem == nil {
-			continue
+			return nil
 		}
 
 		itemPath := basePath + "['" + name + "']"
@@ -181,7 +177,8 @@ func (w *Walker) walkOAS3Webhooks(webhooks map[string]*parser.PathItem, basePath
 		continueToChildren := true
 		if w.onPathItem != nil {
 			wc := webhookState.buildContext(itemPath)
-			continueToChildren = w.handleAction(w.onPathItem(wc, pathItem))
+			wc.slot = mapSlot(webhooks, name)
+			continueToChildren = w.handleAction(wc, w.onPathItem(wc, pathItem))
 			releaseContext(wc)
 			if w.stopped {
 				return nil
@@ -189,16 +186,14 @@ func (w *Walker) walkOAS3Webhooks(webhooks map[string]*parser.PathItem, basePath
 		}
 
 		if continueToChildren {
-			if err := w.walkOAS3PathItemOperations(pathItem, itemPath, webhookState); err != nil {
-				return err
-			}
+			return w.walkOAS3PathItemOperations(pathItem, itemPath, webhookState)
 		}
-	}
-	return nil
+		return nil
+	})
 }
 
-// walkOAS3PathItem walks a single PathItem.
-func (w *Walker) walkOAS3PathItem(pathItem *parser.PathItem, basePath string, state *walkState) error {
+// walkOAS3PathItem walks a single PathItem held in slot.
+func (w *Walker) walkOAS3PathItem(pathItem *parser.PathItem, slot nodeSlot, basePath string, state *walkState) error {
 	// Check for $ref
 	if w.handleRef(pathItem.Ref, basePath, RefNodePathItem, state) == Stop {
 		return nil
@@ -208,7 +203,8 @@ func (w *Walker) walkOAS3PathItem(pathItem *parser.PathItem, basePath string, st
 	continueToChildren := true
 	if w.onPathItem != nil {
 		wc := state.buildContext(basePath)
-		continueToChildren = w.handleAction(w.onPathItem(wc, pathItem))
+		wc.slot = slot
+		continueToChildren = w.handleAction(wc, w.onPathItem(wc, pathItem))
 		releaseContext(wc)
 		if w.stopped {
 			return nil
@@ -228,7 +224,7 @@ func (w *Walker) walkOAS3PathItem(pathItem *parser.PathItem, basePath string, st
 		if w.stopped {
 			return nil
 		}
-		if err := w.walkParameter(param, fmt.Sprintf("%s.parameters[%d]", basePath, i), state); err != nil {
+		if err := w.walkParameter(param, indexSlot(&pathItem.Parameters, i), fmt.Sprintf("%s.parameters[%d]", basePath, i), state); err != nil {
 			return err
 		}
 	}
@@ -241,7 +237,9 @@ func (w *Walker) walkOAS3PathItem(pathItem *parser.PathItem, basePath string, st
 	// Call post-visit handler after children (but before popParent)
 	if w.onPathItemPost != nil && !w.stopped {
 		wc := state.buildContext(basePath)
+		wc.slot = slot
 		w.onPathItemPost(wc, pathItem)
+		w.handleEdit(wc)
 		releaseContext(wc)
 	}
 
@@ -253,27 +251,27 @@ func (w *Walker) walkOAS3PathItemOperations(pathItem *parser.PathItem, basePath
 	// Standard HTTP methods
 	ops := []struct {
 		method string
-		op     *parser.Operation
+		op     **parser.Operation
 	}{
-		{"get", pathItem.Get},
-		{"put", pathItem.Put},
-		{"post", pathItem.Post},
-		{"delete", pathItem.Delete},
-		{"options", pathItem.Options},
-		{"head", pathItem.Head},
-		{"patch", pathItem.Patch},
-		{"trace", pathItem.Trace},
-		{"query", pathItem.Query}, // OAS 3.2+
+		{"get", &pathItem.Get},
+		{"put", &pathItem.Put},
+		{"post", &pathItem.Post},
+		{"delete", &pathItem.Delete},
+		{"options", &pathItem.Options},
+		{"head", &pathItem.Head},
+		{"patch", &pathItem.Patch},
+		{"trace", &pathItem.Trace},
+		{"query", &pathItem.Query}, // OAS 3.2+
 	}
 
 	for _, item := range ops {
 		if w.stopped {
 			return nil
 		}
-		if item.op != nil {
+		if op := *item.op; op != nil {
 			opState := state.clone()
 			opState.method = item.method
-			if err := w.walkOAS3Operation(item.op, basePath+"."+item.method, opState); err != nil {
+			if err := w.walkOAS3Operation(op, fieldSlot(item.op), basePath+"."+item.method, opState); err != nil {
 				return err
 			}
 		}
@@ -289,7 +287,7 @@ func (w *Walker) walkOAS3PathItemOperations(pathItem *parser.PathItem, basePath
 			if op != nil {
 				opState := state.clone()
 				opState.method = method
-				if err := w.walkOAS3Operation(op, basePath+".additionalOperations."+method, opState); err != nil {
+				if err := w.walkOAS3Operation(op, mapSlot(pathItem.AdditionalOperations, method), basePath+".additionalOperations."+method, opState); err != nil {
 					return err
 				}
 			}
@@ -299,13 +297,14 @@ func (w *Walker) walkOAS3PathItemOperations(pathItem *parser.PathItem, basePath
 	return nil
 }
 
-// walkOAS3Operation walks a single Operation.
-func (w *Walker) walkOAS3Operation(op *parser.Operation, basePath string, state *walkState) error {
+// walkOAS3Operation walks a single Operation held in slot.
+func (w *Walker) walkOAS3Operation(op *parser.Operation, slot nodeSlot, basePath string, state *walkState) error {
 	// Operation pre-visit handler
 	continueToChildren := true
 	if w.onOperation != nil {
 		wc := state.buildContext(basePath)
-		continueToChildren = w.handleAction(w.onOperation(wc, op))
+		wc.slot = slot
+		continueToChildren = w.handleAction(wc, w.onOperation(wc, op))
 		releaseContext(wc)
 		if w.stopped {
 			return nil
@@ -323,7 +322,8 @@ func (w *Walker) walkOAS3Operation(op *parser.Operation, basePath string, state
 	// ExternalDocs
 	if op.ExternalDocs != nil && w.onExternalDocs != nil {
 		wc := state.buildContext(basePath + ".externalDocs")
-		w.handleAction(w.onExternalDocs(wc, op.ExternalDocs))
+		wc.slot = fieldSlot(&op.ExternalDocs)
+		w.handleAction(wc, w.onExternalDocs(wc, op.ExternalDocs))
 		releaseContext(wc)
 		if w.stopped {
 			return nil
@@ -335,14 +335,14 @@ func (w *Walker) walkOAS3Operation(op *parser.Operation, basePath string, state
 		if w.stopped {
 			return nil
 		}
-		if err := w.walkParameter(param, fmt.Sprintf("%s.parameters[%d]", basePath, i), state); err != nil {
+		if err := w.walkParameter(param, indexSlot(&op.Parameters, i), fmt.Sprintf("%s.parameters[%d]", basePath, i), state); err != nil {
 			return err
 		}
 	}
 
 	// RequestBody
 	if op.RequestBody != nil {
-		if err := w.walkOAS3RequestBody(op.RequestBody, basePath+".requestBody", state); err != nil {
+		if err := w.walkOAS3RequestBody(op.RequestBody, fieldSlot(&op.RequestBody), basePath+".requestBody", state); err != nil {
 			return err
 		}
 	}
@@ -362,7 +362,7 @@ func (w *Walker) walkOAS3Operation(op *parser.Operation, basePath string, state
 			}
 			callback := op.Callbacks[name]
 			if callback != nil {
-				if err := w.walkOAS3Callback(name, *callback, basePath+".callbacks['"+name+"']", state); err != nil {
+				if err := w.walkOAS3Callback(name, *callback, mapSlot(op.Callbacks, name), basePath+".callbacks['"+name+"']", state); err != nil {
 					return err
 				}
 			}
@@ -375,15 +375,17 @@ func (w *Walker) walkOAS3Operation(op *parser.Operation, basePath string, state
 	// Call post-visit handler after children (but before popParent)
 	if w.onOperationPost != nil && !w.stopped {
 		wc := state.buildContext(basePath)
+		wc.slot = slot
 		w.onOperationPost(wc, op)
+		w.handleEdit(wc)
 		releaseContext(wc)
 	}
 
 	return nil
 }
 
-// walkOAS3RequestBody walks a RequestBody.
-func (w *Walker) walkOAS3RequestBody(reqBody *parser.RequestBody, basePath string, state *walkState) error {
+// walkOAS3RequestBody walks a RequestBody held in slot.
+func (w *Walker) walkOAS3RequestBody(reqBody *parser.RequestBody, slot nodeSlot, basePath string, state *walkState) error {
 	// Check for $ref
 	if w.handleRef(reqBody.Ref, basePath, RefNodeRequestBody, state) == Stop {
 		return nil
@@ -393,7 +395,8 @@ func (w *Walker) walkOAS3RequestBody(reqBody *parser.RequestBody, basePath strin
 	continueToChildren := true
 	if w.onRequestBody != nil {
 		wc := state.buildContext(basePath)
-		continueToChildren = w.handleAction(w.onRequestBody(wc, reqBody))
+		wc.slot = slot
+		continueToChildren = w.handleAction(wc, w.onRequestBody(wc, reqBody))
 		releaseContext(wc)
 		if w.stopped {
 			return nil
@@ -416,7 +419,9 @@ func (w *Walker) walkOAS3RequestBody(reqBody *parser.RequestBody, basePath strin
 	// Call post-visit handler after children (but before popParent)
 	if w.onRequestBodyPost != nil && !w.stopped {
 		wc := state.buildContext(basePath)
+		wc.slot = slot
 		w.onRequestBodyPost(wc, reqBody)
+		w.handleEdit(wc)
 		releaseContext(wc)
 	}
 
@@ -429,7 +434,7 @@ func (w *Walker) walkOAS3Responses(responses *parser.Responses, basePath string,
 	if responses.Default != nil {
 		respState := state.clone()
 		respState.statusCode = "default"
-		if err := w.walkOAS3Response(responses.Default, basePath+".default", respState); err != nil {
+		if err := w.walkOAS3Response(responses.Default, fieldSlot(&responses.Default), basePath+".default", respState); err != nil {
 			return err
 		}
 	}
@@ -444,7 +449,7 @@ func (w *Walker) walkOAS3Responses(responses *parser.Responses, basePath string,
 			if resp != nil {
 				respState := state.clone()
 				respState.statusCode = code
-				if err := w.walkOAS3Response(resp, basePath+"['"+code+"']", respState); err != nil {
+				if err := w.walkOAS3Response(resp, mapSlot(responses.Codes, code), basePath+"['"+code+"']", respState); err != nil {
 					return err
 				}
 			}
@@ -454,8 +459,8 @@ func (w *Walker) walkOAS3Responses(responses *parser.Responses, basePath string,
 	return nil
 }
 
-// walkOAS3Response walks a single Response.
-func (w *Walker) walkOAS3Response(resp *parser.Response, basePath string, state *walkState) error {
+// walkOAS3Response walks a single Response held in slot.
+func (w *Walker) walkOAS3Response(resp *parser.Response, slot nodeSlot, basePath string, state *walkState) error {
 	// Check for $ref
 	if w.handleRef(resp.Ref, basePath, RefNodeResponse, state) == Stop {
 		return nil
@@ -465,7 +470,8 @@ func (w *Walker) walkOAS3Response(resp *parser.Response, basePath string, state
 	continueToChildren := true
 	if w.onResponse != nil {
 		wc := state.buildContext(basePath)
-		continueToChildren = w.handleAction(w.onResponse(wc, resp))
+		wc.slot = slot
+		continueToChildren = w.handleAction(wc, w.onResponse(wc, resp))
 		releaseContext(wc)
 		if w.stopped {
 			return nil
@@ -516,7 +522,8 @@ func (w *Walker) walkOAS3Response(resp *parser.Response, basePath string, state
 
 			if w.onLink != nil {
 				wc := linkState.buildContext(linkPath)
-				w.handleAction(w.onLink(wc, link))
+				wc.slot = mapSlot(resp.Links, name)
+				w.handleAction(wc, w.onLink(wc, link))
 				releaseContext(wc)
 			}
 		}
@@ -525,15 +532,17 @@ func (w *Walker) walkOAS3Response(resp *parser.Response, basePath string, state
 	// Call post-visit handler after children (but before popParent)
 	if w.onResponsePost != nil && !w.stopped {
 		wc := state.buildContext(basePath)
+		wc.slot = slot
 		w.onResponsePost(wc, resp)
+		w.handleEdit(wc)
 		releaseContext(wc)
 	}
 
 	return nil
 }
 
-// walkOAS3Callback walks a Callback.
-func (w *Walker) walkOAS3Callback(name string, callback parser.Callback, basePath string, state *walkState) error {
+// walkOAS3Callback walks a Callback held in slot.
+func (w *Walker) walkOAS3Callback(name string, callback parser.Callback, slot nodeSlot, basePath string, state *walkState) error {
 	// Callback pre-visit handler
 	cbState := state.clone()
 	cbState.name = name
@@ -541,7 +550,8 @@ func (w *Walker) walkOAS3Callback(name string, callback parser.Callback, basePat
 	continueToChildren := true
 	if w.onCallback != nil {
 		wc := cbState.buildContext(basePath)
-		continueToChildren = w.handleAction(w.onCallback(wc, callback))
+		wc.slot = slot
+		continueToChildren = w.handleAction(wc, w.onCallback(wc, callback))
 		releaseContext(wc)
 		if w.stopped {
 			return nil
@@ -565,7 +575,7 @@ func (w *Walker) walkOAS3Callback(name string, callback parser.Callback, basePat
 			// Expression becomes the pathTemplate within the callback
 			exprState := cbState.clone()
 			exprState.pathTemplate = expr
-			if err := w.walkOAS3PathItem(pathItem, basePath+"['"+expr+"']", exprState); err != nil {
+			if err := w.walkOAS3PathItem(pathItem, mapSlot(callback, expr), basePath+"['"+expr+"']", exprState); err != nil {
 				return err
 			}
 		}
@@ -574,7 +584,9 @@ func (w *Walker) walkOAS3Callback(name string, callback parser.Callback, basePat
 	// Call post-visit handler after children
 	if w.onCallbackPost != nil && !w.stopped {
 		wc := cbState.buildContext(basePath)
+		wc.slot = slot
 		w.onCallbackPost(wc, callback)
+		w.handleEdit(wc)
 		releaseContext(wc)
 	}
 
@@ -617,19 +629,15 @@ func (w *Walker) walkComponentSchemas(components *parser.Components, basePath st
 	if components.Schemas == nil {
 		return nil
 	}
-	for _, name := range maputil.SortedKeys(components.Schemas) {
-		if w.stopped {
+	return w.walkEach(maputil.SortedKeys(components.Schemas), components.Schemas, state, func(w *Walker, name string, state *walkState) error {
+		schema := components.Schemas[name]
+		if schema == nil {
 			return nil
 		}
-		if schema := components.Schemas[name]; schema != nil {
-			schemaState := state.clone()
-			schemaState.name = name
-			if err := w.walkSchema(schema, basePath+".schemas['"+name+"']", 0, schemaState); err != nil {
-				return err
-			}
-		}
-	}
-	return nil
+		schemaState := state.clone()
+		schemaState.name = name
+		return w.walkSchema(schema, mapSlot(components.Schemas, name), basePath+".schemas['"+name+"']", 0, schemaState)
+	})
 }
 
 func (w *Walker) walkComponentResponses(components *parser.Components, basePath string, state *walkState) error {
@@ -644,7 +652,7 @@ func (w *Walker) walkComponentResponses(components *parser.Components, basePath
 			respState := state.clone()
 			respState.name = name
 			// For component responses, statusCode is not set (it's a reusable response)
-			if err := w.walkOAS3Response(resp, basePath+".responses['"+name+"']", respState); err != nil {
+			if err := w.walkOAS3Response(resp, mapSlot(components.Responses, name), basePath+".responses['"+name+"']", respState); err != nil {
 				return err
 			}
 		}
@@ -663,7 +671,7 @@ func (w *Walker) walkComponentParameters(components *parser.Components, basePath
 		if param := components.Parameters[name]; param != nil {
 			paramState := state.clone()
 			paramState.name = name
-			if err := w.walkParameter(param, basePath+".parameters['"+name+"']", paramState); err != nil {
+			if err := w.walkParameter(param, mapSlot(components.Parameters, name), basePath+".parameters['"+name+"']", paramState); err != nil {
 				return err
 			}
 		}
@@ -682,7 +690,7 @@ func (w *Walker) walkComponentRequestBodies(components *parser.Components, baseP
 		if rb := components.RequestBodies[name]; rb != nil {
 			rbState := state.clone()
 			rbState.name = name
-			if err := w.walkOAS3RequestBody(rb, basePath+".requestBodies['"+name+"']", rbState); err != nil {
+			if err := w.walkOAS3RequestBody(rb, mapSlot(components.RequestBodies, name), basePath+".requestBodies['"+name+"']", rbState); err != nil {
 				return err
 			}
 		}
@@ -721,7 +729,8 @@ func (w *Walker) walkComponentSecuritySchemes(components *parser.Components, bas
 
 		if w.onSecurityScheme != nil {
 			wc := ssState.buildContext(ssPath)
-			w.handleAction(w.onSecurityScheme(wc, ss))
+			wc.slot = mapSlot(components.SecuritySchemes, name)
+			w.handleAction(wc, w.onSecurityScheme(wc, ss))
 			releaseContext(wc)
 		}
 	}
@@ -752,7 +761,8 @@ func (w *Walker) walkComponentLinks(components *parser.Components, basePath stri
 
 		if w.onLink != nil {
 			wc := linkState.buildContext(linkPath)
-			w.handleAction(w.onLink(wc, link))
+			wc.slot = mapSlot(components.Links, name)
+			w.handleAction(wc, w.onLink(wc, link))
 			releaseContext(wc)
 		}
 	}
@@ -765,7 +775,7 @@ func (w *Walker) walkComponentCallbacks(components *parser.Components, basePath
 			return nil
 		}
 		if cb := components.Callbacks[name]; cb != nil {
-			if err := w.walkOAS3Callback(name, *cb, basePath+".callbacks['"+name+"']", state); err != nil {
+			if err := w.walkOAS3Callback(name, *cb, mapSlot(components.Callbacks, name), basePath+".callbacks['"+name+"']", state); err != nil {
 				return err
 			}
 		}
@@ -822,7 +832,8 @@ func (w *Walker) walkComponentExamples(components *parser.Components, basePath s
 
 		if w.onExample != nil {
 			wc := exState.buildContext(exPath)
-			w.handleAction(w.onExample(wc, ex))
+			wc.slot = mapSlot(components.Examples, name)
+			w.handleAction(wc, w.onExample(wc, ex))
 			releaseContext(wc)
 		}
 	}
@@ -840,7 +851,7 @@ func (w *Walker) walkComponentPathItems(components *parser.Components, basePath
 		if pi := components.PathItems[name]; pi != nil {
 			piState := state.clone()
 			piState.name = name
-			if err := w.walkOAS3PathItem(pi, basePath+".pathItems['"+name+"']", piState); err != nil {
+			if err := w.walkOAS3PathItem(pi, mapSlot(components.PathItems, name), basePath+".pathItems['"+name+"']", piState); err != nil {
 				return err
 			}
 		}
@@ -27,7 +27,7 @@ func (w *Walker) handleRef(ref string, jsonPath string, nodeType RefNodeType, st
 		action := w.onRef(wc, refInfo)
 		releaseContext(wc)
 		if action == Stop {
-			w.stopped = true
+			w.stop()
 			return Stop
 		}
 	}
@@ -35,8 +35,8 @@ func (w *Walker) handleRef(ref string, jsonPath string, nodeType RefNodeType, st
 	return Continue
 }
 
-// walkParameter walks a Parameter.
-func (w *Walker) walkParameter(param *parser.Parameter, basePath string, state *walkState) error {
+// walkParameter walks a Parameter held in slot.
+func (w *Walker) walkParameter(param *parser.Parameter, slot nodeSlot, basePath string, state *walkState) error {
 	if param == nil {
 		return nil
 	}
@@ -49,7 +49,8 @@ func (w *Walker) walkParameter(param *parser.Parameter, basePath string, state *
 	continueToChildren := true
 	if w.onParameter != nil {
 		wc := state.buildContext(basePath)
-		continueToChildren = w.handleAction(w.onParameter(wc, param))
+		wc.slot = slot
+		continueToChildren = w.handleAction(wc, w.onParameter(wc, param))
 		releaseContext(wc)
 		if w.stopped {
 			return nil
@@ -68,7 +69,7 @@ func (w *Walker) walkParameter(param *parser.Parameter, basePath string, state *
 	if param.Schema != nil {
 		schemaState := state.clone()
 		schemaState.name = "" // Clear name for nested schemas
-		if err := w.walkSchema(param.Schema, basePath+".schema", 0, schemaState); err != nil {
+		if err := w.walkSchema(param.Schema, fieldSlot(&param.Schema), basePath+".schema", 0, schemaState); err != nil {
 			return err
 		}
 	}
@@ -96,7 +97,7 @@ func (w *Walker) walkHeaders(headers map[string]*parser.Header, basePath string,
 		}
 		header := headers[name]
 		if header != nil {
-			if err := w.walkHeader(name, header, basePath+"['"+name+"']", state); err != nil {
+			if err := w.walkHeader(name, header, mapSlot(headers, name), basePath+"['"+name+"']", state); err != nil {
 				return err
 			}
 		}
@@ -104,8 +105,8 @@ func (w *Walker) walkHeaders(headers map[string]*parser.Header, basePath string,
 	return nil
 }
 
-// walkHeader walks a single Header.
-func (w *Walker) walkHeader(name string, header *parser.Header, basePath string, state *walkState) error {
+// walkHeader walks a single Header held in slot.
+func (w *Walker) walkHeader(name string, header *parser.Header, slot nodeSlot, basePath string, state *walkState) error {
 	headerState := state.clone()
 	headerState.name = name
 
@@ -117,7 +118,8 @@ func (w *Walker) walkHeader(name string, header *parser.Header, basePath string,
 	continueToChildren := true
 	if w.onHeader != nil {
 		wc := headerState.buildContext(basePath)
-		continueToChildren = w.handleAction(w.onHeader(wc, header))
+		wc.slot = slot
+		continueToChildren = w.handleAction(wc, w.onHeader(wc, header))
 		releaseContext(wc)
 		if w.stopped {
 			return nil
@@ -136,7 +138,7 @@ func (w *Walker) walkHeader(name string, header *parser.Header, basePath string,
 	if header.Schema != nil {
 		schemaState := headerState.clone()
 		schemaState.name = "" // Clear name for nested schemas
-		if err := w.walkSchema(header.Schema, basePath+".schema", 0, schemaState); err != nil {
+		if err := w.walkSchema(header.Schema, fieldSlot(&header.Schema), basePath+".schema", 0, schemaState); err != nil {
 			return err
 		}
 	}
@@ -164,7 +166,7 @@ func (w *Walker) walkContent(content map[string]*parser.MediaType, basePath stri
 		}
 		mt := content[mtName]
 		if mt != nil {
-			if err := w.walkMediaType(mtName, mt, basePath+"['"+mtName+"']", state); err != nil {
+			if err := w.walkMediaType(mtName, mt, mapSlot(content, mtName), basePath+"['"+mtName+"']", state); err != nil {
 				return err
 			}
 		}
@@ -172,15 +174,16 @@ func (w *Walker) walkContent(content map[string]*parser.MediaType, basePath stri
 	return nil
 }
 
-// walkMediaType walks a single MediaType.
-func (w *Walker) walkMediaType(name string, mt *parser.MediaType, basePath string, state *walkState) error {
+// walkMediaType walks a single MediaType held in slot.
+func (w *Walker) walkMediaType(name string, mt *parser.MediaType, slot nodeSlot, basePath string, state *walkState) error {
 	mtState := state.clone()
 	mtState.name = name
 
 	continueToChildren := true
 	if w.onMediaType != nil {
 		wc := mtState.buildContext(basePath)
-		continueToChildren = w.handleAction(w.onMediaType(wc, mt))
+		wc.slot = slot
+		continueToChildren = w.handleAction(wc, w.onMediaType(wc, mt))
 		releaseContext(wc)
 		if w.stopped {
 			return nil
@@ -199,7 +202,7 @@ func (w *Walker) walkMediaType(name string, mt *parser.MediaType, basePath strin
 	if mt.Schema != nil {
 		schemaState := mtState.clone()
 		schemaState.name = "" // Clear name for nested schemas
-		if err := w.walkSchema(mt.Schema, basePath+".schema", 0, schemaState); err != nil {
+		if err := w.walkSchema(mt.Schema, fieldSlot(&mt.Schema), basePath+".schema", 0, schemaState); err != nil {
 			return err
 		}
 	}
@@ -234,14 +237,15 @@ func (w *Walker) walkExamples(examples map[string]*parser.Example, basePath stri
 
 		if w.onExample != nil {
 			wc := exState.buildContext(exPath)
-			w.handleAction(w.onExample(wc, ex))
+			wc.slot = mapSlot(examples, name)
+			w.handleAction(wc, w.onExample(wc, ex))
 			releaseContext(wc)
 		}
 	}
 }
 
-// walkSchema walks a Schema and all its nested schemas.
-func (w *Walker) walkSchema(schema *parser.Schema, basePath string, depth int, state *walkState) error {
+// walkSchema walks a Schema held in slot and all its nested schemas.
+func (w *Walker) walkSchema(schema *parser.Schema, slot nodeSlot, basePath string, depth int, state *walkState) error {
 	if schema == nil {
 		return nil
 	}
@@ -277,7 +281,8 @@ func (w *Walker) walkSchema(schema *parser.Schema, basePath string, depth int, s
 	// Call pre-visit handler
 	if w.onSchema != nil {
 		wc := state.buildContext(basePath)
-		continueToChildren := w.handleAction(w.onSchema(wc, schema))
+		wc.slot = slot
+		continueToChildren := w.handleAction(wc, w.onSchema(wc, schema))
 		releaseContext(wc)
 		if !continueToChildren {
 			if w.stopped {
@@ -314,7 +319,9 @@ func (w *Walker) walkSchema(schema *parser.Schema, basePath string, depth int, s
 	// Call post-visit handler after children (but before popParent)
 	if w.onSchemaPost != nil && !w.stopped {
 		wc := state.buildContext(basePath)
+		wc.slot = slot
 		w.onSchemaPost(wc, schema)
+		w.handleEdit(wc)
 		releaseContext(wc)
 	}
 
@@ -331,7 +338,7 @@ func (w *Walker) walkSchemaProperties(schema *parser.Schema, basePath string, de
 		if prop := schema.Properties[name]; prop != nil {
 			propState := state.clone()
 			propState.name = name
-			if err := w.walkSchema(prop, basePath+".properties['"+name+"']", depth+1, propState); err != nil {
+			if err := w.walkSchema(prop, mapSlot(schema.Properties, name), basePath+".properties['"+name+"']", depth+1, propState); err != nil {
 				return err
 			}
 		}
@@ -343,25 +350,25 @@ func (w *Walker) walkSchemaProperties(schema *parser.Schema, basePath string, de
 			return nil
 		}
 		if prop := schema.PatternProperties[pattern]; prop != nil {
-			if err := w.walkSchema(prop, basePath+".patternProperties['"+pattern+"']", depth+1, state); err != nil {
+			if err := w.walkSchema(prop, mapSlot(schema.PatternProperties, pattern), basePath+".patternProperties['"+pattern+"']", depth+1, state); err != nil {
 				return err
 			}
 		}
 	}
 
 	// AdditionalProperties
-	if err := w.walkSchemaOrBool(schema.AdditionalProperties, basePath+".additionalProperties", depth, state); err != nil {
+	if err := w.walkSchemaOrBool(&schema.AdditionalProperties, basePath+".additionalProperties", depth, state); err != nil {
 		return err
 	}
 
 	// UnevaluatedProperties
-	if err := w.walkSchemaOrBool(schema.UnevaluatedProperties, basePath+".unevaluatedProperties", depth, state); err != nil {
+	if err := w.walkSchemaOrBool(&schema.UnevaluatedProperties, basePath+".unevaluatedProperties", depth, state); err != nil {
 		return err
 	}
 
 	// PropertyNames
 	if schema.PropertyNames != nil {
-		if err := w.walkSchema(schema.PropertyNames, basePath+".propertyNames", depth+1, state); err != nil {
+		if err := w.walkSchema(schema.PropertyNames, fieldSlot(&schema.PropertyNames), basePath+".propertyNames", depth+1, state); err != nil {
 			return err
 		}
 	}
@@ -372,7 +379,7 @@ func (w *Walker) walkSchemaProperties(schema *parser.Schema, basePath string, de
 			return nil
 		}
 		if ds := schema.DependentSchemas[name]; ds != nil {
-			if err := w.walkSchema(ds, basePath+".dependentSchemas['"+name+"']", depth+1, state); err != nil {
+			if err := w.walkSchema(ds, mapSlot(schema.DependentSchemas, name), basePath+".dependentSchemas['"+name+"']", depth+1, state); err != nil {
 				return err
 			}
 		}
@@ -381,9 +388,9 @@ func (w *Walker) walkSchemaProperties(schema *parser.Schema, basePath string, de
 	return nil
 }
 
-// walkSchemaOrBool walks a schema-or-bool field, plus the raw map form that
-// [schemautil.SchemaOrBoolSchemas] does not cover.
-func (w *Walker) walkSchemaOrBool(field any, basePath string, depth int, state *walkState) error {
+// walkSchemaOrBool walks the schema-or-bool field that field points to, plus
+// the raw map form that [schemautil.SchemaOrBoolSchemas] does not cover.
+func (w *Walker) walkSchemaOrBool(field *any, basePath string, depth int, state *walkState) error {
 	// Checked on entry as well as per element: the caller walks several of these
 	// fields in a row, so a handler that stopped during an earlier one must not
 	// be reached again through the next.
@@ -391,16 +398,16 @@ func (w *Walker) walkSchemaOrBool(field any, basePath string, depth int, state *
 		return nil
 	}
 
-	for i, s := range schemautil.SchemaOrBoolSchemas(field) {
+	for i, s := range schemautil.SchemaOrBoolSchemas(*field) {
 		if w.stopped {
 			return nil
 		}
-		if err := w.walkSchema(s, basePath+schemautil.IndexSuffix(i), depth+1, state); err != nil {
+		if err := w.walkSchema(s, tupleSlot(field, i), basePath+schemautil.IndexSuffix(i), depth+1, state); err != nil {
 			return err
 		}
 	}
 
-	if m, ok := field.(map[string]any); ok && w.trackMapRefs {
+	if m, ok := (*field).(map[string]any); ok && w.trackMapRefs {
 		if ref, ok := m["$ref"].(string); ok && ref != "" {
 			if w.handleRef(ref, basePath, RefNodeSchema, state) == Stop {
 				return nil
@@ -414,12 +421,12 @@ func (w *Walker) walkSchemaOrBool(field any, basePath string, depth int, state *
 // walkSchemaArrayKeywords walks array-related schema keywords.
 func (w *Walker) walkSchemaArrayKeywords(schema *parser.Schema, basePath string, depth int, state *walkState) error {
 	// Items
-	if err := w.walkSchemaOrBool(schema.Items, basePath+".items", depth, state); err != nil {
+	if err := w.walkSchemaOrBool(&schema.Items, basePath+".items", depth, state); err != nil {
 		return err
 	}
 
 	// AdditionalItems
-	if err := w.walkSchemaOrBool(schema.AdditionalItems, basePath+".additionalItems", depth, state); err != nil {
+	if err := w.walkSchemaOrBool(&schema.AdditionalItems, basePath+".additionalItems", depth, state); err != nil {
 		return err
 	}
 
@@ -429,20 +436,20 @@ func (w *Walker) walkSchemaArrayKeywords(schema *parser.Schema, basePath string,
 			return nil
 		}
 		if prefixItem != nil {
-			if err := w.walkSchema(prefixItem, fmt.Sprintf("%s.prefixItems[%d]", basePath, i), depth+1, state); err != nil {
+			if err := w.walkSchema(prefixItem, indexSlot(&schema.PrefixItems, i), fmt.Sprintf("%s.prefixItems[%d]", basePath, i), depth+1, state); err != nil {
 				return err
 			}
 		}
 	}
 
 	// UnevaluatedItems
-	if err := w.walkSchemaOrBool(schema.UnevaluatedItems, basePath+".unevaluatedItems", depth, state); err != nil {
+	if err := w.walkSchemaOrBool(&schema.UnevaluatedItems, basePath+".unevaluatedItems", depth, state); err != nil {
 		return err
 	}
 
 	// Contains
 	if schema.Contains != nil {
-		if err := w.walkSchema(schema.Contains, basePath+".contains", depth+1, state); err != nil {
+		if err := w.walkSchema(schema.Contains, fieldSlot(&schema.Contains), basePath+".contains", depth+1, state); err != nil {
 			return err
 		}
 	}
@@ -458,7 +465,7 @@ func (w *Walker) walkSchemaComposition(schema *parser.Schema, basePath string, d
 			return nil
 		}
 		if sub != nil {
-			if err := w.walkSchema(sub, fmt.Sprintf("%s.allOf[%d]", basePath, i), depth+1, state); err != nil {
+			if err := w.walkSchema(sub, indexSlot(&schema.AllOf, i), fmt.Sprintf("%s.allOf[%d]", basePath, i), depth+1, state); err != nil {
 				return err
 			}
 		}
@@ -470,7 +477,7 @@ func (w *Walker) walkSchemaComposition(schema *parser.Schema, basePath string, d
 			return nil
 		}
 		if sub != nil {
-			if err := w.walkSchema(sub, fmt.Sprintf("%s.anyOf[%d]", basePath, i), depth+1, state); err != nil {
+			if err := w.walkSchema(sub, indexSlot(&schema.AnyOf, i), fmt.Sprintf("%s.anyOf[%d]", basePath, i), depth+1, state); err != nil {
 				return err
 			}
 		}
@@ -482,7 +489,7 @@ func (w *Walker) walkSchemaComposition(schema *parser.Schema, basePath string, d
 			return nil
 		}
 		if sub != nil {
-			if err := w.walkSchema(sub, fmt.Sprintf("%s.oneOf[%d]", basePath, i), depth+1, state); err != nil {
+			if err := w.walkSchema(sub, indexSlot(&schema.OneOf, i), fmt.Sprintf("%s.oneOf[%d]", basePath, i), depth+1, state); err != nil {
 				return err
 			}
 		}
@@ -490,7 +497,7 @@ func (w *Walker) walkSchemaComposition(schema *parser.Schema, basePath string, d
 
 	// Not
 	if schema.Not != nil {
-		if err := w.walkSchema(schema.Not, basePath+".not", depth+1, state); err != nil {
+		if err := w.walkSchema(schema.Not, fieldSlot(&schema.Not), basePath+".not", depth+1, state); err != nil {
 			return err
 		}
 	}
@@ -501,17 +508,17 @@ func (w *Walker) walkSchemaComposition(schema *parser.Schema, basePath string, d
 // walkSchemaConditionals walks if/then/else keywords.
 func (w *Walker) walkSchemaConditionals(schema *parser.Schema, basePath string, depth int, state *walkState) error {
 	if schema.If != nil {
-		if err := w.walkSchema(schema.If, basePath+".if", depth+1, state); err != nil {
+		if err := w.walkSchema(schema.If, fieldSlot(&schema.If), basePath+".if", depth+1, state); err != nil {
 			return err
 		}
 	}
 	if schema.Then != nil {
-		if err := w.walkSchema(schema.Then, basePath+".then", depth+1, state); err != nil {
+		if err := w.walkSchema(schema.Then, fieldSlot(&schema.Then), basePath+".then", depth+1, state); err != nil {
 			return err
 		}
 	}
 	if schema.Else != nil {
-		if err := w.walkSchema(schema.Else, basePath+".else", depth+1, state); err != nil {
+		if err := w.walkSchema(schema.Else, fieldSlot(&schema.Else), basePath+".else", depth+1, state); err != nil {
 			return err
 		}
 	}
@@ -522,7 +529,7 @@ func (w *Walker) walkSchemaConditionals(schema *parser.Schema, basePath string,
 func (w *Walker) walkSchemaMisc(schema *parser.Schema, basePath string, depth int, state *walkState) error {
 	// ContentSchema
 	if schema.ContentSchema != nil {
-		if err := w.walkSchema(schema.ContentSchema, basePath+".contentSchema", depth+1, state); err != nil {
+		if err := w.walkSchema(schema.ContentSchema, fieldSlot(&schema.ContentSchema), basePath+".contentSchema", depth+1, state); err != nil {
 			return err
 		}
 	}
@@ -535,7 +542,7 @@ func (w *Walker) walkSchemaMisc(schema *parser.Schema, basePath string, depth in
 		if def := schema.Defs[name]; def != nil {
 			defState := state.clone()
 			defState.name = name
-			if err := w.walkSchema(def, basePath+".$defs['"+name+"']", depth+1, defState); err != nil {
+			if err := w.walkSchema(def, mapSlot(schema.Defs, name), basePath+".$defs['"+name+"']", depth+1, defState); err != nil {
 				return err
 			}
 		}
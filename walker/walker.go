@@ -3,6 +3,8 @@ package walker
 import (
 	"context"
 	"fmt"
+	"sync"
+	"sync/atomic"
 
 	"github.com/erraggy/oastools/parser"
 )
@@ -209,10 +211,23 @@ type Walker struct {
 	trackMapRefs bool
 	trackParent  bool
 	userCtx      context.Context
+	concurrency  int
 
 	// Internal state
 	visitedSchemas map[*parser.Schema]bool
 	stopped        bool
+	editErr        error
+	deleted        []deletedElement
+	order          visitOrder
+
+	// batchMap is the map whose entries a concurrent batch is walking, and
+	// queued the edits to it deferred until the batch finishes (fork only)
+	batchMap uintptr
+	queued   []queuedEdit
+
+	// Shared by the forks of a concurrent walk (nil when walking sequentially)
+	halt   *atomic.Bool
+	editMu *sync.Mutex
 }
 
 // New creates a new Walker with default settings.
@@ -439,6 +454,13 @@ func Walk(result *parser.ParseResult, opts ...Option) error {
 func (w *Walker) walk(result *parser.ParseResult) error {
 	w.visitedSchemas = make(map[*parser.Schema]bool)
 	w.stopped = false
+	w.editErr = nil
+	w.deleted = nil
+	w.order = visitOrder{}
+	w.halt, w.editMu = nil, nil
+	if w.concurrency > 1 {
+		w.halt, w.editMu = new(atomic.Bool), new(sync.Mutex)
+	}
 
 	// Create initial walk state with user context and walker reference
 	state := &walkState{
@@ -452,14 +474,22 @@ func (w *Walker) walk(result *parser.ParseResult) error {
 		state.parentStack = &stack
 	}
 
+	var err error
 	switch doc := result.Document.(type) {
 	case *parser.OAS2Document:
-		return w.walkOAS2(doc, state)
+		err = w.walkOAS2(doc, state)
 	case *parser.OAS3Document:
-		return w.walkOAS3(doc, state)
+		err = w.walkOAS3(doc, state)
 	default:
 		return fmt.Errorf("walker: unsupported document type: %T", result.Document)
 	}
+
+	// Remove the slice elements handlers deleted, even if the walk stopped
+	w.compactDeleted()
+	if w.editErr != nil {
+		return w.editErr
+	}
+	return err
 }
 
 // handleAction processes the action returned by a handler.
@@ -474,10 +504,22 @@ func (w *Walker) walk(result *parser.ParseResult) error {
 //
 // Invalid Action values (e.g., Action(42)) are treated as Continue.
 // Use Action.IsValid() to check if an action is one of the defined constants.
-func (w *Walker) handleAction(action Action) bool {
+//
+// A node the handler replaced or deleted through wc is updated in its parent
+// here, and its children are skipped whatever the action.
+func (w *Walker) handleAction(wc *WalkContext, action Action) bool {
+	if w.handleEdit(wc) {
+		if action == Stop {
+			w.stop()
+		}
+		return false
+	}
+	if action != Stop && w.halted() {
+		return false
+	}
 	switch action {
 	case Stop:
-		w.stopped = true
+		w.stop()
 		return false
 	case SkipChildren:
 		return false
@@ -1,6 +1,10 @@
 package walker
 
 import (
+	"fmt"
+	"runtime"
+	"slices"
+	"sync/atomic"
 	"testing"
 
 	"github.com/erraggy/oastools/parser"
@@ -275,3 +279,99 @@ func BenchmarkWalkOAS2(b *testing.B) {
 		)
 	}
 }
+
+// largeDocument builds an OAS 3 document with n paths, each with two
+// operations and nested schemas, and n component schemas.
+func largeDocument(n int) *parser.ParseResult {
+	paths := make(parser.Paths, n)
+	schemas := make(map[string]*parser.Schema, n)
+	for i := range n {
+		name := fmt.Sprintf("Resource%03d", i)
+		item := func() *parser.Schema {
+			return &parser.Schema{
+				Type: "object",
+				Properties: map[string]*parser.Schema{
+					"id":   {Type: "integer"},
+					"tags": {Type: "array", Items: &parser.Schema{Type: "string"}},
+					"owner": {Type: "object", Properties: map[string]*parser.Schema{
+						"name": {Type: "string"},
+					}},
+				},
+			}
+		}
+		response := func() *parser.Responses {
+			return &parser.Responses{Codes: map[string]*parser.Response{
+				"200": {Description: "OK", Content: map[string]*parser.MediaType{
+					"application/json": {Schema: item()},
+				}},
+			}}
+		}
+		paths["/"+name] = &parser.PathItem{
+			Get: &parser.Operation{
+				OperationID: "get" + name,
+				Tags:        []string{fmt.Sprintf("group%d", i%5)},
+				Parameters: []*parser.Parameter{
+					{Name: "limit", In: "query", Schema: &parser.Schema{Type: "integer"}},
+				},
+				Responses: response(),
+			},
+			Put: &parser.Operation{
+				OperationID: "put" + name,
+				RequestBody: &parser.RequestBody{Content: map[string]*parser.MediaType{
+					"application/json": {Schema: item()},
+				}},
+				Responses: response(),
+			},
+		}
+		schemas[name] = item()
+	}
+	return &parser.ParseResult{
+		Document: &parser.OAS3Document{
+			OpenAPI:    "3.0.3",
+			Info:       &parser.Info{Title: "Large", Version: "1.0.0"},
+			Paths:      paths,
+			Components: &parser.Components{Schemas: schemas},
+		},
+		OASVersion: parser.OASVersion303,
+	}
+}
+
+// benchWorkers returns the distinct worker counts to benchmark.
+func benchWorkers() []int {
+	return slices.Compact(slices.Sorted(slices.Values([]int{1, 4, runtime.GOMAXPROCS(0)})))
+}
+
+func BenchmarkWalkConcurrency(b *testing.B) {
+	result := largeDocument(500)
+
+	for _, n := range benchWorkers() {
+		b.Run(fmt.Sprintf("workers=%d", n), func(b *testing.B) {
+			var count atomic.Int64
+			for b.Loop() {
+				_ = Walk(result,
+					WithConcurrency(n),
+					WithOperationHandler(func(wc *WalkContext, op *parser.Operation) Action {
+						count.Add(1)
+						return Continue
+					}),
+					WithSchemaHandler(func(wc *WalkContext, schema *parser.Schema) Action {
+						count.Add(int64(len(wc.JSONPath)))
+						return Continue
+					}),
+				)
+			}
+		})
+	}
+}
+
+func BenchmarkCollectSchemasConcurrency(b *testing.B) {
+	result := largeDocument(500)
+
+	for _, n := range benchWorkers() {
+		b.Run(fmt.Sprintf("workers=%d", n), func(b *testing.B) {
+			for b.Loop() {
+				_, _ = CollectSchemas(result, WithConcurrency(n))
+			}
+		})
+	}
+}
@@ -0,0 +1,336 @@
+package walker
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erraggy/oastools/parser"
+)
+
+// jsonPaths returns the JSON paths of infos in order.
+func jsonPaths[T any](infos []T, path func(T) string) []string {
+	out := make([]string, len(infos))
+	for i, info := range infos {
+		out[i] = path(info)
+	}
+	return out
+}
+
+func TestWithConcurrency_CollectorsMatchSequential(t *testing.T) {
+	result := largeDocument(40)
+
+	seqSchemas, err := CollectSchemas(result)
+	require.NoError(t, err)
+	seqOps, err := CollectOperations(result)
+	require.NoError(t, err)
+	seqParams, err := CollectParameters(result)
+	require.NoError(t, err)
+	seqResps, err := CollectResponses(result)
+	require.NoError(t, err)
+
+	for _, n := range []int{2, 8, 100} {
+		t.Run(fmt.Sprintf("workers=%d", n), func(t *testing.T) {
+			schemas, err := CollectSchemas(result, WithConcurrency(n))
+			require.NoError(t, err)
+			schemaPath := func(i *SchemaInfo) string { return i.JSONPath }
+			assert.Equal(t, jsonPaths(seqSchemas.All, schemaPath), jsonPaths(schemas.All, schemaPath))
+			assert.Equal(t, jsonPaths(seqSchemas.Components, schemaPath), jsonPaths(schemas.Components, schemaPath))
+			assert.Equal(t, jsonPaths(seqSchemas.Inline, schemaPath), jsonPaths(schemas.Inline, schemaPath))
+			assert.Len(t, schemas.ByName, len(seqSchemas.ByName))
+
+			ops, err := CollectOperations(result, WithConcurrency(n))
+			require.NoError(t, err)
+			opPath := func(i *OperationInfo) string { return i.JSONPath }
+			assert.Equal(t, jsonPaths(seqOps.All, opPath), jsonPaths(ops.All, opPath))
+			for tag, infos := range seqOps.ByTag {
+				assert.Equal(t, jsonPaths(infos, opPath), jsonPaths(ops.ByTag[tag], opPath), tag)
+			}
+
+			params, err := CollectParameters(result, WithConcurrency(n))
+			require.NoError(t, err)
+			paramPath := func(i *ParameterInfo) string { return i.JSONPath }
+			assert.Equal(t, jsonPaths(seqParams.All, paramPath), jsonPaths(params.All, paramPath))
+
+			resps, err := CollectResponses(result, WithConcurrency(n))
+			require.NoError(t, err)
+			respPath := func(i *ResponseInfo) string { return i.JSONPath }
+			assert.Equal(t, jsonPaths(seqResps.All, respPath), jsonPaths(resps.All, respPath))
+		})
+	}
+}
+
+func TestWithConcurrency_OAS2Definitions(t *testing.T) {
+	definitions := make(map[string]*parser.Schema)
+	paths := make(parser.Paths)
+	for i := range 20 {
+		name := fmt.Sprintf("Def%02d", i)
+		definitions[name] = &parser.Schema{
+			Type:       "object",
+			Properties: map[string]*parser.Schema{"id": {Type: "integer"}},
+		}
+		paths["/"+name] = &parser.PathItem{Get: &parser.Operation{OperationID: "get" + name}}
+	}
+	result := &parser.ParseResult{
+		Document: &parser.OAS2Document{
+			Swagger:     "2.0",
+			Info:        &parser.Info{Title: "Test", Version: "1.0.0"},
+			Paths:       paths,
+			Definitions: definitions,
+		},
+		OASVersion: parser.OASVersion20,
+	}
+
+	seq, err := CollectSchemas(result)
+	require.NoError(t, err)
+	conc, err := CollectSchemas(result, WithConcurrency(4))
+	require.NoError(t, err)
+
+	schemaPath := func(i *SchemaInfo) string { return i.JSONPath }
+	assert.Len(t, conc.All, 40)
+	assert.Equal(t, jsonPaths(seq.All, schemaPath), jsonPaths(conc.All, schemaPath))
+}
+
+func TestWithConcurrency_SubtreeOrder(t *testing.T) {
+	result := largeDocument(30)
+
+	// Within one path item, handlers see the same sequence as a sequential walk.
+	visits := func(opts ...Option) map[string][]string {
+		var mu sync.Mutex
+		byPath := make(map[string][]string)
+		record := func(wc *WalkContext) {
+			mu.Lock()
+			byPath[wc.PathTemplate] = append(byPath[wc.PathTemplate], wc.JSONPath)
+			mu.Unlock()
+		}
+		err := Walk(result, append(opts,
+			WithOperationHandler(func(wc *WalkContext, op *parser.Operation) Action {
+				record(wc)
+				return Continue
+			}),
+			WithSchemaHandler(func(wc *WalkContext, schema *parser.Schema) Action {
+				record(wc)
+				return Continue
+			}),
+			WithSchemaPostHandler(func(wc *WalkContext, schema *parser.Schema) {
+				record(wc)
+			}),
+		)...)
+		require.NoError(t, err)
+		return byPath
+	}
+
+	assert.Equal(t, visits(), visits(WithConcurrency(4)))
+}
+
+func TestWithConcurrency_DocumentHandlersRunAlone(t *testing.T) {
+	result := largeDocument(20)
+
+	var schemas atomic.Int64
+	var atPost int64
+	err := Walk(result,
+		WithConcurrency(4),
+		WithSchemaHandler(func(wc *WalkContext, schema *parser.Schema) Action {
+			schemas.Add(1)
+			return Continue
+		}),
+		WithOAS3DocumentPostHandler(func(wc *WalkContext, doc *parser.OAS3Document) {
+			atPost = schemas.Load()
+		}),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, schemas.Load(), atPost, "post handler runs after every subtree finished")
+	assert.Positive(t, atPost)
+}
+
+func TestWithConcurrency_Stop(t *testing.T) {
+	result := largeDocument(200)
+
+	var ops atomic.Int64
+	err := Walk(result,
+		WithConcurrency(4),
+		WithOperationHandler(func(wc *WalkContext, op *parser.Operation) Action {
+			ops.Add(1)
+			if wc.PathTemplate == "/Resource010" {
+				return Stop
+			}
+			return Continue
+		}),
+		WithOAS3DocumentPostHandler(func(wc *WalkContext, doc *parser.OAS3Document) {
+			t.Error("post handler must not run after Stop")
+		}),
+	)
+	require.NoError(t, err)
+
+	assert.Less(t, ops.Load(), int64(400), "walk stops before visiting every operation")
+}
+
+func TestWithConcurrency_Edits(t *testing.T) {
+	result := largeDocument(30)
+	doc := result.Document.(*parser.OAS3Document)
+
+	err := Walk(result,
+		WithConcurrency(4),
+		WithSchemaHandler(func(wc *WalkContext, schema *parser.Schema) Action {
+			switch {
+			case wc.IsComponent && wc.Name != "" && strings.HasSuffix(wc.Name, "5"):
+				return wc.Delete()
+			case wc.Name == "tags":
+				return wc.Replace(&parser.Schema{Type: "string"})
+			}
+			return Continue
+		}),
+		WithPathHandler(func(wc *WalkContext, pathItem *parser.PathItem) Action {
+			if strings.HasSuffix(wc.PathTemplate, "0") {
+				return wc.Delete()
+			}
+			return Continue
+		}),
+	)
+	require.NoError(t, err)
+
+	assert.Len(t, doc.Components.Schemas, 27)
+	assert.NotContains(t, doc.Components.Schemas, "Resource015")
+	assert.Len(t, doc.Paths, 27)
+	assert.NotContains(t, doc.Paths, "/Resource020")
+	assert.Equal(t, "string", doc.Components.Schemas["Resource001"].Properties["tags"].Type)
+	assert.Equal(t, "string", doc.Paths["/Resource001"].Put.RequestBody.Content["application/json"].Schema.Properties["tags"].Type)
+}
+
+// TestWithConcurrency_LargeDocumentEdits deletes and replaces entries of the
+// maps a concurrent batch is walking while the other subtrees read them. Run
+// with -race: the edits must wait for the batch rather than race its reads.
+func TestWithConcurrency_LargeDocumentEdits(t *testing.T) {
+	const n = 2000
+	result := largeDocument(n)
+	doc := result.Document.(*parser.OAS3Document)
+
+	err := Walk(result,
+		WithConcurrency(8),
+		WithSchemaHandler(func(wc *WalkContext, schema *parser.Schema) Action {
+			if !wc.IsComponent || wc.JSONPath != "$.components.schemas['"+wc.Name+"']" {
+				return Continue
+			}
+			switch {
+			case strings.HasSuffix(wc.Name, "3"):
+				return wc.Delete()
+			case strings.HasSuffix(wc.Name, "7"):
+				return wc.Replace(&parser.Schema{Type: "string"})
+			}
+			return Continue
+		}),
+		WithPathHandler(func(wc *WalkContext, pathItem *parser.PathItem) Action {
+			if strings.HasSuffix(wc.PathTemplate, "1") {
+				return wc.Delete()
+			}
+			return Continue
+		}),
+	)
+	require.NoError(t, err)
+
+	assert.Len(t, doc.Components.Schemas, n-n/10)
+	assert.NotContains(t, doc.Components.Schemas, "Resource1003")
+	assert.Equal(t, "string", doc.Components.Schemas["Resource1007"].Type)
+	assert.Len(t, doc.Paths, n-n/10)
+	assert.NotContains(t, doc.Paths, "/Resource1001")
+}
+
+func TestWithConcurrency_LargeDocumentEditsOAS2(t *testing.T) {
+	const n = 2000
+	doc := &parser.OAS2Document{
+		Swagger:     "2.0",
+		Paths:       make(parser.Paths, n),
+		Definitions: make(map[string]*parser.Schema, n),
+	}
+	for i := range n {
+		name := fmt.Sprintf("Resource%04d", i)
+		doc.Definitions[name] = &parser.Schema{Type: "object", Properties: map[string]*parser.Schema{"id": {Type: "integer"}}}
+		doc.Paths["/"+name] = &parser.PathItem{Get: &parser.Operation{OperationID: "get" + name}}
+	}
+	result := &parser.ParseResult{Document: doc, OASVersion: parser.OASVersion20}
+
+	err := Walk(result,
+		WithConcurrency(8),
+		WithSchemaHandler(func(wc *WalkContext, schema *parser.Schema) Action {
+			if wc.IsComponent && wc.JSONPath == "$.definitions['"+wc.Name+"']" && strings.HasSuffix(wc.Name, "3") {
+				return wc.Delete()
+			}
+			return Continue
+		}),
+		WithPathHandler(func(wc *WalkContext, pathItem *parser.PathItem) Action {
+			if strings.HasSuffix(wc.PathTemplate, "1") {
+				return wc.Replace(&parser.PathItem{})
+			}
+			return Continue
+		}),
+	)
+	require.NoError(t, err)
+
+	assert.Len(t, doc.Definitions, n-n/10)
+	assert.Len(t, doc.Paths, n)
+	assert.Nil(t, doc.Paths["/Resource0001"].Get)
+}
+
+func TestWithConcurrency_EditError(t *testing.T) {
+	result := largeDocument(10)
+
+	err := Walk(result,
+		WithConcurrency(4),
+		WithSchemaHandler(func(wc *WalkContext, schema *parser.Schema) Action {
+			if wc.Name == "owner" {
+				return wc.Replace(&parser.Parameter{})
+			}
+			return Continue
+		}),
+	)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "walker: replace of")
+}
+
+func TestWithConcurrency_ParentTracking(t *testing.T) {
+	result := largeDocument(20)
+
+	var mu sync.Mutex
+	var missing []string
+	err := Walk(result,
+		WithConcurrency(4),
+		WithParentTracking(),
+		WithSchemaHandler(func(wc *WalkContext, schema *parser.Schema) Action {
+			if _, ok := wc.ParentOperation(); wc.PathTemplate != "" && !ok {
+				mu.Lock()
+				missing = append(missing, wc.JSONPath)
+				mu.Unlock()
+			}
+			return Continue
+		}),
+	)
+	require.NoError(t, err)
+	assert.Empty(t, missing)
+}
+
+func TestWithConcurrency_SequentialValues(t *testing.T) {
+	result := largeDocument(10)
+
+	order := func(opts ...Option) []string {
+		var paths []string
+		err := Walk(result, append(opts,
+			WithSchemaHandler(func(wc *WalkContext, schema *parser.Schema) Action {
+				paths = append(paths, wc.JSONPath)
+				return Continue
+			}),
+		)...)
+		require.NoError(t, err)
+		return paths
+	}
+
+	want := order()
+	for _, n := range []int{-1, 0, 1} {
+		assert.Equal(t, want, order(WithConcurrency(n)), "n=%d walks sequentially", n)
+	}
+}